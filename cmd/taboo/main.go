@@ -8,79 +8,40 @@ import (
 	"github.com/aussiebroadwan/taboo/internal/app"
 )
 
-var (
-	configPath string
-	logLevel   string
-	verbose    bool
-)
-
 func main() {
-	// Define global flags
-	flag.StringVar(&configPath, "config", "./config.yaml", "config file path")
-	flag.StringVar(&configPath, "c", "./config.yaml", "config file path (shorthand)")
-	flag.StringVar(&logLevel, "log-level", "", "override log level (debug, info, warn, error)")
-	flag.BoolVar(&verbose, "verbose", false, "shorthand for --log-level=debug")
-	flag.BoolVar(&verbose, "v", false, "shorthand for --log-level=debug (shorthand)")
+	var g app.Globals
+
+	// Define global flags (documented in app.GlobalFlags)
+	flag.StringVar(&g.ConfigPath, "config", "./config.yaml", "config file path")
+	flag.StringVar(&g.ConfigPath, "c", "./config.yaml", "config file path (shorthand)")
+	flag.StringVar(&g.LogLevel, "log-level", "", "override log level (debug, info, warn, error)")
+	flag.BoolVar(&g.Verbose, "verbose", false, "shorthand for --log-level=debug")
+	flag.BoolVar(&g.Verbose, "v", false, "shorthand for --log-level=debug (shorthand)")
+	flag.BoolVar(&g.JSON, "json", false, "emit machine-readable JSON output")
 
-	flag.Usage = printUsage
+	flag.Usage = func() { app.PrintUsage(os.Stderr) }
 	flag.Parse()
 
 	// Subcommand dispatch
 	args := flag.Args()
 	if len(args) == 0 {
-		printUsage()
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	var err error
-	switch args[0] {
-	case "serve":
-		err = app.RunServe(configPath, logLevel, verbose)
-	case "migrate":
-		err = app.RunMigrate(configPath, args[1:])
-	case "verify":
-		err = app.RunVerify(configPath)
-	case "version":
-		app.RunVersion()
-	case "help":
-		printUsage()
-	default:
+	cmd, ok := app.LookupCommand(args[0])
+	if !ok {
 		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", args[0])
-		printUsage()
+		flag.Usage()
 		os.Exit(1)
 	}
+	if cmd.Run == nil {
+		flag.Usage()
+		return
+	}
 
-	if err != nil {
+	if err := cmd.Run(g, args[1:]); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
-
-func printUsage() {
-	fmt.Fprintf(os.Stderr, `taboo - Keno-style lottery visualization
-
-Usage:
-  taboo [flags] <command>
-
-Commands:
-  serve     Start the HTTP server
-  migrate   Manage database migrations
-  verify    Verify configuration and database
-  version   Print version information
-  help      Show this help message
-
-Flags:
-  -c, --config string      Config file path (default "./config.yaml")
-  --log-level string       Override log level (debug, info, warn, error)
-  -v, --verbose            Shorthand for --log-level=debug
-
-Examples:
-  taboo serve                         Start with default config
-  taboo serve -c config.yaml          Start with custom config
-  taboo serve --log-level debug       Start with debug logging
-  taboo migrate up                    Apply all pending migrations
-  taboo migrate status                Show migration status
-  taboo verify                        Verify configuration and database
-  taboo version                       Print version info
-`)
-}
@@ -3,15 +3,18 @@ import { SSEClient } from "./sse";
 import { usingDiscordSDK } from "./discord";
 import { mountLiveDraw } from "./views/live-draw";
 import { mountPreviousDraw } from "./views/previous-draw";
+import { mountBoardOverlay, mountTickerOverlay, readOverlayTheme } from "./views/overlay";
+import { SSE_GAME_STATE, SSE_GAME_PICK, SSE_GAME_COMPLETE } from "./types";
 import logger from "./logger";
 
 const log = logger.with({ component: "main" });
 
-function getSSEUrl(): string {
+function getSSEUrl(events?: string[]): string {
     const protocol = window.location.protocol;
     const hostname = window.location.host;
     const baseUrl = `${protocol}//${hostname}${usingDiscordSDK ? "/.proxy" : ""}`;
-    return `${baseUrl}/api/v1/events`;
+    const filter = events ? `?events=${events.join(",")}` : "";
+    return `${baseUrl}/api/v1/events${filter}`;
 }
 
 function route(app: HTMLElement): void {
@@ -31,6 +34,18 @@ function route(app: HTMLElement): void {
         const sseClient = new SSEClient(getSSEUrl(), { reconnectInterval: 3000 });
         mountLiveDraw(app, sseClient);
         sseClient.connect();
+    } else if (path === "/overlay/board") {
+        // Overlays only subscribe to the events they draw
+        const sseClient = new SSEClient(getSSEUrl([SSE_GAME_STATE, SSE_GAME_PICK]), { reconnectInterval: 3000 });
+        mountBoardOverlay(app, sseClient, readOverlayTheme(new URLSearchParams(window.location.search)));
+        sseClient.connect();
+    } else if (path === "/overlay/ticker") {
+        const sseClient = new SSEClient(
+            getSSEUrl([SSE_GAME_STATE, SSE_GAME_PICK, SSE_GAME_COMPLETE]),
+            { reconnectInterval: 3000 },
+        );
+        mountTickerOverlay(app, sseClient, readOverlayTheme(new URLSearchParams(window.location.search)));
+        sseClient.connect();
     } else {
         log.warn("Page not found, redirecting to live", { path });
         window.location.href = "/";
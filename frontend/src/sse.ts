@@ -1,5 +1,5 @@
 import logger from "./logger";
-import type { GameStateData, GamePickData, GameCompleteData } from "./types";
+import type { GameStateData, GamePickData, GameCompleteData, ServerNoticeData, StreamGapData } from "./types";
 
 const log = logger.with({ component: "sse" });
 
@@ -10,6 +10,8 @@ export interface SSEClientOptions {
 export type GameStateHandler = (data: GameStateData) => void;
 export type GamePickHandler = (data: GamePickData) => void;
 export type GameCompleteHandler = (data: GameCompleteData) => void;
+export type ServerNoticeHandler = (data: ServerNoticeData) => void;
+export type StreamGapHandler = (data: StreamGapData) => void;
 
 export class SSEClient {
     private url: string;
@@ -21,6 +23,9 @@ export class SSEClient {
     onGameState: GameStateHandler | null = null;
     onGamePick: GamePickHandler | null = null;
     onGameComplete: GameCompleteHandler | null = null;
+    onServerNotice: ServerNoticeHandler | null = null;
+    /** Called when events were dropped; views should rebuild from the next game:state. */
+    onStreamGap: StreamGapHandler | null = null;
 
     constructor(url: string, options: SSEClientOptions = {}) {
         this.url = url;
@@ -57,7 +62,18 @@ export class SSEClient {
             this.onGameComplete?.(data);
         });
 
-        this.eventSource.addEventListener("game:heartbeat", () => {
+        this.eventSource.addEventListener("server:notice", (e) => {
+            const data: ServerNoticeData = JSON.parse((e as MessageEvent).data);
+            this.onServerNotice?.(data);
+        });
+
+        this.eventSource.addEventListener("stream:gap", (e) => {
+            const data: StreamGapData = JSON.parse((e as MessageEvent).data);
+            log.warn("Server dropped events, resyncing", { dropped: data.dropped, latest_seq: data.latest_seq });
+            this.onStreamGap?.(data);
+        });
+
+        this.eventSource.addEventListener("sys:heartbeat", () => {
             // Keep-alive, no action needed
         });
     }
@@ -4,22 +4,57 @@ export interface GameStateData {
     game_id: number;
     picks: number[];
     next_game: string;
+    /** Set when the game is run as a scheduled special event. */
+    special?: SpecialGameInfo;
+}
+
+export interface SpecialGameInfo {
+    id: number;
+    name: string;
+    /** Theming such as "accent": "#EF8E3C". Unknown keys are ignored. */
+    branding?: Record<string, string>;
+    payouts?: Record<string, string>;
 }
 
 export interface GamePickData {
     pick: number;
+    revealed_at: string;
+    next_reveal_in_ms: number;
+    /** The pick spelled out for screen readers, e.g. "forty-two". */
+    call_text?: string;
+    /** Path of a recorded call of the pick, e.g. "/api/v1/calls/42". */
+    call_audio?: string;
 }
 
 export interface GameCompleteData {
     game_id: number;
 }
 
+export interface EngineDegradedData {
+    operation: string;
+    reason: string;
+}
+
+/** Operator announcement, shown for ttl_seconds after it arrives. */
+export interface ServerNoticeData {
+    message: string;
+    severity: "info" | "warning" | "critical";
+    ttl_seconds: number;
+}
+
+/** Sent in place of events the server dropped for a slow stream. */
+export interface StreamGapData {
+    dropped: number;
+    latest_seq: number;
+}
+
 // REST API types (matching Go sdk/dto.go)
 
 export interface GameResponse {
     id: number;
     picks: number[];
     created_at: string;
+    result_hash: string;
 }
 
 export interface GameListResponse {
@@ -27,6 +62,15 @@ export interface GameListResponse {
     next_cursor?: number;
 }
 
+/** Timing of every game cycle, from GET /api/v1/config/pacing. */
+export interface PacingResponse {
+    pick_count: number;
+    draw_duration_ms: number;
+    wait_duration_ms: number;
+    reveal_curve: string;
+    reveal_offsets_ms: number[];
+}
+
 export interface ErrorResponse {
     error: {
         code: string;
@@ -38,4 +82,4 @@ export interface ErrorResponse {
 export const SSE_GAME_STATE = "game:state";
 export const SSE_GAME_PICK = "game:pick";
 export const SSE_GAME_COMPLETE = "game:complete";
-export const SSE_GAME_HEARTBEAT = "game:heartbeat";
+export const SSE_SYS_HEARTBEAT = "sys:heartbeat";
@@ -3,9 +3,9 @@ import { createGrid } from "../components/grid";
 import { createLargeCounter, createSmallCounter, type Counter } from "../components/counter";
 import { placePickInstant, placePickAnimated } from "../components/pick";
 import { createGameState, resetGameState, addPick, type GameState } from "../state";
-import { useDiscordSDK } from "../discord";
+import { useDiscordSDK, usingDiscordSDK } from "../discord";
 import type { SSEClient } from "../sse";
-import type { GameStateData, GamePickData } from "../types";
+import type { GameStateData, GamePickData, PacingResponse, ServerNoticeData, SpecialGameInfo } from "../types";
 import logger from "../logger";
 
 const log = logger.with({ component: "live_draw_view" });
@@ -15,9 +15,27 @@ const MS_PER_SECOND = 1_000;
 
 const GAME_DRAW_TIME = 1.5 * MS_PER_MINUTE;
 const GAME_WAIT_TIME = 1.5 * MS_PER_MINUTE;
-const GAME_TOTAL_TIME = GAME_DRAW_TIME + GAME_WAIT_TIME;
+
+// Length of a game cycle, the defaults until the server's pacing arrives
+let gameTotalTime = GAME_DRAW_TIME + GAME_WAIT_TIME;
+
+function loadPacing(): void {
+    const baseUrl = `${window.location.protocol}//${window.location.host}${usingDiscordSDK ? "/.proxy" : ""}`;
+    fetch(`${baseUrl}/api/v1/config/pacing`)
+        .then((response) => {
+            if (!response.ok) throw new Error(`status ${response.status}`);
+            return response.json();
+        })
+        .then((pacing: PacingResponse) => {
+            gameTotalTime = pacing.draw_duration_ms + pacing.wait_duration_ms;
+            log.debug("Pacing loaded", { draw_ms: pacing.draw_duration_ms, wait_ms: pacing.wait_duration_ms });
+        })
+        .catch((err) => log.warn("Failed to load pacing, using defaults", { error: String(err) }));
+}
 
 export function mountLiveDraw(root: HTMLElement, sseClient: SSEClient): void {
+    loadPacing();
+
     const container = document.createElement("div");
     container.className = "game-container";
     root.appendChild(container);
@@ -40,6 +58,24 @@ export function mountLiveDraw(root: HTMLElement, sseClient: SSEClient): void {
     container.appendChild(counterBar);
     container.appendChild(gridEl);
 
+    // Names the special event the game is run as, if any
+    const specialBanner = document.createElement("div");
+    specialBanner.className = "special-banner";
+    container.appendChild(specialBanner);
+
+    // Operator notices, shown until their TTL runs out
+    const noticeBanner = document.createElement("div");
+    noticeBanner.className = "notice-banner";
+    noticeBanner.setAttribute("role", "status");
+    container.appendChild(noticeBanner);
+    let noticeTimeoutId: ReturnType<typeof setTimeout> | null = null;
+
+    // Announces each pick to screen readers
+    const announcer = document.createElement("div");
+    announcer.className = "pick-announcer";
+    announcer.setAttribute("aria-live", "polite");
+    container.appendChild(announcer);
+
     // Loading overlay (hidden once first game:state arrives)
     const overlay = document.createElement("div");
     overlay.className = "loading-overlay";
@@ -66,16 +102,31 @@ export function mountLiveDraw(root: HTMLElement, sseClient: SSEClient): void {
             container, cells, state, data,
             timerCounter, drawCounter, headsCounter, tailsCounter,
         );
+        applySpecial(container, specialBanner, data.special);
     };
 
     sseClient.onGamePick = (data: GamePickData) => {
         handleGamePick(container, cells, state, data, headsCounter, tailsCounter);
+        announcer.textContent = data.call_text ?? String(data.pick);
     };
 
     sseClient.onGameComplete = (data) => {
         log.info("Game completed", { game_id: data.game_id });
     };
 
+    sseClient.onServerNotice = (data: ServerNoticeData) => {
+        log.info("Server notice", { severity: data.severity, ttl_seconds: data.ttl_seconds });
+        if (noticeTimeoutId) clearTimeout(noticeTimeoutId);
+        showNotice(noticeBanner, data);
+        noticeTimeoutId = setTimeout(() => showNotice(noticeBanner, null), data.ttl_seconds * MS_PER_SECOND);
+    };
+
+    // Forget the current game so the next game:state redraws the board
+    // with the picks that were missed
+    sseClient.onStreamGap = () => {
+        state.gameId = 0;
+    };
+
     // Store cleanup reference on the container for potential future use
     container.dataset.timerInterval = String(timerInterval);
 }
@@ -126,7 +177,7 @@ function handleGameState(
         timerCounter.setValue(getTimeLeftString(state));
 
         // Set Discord rich presence
-        const gameStart = new Date(state.nextGame).getTime() - GAME_TOTAL_TIME;
+        const gameStart = new Date(state.nextGame).getTime() - gameTotalTime;
         useDiscordSDK((sdk) =>
             sdk.commands.setActivity({
                 activity: {
@@ -156,6 +207,23 @@ function handleGamePick(
     tailsCounter.setValue(state.tails);
 }
 
+function applySpecial(container: HTMLElement, banner: HTMLElement, special?: SpecialGameInfo): void {
+    banner.textContent = special?.name ?? "";
+    container.classList.toggle("game-container--special", special !== undefined);
+
+    const accent = special?.branding?.accent;
+    if (accent && CSS.supports("color", accent)) {
+        container.style.setProperty("--special-accent", accent);
+    } else {
+        container.style.removeProperty("--special-accent");
+    }
+}
+
+function showNotice(banner: HTMLElement, notice: ServerNoticeData | null): void {
+    banner.textContent = notice?.message ?? "";
+    banner.className = notice ? `notice-banner notice-banner--${notice.severity}` : "notice-banner";
+}
+
 function getTimeLeftString(state: GameState): string {
     if (!state.nextGame) return "00:00";
     const now = Date.now();
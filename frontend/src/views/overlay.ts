@@ -0,0 +1,169 @@
+import { createGrid } from "../components/grid";
+import { placePickInstant } from "../components/pick";
+import { createGameState, resetGameState, addPick } from "../state";
+import type { SSEClient } from "../sse";
+import type { GameStateData } from "../types";
+import { COLORS } from "../constants";
+import logger from "../logger";
+
+const log = logger.with({ component: "overlay_view" });
+
+/**
+ * Overlay theme, read from query parameters so streamers can match their
+ * scene in the OBS browser source URL, e.g.
+ * /overlay/ticker?fg=white&accent=%23EF8E3C&font=Bebas%20Neue&size=48
+ */
+export interface OverlayTheme {
+    bg: string;
+    fg: string;
+    accent: string;
+    font: string;
+    size: number;
+}
+
+const DEFAULT_THEME: OverlayTheme = {
+    bg: "transparent",
+    fg: "#000",
+    accent: COLORS.BLUE,
+    font: "",
+    size: 32,
+};
+
+/**
+ * Reads the overlay theme from query parameters, ignoring values that are not
+ * valid so a typo falls back to the default rather than breaking the scene.
+ */
+export function readOverlayTheme(params: URLSearchParams): OverlayTheme {
+    const theme = { ...DEFAULT_THEME };
+
+    for (const key of ["bg", "fg", "accent"] as const) {
+        const value = params.get(key);
+        if (value === null) continue;
+        if (CSS.supports("color", value)) {
+            theme[key] = value;
+        } else {
+            log.warn("Ignoring invalid overlay color", { param: key, value });
+        }
+    }
+
+    const font = params.get("font");
+    if (font !== null) {
+        if (/^[\w -]+$/.test(font)) {
+            theme.font = font;
+        } else {
+            log.warn("Ignoring invalid overlay font", { value: font });
+        }
+    }
+
+    const size = Number(params.get("size") ?? theme.size);
+    if (Number.isInteger(size) && size >= 8 && size <= 256) {
+        theme.size = size;
+    } else {
+        log.warn("Ignoring invalid overlay size", { value: params.get("size") });
+    }
+
+    return theme;
+}
+
+function applyOverlayTheme(root: HTMLElement, theme: OverlayTheme): void {
+    document.body.classList.add("overlay");
+    document.documentElement.style.setProperty("--overlay-bg", theme.bg);
+    root.style.setProperty("--overlay-fg", theme.fg);
+    root.style.setProperty("--overlay-accent", theme.accent);
+    root.style.setProperty("--overlay-size", `${theme.size}px`);
+    if (theme.font) {
+        root.style.setProperty("--overlay-font", `"${theme.font}"`);
+    }
+}
+
+/**
+ * Mounts the board overlay: the bare number grid, filled in as picks arrive.
+ */
+export function mountBoardOverlay(root: HTMLElement, sseClient: SSEClient, theme: OverlayTheme): void {
+    applyOverlayTheme(root, theme);
+
+    const container = document.createElement("div");
+    container.className = "game-container overlay-board";
+    root.appendChild(container);
+
+    const { element: gridEl, cells } = createGrid();
+    container.appendChild(gridEl);
+
+    const state = createGameState();
+
+    sseClient.onGameState = (data: GameStateData) => {
+        if (data.game_id === state.gameId) return;
+        state.gameId = data.game_id;
+        resetGameState(state);
+
+        cells.forEach((cell) => {
+            cell.style.backgroundColor = COLORS.GREY_BG;
+            cell.classList.remove("grid-cell--picked");
+        });
+        for (const pick of data.picks) {
+            addPick(state, pick);
+            placePickInstant(cells, pick);
+        }
+    };
+
+    sseClient.onGamePick = (data) => {
+        addPick(state, data.pick);
+        placePickInstant(cells, data.pick);
+    };
+
+    // Rebuild from the next game:state after missing events
+    sseClient.onStreamGap = () => {
+        state.gameId = 0;
+    };
+}
+
+/**
+ * Mounts the ticker overlay: a single line with the game number and its
+ * picks so far, marked final once the game completes.
+ */
+export function mountTickerOverlay(root: HTMLElement, sseClient: SSEClient, theme: OverlayTheme): void {
+    applyOverlayTheme(root, theme);
+
+    const ticker = document.createElement("div");
+    ticker.className = "overlay-ticker";
+    const label = document.createElement("span");
+    label.className = "overlay-ticker__label";
+    const picks = document.createElement("span");
+    picks.className = "overlay-ticker__picks";
+    ticker.appendChild(label);
+    ticker.appendChild(picks);
+    root.appendChild(ticker);
+
+    const state = createGameState();
+
+    const render = () => {
+        label.textContent = `Game ${state.gameId}`;
+        picks.textContent = state.picks.join(" · ");
+    };
+
+    sseClient.onGameState = (data: GameStateData) => {
+        if (data.game_id === state.gameId) return;
+        state.gameId = data.game_id;
+        resetGameState(state);
+        for (const pick of data.picks) {
+            addPick(state, pick);
+        }
+        ticker.classList.remove("overlay-ticker--final");
+        render();
+    };
+
+    sseClient.onGamePick = (data) => {
+        addPick(state, data.pick);
+        render();
+    };
+
+    sseClient.onGameComplete = (data) => {
+        if (data.game_id !== state.gameId) return;
+        ticker.classList.add("overlay-ticker--final");
+    };
+
+    // Rebuild from the next game:state after missing events
+    sseClient.onStreamGap = () => {
+        state.gameId = 0;
+    };
+}
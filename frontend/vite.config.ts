@@ -7,4 +7,8 @@ export default defineConfig({
             "@": fileURLToPath(new URL("./src", import.meta.url)),
         },
     },
+    build: {
+        // Checked by the server at startup, see internal/frontend/validate.go
+        manifest: "asset-manifest.json",
+    },
 });
@@ -1,12 +1,17 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
+	"time"
 
 	"github.com/aussiebroadwan/taboo/internal/config"
 	"github.com/aussiebroadwan/taboo/internal/store"
 	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+	"github.com/aussiebroadwan/taboo/internal/store/shadow"
+	"github.com/aussiebroadwan/taboo/internal/store/traced"
 	"github.com/aussiebroadwan/taboo/pkg/slogx"
 )
 
@@ -22,10 +27,37 @@ type App struct {
 	Config *config.Config
 	Logger *slog.Logger
 	Store  store.Store
+
+	// LogLevel is the logger's level, and LogQueries whether the store logs
+	// its statements; both can be changed while running.
+	LogLevel   *slog.LevelVar
+	LogQueries *atomic.Bool
+
+	logFile *slogx.RotatingFile
+}
+
+// Option configures New.
+type Option func(*options)
+
+type options struct {
+	defaults *config.Config
+}
+
+// WithDefaults loads the config file over defaults instead of
+// config.Default, as serve --dev does with config.Dev.
+func WithDefaults(defaults *config.Config) Option {
+	return func(o *options) {
+		o.defaults = defaults
+	}
 }
 
 // New creates a new App with all dependencies initialized.
-func New(configPath, logLevel string, verbose bool) (*App, error) {
+func New(configPath, logLevel string, verbose bool, opts ...Option) (*App, error) {
+	o := options{defaults: config.Default()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Determine effective log level
 	effectiveLevel := logLevel
 	if verbose && logLevel == "" {
@@ -33,7 +65,7 @@ func New(configPath, logLevel string, verbose bool) (*App, error) {
 	}
 
 	// Load configuration
-	cfg, err := config.Load(configPath)
+	cfg, err := config.LoadWith(o.defaults, configPath)
 	if err != nil {
 		return nil, fmt.Errorf("loading config: %w", err)
 	}
@@ -43,42 +75,109 @@ func New(configPath, logLevel string, verbose bool) (*App, error) {
 		cfg.Logging.Level = effectiveLevel
 	}
 
+	if cfg.Server.NodeID == "" {
+		cfg.Server.NodeID = newNodeID()
+	}
+
 	// Create logger
-	logger := slogx.New(
-		slogx.WithLevel(slogx.ParseLevel(cfg.Logging.Level)),
+	level := new(slog.LevelVar)
+	level.Set(slogx.ParseLevel(cfg.Logging.Level))
+	logOpts := []slogx.Option{
+		slogx.WithLevelVar(level),
 		slogx.WithFormat(slogx.ParseFormat(cfg.Logging.Format)),
 		slogx.WithService("taboo"),
 		slogx.WithVersion(Version),
-	)
+		slogx.WithNode(cfg.Server.NodeID),
+		slogx.WithRedactKeys(slogx.DefaultRedactKeys...),
+	}
+
+	var logFile *slogx.RotatingFile
+	if cfg.Logging.File != "" {
+		logFile, err = slogx.NewRotatingFile(slogx.RotateConfig{
+			Path:       cfg.Logging.File,
+			MaxSize:    int64(cfg.Logging.MaxSizeMB) << 20,
+			MaxBackups: cfg.Logging.MaxBackups,
+			Compress:   cfg.Logging.Compress,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating log file: %w", err)
+		}
+		logOpts = append(logOpts, slogx.WithOutput(logFile))
+	}
+
+	logger := slogx.New(logOpts...)
 
 	// Create store
-	var st store.Store
-	switch cfg.Database.Driver {
-	case "sqlite":
-		st, err = sqlite.New(cfg.Database.DSN)
+	logQueries := new(atomic.Bool)
+	logQueries.Store(cfg.Database.LogQueries)
+	storeLogger := logger.With(slog.String("component", "store"))
+
+	st, err := openStore(cfg.Database.Driver, cfg.Database.DSN, cfg.Database.QueryTimeout.Duration(), storeLogger, logQueries)
+	if err != nil {
+		closeLogFile(logFile)
+		return nil, err
+	}
+
+	if shadowCfg := cfg.Database.Shadow; shadowCfg.Driver != "" {
+		secondary, err := openStore(shadowCfg.Driver, shadowCfg.DSN, cfg.Database.QueryTimeout.Duration(),
+			storeLogger.With(slog.Bool("shadow", true)), logQueries)
 		if err != nil {
-			return nil, fmt.Errorf("creating sqlite store: %w", err)
+			_ = st.Close()
+			closeLogFile(logFile)
+			return nil, fmt.Errorf("opening shadow store: %w", err)
 		}
-	default:
-		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Database.Driver)
+		st = shadow.New(st, secondary)
+		logger.Info("Shadowing database writes", slog.String("driver", shadowCfg.Driver))
 	}
 
+	// Outermost, so shadow comparisons aren't counted against requests
+	st = traced.New(st)
+
 	logger.Info("Application initialized",
 		slog.String("version", Version),
 		slog.String("log_level", cfg.Logging.Level),
 	)
 
 	return &App{
-		Config: cfg,
-		Logger: logger,
-		Store:  st,
+		Config:     cfg,
+		Logger:     logger,
+		Store:      st,
+		LogLevel:   level,
+		LogQueries: logQueries,
+		logFile:    logFile,
 	}, nil
 }
 
+// openStore creates the store for a database driver, logging its
+// statements to logger while logQueries is set.
+func openStore(driver, dsn string, queryTimeout time.Duration, logger *slog.Logger, logQueries *atomic.Bool) (store.Store, error) {
+	switch driver {
+	case "sqlite":
+		st, err := sqlite.New(dsn, sqlite.WithQueryTimeout(queryTimeout), sqlite.WithQueryLog(logger, logQueries.Load))
+		if err != nil {
+			return nil, fmt.Errorf("creating sqlite store: %w", err)
+		}
+		return st, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+	}
+}
+
 // Close releases all application resources.
 func (a *App) Close() error {
+	var errs []error
 	if a.Store != nil {
-		return a.Store.Close()
+		errs = append(errs, a.Store.Close())
+	}
+	if a.logFile != nil {
+		errs = append(errs, a.logFile.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// closeLogFile closes a log file opened during a failed initialization.
+func closeLogFile(f *slogx.RotatingFile) {
+	if f != nil {
+		_ = f.Close()
 	}
-	return nil
 }
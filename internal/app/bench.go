@@ -0,0 +1,327 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// benchBuckets are the upper bounds of the latency histogram buckets.
+var benchBuckets = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+}
+
+// benchOp is a single REST call exercised by the benchmark.
+type benchOp struct {
+	name string
+	call func(ctx context.Context, c *sdk.Client) error
+}
+
+// benchStats accumulates results for one endpoint.
+type benchStats struct {
+	latencies []time.Duration
+	errors    map[string]int
+}
+
+func (s *benchStats) merge(other *benchStats) {
+	s.latencies = append(s.latencies, other.latencies...)
+	for k, v := range other.errors {
+		s.errors[k] += v
+	}
+}
+
+func (s *benchStats) errorCount() int {
+	n := 0
+	for _, v := range s.errors {
+		n += v
+	}
+	return n
+}
+
+func newBenchStats() *benchStats {
+	return &benchStats{errors: make(map[string]int)}
+}
+
+// RunBench runs the bench subcommand.
+func RunBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.Usage = func() { printBenchUsage(fs) }
+	target := fs.String("target", "http://localhost:8080", "base URL of the server to benchmark")
+	concurrency := fs.Int("concurrency", 10, "number of concurrent workers")
+	duration := fs.Duration("duration", 30*time.Second, "how long to run the benchmark")
+	timeout := fs.Duration("timeout", 10*time.Second, "per-request timeout")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", *concurrency)
+	}
+	if *duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", *duration)
+	}
+
+	client := sdk.NewClient(*target,
+		sdk.WithCallTimeout(*timeout),
+		sdk.WithHTTPClient(&http.Client{
+			Transport: &http.Transport{
+				MaxIdleConns:        *concurrency,
+				MaxIdleConnsPerHost: *concurrency,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		}),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ops, err := benchOps(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Benchmarking %s with %d worker(s) for %s\n", *target, *concurrency, *duration)
+
+	ctx, cancel := context.WithTimeout(ctx, *duration)
+	defer cancel()
+
+	start := time.Now()
+	results := make([][]*benchStats, *concurrency)
+
+	var wg sync.WaitGroup
+	for w := range *concurrency {
+		wg.Go(func() {
+			results[w] = benchWorker(ctx, client, ops, w)
+		})
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// Merge per-worker stats per endpoint
+	merged := make([]*benchStats, len(ops))
+	total := newBenchStats()
+	for i := range ops {
+		merged[i] = newBenchStats()
+		for _, worker := range results {
+			merged[i].merge(worker[i])
+		}
+		total.merge(merged[i])
+	}
+
+	printBenchReport(ops, merged, total, elapsed)
+	return nil
+}
+
+// benchOps returns the endpoints to exercise. GetGame is only included when
+// the server has at least one game to fetch.
+func benchOps(ctx context.Context, client *sdk.Client) ([]benchOp, error) {
+	ops := []benchOp{
+		{"ListGames", func(ctx context.Context, c *sdk.Client) error {
+			_, err := c.ListGames(ctx, nil)
+			return err
+		}},
+		{"GetLatestGame", func(ctx context.Context, c *sdk.Client) error {
+			_, err := c.GetLatestGame(ctx)
+			return err
+		}},
+	}
+
+	latest, err := client.GetLatestGame(ctx)
+	var apiErr *sdk.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+		fmt.Fprintln(os.Stderr, "no games found, skipping GetGame")
+		return ops, nil
+	case err != nil:
+		return nil, fmt.Errorf("reaching target: %w", err)
+	}
+
+	id := latest.ID
+	ops = append(ops, benchOp{"GetGame", func(ctx context.Context, c *sdk.Client) error {
+		_, err := c.GetGame(ctx, id)
+		return err
+	}})
+	return ops, nil
+}
+
+// benchWorker issues requests round-robin across ops until ctx is done. Each
+// worker starts at a different op so load is spread evenly.
+func benchWorker(ctx context.Context, client *sdk.Client, ops []benchOp, offset int) []*benchStats {
+	stats := make([]*benchStats, len(ops))
+	for i := range stats {
+		stats[i] = newBenchStats()
+	}
+
+	for n := offset; ctx.Err() == nil; n++ {
+		i := n % len(ops)
+
+		start := time.Now()
+		err := ops[i].call(ctx, client)
+		latency := time.Since(start)
+
+		// Requests cut short by the end of the run are not counted
+		if ctx.Err() != nil {
+			break
+		}
+
+		stats[i].latencies = append(stats[i].latencies, latency)
+		if err != nil {
+			stats[i].errors[benchErrorKind(err)]++
+		}
+	}
+
+	return stats
+}
+
+// benchErrorKind classifies an error for the report.
+func benchErrorKind(err error) string {
+	var apiErr *sdk.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return fmt.Sprintf("HTTP %d", apiErr.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "transport"
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p / 100 * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func printBenchReport(ops []benchOp, perOp []*benchStats, total *benchStats, elapsed time.Duration) {
+	fmt.Println()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "endpoint\trequests\terrors\treq/s\tp50\tp90\tp99\tmax\t")
+	row := func(name string, s *benchStats) {
+		slices.Sort(s.latencies)
+		n := len(s.latencies)
+		var maxLatency time.Duration
+		if n > 0 {
+			maxLatency = s.latencies[n-1]
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n",
+			name, n, s.errorCount(), float64(n)/elapsed.Seconds(),
+			roundLatency(percentile(s.latencies, 50)),
+			roundLatency(percentile(s.latencies, 90)),
+			roundLatency(percentile(s.latencies, 99)),
+			roundLatency(maxLatency),
+		)
+	}
+	for i, op := range ops {
+		row(op.name, perOp[i])
+	}
+	row("total", total)
+	_ = tw.Flush()
+
+	printBenchHistogram(total.latencies)
+
+	if len(total.errors) > 0 {
+		fmt.Println()
+		fmt.Println("Errors:")
+		kinds := make([]string, 0, len(total.errors))
+		for k := range total.errors {
+			kinds = append(kinds, k)
+		}
+		slices.Sort(kinds)
+		for _, k := range kinds {
+			fmt.Printf("  %-12s %d\n", k, total.errors[k])
+		}
+	}
+
+	fmt.Println()
+	errorRate := 0.0
+	if n := len(total.latencies); n > 0 {
+		errorRate = float64(total.errorCount()) / float64(n) * 100
+	}
+	fmt.Printf("Summary: %d request(s) in %s, %.1f req/s, %.2f%% error(s)\n",
+		len(total.latencies), elapsed.Round(time.Millisecond),
+		float64(len(total.latencies))/elapsed.Seconds(), errorRate)
+}
+
+// printBenchHistogram prints a bar chart of latencies across benchBuckets.
+func printBenchHistogram(latencies []time.Duration) {
+	if len(latencies) == 0 {
+		return
+	}
+
+	counts := make([]int, len(benchBuckets)+1)
+	for _, l := range latencies {
+		i, _ := slices.BinarySearch(benchBuckets, l)
+		counts[i]++
+	}
+
+	peak := slices.Max(counts)
+	const barWidth = 40
+
+	fmt.Println()
+	fmt.Println("Latency histogram:")
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		label := "> " + benchBuckets[len(benchBuckets)-1].String()
+		if i < len(benchBuckets) {
+			label = "<= " + benchBuckets[i].String()
+		}
+		bar := strings.Repeat("#", max(1, count*barWidth/peak))
+		fmt.Printf("  %8s  %-*s %d\n", label, barWidth, bar, count)
+	}
+}
+
+// roundLatency rounds a latency for display.
+func roundLatency(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(10 * time.Microsecond)
+}
+
+func printBenchUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo bench - REST throughput and latency benchmark
+
+Usage:
+  taboo bench [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo bench --target http://localhost:8080
+  taboo bench --target https://taboo.example.com --concurrency 50 --duration 1m
+`)
+}
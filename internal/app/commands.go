@@ -0,0 +1,237 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Globals holds the values of the global flags shared by every command.
+type Globals struct {
+	ConfigPath string
+	LogLevel   string
+	Verbose    bool
+	JSON       bool
+}
+
+// Flag describes a global flag for usage text, completions and the man page.
+// The flags themselves are registered by the caller with the flag package.
+type Flag struct {
+	Name  string // long name without dashes, e.g. "config"
+	Short string // optional single-letter alias
+	Arg   string // value placeholder, empty for booleans
+	Usage string
+
+	// Values are the accepted values offered by shell completion. Flags
+	// that take an argument but list no values complete file names.
+	Values []string
+}
+
+// Command describes a subcommand.
+type Command struct {
+	Name    string
+	Summary string
+
+	// Subcommands and Flags are the command's own arguments, listed for
+	// shell completion.
+	Subcommands []string
+	Flags       []string
+
+	Run func(g Globals, args []string) error
+}
+
+// GlobalFlags are the flags accepted before the command name.
+var GlobalFlags = []Flag{
+	{Name: "config", Short: "c", Arg: "string", Usage: `Config file path (default "./config.yaml")`},
+	{Name: "log-level", Arg: "string", Usage: "Override log level (debug, info, warn, error)", Values: []string{"debug", "info", "warn", "error"}},
+	{Name: "verbose", Short: "v", Usage: "Shorthand for --log-level=debug"},
+	{Name: "json", Usage: "Emit JSON output (migrate, db, routes, verify, version)"},
+}
+
+// usageExamples are shown at the end of the usage text and man page.
+var usageExamples = [][2]string{
+	{"taboo init", "Create config.yaml interactively"},
+	{"taboo serve", "Start with default config"},
+	{"taboo serve -c config.yaml", "Start with custom config"},
+	{"taboo serve --log-level debug", "Start with debug logging"},
+	{"taboo serve --dev", "Start with fast games and an in-memory database"},
+	{"taboo serve --deterministic 42", "Draw reproducible games for tests and demos"},
+	{"taboo mirror --source URL", "Serve a read-only replica of URL"},
+	{"taboo migrate up", "Apply all pending migrations"},
+	{"taboo migrate status", "Show migration status"},
+	{"taboo db analyze", "Report query plans and missing indexes"},
+	{"taboo db backup taboo-backup.db", "Back up the database to a new file"},
+	{"taboo seed --games 500 --since 30d", "Generate a month of demo history"},
+	{"taboo bench --concurrency 20", "Load test a local server for 30s"},
+	{"taboo record --duration 5m", "Record events to fixtures.jsonl"},
+	{"taboo healthcheck", "Check /readyz on localhost:8080"},
+	{"taboo routes", "List routes and their middleware"},
+	{"taboo verify", "Verify configuration and database"},
+	{"taboo --json migrate status", "Print migration status as JSON"},
+	{"taboo completion bash", "Print the bash completion script"},
+	{"taboo version", "Print version info"},
+}
+
+// Commands returns every subcommand in the order shown in usage text.
+func Commands() []Command {
+	return []Command{
+		{
+			Name:    "serve",
+			Summary: "Start the HTTP server",
+			Flags:   []string{"--dev", "--deterministic", "--time-scale"},
+			Run: func(g Globals, args []string) error {
+				return RunServe(g.ConfigPath, g.LogLevel, g.Verbose, args)
+			},
+		},
+		{
+			Name:    "mirror",
+			Summary: "Serve a read-only replica synced from another server",
+			Flags:   []string{"--source", "--interval"},
+			Run: func(g Globals, args []string) error {
+				return RunMirror(g.ConfigPath, g.LogLevel, g.Verbose, args)
+			},
+		},
+		{
+			Name:        "migrate",
+			Summary:     "Manage database migrations",
+			Subcommands: []string{"up", "down", "status"},
+			Run: func(g Globals, args []string) error {
+				return RunMigrate(g.ConfigPath, args, g.JSON)
+			},
+		},
+		{
+			Name:        "db",
+			Summary:     "Database maintenance and diagnostics",
+			Subcommands: []string{"analyze", "audit", "vacuum", "backup"},
+			Run: func(g Globals, args []string) error {
+				return RunDB(g.ConfigPath, args, g.JSON)
+			},
+		},
+		{
+			Name:    "seed",
+			Summary: "Generate historical games for demos",
+			Flags:   []string{"--games", "--since"},
+			Run: func(g Globals, args []string) error {
+				return RunSeed(g.ConfigPath, g.LogLevel, g.Verbose, args)
+			},
+		},
+		{
+			Name:    "bench",
+			Summary: "Benchmark REST throughput against a running server",
+			Flags:   []string{"--target", "--concurrency", "--duration", "--timeout"},
+			Run: func(_ Globals, args []string) error {
+				return RunBench(args)
+			},
+		},
+		{
+			Name:    "record",
+			Summary: "Record the live event stream as a replay fixture",
+			Flags:   []string{"--target", "--duration", "--out"},
+			Run: func(_ Globals, args []string) error {
+				return RunRecord(args)
+			},
+		},
+		{
+			Name:    "healthcheck",
+			Summary: "Exit non-zero unless a running server is ready",
+			Flags:   []string{"--url", "--timeout"},
+			Run: func(_ Globals, args []string) error {
+				return RunHealthcheck(args)
+			},
+		},
+		{
+			Name:    "init",
+			Summary: "Interactively create a config file",
+			Flags:   []string{"--output", "--force"},
+			Run: func(g Globals, args []string) error {
+				return RunInit(g.ConfigPath, args)
+			},
+		},
+		{
+			Name:    "routes",
+			Summary: "List HTTP routes and their middleware",
+			Run: func(g Globals, _ []string) error {
+				return RunRoutes(g.ConfigPath, g.JSON)
+			},
+		},
+		{
+			Name:    "verify",
+			Summary: "Verify configuration and database",
+			Run: func(g Globals, _ []string) error {
+				return RunVerify(g.ConfigPath, g.JSON)
+			},
+		},
+		{
+			Name:        "completion",
+			Summary:     "Print a shell completion script",
+			Subcommands: []string{"bash", "zsh", "fish"},
+			Run: func(_ Globals, args []string) error {
+				return RunCompletion(args)
+			},
+		},
+		{
+			Name:        "docs",
+			Summary:     "Generate documentation",
+			Subcommands: []string{"man"},
+			Run: func(_ Globals, args []string) error {
+				return RunDocs(args)
+			},
+		},
+		{
+			Name:    "version",
+			Summary: "Print version information",
+			Run: func(g Globals, _ []string) error {
+				return RunVersion(g.JSON)
+			},
+		},
+		{
+			Name:    "help",
+			Summary: "Show this help message",
+		},
+	}
+}
+
+// LookupCommand returns the command with the given name.
+func LookupCommand(name string) (Command, bool) {
+	for _, cmd := range Commands() {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return Command{}, false
+}
+
+// PrintUsage writes the top-level usage text to w.
+func PrintUsage(w io.Writer) {
+	fmt.Fprintf(w, "taboo - Keno-style lottery visualization\n\n")
+	fmt.Fprintf(w, "Usage:\n  taboo [flags] <command>\n\n")
+
+	fmt.Fprintf(w, "Commands:\n")
+	for _, cmd := range Commands() {
+		fmt.Fprintf(w, "  %-11s  %s\n", cmd.Name, cmd.Summary)
+	}
+
+	fmt.Fprintf(w, "\nFlags:\n")
+	for _, f := range GlobalFlags {
+		fmt.Fprintf(w, "  %-23s  %s\n", f.synopsis(), f.Usage)
+	}
+
+	fmt.Fprintf(w, "\nExamples:\n")
+	for _, ex := range usageExamples {
+		fmt.Fprintf(w, "  %-34s  %s\n", ex[0], ex[1])
+	}
+}
+
+// synopsis renders the flag as it appears in usage text, e.g.
+// "-c, --config string".
+func (f Flag) synopsis() string {
+	var b strings.Builder
+	if f.Short != "" {
+		b.WriteString("-" + f.Short + ", ")
+	}
+	b.WriteString("--" + f.Name)
+	if f.Arg != "" {
+		b.WriteString(" " + f.Arg)
+	}
+	return b.String()
+}
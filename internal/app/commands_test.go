@@ -0,0 +1,60 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompletionScripts_CoverCommands(t *testing.T) {
+	noEscape := func(s string) string { return s }
+	writers := map[string]struct {
+		write  func(*bytes.Buffer)
+		escape func(string) string
+	}{
+		"bash": {func(b *bytes.Buffer) { writeBashCompletion(b) }, noEscape},
+		"zsh":  {func(b *bytes.Buffer) { writeZshCompletion(b) }, noEscape},
+		"fish": {func(b *bytes.Buffer) { writeFishCompletion(b) }, noEscape},
+		"man":  {func(b *bytes.Buffer) { writeManPage(b) }, roffEscape},
+	}
+
+	for name, w := range writers {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w.write(&buf)
+			out := buf.String()
+
+			for _, cmd := range Commands() {
+				if !strings.Contains(out, w.escape(cmd.Name)) {
+					t.Errorf("missing command %q", cmd.Name)
+				}
+				for _, sub := range cmd.Subcommands {
+					if !strings.Contains(out, w.escape(sub)) {
+						t.Errorf("missing %s argument %q", cmd.Name, sub)
+					}
+				}
+			}
+			for _, f := range GlobalFlags {
+				if !strings.Contains(out, w.escape(f.Name)) {
+					t.Errorf("missing flag %q", f.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestRoffEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"--json", `\-\-json`},
+		{".hidden", `\&.hidden`},
+		{`C:\path`, `C:\epath`},
+	}
+
+	for _, tt := range tests {
+		if got := roffEscape(tt.in); got != tt.want {
+			t.Errorf("roffEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
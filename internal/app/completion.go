@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// RunCompletion runs the completion subcommand, printing a completion script
+// for the requested shell generated from the command registry.
+func RunCompletion(args []string) error {
+	if len(args) == 0 {
+		printCompletionUsage()
+		return nil
+	}
+
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(os.Stdout)
+	case "zsh":
+		writeZshCompletion(os.Stdout)
+	case "fish":
+		writeFishCompletion(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell %q (expected bash, zsh or fish)", args[0])
+	}
+	return nil
+}
+
+func writeBashCompletion(w io.Writer) {
+	var names, globals, argFlags []string
+	for _, cmd := range Commands() {
+		names = append(names, cmd.Name)
+	}
+	for _, f := range GlobalFlags {
+		forms := []string{"--" + f.Name}
+		if f.Short != "" {
+			forms = append(forms, "-"+f.Short)
+		}
+		globals = append(globals, forms...)
+		if f.Arg != "" {
+			argFlags = append(argFlags, strings.Join(forms, "|"))
+		}
+	}
+
+	fmt.Fprintf(w, "# bash completion for taboo\n\n")
+	fmt.Fprintf(w, "_taboo() {\n")
+	fmt.Fprintf(w, "    local cur prev cmd i\n")
+	fmt.Fprintf(w, "    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(w, "    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+
+	// Values for global flags
+	fmt.Fprintf(w, "    case \"$prev\" in\n")
+	for _, f := range GlobalFlags {
+		if f.Arg == "" {
+			continue
+		}
+		pattern := "--" + f.Name
+		if f.Short != "" {
+			pattern = "-" + f.Short + "|" + pattern
+		}
+		if len(f.Values) > 0 {
+			fmt.Fprintf(w, "        %s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", pattern, strings.Join(f.Values, " "))
+		} else {
+			fmt.Fprintf(w, "        %s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", pattern)
+		}
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	// Find the command, skipping global flags and their values
+	fmt.Fprintf(w, "    cmd=\"\"\n")
+	fmt.Fprintf(w, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(w, "        case \"${COMP_WORDS[i]}\" in\n")
+	if len(argFlags) > 0 {
+		fmt.Fprintf(w, "            %s) ((i++)) ;;\n", strings.Join(argFlags, "|"))
+	}
+	fmt.Fprintf(w, "            -*) ;;\n")
+	fmt.Fprintf(w, "            *) cmd=\"${COMP_WORDS[i]}\"; break ;;\n")
+	fmt.Fprintf(w, "        esac\n")
+	fmt.Fprintf(w, "    done\n\n")
+
+	fmt.Fprintf(w, "    case \"$cmd\" in\n")
+	fmt.Fprintf(w, "        \"\") COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(append(names, globals...), " "))
+	for _, cmd := range Commands() {
+		if words := cmd.completionWords(); len(words) > 0 {
+			fmt.Fprintf(w, "        %s) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", cmd.Name, strings.Join(words, " "))
+		}
+	}
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "complete -F _taboo taboo\n")
+}
+
+func writeZshCompletion(w io.Writer) {
+	fmt.Fprintf(w, "#compdef taboo\n\n")
+	fmt.Fprintf(w, "_taboo() {\n")
+	fmt.Fprintf(w, "    local -a commands\n")
+	fmt.Fprintf(w, "    commands=(\n")
+	for _, cmd := range Commands() {
+		fmt.Fprintf(w, "        '%s:%s'\n", cmd.Name, zshEscape(cmd.Summary))
+	}
+	fmt.Fprintf(w, "    )\n\n")
+
+	fmt.Fprintf(w, "    _arguments -C \\\n")
+	for _, f := range GlobalFlags {
+		spec := fmt.Sprintf("'--%s[%s]", f.Name, zshEscape(f.Usage))
+		if f.Short != "" {
+			spec = fmt.Sprintf("'(-%s --%s)'{-%s,--%s}'[%s]", f.Short, f.Name, f.Short, f.Name, zshEscape(f.Usage))
+		}
+		switch {
+		case f.Arg != "" && len(f.Values) > 0:
+			spec += fmt.Sprintf(":%s:(%s)", f.Arg, strings.Join(f.Values, " "))
+		case f.Arg != "":
+			spec += fmt.Sprintf(":%s:_files", f.Arg)
+		}
+		fmt.Fprintf(w, "        %s' \\\n", spec)
+	}
+	fmt.Fprintf(w, "        '1:command:->command' \\\n")
+	fmt.Fprintf(w, "        '*::arg:->args'\n\n")
+
+	fmt.Fprintf(w, "    case $state in\n")
+	fmt.Fprintf(w, "        command) _describe 'command' commands ;;\n")
+	fmt.Fprintf(w, "        args)\n")
+	fmt.Fprintf(w, "            case $words[1] in\n")
+	for _, cmd := range Commands() {
+		if words := cmd.completionWords(); len(words) > 0 {
+			fmt.Fprintf(w, "                %s) compadd -- %s ;;\n", cmd.Name, strings.Join(words, " "))
+		}
+	}
+	fmt.Fprintf(w, "            esac\n")
+	fmt.Fprintf(w, "            ;;\n")
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	// Support both autoloading from fpath and sourcing directly
+	fmt.Fprintf(w, "if [ \"$funcstack[1]\" = \"_taboo\" ]; then\n")
+	fmt.Fprintf(w, "    _taboo \"$@\"\n")
+	fmt.Fprintf(w, "else\n")
+	fmt.Fprintf(w, "    compdef _taboo taboo\n")
+	fmt.Fprintf(w, "fi\n")
+}
+
+func writeFishCompletion(w io.Writer) {
+	fmt.Fprintf(w, "# fish completion for taboo\n\n")
+	fmt.Fprintf(w, "complete -c taboo -f\n")
+
+	for _, f := range GlobalFlags {
+		line := "complete -c taboo"
+		if f.Short != "" {
+			line += " -s " + f.Short
+		}
+		line += " -l " + f.Name
+		switch {
+		case f.Arg != "" && len(f.Values) > 0:
+			line += fmt.Sprintf(" -x -a '%s'", strings.Join(f.Values, " "))
+		case f.Arg != "":
+			line += " -r -F"
+		}
+		fmt.Fprintf(w, "%s -d '%s'\n", line, fishEscape(f.Usage))
+	}
+
+	for _, cmd := range Commands() {
+		fmt.Fprintf(w, "complete -c taboo -n __fish_use_subcommand -a %s -d '%s'\n", cmd.Name, fishEscape(cmd.Summary))
+	}
+
+	for _, cmd := range Commands() {
+		condition := "'__fish_seen_subcommand_from " + cmd.Name + "'"
+		if len(cmd.Subcommands) > 0 {
+			fmt.Fprintf(w, "complete -c taboo -n %s -a '%s'\n", condition, strings.Join(cmd.Subcommands, " "))
+		}
+		for _, flag := range cmd.Flags {
+			fmt.Fprintf(w, "complete -c taboo -n %s -l %s -r\n", condition, strings.TrimPrefix(flag, "--"))
+		}
+	}
+}
+
+// completionWords returns the words offered after the command name.
+func (c Command) completionWords() []string {
+	return append(append([]string{}, c.Subcommands...), c.Flags...)
+}
+
+// zshEscape escapes text for use inside a single-quoted _arguments spec or
+// _describe entry.
+func zshEscape(s string) string {
+	return strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(s)
+}
+
+// fishEscape escapes text for use inside single quotes.
+func fishEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+func printCompletionUsage() {
+	fmt.Fprintf(os.Stderr, `taboo completion - Shell completion scripts
+
+Usage:
+  taboo completion <bash|zsh|fish>
+
+Examples:
+  source <(taboo completion bash)                          Enable for the current bash session
+  taboo completion zsh > "${fpath[1]}/_taboo"              Install for zsh
+  taboo completion fish > ~/.config/fish/completions/taboo.fish
+`)
+}
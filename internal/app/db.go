@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+)
+
+// slowQueryThreshold is the execution time above which analyze flags a query.
+const slowQueryThreshold = 50 * time.Millisecond
+
+// maintenanceResult is the JSON form of the vacuum and backup commands.
+type maintenanceResult struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path,omitempty"` // the backup written
+}
+
+// RunDB runs the db subcommand.
+func RunDB(configPath string, args []string, jsonOut bool) error {
+	if len(args) == 0 {
+		printDBUsage()
+		return nil
+	}
+
+	// Load config for database DSN
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	switch args[0] {
+	case "analyze":
+		return runDBAnalyze(cfg, jsonOut)
+	case "audit":
+		return runDBAudit(cfg, args[1:], jsonOut)
+	case "vacuum":
+		return runDBVacuum(cfg, jsonOut)
+	case "backup":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: taboo db backup <path>")
+		}
+		return runDBBackup(cfg, args[1], jsonOut)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown db command: %s\n\n", args[0])
+		printDBUsage()
+		return nil
+	}
+}
+
+func runDBAnalyze(cfg *config.Config, jsonOut bool) error {
+	st, err := openDBStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	plans, err := st.ExplainQueries(ctx)
+	if err != nil {
+		return fmt.Errorf("analyzing queries: %w", err)
+	}
+
+	c := lint.NewCollector()
+	for _, p := range plans {
+		location := "query." + p.Name
+		c.Infof("query-plan", location, "%s (%s)", strings.Join(p.Steps, "; "), p.Duration.Round(time.Microsecond))
+
+		if p.FullScan() {
+			c.Warn("index-missing", location, "query scans the full table; consider adding an index")
+		}
+		if p.TempSort() {
+			c.Warn("temp-sort", location, "query sorts with a temporary b-tree; consider an index matching ORDER BY")
+		}
+		if p.Duration > slowQueryThreshold {
+			c.Warnf("query-slow", location, "query took %s (threshold %s)", p.Duration.Round(time.Millisecond), slowQueryThreshold)
+		}
+	}
+
+	issues := c.Issues()
+	if jsonOut {
+		return writeJSON(newIssueReport(issues))
+	}
+
+	// Print all issues
+	fmt.Println()
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	fmt.Println()
+
+	_, warnCount, infoCount := issues.Count()
+	fmt.Printf("Summary: %d query(s) analyzed, %d warning(s), %d info\n", len(plans), warnCount, infoCount)
+
+	return nil
+}
+
+func runDBAudit(cfg *config.Config, args []string, jsonOut bool) error {
+	fs := flag.NewFlagSet("db audit", flag.ContinueOnError)
+	quarantine := fs.Bool("quarantine", false, "move games that fail the audit into the quarantined_games table")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	st, err := openDBStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	audit, err := st.AuditGames(ctx, cfg.Game)
+	if err != nil {
+		return fmt.Errorf("auditing games: %w", err)
+	}
+
+	c := lint.NewCollector().Merge(audit.Issues)
+	if *quarantine && len(audit.Bad) > 0 {
+		if err := st.Quarantine(ctx, audit.Bad); err != nil {
+			return fmt.Errorf("quarantining games: %w", err)
+		}
+		c.Infof("games-quarantined", "quarantined_games", "moved %d game(s) out of the games table", len(audit.Bad))
+	}
+
+	issues := c.Issues()
+	if jsonOut {
+		if err := writeJSON(newIssueReport(issues)); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		fmt.Println()
+
+		errorCount, warnCount, infoCount := issues.Count()
+		fmt.Printf("Summary: %d game(s) audited, %d error(s), %d warning(s), %d info\n", audit.Games, errorCount, warnCount, infoCount)
+	}
+
+	// Bad games left in place fail the command, so scripts notice them
+	if len(audit.Bad) > 0 && !*quarantine {
+		return fmt.Errorf("%d game(s) failed the audit; rerun with --quarantine to set them aside", len(audit.Bad))
+	}
+	return nil
+}
+
+// openDBStore opens the configured database without migrating it, with
+// database.query_timeout bounding each query as it does when serving.
+func openDBStore(cfg *config.Config) (*sqlite.Store, error) {
+	return sqlite.Open(cfg.Database.DSN, sqlite.WithQueryTimeout(cfg.Database.QueryTimeout.Duration()))
+}
+
+func runDBVacuum(cfg *config.Config, jsonOut bool) error {
+	db, err := sqlite.OpenDB(cfg.Database.DSN)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.Vacuum(context.Background(), db); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return writeJSON(maintenanceResult{Operation: "vacuum"})
+	}
+	fmt.Println("Database vacuumed")
+	return nil
+}
+
+func runDBBackup(cfg *config.Config, path string, jsonOut bool) error {
+	db, err := sqlite.OpenDB(cfg.Database.DSN)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	if err := sqlite.Backup(context.Background(), db, path); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return writeJSON(maintenanceResult{Operation: "backup", Path: path})
+	}
+	fmt.Printf("Database backed up to %s\n", path)
+	return nil
+}
+
+func printDBUsage() {
+	fmt.Fprintf(os.Stderr, `taboo db - Database maintenance and diagnostics
+
+Usage:
+  taboo db <command>
+
+Commands:
+  analyze     Report query plans, missing indexes, and slow queries
+  audit       Check every stored game for invalid picks and out-of-order rows
+  vacuum      Rebuild the database to reclaim free space
+  backup      Write a compacted copy of the database to a new file
+
+Examples:
+  taboo db analyze                Analyze hot queries against the configured database
+  taboo db audit                  Report games that break the configured game rules
+  taboo db audit --quarantine     Also move failing games into quarantined_games
+  taboo db vacuum                 Reclaim space left by deleted rows
+  taboo db backup taboo-backup.db Back up the configured database
+
+The last vacuum and backup times are reported by GET /api/v1/admin/db/stats.
+`)
+	flag.PrintDefaults()
+}
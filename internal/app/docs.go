@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// RunDocs runs the docs subcommand.
+func RunDocs(args []string) error {
+	if len(args) == 0 {
+		printDocsUsage()
+		return nil
+	}
+
+	switch args[0] {
+	case "man":
+		writeManPage(os.Stdout)
+		return nil
+	default:
+		fmt.Fprintf(os.Stderr, "unknown docs command: %s\n\n", args[0])
+		printDocsUsage()
+		return nil
+	}
+}
+
+// writeManPage writes a taboo(1) man page in roff generated from the command
+// registry.
+func writeManPage(w io.Writer) {
+	fmt.Fprintf(w, ".TH TABOO 1 \"\" \"taboo %s\" \"User Commands\"\n", roffEscape(Version))
+
+	fmt.Fprintf(w, ".SH NAME\n")
+	fmt.Fprintf(w, "taboo \\- Keno\\-style lottery visualization\n")
+
+	fmt.Fprintf(w, ".SH SYNOPSIS\n")
+	fmt.Fprintf(w, ".B taboo\n")
+	fmt.Fprintf(w, "[\\fIflags\\fR] \\fIcommand\\fR [\\fIarguments\\fR]\n")
+
+	fmt.Fprintf(w, ".SH DESCRIPTION\n")
+	fmt.Fprintf(w, "taboo runs a Keno\\-style draw engine and serves game history over a REST API\n")
+	fmt.Fprintf(w, "and live game events over Server\\-Sent Events.\n")
+
+	fmt.Fprintf(w, ".SH COMMANDS\n")
+	for _, cmd := range Commands() {
+		fmt.Fprintf(w, ".TP\n.B %s\n%s\n", roffEscape(cmd.Name), roffEscape(cmd.Summary))
+		if words := cmd.completionWords(); len(words) > 0 {
+			fmt.Fprintf(w, ".br\nArguments: %s\n", roffEscape(strings.Join(words, ", ")))
+		}
+	}
+
+	fmt.Fprintf(w, ".SH OPTIONS\n")
+	for _, f := range GlobalFlags {
+		fmt.Fprintf(w, ".TP\n.B %s\n%s\n", roffEscape(f.synopsis()), roffEscape(f.Usage))
+	}
+
+	fmt.Fprintf(w, ".SH ENVIRONMENT\n")
+	fmt.Fprintf(w, "Every configuration value can be overridden with a TABOO_* environment variable,\n")
+	fmt.Fprintf(w, "for example TABOO_SERVER_PORT or TABOO_DATABASE_DSN.\n")
+
+	fmt.Fprintf(w, ".SH EXAMPLES\n")
+	for _, ex := range usageExamples {
+		fmt.Fprintf(w, ".TP\n.B %s\n%s\n", roffEscape(ex[0]), roffEscape(ex[1]))
+	}
+}
+
+// roffEscape escapes text for a roff text line.
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, `-`, `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+func printDocsUsage() {
+	fmt.Fprintf(os.Stderr, `taboo docs - Documentation generation
+
+Usage:
+  taboo docs <command>
+
+Commands:
+  man         Print the taboo(1) man page in roff format
+
+Examples:
+  taboo docs man > taboo.1        Write the man page
+  taboo docs man | man -l -       Preview the man page
+`)
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxHealthcheckBody caps how much of the /readyz response is read.
+const maxHealthcheckBody = 64 << 10
+
+// RunHealthcheck runs the healthcheck subcommand. It probes /readyz on a
+// running server and returns an error unless the server reports ready, so it
+// can be used as a container HEALTHCHECK without curl or wget in the image.
+func RunHealthcheck(args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	fs.Usage = func() { printHealthcheckUsage(fs) }
+	url := fs.String("url", "http://localhost:8080", "base URL of the server to check")
+	timeout := fs.Duration("timeout", 5*time.Second, "how long to wait for a response")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	return checkReady(ctx, http.DefaultClient, *url)
+}
+
+// checkReady requests baseURL/readyz and returns an error describing the
+// failing checks when the response is not 200 OK.
+func checkReady(ctx context.Context, client *http.Client, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/readyz", nil)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("server unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var body struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxHealthcheckBody))
+	if err := json.Unmarshal(data, &body); err != nil || len(body.Checks) == 0 {
+		return fmt.Errorf("server not ready: HTTP %d", resp.StatusCode)
+	}
+
+	var failing []string
+	for _, name := range slices.Sorted(maps.Keys(body.Checks)) {
+		if v := body.Checks[name]; v != "ok" {
+			failing = append(failing, name+": "+v)
+		}
+	}
+	return fmt.Errorf("server not ready: HTTP %d (%s)", resp.StatusCode, strings.Join(failing, ", "))
+}
+
+func printHealthcheckUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo healthcheck - Probe a running server's readiness
+
+Exits with status 0 when GET /readyz returns 200 OK and 1 otherwise.
+
+Usage:
+  taboo healthcheck [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo healthcheck
+  taboo healthcheck --url http://localhost:9090 --timeout 2s
+`)
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckReady(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"ready", http.StatusOK, `{"status":"ok","checks":{"database":"ok","engine":"ok"}}`, ""},
+		{"degraded", http.StatusServiceUnavailable, `{"status":"degraded","checks":{"database":"ok","engine":"stalled"}}`, "engine: stalled"},
+		{"no body", http.StatusBadGateway, ``, "HTTP 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/readyz" {
+					http.NotFound(w, r)
+					return
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := checkReady(context.Background(), srv.Client(), srv.URL+"/")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkReady() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkReady() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckReady_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	if err := checkReady(context.Background(), http.DefaultClient, url); err == nil {
+		t.Fatal("expected an error for an unreachable server")
+	}
+}
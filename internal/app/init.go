@@ -0,0 +1,238 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+	"github.com/golang-migrate/migrate/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// RunInit runs the init subcommand, interactively generating a config file.
+func RunInit(configPath string, args []string) error {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.Usage = func() { printInitUsage(fs) }
+	output := fs.String("output", configPath, "path of the config file to write")
+	force := fs.Bool("force", false, "overwrite an existing config file")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	return runInit(os.Stdin, os.Stdout, *output, *force)
+}
+
+// runInit prompts on in/out for the common settings, writes the config to
+// path, lints it and optionally applies migrations.
+func runInit(in io.Reader, out io.Writer, path string, force bool) error {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+	}
+
+	p := &prompter{in: bufio.NewReader(in), out: out}
+	cfg := config.Default()
+
+	fmt.Fprintf(out, "Creating %s. Press enter to accept the default shown in brackets.\n\n", path)
+
+	cfg.Environment = p.choice("Environment", cfg.Environment, "development", "production")
+	cfg.Server.Port = p.number("Server port", cfg.Server.Port)
+	cfg.Database.DSN = p.text("Database file", cfg.Database.DSN)
+
+	cfg.Discord.ClientID = p.text("Discord client ID (blank to skip)", cfg.Discord.ClientID)
+	if cfg.Discord.ClientID != "" {
+		cfg.Discord.ClientSecret = p.text("Discord client secret", cfg.Discord.ClientSecret)
+	}
+
+	cfg.Game.PickCount = p.number("Picks per game", cfg.Game.PickCount)
+	cfg.Game.MinNumber = p.number("Lowest number", cfg.Game.MinNumber)
+	cfg.Game.MaxNumber = p.number("Highest number", cfg.Game.MaxNumber)
+	cfg.Game.DrawDuration = p.duration("Draw duration", cfg.Game.DrawDuration)
+	cfg.Game.WaitDuration = p.duration("Wait between games", cfg.Game.WaitDuration)
+
+	if p.err != nil {
+		return fmt.Errorf("reading input: %w", p.err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	// The file may hold the Discord secret
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Fprintf(out, "\nWrote %s\n", path)
+
+	c := lint.NewCollector()
+	c.Merge(config.Lint(cfg))
+
+	if !c.HasErrors() && p.confirm("Apply database migrations now?", true) {
+		if err := applyMigrations(cfg); err != nil {
+			c.Errorf("migrations-error", "database", "failed to apply migrations: %v", err)
+		}
+	}
+	if !c.HasErrors() {
+		verifyDatabase(c, cfg)
+	}
+
+	issues := c.Issues()
+	fmt.Fprintln(out)
+	for _, issue := range issues {
+		fmt.Fprintln(out, issue)
+	}
+	fmt.Fprintln(out)
+
+	errorCount, warnCount, infoCount := issues.Count()
+	fmt.Fprintf(out, "Summary: %d error(s), %d warning(s), %d info\n", errorCount, warnCount, infoCount)
+
+	if errorCount > 0 {
+		return fmt.Errorf("%s has %d error(s); fix them and run taboo verify", path, errorCount)
+	}
+	return nil
+}
+
+// applyMigrations brings the configured database up to date.
+func applyMigrations(cfg *config.Config) error {
+	db, err := sqlite.OpenDB(cfg.Database.DSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := sqlite.NewMigrate(db)
+	if err != nil {
+		return err
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// prompter asks questions on out and reads answers from in. Invalid answers
+// are asked again; once input fails every later prompt keeps its default
+// and the first error is kept in err.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	err error
+}
+
+// ask prints the prompt and returns the trimmed answer, or def when the
+// answer is blank.
+func (p *prompter) ask(label, def string) string {
+	if p.err != nil {
+		return def
+	}
+
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		p.err = err
+		return def
+	}
+	if err != nil && line == "" {
+		// End of input accepts the remaining defaults
+		fmt.Fprintln(p.out)
+		return def
+	}
+
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return def
+}
+
+func (p *prompter) text(label, def string) string {
+	return p.ask(label, def)
+}
+
+func (p *prompter) number(label string, def int) int {
+	for {
+		answer := p.ask(label, strconv.Itoa(def))
+		n, err := strconv.Atoi(answer)
+		if err == nil {
+			return n
+		}
+		fmt.Fprintf(p.out, "  %q is not a number\n", answer)
+	}
+}
+
+func (p *prompter) duration(label string, def config.Duration) config.Duration {
+	for {
+		answer := p.ask(label, def.Duration().String())
+		if d, err := time.ParseDuration(answer); err == nil && d > 0 {
+			return config.Duration(d)
+		}
+		fmt.Fprintf(p.out, "  %q is not a duration (e.g. 90s, 2m)\n", answer)
+	}
+}
+
+func (p *prompter) choice(label, def string, options ...string) string {
+	for {
+		answer := p.ask(fmt.Sprintf("%s (%s)", label, strings.Join(options, "/")), def)
+		for _, o := range options {
+			if strings.EqualFold(answer, o) {
+				return o
+			}
+		}
+		fmt.Fprintf(p.out, "  expected one of: %s\n", strings.Join(options, ", "))
+	}
+}
+
+func (p *prompter) confirm(label string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		answer := p.ask(fmt.Sprintf("%s (%s)", label, hint), "")
+		switch strings.ToLower(answer) {
+		case "":
+			return def
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+		fmt.Fprintln(p.out, "  please answer y or n")
+	}
+}
+
+func printInitUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo init - Interactive first-run setup
+
+Prompts for the common settings, writes a config file, checks it as
+taboo verify would and optionally applies database migrations.
+
+Usage:
+  taboo init [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo init                          Write ./config.yaml
+  taboo init --output /etc/taboo.yaml Write the config elsewhere
+`)
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestRunInit_WritesConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	dsn := filepath.Join(dir, "taboo.db")
+
+	input := strings.Join([]string{
+		"staging",    // rejected, asked again
+		"production", // environment
+		"9090",       // port
+		dsn,          // database
+		"",           // skip Discord
+		"10",         // picks
+		"",           // min number
+		"40",         // max number
+		"soon",       // rejected, asked again
+		"45s",        // draw duration
+		"",           // wait duration
+		"y",          // apply migrations
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	if err := runInit(strings.NewReader(input), &out, path, false); err != nil {
+		t.Fatalf("runInit() error: %v\n%s", err, out.String())
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("loading written config: %v", err)
+	}
+	if cfg.Environment != "production" || cfg.Server.Port != 9090 || cfg.Database.DSN != dsn {
+		t.Errorf("unexpected config: env=%s port=%d dsn=%s", cfg.Environment, cfg.Server.Port, cfg.Database.DSN)
+	}
+	if cfg.Game.PickCount != 10 || cfg.Game.MaxNumber != 40 || cfg.Game.DrawDuration.Duration() != 45*time.Second {
+		t.Errorf("unexpected game config: %+v", cfg.Game)
+	}
+	if !strings.Contains(out.String(), "migrations-current") {
+		t.Errorf("expected migrations to be applied, got:\n%s", out.String())
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("config permissions = %o, want 600", perm)
+	}
+}
+
+func TestRunInit_RefusesOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("environment: development\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runInit(strings.NewReader(""), &out, path, false); err == nil {
+		t.Fatal("expected an error for an existing config file")
+	}
+}
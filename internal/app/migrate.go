@@ -12,8 +12,15 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 )
 
+// migrateStatus is the JSON form of the migrate commands.
+type migrateStatus struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	Applied bool `json:"applied"` // false when no migrations have been applied
+}
+
 // RunMigrate runs the migrate subcommand.
-func RunMigrate(configPath string, args []string) error {
+func RunMigrate(configPath string, args []string, jsonOut bool) error {
 	if len(args) == 0 {
 		printMigrateUsage()
 		return nil
@@ -40,11 +47,11 @@ func RunMigrate(configPath string, args []string) error {
 
 	switch args[0] {
 	case "up":
-		return runMigrateUp(m, args[1:])
+		return runMigrateUp(m, args[1:], jsonOut)
 	case "down":
-		return runMigrateDown(m, args[1:])
+		return runMigrateDown(m, args[1:], jsonOut)
 	case "status":
-		return runMigrateStatus(m)
+		return runMigrateStatus(m, jsonOut)
 	default:
 		fmt.Fprintf(os.Stderr, "unknown migrate command: %s\n\n", args[0])
 		printMigrateUsage()
@@ -52,7 +59,7 @@ func RunMigrate(configPath string, args []string) error {
 	}
 }
 
-func runMigrateUp(m *migrate.Migrate, args []string) error {
+func runMigrateUp(m *migrate.Migrate, args []string, jsonOut bool) error {
 	if len(args) > 0 {
 		// Apply N migrations
 		n, err := strconv.Atoi(args[0])
@@ -62,25 +69,23 @@ func runMigrateUp(m *migrate.Migrate, args []string) error {
 		if err := m.Steps(n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 			return fmt.Errorf("applying migrations: %w", err)
 		}
-		fmt.Printf("Applied %d migration(s)\n", n)
+		if !jsonOut {
+			fmt.Printf("Applied %d migration(s)\n", n)
+		}
 	} else {
 		// Apply all migrations
 		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 			return fmt.Errorf("applying migrations: %w", err)
 		}
-		fmt.Println("Applied all pending migrations")
-	}
-
-	version, dirty, err := m.Version()
-	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
-		return fmt.Errorf("getting version: %w", err)
+		if !jsonOut {
+			fmt.Println("Applied all pending migrations")
+		}
 	}
-	fmt.Printf("Current version: %d (dirty: %t)\n", version, dirty)
 
-	return nil
+	return printMigrateVersion(m, jsonOut)
 }
 
-func runMigrateDown(m *migrate.Migrate, args []string) error {
+func runMigrateDown(m *migrate.Migrate, args []string, jsonOut bool) error {
 	n := 1
 	if len(args) > 0 {
 		var err error
@@ -93,20 +98,34 @@ func runMigrateDown(m *migrate.Migrate, args []string) error {
 	if err := m.Steps(-n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return fmt.Errorf("rolling back migrations: %w", err)
 	}
-	fmt.Printf("Rolled back %d migration(s)\n", n)
+	if !jsonOut {
+		fmt.Printf("Rolled back %d migration(s)\n", n)
+	}
 
+	return printMigrateVersion(m, jsonOut)
+}
+
+// printMigrateVersion reports the version after up or down.
+func printMigrateVersion(m *migrate.Migrate, jsonOut bool) error {
 	version, dirty, err := m.Version()
 	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
 		return fmt.Errorf("getting version: %w", err)
 	}
+
+	if jsonOut {
+		return writeJSON(migrateStatus{Version: version, Dirty: dirty, Applied: err == nil})
+	}
 	fmt.Printf("Current version: %d (dirty: %t)\n", version, dirty)
 
 	return nil
 }
 
-func runMigrateStatus(m *migrate.Migrate) error {
+func runMigrateStatus(m *migrate.Migrate, jsonOut bool) error {
 	version, dirty, err := m.Version()
 	if errors.Is(err, migrate.ErrNilVersion) {
+		if jsonOut {
+			return writeJSON(migrateStatus{})
+		}
 		fmt.Println("No migrations have been applied")
 		return nil
 	}
@@ -114,6 +133,10 @@ func runMigrateStatus(m *migrate.Migrate) error {
 		return fmt.Errorf("getting version: %w", err)
 	}
 
+	if jsonOut {
+		return writeJSON(migrateStatus{Version: version, Dirty: dirty, Applied: true})
+	}
+
 	fmt.Printf("Current version: %d\n", version)
 	if dirty {
 		fmt.Println("Status: DIRTY (migration failed, manual intervention required)")
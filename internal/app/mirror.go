@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/http"
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// RunMirror runs the mirror subcommand: the HTTP server backed by a local
+// store that replicates completed games from another server, with no game
+// engine and admin endpoints disabled.
+func RunMirror(configPath, logLevel string, verbose bool, args []string) error {
+	fs := flag.NewFlagSet("mirror", flag.ContinueOnError)
+	fs.Usage = func() { printMirrorUsage(fs) }
+	source := fs.String("source", "", "base URL of the server to replicate (required)")
+	interval := fs.Duration("interval", 10*time.Second, "how often to sync new games")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if u, err := url.Parse(*source); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("--source must be an http(s) URL, got %q", *source)
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", *interval)
+	}
+
+	app, err := New(configPath, logLevel, verbose)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.Logger.Error("Failed to close application", slogx.Error(err))
+		}
+	}()
+
+	// Admin routes reject every request without a token, keeping the
+	// replica read-only
+	if app.Config.Server.AdminToken != "" {
+		app.Logger.Info("Admin endpoints disabled on mirror")
+		app.Config.Server.AdminToken = ""
+	}
+
+	gameService := service.NewGameService(app.Store, &app.Config.Game, streamOptions(app.Config)...)
+	gameService.SetHistorySize(app.Config.Limits.EventHistorySize)
+	settings := service.NewSettingsService(app.Store)
+	mirror := service.NewMirror(gameService, sdk.NewClient(*source, sdk.WithNode(app.Config.Server.NodeID)), *interval, app.Logger)
+
+	server := http.NewServer(app.Config, app.Logger, app.Store, gameService, settings, nil)
+	server.SetMirror(mirror)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	workers := newSupervisor(app.Logger, app.Config.Server.ShutdownTimeout.Duration())
+	server.SetWorkers(workers.Health)
+	server.SetLogLevel(app.LogLevel, app.LogQueries)
+	if app.Config.Observability.Metrics.Enabled {
+		workers.Add("observability", restartOnFailure, http.NewObservabilityServer(app.Config, app.Logger).Run)
+	}
+	workers.Add("mirror", restartOnFailure, mirror.Run)
+	workers.Add("http", stopOnFailure, server.Run)
+
+	app.Logger.Info("Mirroring games", slog.String("source", *source))
+	if err := workers.Run(ctx); err != nil {
+		return fmt.Errorf("server error: %w", err)
+	}
+
+	return nil
+}
+
+func printMirrorUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo mirror - Serve a read-only replica of another server
+
+Copies completed games from the source's /api/v1/sync/games into the
+configured database, checking each game's result hash, and serves them
+with the normal API. No games are drawn and admin endpoints are disabled.
+Live draws are not relayed; clients see each game once it completes.
+
+Usage:
+  taboo mirror --source URL [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo mirror --source https://taboo.example.com
+  taboo -c mirror.yaml mirror --source https://taboo.example.com --interval 30s
+`)
+}
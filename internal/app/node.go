@@ -0,0 +1,31 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// newNodeID returns an ID for an instance with no server.node_id set: its
+// host name, reduced to the characters node IDs allow, and a random suffix
+// so instances sharing a host differ.
+func newNodeID() string {
+	host, _ := os.Hostname()
+	host = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		}
+		return -1
+	}, strings.Split(host, ".")[0])
+	host = strings.Trim(host, "-")
+	if host == "" {
+		host = "taboo"
+	}
+
+	// rand.Read never returns an error
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return host[:min(len(host), 32)] + "-" + hex.EncodeToString(suffix)
+}
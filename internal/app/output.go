@@ -0,0 +1,43 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+)
+
+// writeJSON writes v to stdout as indented JSON. Commands use it in place of
+// their formatted text when the global --json flag is set.
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("writing JSON output: %w", err)
+	}
+	return nil
+}
+
+// issueReport is the JSON form of commands that report lint issues.
+type issueReport struct {
+	Issues  lint.Issues  `json:"issues"`
+	Summary issueSummary `json:"summary"`
+}
+
+type issueSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+}
+
+func newIssueReport(issues lint.Issues) issueReport {
+	errorCount, warnCount, infoCount := issues.Count()
+	if issues == nil {
+		issues = lint.Issues{}
+	}
+	return issueReport{
+		Issues:  issues,
+		Summary: issueSummary{Errors: errorCount, Warnings: warnCount, Infos: infoCount},
+	}
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// RunRecord runs the record subcommand, saving a server's live event stream
+// as a fixture that game.replay_file can replay.
+func RunRecord(args []string) error {
+	fs := flag.NewFlagSet("record", flag.ContinueOnError)
+	fs.Usage = func() { printRecordUsage(fs) }
+	target := fs.String("target", "http://localhost:8080", "base URL of the server to record")
+	duration := fs.Duration("duration", 5*time.Minute, "how long to record")
+	out := fs.String("out", "fixtures.jsonl", "fixture file to write")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", *duration)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("creating fixture: %w", err)
+	}
+	defer f.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *duration)
+	defer cancel()
+
+	rec := &eventRecorder{enc: json.NewEncoder(f), start: time.Now()}
+	client := sdk.NewSSEClient(*target, rec, sdk.WithReconnectDelay(time.Second))
+
+	fmt.Printf("Recording %s for %s to %s\n", *target, *duration, *out)
+	if err := client.Connect(ctx); err != nil && ctx.Err() == nil {
+		return err
+	}
+	if rec.err != nil {
+		return fmt.Errorf("writing fixture: %w", rec.err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing fixture: %w", err)
+	}
+
+	fmt.Printf("Recorded %d event(s) in %s\n", rec.count, time.Since(rec.start).Round(time.Second))
+	return nil
+}
+
+// eventRecorder writes each event it receives as a service.FixtureEvent.
+// Heartbeats are not recorded; a replaying server sends its own.
+type eventRecorder struct {
+	sdk.BaseEventHandler
+
+	enc   *json.Encoder
+	start time.Time
+	count int
+	err   error // first write error, after which events are dropped
+}
+
+func (r *eventRecorder) OnGameState(e sdk.GameStateEvent) {
+	r.record(sdk.EventGameState, e)
+}
+
+func (r *eventRecorder) OnGamePick(e sdk.GamePickEvent) {
+	r.record(sdk.EventGamePick, e)
+}
+
+func (r *eventRecorder) OnGameComplete(e sdk.GameCompleteEvent) {
+	r.record(sdk.EventGameComplete, e)
+}
+
+func (r *eventRecorder) OnConnect() {
+	fmt.Println("Connected")
+}
+
+func (r *eventRecorder) OnDisconnect(err error) {
+	fmt.Fprintf(os.Stderr, "disconnected: %v, reconnecting\n", err)
+}
+
+func (r *eventRecorder) record(eventType string, data any) {
+	if r.err != nil {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	now := time.Now()
+	r.err = r.enc.Encode(service.FixtureEvent{
+		OffsetMS: now.Sub(r.start).Milliseconds(),
+		Time:     now.Round(0),
+		Type:     eventType,
+		Data:     raw,
+	})
+	if r.err == nil {
+		r.count++
+	}
+}
+
+func printRecordUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo record - Record the live event stream as a replay fixture
+
+Writes one JSON event per line with its offset from the start of the
+recording. Set game.replay_file to the fixture to have the engine replay it
+on a loop instead of running games.
+
+Usage:
+  taboo record [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo record --duration 5m --out fixtures.jsonl
+  taboo record --target https://taboo.example.com --duration 10m
+`)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestEventRecorder_WritesFixture(t *testing.T) {
+	var buf bytes.Buffer
+	rec := &eventRecorder{enc: json.NewEncoder(&buf), start: time.Now()}
+
+	rec.OnGameState(sdk.GameStateEvent{GameID: 3, Picks: sdk.Picks{}})
+	rec.OnHeartbeat()
+	rec.OnGamePick(sdk.GamePickEvent{Pick: 12})
+	rec.OnGameComplete(sdk.GameCompleteEvent{GameID: 3})
+
+	if rec.err != nil || rec.count != 3 {
+		t.Fatalf("count = %d, err = %v, want 3 events", rec.count, rec.err)
+	}
+
+	events, err := service.ReadFixture(&buf)
+	if err != nil {
+		t.Fatalf("ReadFixture() error: %v", err)
+	}
+	want := []string{sdk.EventGameState, sdk.EventGamePick, sdk.EventGameComplete}
+	for i, e := range events {
+		if e.Type != want[i] {
+			t.Errorf("event %d type = %q, want %q", i, e.Type, want[i])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/http"
+)
+
+// RunRoutes prints every HTTP route with its method, the credential it
+// requires and the middleware chain it runs through, as configured by the
+// config file.
+func RunRoutes(configPath string, jsonOut bool) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	// Routes are registered without touching the store or services, so the
+	// server can be built without them.
+	server := http.NewServer(cfg, slog.New(slog.DiscardHandler), nil, nil, nil, nil)
+	routes := server.Routes()
+	if jsonOut {
+		return writeJSON(routes)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tAUTH\tMIDDLEWARE")
+	for _, r := range routes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Method, r.Path, r.Auth, strings.Join(r.Middleware, " > "))
+	}
+	return tw.Flush()
+}
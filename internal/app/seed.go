@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// RunSeed runs the seed subcommand, filling the store with plausible
+// historical games for demo and staging environments.
+func RunSeed(configPath, logLevel string, verbose bool, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.Usage = func() { printSeedUsage(fs) }
+	games := fs.Int("games", 500, "number of games to generate")
+	sinceFlag := fs.String("since", "30d", "how far back the generated history starts, e.g. 30d or 12h")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *games < 1 {
+		return fmt.Errorf("games must be at least 1, got %d", *games)
+	}
+	since, err := parseSince(*sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	app, err := New(configPath, logLevel, verbose)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.Logger.Error("Failed to close application", slogx.Error(err))
+		}
+	}()
+
+	gameService := service.NewGameService(app.Store, &app.Config.Game)
+
+	now := time.Now()
+	seeded, err := gameService.SeedGames(context.Background(), *games, now.Add(-since), now)
+	if len(seeded) > 0 {
+		first, last := seeded[0], seeded[len(seeded)-1]
+		fmt.Printf("Seeded %d game(s), #%d to #%d, from %s to %s\n", len(seeded), first.ID, last.ID,
+			first.CreatedAt.Format(time.DateTime), last.CreatedAt.Format(time.DateTime))
+	}
+	if err != nil {
+		return fmt.Errorf("seeding games: %w", err)
+	}
+	return nil
+}
+
+// parseSince parses a duration, also accepting a whole number of days such
+// as "30d".
+func parseSince(s string) (time.Duration, error) {
+	var (
+		d   time.Duration
+		err error
+	)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("since must be a positive duration such as 30d or 12h, got %q", s)
+	}
+	return d, nil
+}
+
+func printSeedUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo seed - Generate historical games for demos
+
+Stores completed games with random picks, following the configured pick
+count and number range, spread over the period up to now. Games are spaced
+at least one draw and wait cycle apart and follow any games already in the
+database, so demo and staging servers have history and stats to show.
+
+Usage:
+  taboo seed [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo seed
+  taboo seed --games 500 --since 30d
+  taboo -c staging.yaml seed --games 2000 --since 90d
+`)
+}
@@ -2,21 +2,61 @@ package app
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/frontend"
 	"github.com/aussiebroadwan/taboo/internal/http"
 	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
 	"github.com/aussiebroadwan/taboo/pkg/slogx"
 )
 
 // RunServe runs the serve command.
-func RunServe(configPath, logLevel string, verbose bool) error {
+func RunServe(configPath, logLevel string, verbose bool, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.Usage = func() { printServeUsage(fs) }
+	seedFlag := fs.String("deterministic", os.Getenv("TABOO_DETERMINISTIC_SEED"),
+		"draw picks from this seed and each game's ID, for reproducible runs (env TABOO_DETERMINISTIC_SEED)")
+	timeScale := fs.Float64("time-scale", 1, "with --deterministic, run draws and waits this many times faster")
+	dev := fs.Bool("dev", false, "local development defaults: short games, debug logging, open CORS and an in-memory database")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	deterministic := *seedFlag != ""
+	var seed uint64
+	if deterministic {
+		var err error
+		if seed, err = strconv.ParseUint(*seedFlag, 10, 64); err != nil {
+			return fmt.Errorf("deterministic seed must be a non-negative integer, got %q", *seedFlag)
+		}
+	}
+	if *timeScale <= 0 {
+		return fmt.Errorf("time-scale must be positive, got %g", *timeScale)
+	}
+	if *timeScale != 1 && !deterministic {
+		return errors.New("--time-scale requires --deterministic")
+	}
+
 	// Create application
-	app, err := New(configPath, logLevel, verbose)
+	var opts []Option
+	if *dev {
+		opts = append(opts, WithDefaults(config.Dev()))
+	}
+	app, err := New(configPath, logLevel, verbose, opts...)
 	if err != nil {
 		return err
 	}
@@ -26,31 +66,188 @@ func RunServe(configPath, logLevel string, verbose bool) error {
 		}
 	}()
 
-	// Create game service and engine
-	gameService := service.NewGameService(app.Store, &app.Config.Game)
+	if deterministic {
+		scaleGameTiming(&app.Config.Game, *timeScale)
+	}
+	if *dev {
+		app.Logger.Warn("Development mode, not for production",
+			slog.String("environment", app.Config.Environment),
+			slog.String("dsn", app.Config.Database.DSN),
+			slog.Duration("draw_duration", app.Config.Game.DrawDuration.Duration()),
+			slog.Duration("wait_duration", app.Config.Game.WaitDuration.Duration()),
+		)
+	}
+
+	// Create game service, settings and engine
+	gameService := service.NewGameService(app.Store, &app.Config.Game, streamOptions(app.Config)...)
+	gameService.SetHistorySize(app.Config.Limits.EventHistorySize)
+	settings := service.NewSettingsService(app.Store)
 	engine := service.NewEngine(gameService, &app.Config.Game, app.Logger)
+	engine.SetSpecials(service.NewSpecialSchedule(settings))
+
+	if deterministic {
+		engine.SetSeed(seed)
+		app.Logger.Warn("Deterministic mode, picks are predictable from the seed",
+			slog.Uint64("seed", seed),
+			slog.Float64("time_scale", *timeScale),
+			slog.Duration("draw_duration", app.Config.Game.DrawDuration.Duration()),
+			slog.Duration("wait_duration", app.Config.Game.WaitDuration.Duration()),
+		)
+		// Game IDs, and so picks, follow any games already stored
+		if latest, err := gameService.GetLatestGame(context.Background()); err == nil {
+			app.Logger.Warn("Database already has games, use a fresh one for reproducible game IDs",
+				slog.Int64("latest_game_id", latest.ID),
+			)
+		}
+	}
+
+	if dir := app.Config.Game.VoicePack; dir != "" {
+		voice, err := service.LoadVoicePack(dir)
+		if err != nil {
+			return err
+		}
+		if missing := voice.Missing(app.Config.Game.MinNumber, app.Config.Game.MaxNumber); len(missing) > 0 {
+			app.Logger.Warn("Voice pack is missing calls, picks without one are sent without call_audio",
+				slog.String("voice_pack", dir),
+				slog.Any("missing", missing),
+			)
+		}
+		gameService.SetVoicePack(voice)
+	}
+
+	// Catch a broken frontend embed now, rather than when a user loads the page
+	if fsys, err := frontend.GetFS(); err == nil {
+		logIssues(app.Logger.With(slog.String("component", "frontend")), "Frontend bundle problem", frontend.Validate(fsys))
+	}
 
 	// Create HTTP server
-	server := http.NewServer(app.Config, app.Logger, app.Store, gameService, engine)
+	server := http.NewServer(app.Config, app.Logger, app.Store, gameService, settings, engine)
+
+	// An external provider either pushes results to the ingest endpoint or
+	// is polled for them
+	if app.Config.Game.Source == "external" {
+		ext := app.Config.Game.External
+		if ext.IngestSecret != "" {
+			ingest := service.NewIngestSource(&app.Config.Game)
+			engine.SetSource(ingest)
+			server.SetIngest(ingest)
+		} else {
+			engine.SetSource(service.NewPollSource(ext.URL, ext.PollInterval.Duration()))
+		}
+	}
 
 	// Setup signal handling for graceful shutdown
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	// Start game engine in background
-	go func() {
-		if err := engine.Run(ctx); err != nil && ctx.Err() == nil {
-			app.Logger.Error("Game engine failed",
-				slogx.Error(err),
-				slog.String("component", "engine"),
-			)
-		}
-	}()
+	// Long-running workers start in order and stop in reverse, so the HTTP
+	// server serves once the engine is running and drains its clients
+	// before the engine stops
+	workers := newSupervisor(app.Logger, app.Config.Server.ShutdownTimeout.Duration())
+	server.SetWorkers(workers.Health)
+	server.SetLogLevel(app.LogLevel, app.LogQueries)
+
+	// Apply runtime settings as they change
+	workers.Add("settings", restartOnFailure, func(ctx context.Context) error {
+		gameService.SyncHints(slogx.NewContext(ctx, app.Logger.With(slog.String("component", "settings"))), settings)
+		return nil
+	})
+
+	// Alert the operator when games keep failing or readiness stays degraded
+	if alerts := app.Config.Notifications.Alerts; alerts.Enabled() {
+		alerter := service.NewAlerter(alerts)
+		defer alerter.Wait()
+		engine.SetAlerter(alerter)
+
+		workers.Add("alerts", restartOnFailure, func(ctx context.Context) error {
+			alertCtx := slogx.NewContext(ctx, app.Logger.With(slog.String("component", "alerts")))
+			alerter.WatchReadiness(alertCtx, func(ctx context.Context) bool {
+				_, ok := server.Ready(ctx)
+				return ok
+			})
+			return nil
+		})
+	}
 
-	// Run server
-	if err := server.Run(ctx); err != nil {
+	// Metrics and profiling, on their own port
+	if app.Config.Observability.Metrics.Enabled {
+		workers.Add("observability", restartOnFailure, http.NewObservabilityServer(app.Config, app.Logger).Run)
+	}
+
+	workers.AddReady("engine", restartOnFailure, engine.Run, engine.IsRunning)
+	workers.Add("http", stopOnFailure, server.Run)
+
+	if err := workers.Run(ctx); err != nil {
 		return fmt.Errorf("server error: %w", err)
 	}
 
 	return nil
 }
+
+// logIssues logs each issue as msg, at the level matching its severity.
+func logIssues(logger *slog.Logger, msg string, issues lint.Issues) {
+	for _, issue := range issues {
+		level := slog.LevelInfo
+		switch issue.Severity {
+		case lint.Error:
+			level = slog.LevelError
+		case lint.Warning:
+			level = slog.LevelWarn
+		}
+		logger.Log(context.Background(), level, msg,
+			slog.String("rule", issue.Rule),
+			slog.String("location", issue.Location),
+			slog.String("problem", issue.Message),
+		)
+	}
+}
+
+// scaleGameTiming shortens the draw and wait durations by scale, so a
+// deterministic run plays out scale times faster in the same order.
+func scaleGameTiming(cfg *config.GameConfig, scale float64) {
+	cfg.DrawDuration = config.Duration(time.Duration(float64(cfg.DrawDuration) / scale))
+	cfg.WaitDuration = config.Duration(time.Duration(float64(cfg.WaitDuration) / scale))
+}
+
+// streamOptions configures the event broker behind /api/v1/events from the
+// server's stream limits. Lifecycle events get a reserve as large again as
+// the buffer, so a client backed up on picks still hears games change.
+func streamOptions(cfg *config.Config) []pubsub.Option[service.Event] {
+	return []pubsub.Option[service.Event]{
+		pubsub.WithBufferSize[service.Event](cfg.Limits.SSEBufferSize),
+		pubsub.WithPriority(service.IsLifecycleEvent, cfg.Limits.SSEBufferSize),
+		pubsub.WithMaxSubscribers[service.Event](cfg.Server.SSEMaxClients),
+		pubsub.WithEvictAfter[service.Event](cfg.Server.SSEEvictAfter),
+	}
+}
+
+func printServeUsage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, `taboo serve - Start the HTTP server
+
+Runs the game engine and serves the API and event streams.
+
+With --dev, settings the config file and environment leave unset default
+to a local development profile: 10s draws and 5s waits, debug logging,
+CORS open to every origin and an in-memory database, so iterating on the
+frontend takes one flag.
+
+With --deterministic, each game's picks are drawn from the seed and the
+game's ID rather than securely, so on a fresh database every run draws the
+same games in the same order. --time-scale speeds the draw and wait phases
+up for end-to-end tests and demo recordings. Never use it in production.
+
+Usage:
+  taboo serve [flags]
+
+Flags:
+`)
+	fs.PrintDefaults()
+	fmt.Fprintf(os.Stderr, `
+Examples:
+  taboo serve
+  taboo serve --dev
+  taboo -c config.yaml serve
+  taboo serve --deterministic 42 --time-scale 10
+  TABOO_DETERMINISTIC_SEED=42 taboo serve
+`)
+}
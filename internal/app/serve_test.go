@@ -0,0 +1,24 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestScaleGameTiming(t *testing.T) {
+	cfg := config.GameConfig{
+		DrawDuration: config.Duration(2 * time.Minute),
+		WaitDuration: config.Duration(30 * time.Second),
+	}
+
+	scaleGameTiming(&cfg, 10)
+
+	if got := cfg.DrawDuration.Duration(); got != 12*time.Second {
+		t.Errorf("DrawDuration = %s, want 12s", got)
+	}
+	if got := cfg.WaitDuration.Duration(); got != 3*time.Second {
+		t.Errorf("WaitDuration = %s, want 3s", got)
+	}
+}
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// restartPolicy says what a supervisor does when one of its workers
+// returns before shutdown.
+type restartPolicy int
+
+const (
+	// restartOnFailure restarts a worker that returned an error, after a
+	// backoff. A worker that returns nil is done.
+	restartOnFailure restartPolicy = iota
+
+	// restartNever leaves a worker that failed down. Its failure is
+	// reported by readiness but doesn't affect the other workers.
+	restartNever
+
+	// stopOnFailure shuts every worker down when the worker returns an
+	// error, ending the supervisor with it, as for the HTTP server.
+	stopOnFailure
+)
+
+// Worker statuses reported by supervisor.Health. A worker that is ok or
+// finished its work, such as a watcher with nothing to watch, is healthy.
+const (
+	workerStarting   = "starting"
+	workerOK         = "ok"
+	workerFinished   = "finished"
+	workerRestarting = "restarting"
+	workerFailed     = "failed"
+	workerStopped    = "stopped"
+)
+
+// Restart backoff bounds: the first restart waits restartBackoffMin, each
+// later one twice as long up to restartBackoffMax. A worker that stays up
+// for restartResetAfter starts again from the minimum.
+const (
+	restartBackoffMin = time.Second
+	restartBackoffMax = 30 * time.Second
+	restartResetAfter = time.Minute
+)
+
+// stopGrace is how long a worker is waited for beyond the shutdown timeout,
+// so one that bounds its own shutdown by it, like the HTTP server, has time
+// to return.
+const stopGrace = 5 * time.Second
+
+// Startup waits: a worker added with AddReady is checked every
+// readyInterval, and the workers after it start anyway once it has taken
+// readyTimeout, so a worker stuck starting can't keep the HTTP server, and
+// with it the health probes, down.
+const (
+	readyInterval = 10 * time.Millisecond
+	readyTimeout  = 30 * time.Second
+)
+
+// supervisedWorker is a long-running goroutine owned by a supervisor.
+type supervisedWorker struct {
+	name    string
+	run     func(ctx context.Context) error
+	restart restartPolicy
+
+	// ready, if set, reports when the worker has started, holding back the
+	// workers added after it until then.
+	ready func() bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// supervisor runs the long-running goroutines of a command. Workers start
+// in the order they are added, those after a worker added with AddReady
+// waiting until it is ready, and are stopped in reverse, each one finishing
+// before the next is told to stop. So the HTTP server, added last, serves
+// once the engine is running and drains its clients before the engine
+// feeding them stops.
+type supervisor struct {
+	logger *slog.Logger
+
+	// stopTimeout bounds how long each worker is waited for once told to
+	// stop.
+	stopTimeout time.Duration
+
+	// backoff is the wait before a worker's first restart.
+	backoff time.Duration
+
+	// readyTimeout bounds how long startup waits for a worker to be ready.
+	readyTimeout time.Duration
+
+	workers []*supervisedWorker
+
+	mu     sync.Mutex
+	status map[string]string
+}
+
+// newSupervisor returns a supervisor waiting up to shutdownTimeout, plus
+// stopGrace, for each worker to stop.
+func newSupervisor(logger *slog.Logger, shutdownTimeout time.Duration) *supervisor {
+	return &supervisor{
+		logger:       logger.With(slog.String("component", "supervisor")),
+		stopTimeout:  shutdownTimeout + stopGrace,
+		backoff:      restartBackoffMin,
+		readyTimeout: readyTimeout,
+		status:       make(map[string]string),
+	}
+}
+
+// Add registers a worker to start when Run is called.
+func (s *supervisor) Add(name string, restart restartPolicy, run func(ctx context.Context) error) {
+	s.AddReady(name, restart, run, nil)
+}
+
+// AddReady registers a worker like Add, holding back the workers added
+// after it until ready reports true, the worker stops, or readyTimeout
+// passes.
+func (s *supervisor) AddReady(name string, restart restartPolicy, run func(ctx context.Context) error, ready func() bool) {
+	s.workers = append(s.workers, &supervisedWorker{name: name, run: run, restart: restart, ready: ready})
+	s.setStatus(name, workerStarting)
+}
+
+// Health returns the status of each worker, reporting whether all are
+// healthy.
+func (s *supervisor) Health() (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok := true
+	for _, status := range s.status {
+		ok = ok && (status == workerOK || status == workerFinished)
+	}
+	return maps.Clone(s.status), ok
+}
+
+func (s *supervisor) setStatus(name, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status[name] = status
+}
+
+// Run starts every worker in order and blocks until ctx is cancelled or a
+// stopOnFailure worker fails, then stops them all. It returns that
+// worker's error, if any.
+func (s *supervisor) Run(ctx context.Context) error {
+	failed := make(chan error, 1)
+	for _, w := range s.workers {
+		// Workers outlive ctx, so each can be stopped in turn
+		workerCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		w.cancel = cancel
+		w.done = make(chan struct{})
+		go s.supervise(workerCtx, w, failed)
+		s.awaitReady(ctx, w)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-failed:
+	}
+
+	s.shutdown()
+	return err
+}
+
+// awaitReady waits until w, if added with AddReady, is ready. It returns
+// early when w stops or ctx is cancelled, and logs a warning if w is still
+// not ready after readyTimeout; either way the remaining workers start, so
+// every one can be stopped in turn.
+func (s *supervisor) awaitReady(ctx context.Context, w *supervisedWorker) {
+	if w.ready == nil {
+		return
+	}
+
+	ticker := time.NewTicker(readyInterval)
+	defer ticker.Stop()
+	timeout := time.NewTimer(s.readyTimeout)
+	defer timeout.Stop()
+
+	for !w.ready() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.done:
+			return
+		case <-timeout.C:
+			s.logger.Warn("Worker not ready in time, starting the rest",
+				slog.String("worker", w.name),
+				slog.Duration("timeout", s.readyTimeout),
+			)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// supervise runs w until its context is cancelled, restarting it as its
+// policy says.
+func (s *supervisor) supervise(ctx context.Context, w *supervisedWorker, failed chan<- error) {
+	defer close(w.done)
+
+	logger := s.logger.With(slog.String("worker", w.name))
+	backoff := s.backoff
+	for {
+		s.setStatus(w.name, workerOK)
+		started := time.Now()
+		err := s.runWorker(ctx, w)
+		if ctx.Err() != nil {
+			s.setStatus(w.name, workerStopped)
+			return
+		}
+
+		switch {
+		case err == nil:
+			logger.Debug("Worker finished")
+			s.setStatus(w.name, workerFinished)
+			return
+		case w.restart == stopOnFailure:
+			logger.Error("Worker failed, shutting down", slogx.Error(err))
+			s.setStatus(w.name, workerFailed)
+			select {
+			case failed <- fmt.Errorf("%s: %w", w.name, err):
+			default:
+			}
+			return
+		case w.restart == restartNever:
+			logger.Error("Worker failed", slogx.Error(err))
+			s.setStatus(w.name, workerFailed)
+			return
+		}
+
+		if time.Since(started) >= restartResetAfter {
+			backoff = s.backoff
+		}
+		logger.Error("Worker failed, restarting", slogx.Error(err), slog.Duration("backoff", backoff))
+		s.setStatus(w.name, workerRestarting)
+
+		select {
+		case <-ctx.Done():
+			s.setStatus(w.name, workerStopped)
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, restartBackoffMax)
+	}
+}
+
+// runWorker runs w once, turning a panic into an error so it is restarted
+// like any other failure.
+func (s *supervisor) runWorker(ctx context.Context, w *supervisedWorker) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return w.run(ctx)
+}
+
+// shutdown stops the workers in reverse start order, waiting up to
+// stopTimeout for each.
+func (s *supervisor) shutdown() {
+	for i := len(s.workers) - 1; i >= 0; i-- {
+		w := s.workers[i]
+		w.cancel()
+
+		timer := time.NewTimer(s.stopTimeout)
+		select {
+		case <-w.done:
+		case <-timer.C:
+			s.logger.Warn("Worker did not stop in time",
+				slog.String("worker", w.name),
+				slog.Duration("timeout", s.stopTimeout),
+			)
+		}
+		timer.Stop()
+	}
+	s.logger.Info("All workers stopped")
+}
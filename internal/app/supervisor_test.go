@@ -0,0 +1,209 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestSupervisor() *supervisor {
+	s := newSupervisor(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Second)
+	s.backoff = time.Millisecond
+	return s
+}
+
+// blockUntilDone is a worker that runs until told to stop.
+func blockUntilDone(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestSupervisor_RestartsFailedWorker(t *testing.T) {
+	s := newTestSupervisor()
+
+	var runs atomic.Int32
+	restarted := make(chan struct{})
+	s.Add("flaky", restartOnFailure, func(ctx context.Context) error {
+		switch runs.Add(1) {
+		case 1:
+			return errors.New("boom")
+		case 2:
+			panic("boom")
+		case 3:
+			close(restarted)
+		}
+		return blockUntilDone(ctx)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker was not restarted after an error and a panic")
+	}
+	if health, ok := s.Health(); !ok || health["flaky"] != workerOK {
+		t.Errorf("expected flaky ok after restart, got %v", health)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+	if health, _ := s.Health(); health["flaky"] != workerStopped {
+		t.Errorf("expected flaky stopped, got %q", health["flaky"])
+	}
+}
+
+func TestSupervisor_StopsInReverseOrder(t *testing.T) {
+	s := newTestSupervisor()
+
+	var mu sync.Mutex
+	var stopped []string
+	for _, name := range []string{"settings", "engine", "http"} {
+		s.Add(name, restartOnFailure, func(ctx context.Context) error {
+			<-ctx.Done()
+			mu.Lock()
+			stopped = append(stopped, name)
+			mu.Unlock()
+			return ctx.Err()
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []string{"http", "engine", "settings"}; !slices.Equal(stopped, want) {
+		t.Errorf("expected workers stopped in order %v, got %v", want, stopped)
+	}
+}
+
+func TestSupervisor_StartsInOrder(t *testing.T) {
+	s := newTestSupervisor()
+
+	var engineReady atomic.Bool
+	s.AddReady("engine", restartOnFailure, func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		engineReady.Store(true)
+		return blockUntilDone(ctx)
+	}, engineReady.Load)
+
+	httpStarted := make(chan bool, 1)
+	s.Add("http", restartOnFailure, func(ctx context.Context) error {
+		httpStarted <- engineReady.Load()
+		return blockUntilDone(ctx)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	if ready := <-httpStarted; !ready {
+		t.Error("expected http to start once the engine was ready")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+}
+
+func TestSupervisor_StartsAfterReadyTimeout(t *testing.T) {
+	s := newTestSupervisor()
+	s.readyTimeout = 20 * time.Millisecond
+
+	s.AddReady("engine", restartOnFailure, blockUntilDone, func() bool { return false })
+	httpStarted := make(chan struct{})
+	s.Add("http", restartOnFailure, func(ctx context.Context) error {
+		close(httpStarted)
+		return blockUntilDone(ctx)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	select {
+	case <-httpStarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected http to start once the engine's ready timeout passed")
+	}
+	cancel()
+	<-done
+}
+
+func TestSupervisor_StopOnFailure(t *testing.T) {
+	s := newTestSupervisor()
+
+	engineStopped := make(chan struct{})
+	s.Add("engine", restartOnFailure, func(ctx context.Context) error {
+		defer close(engineStopped)
+		return blockUntilDone(ctx)
+	})
+	s.Add("http", stopOnFailure, func(context.Context) error {
+		return errors.New("address in use")
+	})
+
+	err := s.Run(context.Background())
+	if err == nil || err.Error() != "http: address in use" {
+		t.Errorf("expected the http worker's error, got %v", err)
+	}
+	select {
+	case <-engineStopped:
+	default:
+		t.Error("expected the engine stopped with the server")
+	}
+
+	health, ok := s.Health()
+	if ok || health["http"] != workerFailed {
+		t.Errorf("expected http failed and unhealthy, got %v, %v", health, ok)
+	}
+}
+
+func TestSupervisor_Health(t *testing.T) {
+	s := newTestSupervisor()
+
+	idle := make(chan struct{})
+	failed := make(chan struct{})
+	s.Add("idle", restartOnFailure, func(context.Context) error {
+		defer close(idle)
+		return nil
+	})
+	s.Add("once", restartNever, func(context.Context) error {
+		defer close(failed)
+		return errors.New("boom")
+	})
+	s.Add("engine", restartOnFailure, blockUntilDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.Run(ctx) }()
+	<-idle
+	<-failed
+
+	// Let the supervisor record both results and start the engine
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		health, ok := s.Health()
+		if health["idle"] == workerFinished && health["once"] == workerFailed && health["engine"] == workerOK {
+			if ok {
+				t.Error("expected a failed worker to make the workers unhealthy")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("unexpected health %v", health)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -9,12 +9,13 @@ import (
 
 	"github.com/aussiebroadwan/taboo/internal/config"
 	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
 	"github.com/aussiebroadwan/taboo/pkg/lint"
 	"github.com/golang-migrate/migrate/v4"
 )
 
 // RunVerify runs the verify subcommand.
-func RunVerify(configPath string) error {
+func RunVerify(configPath string, jsonOut bool) error {
 	c := lint.NewCollector()
 
 	// Step 1: Load and validate configuration
@@ -43,17 +44,27 @@ func RunVerify(configPath string) error {
 		verifyDatabase(c, cfg)
 	}
 
-	// Print all issues
-	issues := c.Issues()
-	fmt.Println()
-	for _, issue := range issues {
-		fmt.Println(issue)
-	}
-	fmt.Println()
+	// Step 3: Message catalogs built into the binary
+	c.Merge(i18n.Lint())
 
-	// Summary
+	issues := c.Issues()
 	errorCount, warnCount, infoCount := issues.Count()
-	fmt.Printf("Summary: %d error(s), %d warning(s), %d info\n", errorCount, warnCount, infoCount)
+
+	if jsonOut {
+		if err := writeJSON(newIssueReport(issues)); err != nil {
+			return err
+		}
+	} else {
+		// Print all issues
+		fmt.Println()
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		fmt.Println()
+
+		// Summary
+		fmt.Printf("Summary: %d error(s), %d warning(s), %d info\n", errorCount, warnCount, infoCount)
+	}
 
 	// Exit with error code if there are errors
 	if errorCount > 0 {
@@ -5,11 +5,32 @@ import (
 	"runtime"
 )
 
+// versionInfo is the JSON form of the version command.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
 // RunVersion prints version information.
-func RunVersion() {
-	fmt.Printf("taboo %s\n", Version)
-	fmt.Printf("  commit:     %s\n", Commit)
-	fmt.Printf("  built:      %s\n", BuildTime)
-	fmt.Printf("  go version: %s\n", runtime.Version())
-	fmt.Printf("  platform:   %s/%s\n", runtime.GOOS, runtime.GOARCH)
+func RunVersion(jsonOut bool) error {
+	info := versionInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if jsonOut {
+		return writeJSON(info)
+	}
+
+	fmt.Printf("taboo %s\n", info.Version)
+	fmt.Printf("  commit:     %s\n", info.Commit)
+	fmt.Printf("  built:      %s\n", info.BuildTime)
+	fmt.Printf("  go version: %s\n", info.GoVersion)
+	fmt.Printf("  platform:   %s\n", info.Platform)
+	return nil
 }
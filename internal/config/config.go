@@ -10,12 +10,17 @@ import (
 
 // Config holds all application configuration.
 type Config struct {
-	Environment string         `yaml:"environment"` // "development" or "production"
-	Server      ServerConfig   `yaml:"server"`
-	Game        GameConfig     `yaml:"game"`
-	Database    DatabaseConfig `yaml:"database"`
-	Logging     LoggingConfig  `yaml:"logging"`
-	Discord     DiscordConfig  `yaml:"discord"`
+	Environment   string              `yaml:"environment"` // "development" or "production"
+	Server        ServerConfig        `yaml:"server"`
+	Game          GameConfig          `yaml:"game"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Logging       LoggingConfig       `yaml:"logging"`
+	Discord       DiscordConfig       `yaml:"discord"`
+	Cache         CacheConfig         `yaml:"cache"`
+	Chaos         ChaosConfig         `yaml:"chaos"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Limits        LimitsConfig        `yaml:"limits"`
+	Observability ObservabilityConfig `yaml:"observability"`
 }
 
 // ServerConfig holds HTTP server configuration.
@@ -30,6 +35,38 @@ type ServerConfig struct {
 	CORSOrigins     []string `yaml:"cors_origins"`
 	RateLimit       int      `yaml:"rate_limit"`
 	RateBurst       int      `yaml:"rate_burst"`
+
+	// SSEHeartbeatMin and SSEHeartbeatMax bound the heartbeat interval a
+	// client may request with ?heartbeat=; requests outside are clamped.
+	// Zero leaves that side unbounded.
+	SSEHeartbeatMin Duration `yaml:"sse_heartbeat_min"`
+	SSEHeartbeatMax Duration `yaml:"sse_heartbeat_max"`
+
+	// SSEMaxClients caps concurrent event streams; clients beyond it are
+	// turned away with 503. Zero leaves it unbounded.
+	SSEMaxClients int `yaml:"sse_max_clients"`
+
+	// SSEEvictAfter disconnects a stream once this many events in a row
+	// were dropped because the client wasn't reading, so it reconnects and
+	// resyncs rather than silently missing picks. Zero never evicts.
+	SSEEvictAfter int `yaml:"sse_evict_after"`
+
+	// LegacyEventNames also sends each renamed event under the name it
+	// replaced, so clients not yet updated keep working for a deprecation
+	// cycle. Turn it off once they have moved to the new names.
+	LegacyEventNames bool `yaml:"legacy_event_names"`
+
+	// GzipMinSize is the smallest response body, in bytes, that is gzipped.
+	// Zero compresses every response.
+	GzipMinSize int `yaml:"gzip_min_size"`
+
+	// AdminToken is the bearer token required by /api/v1/admin endpoints.
+	// Admin endpoints are disabled when it is empty.
+	AdminToken string `yaml:"admin_token"`
+
+	// NodeID identifies this instance in logs, responses and the cluster
+	// status of its peers. A random ID is generated at startup when empty.
+	NodeID string `yaml:"node_id"`
 }
 
 // Addr returns the server address in host:port format.
@@ -42,19 +79,114 @@ type GameConfig struct {
 	DrawDuration Duration `yaml:"draw_duration"`
 	WaitDuration Duration `yaml:"wait_duration"`
 	PickCount    int      `yaml:"pick_count"`
+	MinNumber    int      `yaml:"min_number"`
 	MaxNumber    int      `yaml:"max_number"`
+
+	// WatchdogTolerance is added to a full game cycle to give the longest
+	// the engine may go without broadcasting before it is considered stalled.
+	WatchdogTolerance Duration `yaml:"watchdog_tolerance"`
+
+	// StoreTimeout bounds each store call the engine makes, so a hung
+	// database can't stall the game loop. Zero leaves calls unbounded.
+	StoreTimeout Duration `yaml:"store_timeout"`
+
+	// DuplicateWindow is how many earlier games each game's picks are
+	// compared against once drawn. A game sharing at least
+	// DuplicateThreshold of its picks with one of them is logged and
+	// alerted, as a tripwire for RNG or persistence bugs. Zero turns the
+	// check off.
+	DuplicateWindow    int     `yaml:"duplicate_window"`
+	DuplicateThreshold float64 `yaml:"duplicate_threshold"`
+
+	// CallLocale is the locale pick events spell each number in, as
+	// call_text, for screen readers and text-to-speech. Empty leaves
+	// call_text out.
+	CallLocale string `yaml:"call_locale"`
+
+	// VoicePack is a directory of recorded number calls, one clip per
+	// number named after it (e.g. 42.mp3), served at /api/v1/calls/{pick}
+	// and linked from pick events as call_audio. Empty turns audio off.
+	VoicePack string `yaml:"voice_pack"`
+
+	// IdleWhenEmpty pauses the game loop between games once no client has
+	// been connected for game events for IdleAfter, saving database writes
+	// and CPU on quiet instances. The first client to connect resumes it
+	// with a fresh game.
+	IdleWhenEmpty bool     `yaml:"idle_when_empty"`
+	IdleAfter     Duration `yaml:"idle_after"`
+
+	// ReplayFile, when set, replays a fixture recorded by taboo record
+	// instead of running games. Intended for frontend development.
+	ReplayFile string `yaml:"replay_file"`
+
+	// Variant is the game played on the draws: "classic" Keno,
+	// "heads_tails", where the half of the board with more picks wins, or
+	// "bingo", settling 5x5 cards by completed lines.
+	Variant string `yaml:"variant"`
+
+	// Source is where each game's picks come from: "local" draws them,
+	// "external" takes them from a results provider configured in External.
+	Source   string               `yaml:"source"`
+	External ExternalSourceConfig `yaml:"external"`
+}
+
+// ExternalSourceConfig configures the results provider used when
+// game.source is external. The provider is either polled at URL or pushes
+// signed results to POST /api/v1/ingest/games when IngestSecret is set.
+type ExternalSourceConfig struct {
+	// URL is polled for the provider's latest game, in the shape of
+	// GET /api/v1/games/latest. Games published between polls are paged
+	// from the list beside it, in the shape of GET /api/v1/games.
+	URL          string   `yaml:"url"`
+	PollInterval Duration `yaml:"poll_interval"`
+
+	// IngestSecret is the HMAC-SHA256 key the provider signs pushed results
+	// with. Setting it enables the ingest endpoint.
+	IngestSecret string `yaml:"ingest_secret"`
+
+	// IngestMaxSkew is how far a pushed result's timestamp may be from the
+	// server's clock. Nonces are remembered for as long, so a captured
+	// request can't be replayed.
+	IngestMaxSkew Duration `yaml:"ingest_max_skew"`
 }
 
 // DatabaseConfig holds database configuration.
 type DatabaseConfig struct {
 	Driver string `yaml:"driver"`
 	DSN    string `yaml:"dsn"`
+
+	// QueryTimeout bounds each store call, so slow queries give up before
+	// the request that issued them does, and each query run by db audit and
+	// db analyze. Zero disables it.
+	QueryTimeout Duration `yaml:"query_timeout"`
+
+	// LogQueries logs every statement the store runs at DEBUG, with its
+	// duration and rows, to diagnose slow queries. It can also be toggled
+	// at runtime with PUT /api/v1/admin/log-level.
+	LogQueries bool `yaml:"log_queries"`
+
+	// Shadow, if its driver is set, receives a copy of every write and has
+	// reads replayed against it, to rehearse a move to another database.
+	Shadow ShadowConfig `yaml:"shadow"`
+}
+
+// ShadowConfig configures the secondary database written alongside the
+// primary one.
+type ShadowConfig struct {
+	Driver string `yaml:"driver"`
+	DSN    string `yaml:"dsn"`
 }
 
 // LoggingConfig holds logging configuration.
 type LoggingConfig struct {
 	Level  string `yaml:"level"`
 	Format string `yaml:"format"`
+
+	// File, when set, writes logs to a rotating file instead of stdout.
+	File       string `yaml:"file"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups"`
+	Compress   bool   `yaml:"compress"`
 }
 
 // DiscordConfig holds Discord integration configuration.
@@ -63,6 +195,115 @@ type DiscordConfig struct {
 	ClientSecret string `yaml:"client_secret"`
 }
 
+// CacheConfig holds CDN caching settings for the game history API.
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// MaxAge is the shared cache lifetime (s-maxage) of completed games.
+	MaxAge Duration `yaml:"max_age"`
+
+	// PurgeURL, if set, receives a POST listing the surrogate keys to purge
+	// whenever a game completes. PurgeToken is sent as a bearer token.
+	PurgeURL   string `yaml:"purge_url"`
+	PurgeToken string `yaml:"purge_token"`
+}
+
+// ChaosConfig holds fault injection settings for exercising client
+// reconnect behavior locally. Only allowed in development.
+type ChaosConfig struct {
+	Enabled           bool               `yaml:"enabled"`
+	LatencyMin        Duration           `yaml:"latency_min"`
+	LatencyMax        Duration           `yaml:"latency_max"`
+	ErrorRate         float64            `yaml:"error_rate"`
+	RouteErrorRates   map[string]float64 `yaml:"route_error_rates"`
+	SSEDisconnectRate float64            `yaml:"sse_disconnect_rate"`
+}
+
+// NotificationsConfig holds operator notification settings.
+type NotificationsConfig struct {
+	Alerts AlertsConfig `yaml:"alerts"`
+}
+
+// AlertsConfig configures alerts sent when the engine keeps failing or the
+// server stays unready. Alerts are disabled unless a URL is set.
+type AlertsConfig struct {
+	// WebhookURL receives a JSON POST for each alert; DiscordWebhookURL
+	// receives it as a Discord message.
+	WebhookURL        string `yaml:"webhook_url"`
+	DiscordWebhookURL string `yaml:"discord_webhook_url"`
+
+	// FailureThreshold is how many game cycles in a row must fail before
+	// alerting. Zero disables the alert.
+	FailureThreshold int `yaml:"failure_threshold"`
+
+	// DegradedFor is how long readiness must stay degraded before alerting.
+	// Zero disables the alert.
+	DegradedFor Duration `yaml:"degraded_for"`
+}
+
+// Enabled reports whether any alert destination is configured.
+func (c AlertsConfig) Enabled() bool {
+	return c.WebhookURL != "" || c.DiscordWebhookURL != ""
+}
+
+// LimitsConfig bounds how much a single request or stream may cost the
+// server. Big deployments can raise them; small ones can tighten them.
+type LimitsConfig struct {
+	// MaxPageSize caps ?limit= on paginated listings such as /api/v1/games.
+	MaxPageSize int `yaml:"max_page_size"`
+
+	// MaxExportRows caps ?limit= on bulk endpoints that hand over many rows
+	// at once, such as /api/v1/sync/games and the admin usage report.
+	MaxExportRows int `yaml:"max_export_rows"`
+
+	// SSEBufferSize is how many events are queued for each event stream
+	// before events for a client that isn't reading are dropped. Game state
+	// and completion events may queue as many again past it.
+	SSEBufferSize int `yaml:"sse_buffer_size"`
+
+	// EventHistorySize is how many recent events are kept for long-poll
+	// clients and other transports catching up on what they missed.
+	EventHistorySize int `yaml:"event_history_size"`
+}
+
+// ObservabilityConfig holds the operational telemetry settings: runtime
+// metrics, trace export and profiling.
+type ObservabilityConfig struct {
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Profiling ProfilingConfig `yaml:"profiling"`
+}
+
+// MetricsConfig configures the observability listener, a second HTTP
+// server on Port serving runtime metrics at /debug/vars, kept off the
+// public port.
+type MetricsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"`
+}
+
+// Addr returns the observability listener's address, on the same host as
+// the public server.
+func (m MetricsConfig) Addr(host string) string {
+	return fmt.Sprintf("%s:%d", host, m.Port)
+}
+
+// TracingConfig configures trace export. Tracing is off unless Endpoint is
+// set.
+type TracingConfig struct {
+	// Endpoint is the OTLP/HTTP collector URL spans are sent to.
+	Endpoint string `yaml:"endpoint"`
+
+	// SampleRate is the fraction of requests traced, between 0 and 1.
+	SampleRate float64 `yaml:"sample_rate"`
+}
+
+// ProfilingConfig configures the pprof endpoints, served under
+// /debug/pprof/ on the observability listener.
+type ProfilingConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
 // Duration is a wrapper around time.Duration that supports YAML unmarshaling.
 type Duration time.Duration
 
@@ -92,7 +333,11 @@ func (d Duration) Duration() time.Duration {
 
 // Load reads configuration from a YAML file and applies environment overrides.
 func Load(path string) (*Config, error) {
-	cfg := Default()
+	return LoadWith(Default(), path)
+}
+
+// LoadWith is Load starting from cfg rather than Default, e.g. Dev.
+func LoadWith(cfg *Config, path string) (*Config, error) {
 
 	if path != "" {
 		data, err := os.ReadFile(path)
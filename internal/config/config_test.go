@@ -22,6 +22,7 @@ func TestLoad(t *testing.T) {
 		{"valid full config", testdataPath("valid_full.yaml"), false},
 		{"valid minimal config", testdataPath("valid_minimal.yaml"), false},
 		{"valid memory db", testdataPath("valid_memory_db.yaml"), false},
+		{"valid offset range", testdataPath("valid_offset_range.yaml"), false},
 
 		// Invalid configs
 		{"invalid environment", testdataPath("invalid_environment.yaml"), true},
@@ -32,12 +33,26 @@ func TestLoad(t *testing.T) {
 		{"invalid dsn empty", testdataPath("invalid_dsn_empty.yaml"), true},
 		{"invalid game pick zero", testdataPath("invalid_game_pick_zero.yaml"), true},
 		{"invalid game max lt pick", testdataPath("invalid_game_max_lt_pick.yaml"), true},
+		{"invalid game range lt pick", testdataPath("invalid_game_min_range.yaml"), true},
 		{"invalid log level", testdataPath("invalid_log_level.yaml"), true},
 		{"invalid log format", testdataPath("invalid_log_format.yaml"), true},
+		{"invalid log max size", testdataPath("invalid_log_max_size.yaml"), true},
 		{"invalid rate limit", testdataPath("invalid_rate_limit.yaml"), true},
 		{"invalid rate burst", testdataPath("invalid_rate_burst.yaml"), true},
 		{"invalid timeout zero", testdataPath("invalid_timeout_zero.yaml"), true},
 		{"invalid draw duration zero", testdataPath("invalid_draw_duration.yaml"), true},
+		{"invalid chaos in production", testdataPath("invalid_chaos_production.yaml"), true},
+		{"invalid chaos error rate", testdataPath("invalid_chaos_rate.yaml"), true},
+		{"invalid chaos latency range", testdataPath("invalid_chaos_latency.yaml"), true},
+		{"invalid limits page size", testdataPath("invalid_limits_page_size.yaml"), true},
+		{"invalid call locale", testdataPath("invalid_call_locale.yaml"), true},
+		{"invalid duplicate threshold", testdataPath("invalid_duplicate_threshold.yaml"), true},
+		{"invalid game variant", testdataPath("invalid_game_variant.yaml"), true},
+		{"invalid idle after", testdataPath("invalid_idle_after.yaml"), true},
+		{"invalid node id", testdataPath("invalid_node_id.yaml"), true},
+		{"invalid metrics port", testdataPath("invalid_metrics_port.yaml"), true},
+		{"invalid tracing sample rate", testdataPath("invalid_tracing_sample_rate.yaml"), true},
+		{"invalid profiling", testdataPath("invalid_profiling.yaml"), true},
 
 		// Parse error
 		{"malformed yaml", testdataPath("malformed.yaml"), true},
@@ -138,6 +153,9 @@ func TestLoad_ValidFullValues(t *testing.T) {
 	if cfg.Database.DSN != "production.db" {
 		t.Errorf("Database.DSN = %q, want %q", cfg.Database.DSN, "production.db")
 	}
+	if !cfg.Database.LogQueries {
+		t.Error("Database.LogQueries = false, want true")
+	}
 
 	// Logging
 	if cfg.Logging.Level != "warn" {
@@ -217,6 +235,29 @@ func TestLoad_MinimalUsesDefaults(t *testing.T) {
 	}
 }
 
+func TestLoadWith_Dev(t *testing.T) {
+	cfg, err := LoadWith(Dev(), "")
+	if err != nil {
+		t.Fatalf("LoadWith() unexpected error: %v", err)
+	}
+	if cfg.Database.DSN != ":memory:" || cfg.Logging.Level != "debug" || cfg.Environment != "development" {
+		t.Errorf("expected the dev profile, got dsn %q, level %q, environment %q",
+			cfg.Database.DSN, cfg.Logging.Level, cfg.Environment)
+	}
+	if cfg.Game.DrawDuration.Duration() != 10*time.Second {
+		t.Errorf("Game.DrawDuration = %v, want %v", cfg.Game.DrawDuration, 10*time.Second)
+	}
+
+	// The config file still wins over the profile
+	cfg, err = LoadWith(Dev(), testdataPath("valid_full.yaml"))
+	if err != nil {
+		t.Fatalf("LoadWith() unexpected error: %v", err)
+	}
+	if cfg.Database.DSN != "production.db" || cfg.Logging.Level != "warn" {
+		t.Errorf("expected the file's dsn and level, got %q, %q", cfg.Database.DSN, cfg.Logging.Level)
+	}
+}
+
 func TestDefault(t *testing.T) {
 	cfg := Default()
 
@@ -252,6 +293,9 @@ func TestDefault(t *testing.T) {
 	if cfg.Database.DSN != "taboo.db" {
 		t.Errorf("Database.DSN = %q, want %q", cfg.Database.DSN, "taboo.db")
 	}
+	if cfg.Database.QueryTimeout.Duration() != 5*time.Second {
+		t.Errorf("Database.QueryTimeout = %v, want %v", cfg.Database.QueryTimeout.Duration(), 5*time.Second)
+	}
 	if cfg.Logging.Level != "info" {
 		t.Errorf("Logging.Level = %q, want %q", cfg.Logging.Level, "info")
 	}
@@ -264,6 +308,12 @@ func TestDefault(t *testing.T) {
 	if cfg.Server.RateBurst != 20 {
 		t.Errorf("Server.RateBurst = %d, want %d", cfg.Server.RateBurst, 20)
 	}
+	if cfg.Limits.MaxPageSize != 100 {
+		t.Errorf("Limits.MaxPageSize = %d, want %d", cfg.Limits.MaxPageSize, 100)
+	}
+	if cfg.Limits.MaxExportRows != 1000 {
+		t.Errorf("Limits.MaxExportRows = %d, want %d", cfg.Limits.MaxExportRows, 1000)
+	}
 }
 
 func TestApplyEnv(t *testing.T) {
@@ -303,6 +353,26 @@ func TestApplyEnv(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "TABOO_SERVER_SSE_HEARTBEAT_MAX",
+			envVar: "TABOO_SERVER_SSE_HEARTBEAT_MAX",
+			value:  "2m",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.SSEHeartbeatMax.Duration() != 2*time.Minute {
+					t.Errorf("Server.SSEHeartbeatMax = %v, want %v", cfg.Server.SSEHeartbeatMax.Duration(), 2*time.Minute)
+				}
+			},
+		},
+		{
+			name:   "TABOO_GAME_EXTERNAL_INGEST_SECRET",
+			envVar: "TABOO_GAME_EXTERNAL_INGEST_SECRET",
+			value:  "s3cret",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Game.External.IngestSecret != "s3cret" {
+					t.Errorf("Game.External.IngestSecret = %q, want %q", cfg.Game.External.IngestSecret, "s3cret")
+				}
+			},
+		},
 		{
 			name:   "TABOO_SERVER_PORT invalid is ignored",
 			envVar: "TABOO_SERVER_PORT",
@@ -359,6 +429,16 @@ func TestApplyEnv(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "TABOO_SERVER_ADMIN_TOKEN",
+			envVar: "TABOO_SERVER_ADMIN_TOKEN",
+			value:  "s3cret",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.AdminToken != "s3cret" {
+					t.Errorf("Server.AdminToken = %q, want %q", cfg.Server.AdminToken, "s3cret")
+				}
+			},
+		},
 		{
 			name:   "TABOO_SERVER_RATE_BURST",
 			envVar: "TABOO_SERVER_RATE_BURST",
@@ -379,6 +459,16 @@ func TestApplyEnv(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "TABOO_GAME_MIN_NUMBER",
+			envVar: "TABOO_GAME_MIN_NUMBER",
+			value:  "10",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Game.MinNumber != 10 {
+					t.Errorf("Game.MinNumber = %d, want %d", cfg.Game.MinNumber, 10)
+				}
+			},
+		},
 		{
 			name:   "TABOO_GAME_MAX_NUMBER",
 			envVar: "TABOO_GAME_MAX_NUMBER",
@@ -419,6 +509,36 @@ func TestApplyEnv(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "TABOO_DATABASE_QUERY_TIMEOUT",
+			envVar: "TABOO_DATABASE_QUERY_TIMEOUT",
+			value:  "2s",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Database.QueryTimeout.Duration() != 2*time.Second {
+					t.Errorf("Database.QueryTimeout = %v, want %v", cfg.Database.QueryTimeout.Duration(), 2*time.Second)
+				}
+			},
+		},
+		{
+			name:   "TABOO_DATABASE_LOG_QUERIES",
+			envVar: "TABOO_DATABASE_LOG_QUERIES",
+			value:  "true",
+			check: func(t *testing.T, cfg *Config) {
+				if !cfg.Database.LogQueries {
+					t.Error("Database.LogQueries = false, want true")
+				}
+			},
+		},
+		{
+			name:   "TABOO_CACHE_MAX_AGE",
+			envVar: "TABOO_CACHE_MAX_AGE",
+			value:  "1h",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Cache.MaxAge.Duration() != time.Hour {
+					t.Errorf("Cache.MaxAge = %v, want %v", cfg.Cache.MaxAge.Duration(), time.Hour)
+				}
+			},
+		},
 		{
 			name:   "TABOO_LOGGING_LEVEL",
 			envVar: "TABOO_LOGGING_LEVEL",
@@ -459,6 +579,126 @@ func TestApplyEnv(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "TABOO_LOGGING_FILE",
+			envVar: "TABOO_LOGGING_FILE",
+			value:  "/var/log/taboo.log",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Logging.File != "/var/log/taboo.log" {
+					t.Errorf("Logging.File = %q, want %q", cfg.Logging.File, "/var/log/taboo.log")
+				}
+			},
+		},
+		{
+			name:   "TABOO_LOGGING_MAX_BACKUPS",
+			envVar: "TABOO_LOGGING_MAX_BACKUPS",
+			value:  "3",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Logging.MaxBackups != 3 {
+					t.Errorf("Logging.MaxBackups = %d, want %d", cfg.Logging.MaxBackups, 3)
+				}
+			},
+		},
+		{
+			name:   "TABOO_CHAOS_ENABLED",
+			envVar: "TABOO_CHAOS_ENABLED",
+			value:  "true",
+			check: func(t *testing.T, cfg *Config) {
+				if !cfg.Chaos.Enabled {
+					t.Error("Chaos.Enabled = false, want true")
+				}
+			},
+		},
+		{
+			name:   "TABOO_CHAOS_ERROR_RATE",
+			envVar: "TABOO_CHAOS_ERROR_RATE",
+			value:  "0.25",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Chaos.ErrorRate != 0.25 {
+					t.Errorf("Chaos.ErrorRate = %g, want %g", cfg.Chaos.ErrorRate, 0.25)
+				}
+			},
+		},
+		{
+			name:   "TABOO_GAME_CALL_LOCALE empty",
+			envVar: "TABOO_GAME_CALL_LOCALE",
+			value:  "",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Game.CallLocale != "" {
+					t.Errorf("Game.CallLocale = %q, want empty", cfg.Game.CallLocale)
+				}
+			},
+		},
+		{
+			name:   "TABOO_SERVER_NODE_ID",
+			envVar: "TABOO_SERVER_NODE_ID",
+			value:  "syd-1",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.NodeID != "syd-1" {
+					t.Errorf("Server.NodeID = %q, want %q", cfg.Server.NodeID, "syd-1")
+				}
+			},
+		},
+		{
+			name:   "TABOO_GAME_IDLE_WHEN_EMPTY",
+			envVar: "TABOO_GAME_IDLE_WHEN_EMPTY",
+			value:  "true",
+			check: func(t *testing.T, cfg *Config) {
+				if !cfg.Game.IdleWhenEmpty {
+					t.Error("Game.IdleWhenEmpty = false, want true")
+				}
+			},
+		},
+		{
+			name:   "TABOO_GAME_IDLE_AFTER",
+			envVar: "TABOO_GAME_IDLE_AFTER",
+			value:  "10m",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Game.IdleAfter.Duration() != 10*time.Minute {
+					t.Errorf("Game.IdleAfter = %v, want %v", cfg.Game.IdleAfter, 10*time.Minute)
+				}
+			},
+		},
+		{
+			name:   "TABOO_LIMITS_MAX_PAGE_SIZE",
+			envVar: "TABOO_LIMITS_MAX_PAGE_SIZE",
+			value:  "50",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Limits.MaxPageSize != 50 {
+					t.Errorf("Limits.MaxPageSize = %d, want %d", cfg.Limits.MaxPageSize, 50)
+				}
+			},
+		},
+		{
+			name:   "TABOO_LIMITS_SSE_BUFFER_SIZE",
+			envVar: "TABOO_LIMITS_SSE_BUFFER_SIZE",
+			value:  "64",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Limits.SSEBufferSize != 64 {
+					t.Errorf("Limits.SSEBufferSize = %d, want %d", cfg.Limits.SSEBufferSize, 64)
+				}
+			},
+		},
+		{
+			name:   "TABOO_OBSERVABILITY_METRICS_PORT",
+			envVar: "TABOO_OBSERVABILITY_METRICS_PORT",
+			value:  "9100",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Observability.Metrics.Port != 9100 {
+					t.Errorf("Observability.Metrics.Port = %d, want %d", cfg.Observability.Metrics.Port, 9100)
+				}
+			},
+		},
+		{
+			name:   "TABOO_OBSERVABILITY_TRACING_SAMPLE_RATE",
+			envVar: "TABOO_OBSERVABILITY_TRACING_SAMPLE_RATE",
+			value:  "0.5",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Observability.Tracing.SampleRate != 0.5 {
+					t.Errorf("Observability.Tracing.SampleRate = %g, want %g", cfg.Observability.Tracing.SampleRate, 0.5)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
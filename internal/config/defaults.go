@@ -2,39 +2,105 @@ package config
 
 import "time"
 
+// Dev returns the defaults of taboo serve --dev, for iterating on the
+// frontend: games a few seconds long, debug logging, CORS open to every
+// origin and an in-memory database. The config file and environment
+// variables still override each of them.
+func Dev() *Config {
+	cfg := Default()
+	cfg.Environment = "development"
+	cfg.Game.DrawDuration = Duration(10 * time.Second)
+	cfg.Game.WaitDuration = Duration(5 * time.Second)
+	cfg.Logging.Level = "debug"
+	cfg.Database.DSN = ":memory:"
+	return cfg
+}
+
 // Default returns a Config with default values.
 func Default() *Config {
 	return &Config{
 		Environment: "development",
 		Server: ServerConfig{
-			Host:            "0.0.0.0",
-			Port:            8080,
-			ReadTimeout:     Duration(30 * time.Second),
-			WriteTimeout:    Duration(30 * time.Second),
-			ShutdownTimeout: Duration(10 * time.Second),
-			SSEHeartbeat:    Duration(15 * time.Second),
-			RequestTimeout:  Duration(30 * time.Second),
-			CORSOrigins:     []string{},
-			RateLimit:       100,
-			RateBurst:       20,
+			Host:             "0.0.0.0",
+			Port:             8080,
+			ReadTimeout:      Duration(30 * time.Second),
+			WriteTimeout:     Duration(30 * time.Second),
+			ShutdownTimeout:  Duration(10 * time.Second),
+			SSEHeartbeat:     Duration(15 * time.Second),
+			SSEHeartbeatMin:  Duration(5 * time.Second),
+			SSEHeartbeatMax:  Duration(60 * time.Second),
+			SSEMaxClients:    10000,
+			SSEEvictAfter:    8,
+			LegacyEventNames: true,
+			GzipMinSize:      512,
+			RequestTimeout:   Duration(30 * time.Second),
+			CORSOrigins:      []string{},
+			RateLimit:        100,
+			RateBurst:        20,
 		},
 		Game: GameConfig{
-			DrawDuration: Duration(90 * time.Second),
-			WaitDuration: Duration(90 * time.Second),
-			PickCount:    20,
-			MaxNumber:    80,
+			DrawDuration:       Duration(90 * time.Second),
+			WaitDuration:       Duration(90 * time.Second),
+			PickCount:          20,
+			MinNumber:          1,
+			MaxNumber:          80,
+			WatchdogTolerance:  Duration(30 * time.Second),
+			StoreTimeout:       Duration(10 * time.Second),
+			DuplicateWindow:    100,
+			DuplicateThreshold: 0.75,
+			CallLocale:         "en",
+			IdleAfter:          Duration(5 * time.Minute),
+			Variant:            "classic",
+			Source:             "local",
+			External: ExternalSourceConfig{
+				PollInterval:  Duration(5 * time.Second),
+				IngestMaxSkew: Duration(5 * time.Minute),
+			},
 		},
 		Database: DatabaseConfig{
-			Driver: "sqlite",
-			DSN:    "taboo.db",
+			Driver:       "sqlite",
+			DSN:          "taboo.db",
+			QueryTimeout: Duration(5 * time.Second),
 		},
 		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "text",
+			Level:      "info",
+			Format:     "text",
+			File:       "",
+			MaxSizeMB:  100,
+			MaxBackups: 5,
+			Compress:   false,
 		},
 		Discord: DiscordConfig{
 			ClientID:     "",
 			ClientSecret: "",
 		},
+		Cache: CacheConfig{
+			Enabled: false,
+			MaxAge:  Duration(24 * time.Hour),
+		},
+		Chaos: ChaosConfig{
+			Enabled: false,
+		},
+		Notifications: NotificationsConfig{
+			Alerts: AlertsConfig{
+				FailureThreshold: 3,
+				DegradedFor:      Duration(5 * time.Minute),
+			},
+		},
+		Limits: LimitsConfig{
+			MaxPageSize:      100,
+			MaxExportRows:    1000,
+			SSEBufferSize:    16,
+			EventHistorySize: 64,
+		},
+		Observability: ObservabilityConfig{
+			Metrics: MetricsConfig{
+				Enabled: false,
+				Port:    9090,
+			},
+			Tracing: TracingConfig{
+				SampleRate: 0.1,
+			},
+		},
 	}
 }
@@ -44,6 +44,41 @@ func applyEnv(cfg *Config) {
 			cfg.Server.RequestTimeout = Duration(d)
 		}
 	}
+	if v := os.Getenv("TABOO_SERVER_SSE_HEARTBEAT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.SSEHeartbeat = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_SERVER_SSE_HEARTBEAT_MIN"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.SSEHeartbeatMin = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_SERVER_SSE_HEARTBEAT_MAX"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.SSEHeartbeatMax = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_SERVER_SSE_MAX_CLIENTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Server.SSEMaxClients = n
+		}
+	}
+	if v := os.Getenv("TABOO_SERVER_SSE_EVICT_AFTER"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Server.SSEEvictAfter = n
+		}
+	}
+	if v := os.Getenv("TABOO_SERVER_LEGACY_EVENT_NAMES"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Server.LegacyEventNames = b
+		}
+	}
+	if v := os.Getenv("TABOO_SERVER_GZIP_MIN_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Server.GzipMinSize = n
+		}
+	}
 	if v := os.Getenv("TABOO_SERVER_CORS_ORIGINS"); v != "" {
 		cfg.Server.CORSOrigins = splitAndTrim(v, ",")
 	}
@@ -57,6 +92,12 @@ func applyEnv(cfg *Config) {
 			cfg.Server.RateBurst = n
 		}
 	}
+	if v := os.Getenv("TABOO_SERVER_ADMIN_TOKEN"); v != "" {
+		cfg.Server.AdminToken = v
+	}
+	if v := os.Getenv("TABOO_SERVER_NODE_ID"); v != "" {
+		cfg.Server.NodeID = v
+	}
 
 	// Game
 	if v := os.Getenv("TABOO_GAME_DRAW_DURATION"); v != "" {
@@ -74,11 +115,78 @@ func applyEnv(cfg *Config) {
 			cfg.Game.PickCount = n
 		}
 	}
+	if v := os.Getenv("TABOO_GAME_MIN_NUMBER"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Game.MinNumber = n
+		}
+	}
 	if v := os.Getenv("TABOO_GAME_MAX_NUMBER"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
 			cfg.Game.MaxNumber = n
 		}
 	}
+	if v := os.Getenv("TABOO_GAME_WATCHDOG_TOLERANCE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Game.WatchdogTolerance = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_GAME_STORE_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Game.StoreTimeout = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_GAME_DUPLICATE_WINDOW"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Game.DuplicateWindow = n
+		}
+	}
+	if v := os.Getenv("TABOO_GAME_DUPLICATE_THRESHOLD"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Game.DuplicateThreshold = f
+		}
+	}
+	// Set but empty turns call_text off
+	if v, ok := os.LookupEnv("TABOO_GAME_CALL_LOCALE"); ok {
+		cfg.Game.CallLocale = v
+	}
+	if v := os.Getenv("TABOO_GAME_VOICE_PACK"); v != "" {
+		cfg.Game.VoicePack = v
+	}
+	if v := os.Getenv("TABOO_GAME_IDLE_WHEN_EMPTY"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Game.IdleWhenEmpty = b
+		}
+	}
+	if v := os.Getenv("TABOO_GAME_IDLE_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Game.IdleAfter = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_GAME_REPLAY_FILE"); v != "" {
+		cfg.Game.ReplayFile = v
+	}
+	if v := os.Getenv("TABOO_GAME_VARIANT"); v != "" {
+		cfg.Game.Variant = v
+	}
+	if v := os.Getenv("TABOO_GAME_SOURCE"); v != "" {
+		cfg.Game.Source = v
+	}
+	if v := os.Getenv("TABOO_GAME_EXTERNAL_URL"); v != "" {
+		cfg.Game.External.URL = v
+	}
+	if v := os.Getenv("TABOO_GAME_EXTERNAL_POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Game.External.PollInterval = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_GAME_EXTERNAL_INGEST_SECRET"); v != "" {
+		cfg.Game.External.IngestSecret = v
+	}
+	if v := os.Getenv("TABOO_GAME_EXTERNAL_INGEST_MAX_SKEW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Game.External.IngestMaxSkew = Duration(d)
+		}
+	}
 
 	// Database
 	if v := os.Getenv("TABOO_DATABASE_DRIVER"); v != "" {
@@ -87,6 +195,22 @@ func applyEnv(cfg *Config) {
 	if v := os.Getenv("TABOO_DATABASE_DSN"); v != "" {
 		cfg.Database.DSN = v
 	}
+	if v := os.Getenv("TABOO_DATABASE_QUERY_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Database.QueryTimeout = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_DATABASE_LOG_QUERIES"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Database.LogQueries = b
+		}
+	}
+	if v := os.Getenv("TABOO_DATABASE_SHADOW_DRIVER"); v != "" {
+		cfg.Database.Shadow.Driver = v
+	}
+	if v := os.Getenv("TABOO_DATABASE_SHADOW_DSN"); v != "" {
+		cfg.Database.Shadow.DSN = v
+	}
 
 	// Logging
 	if v := os.Getenv("TABOO_LOGGING_LEVEL"); v != "" {
@@ -95,6 +219,24 @@ func applyEnv(cfg *Config) {
 	if v := os.Getenv("TABOO_LOGGING_FORMAT"); v != "" {
 		cfg.Logging.Format = v
 	}
+	if v := os.Getenv("TABOO_LOGGING_FILE"); v != "" {
+		cfg.Logging.File = v
+	}
+	if v := os.Getenv("TABOO_LOGGING_MAX_SIZE_MB"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Logging.MaxSizeMB = n
+		}
+	}
+	if v := os.Getenv("TABOO_LOGGING_MAX_BACKUPS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Logging.MaxBackups = n
+		}
+	}
+	if v := os.Getenv("TABOO_LOGGING_COMPRESS"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Logging.Compress = b
+		}
+	}
 
 	// Discord
 	if v := os.Getenv("DISCORD_CLIENT_ID"); v != "" {
@@ -103,6 +245,116 @@ func applyEnv(cfg *Config) {
 	if v := os.Getenv("DISCORD_CLIENT_SECRET"); v != "" {
 		cfg.Discord.ClientSecret = v
 	}
+
+	// Cache
+	if v := os.Getenv("TABOO_CACHE_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Cache.Enabled = b
+		}
+	}
+	if v := os.Getenv("TABOO_CACHE_MAX_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Cache.MaxAge = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_CACHE_PURGE_URL"); v != "" {
+		cfg.Cache.PurgeURL = v
+	}
+	if v := os.Getenv("TABOO_CACHE_PURGE_TOKEN"); v != "" {
+		cfg.Cache.PurgeToken = v
+	}
+
+	// Chaos
+	if v := os.Getenv("TABOO_CHAOS_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Chaos.Enabled = b
+		}
+	}
+	if v := os.Getenv("TABOO_CHAOS_LATENCY_MIN"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Chaos.LatencyMin = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_CHAOS_LATENCY_MAX"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Chaos.LatencyMax = Duration(d)
+		}
+	}
+	if v := os.Getenv("TABOO_CHAOS_ERROR_RATE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Chaos.ErrorRate = f
+		}
+	}
+	if v := os.Getenv("TABOO_CHAOS_SSE_DISCONNECT_RATE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Chaos.SSEDisconnectRate = f
+		}
+	}
+
+	// Notifications
+	if v := os.Getenv("TABOO_NOTIFICATIONS_ALERTS_WEBHOOK_URL"); v != "" {
+		cfg.Notifications.Alerts.WebhookURL = v
+	}
+	if v := os.Getenv("TABOO_NOTIFICATIONS_ALERTS_DISCORD_WEBHOOK_URL"); v != "" {
+		cfg.Notifications.Alerts.DiscordWebhookURL = v
+	}
+	if v := os.Getenv("TABOO_NOTIFICATIONS_ALERTS_FAILURE_THRESHOLD"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Notifications.Alerts.FailureThreshold = n
+		}
+	}
+	if v := os.Getenv("TABOO_NOTIFICATIONS_ALERTS_DEGRADED_FOR"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Notifications.Alerts.DegradedFor = Duration(d)
+		}
+	}
+
+	// Limits
+	if v := os.Getenv("TABOO_LIMITS_MAX_PAGE_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Limits.MaxPageSize = n
+		}
+	}
+	if v := os.Getenv("TABOO_LIMITS_MAX_EXPORT_ROWS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Limits.MaxExportRows = n
+		}
+	}
+	if v := os.Getenv("TABOO_LIMITS_SSE_BUFFER_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Limits.SSEBufferSize = n
+		}
+	}
+	if v := os.Getenv("TABOO_LIMITS_EVENT_HISTORY_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Limits.EventHistorySize = n
+		}
+	}
+
+	// Observability
+	if v := os.Getenv("TABOO_OBSERVABILITY_METRICS_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Observability.Metrics.Enabled = b
+		}
+	}
+	if v := os.Getenv("TABOO_OBSERVABILITY_METRICS_PORT"); v != "" {
+		if port, err := strconv.Atoi(v); err == nil {
+			cfg.Observability.Metrics.Port = port
+		}
+	}
+	if v := os.Getenv("TABOO_OBSERVABILITY_TRACING_ENDPOINT"); v != "" {
+		cfg.Observability.Tracing.Endpoint = v
+	}
+	if v := os.Getenv("TABOO_OBSERVABILITY_TRACING_SAMPLE_RATE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Observability.Tracing.SampleRate = f
+		}
+	}
+	if v := os.Getenv("TABOO_OBSERVABILITY_PROFILING_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Observability.Profiling.Enabled = b
+		}
+	}
 }
 
 // splitAndTrim splits a string by separator and trims whitespace from each part.
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"net/url"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aussiebroadwan/taboo/pkg/lint"
+	"github.com/aussiebroadwan/taboo/pkg/numwords"
 )
 
 // Lint checks the configuration and returns all issues (errors, warnings, info).
@@ -16,6 +21,11 @@ func Lint(cfg *Config) lint.Issues {
 	lintDatabase(c, cfg)
 	lintLogging(c, cfg)
 	lintDiscord(c, cfg)
+	lintCache(c, cfg)
+	lintChaos(c, cfg)
+	lintNotifications(c, cfg)
+	lintLimits(c, cfg)
+	lintObservability(c, cfg)
 
 	return c.Issues()
 }
@@ -53,20 +63,67 @@ func lintServer(c *lint.Collector, cfg *Config) {
 	if cfg.Server.RequestTimeout.Duration() <= 0 {
 		c.Error("timeout-invalid", "server.request_timeout", "must be positive")
 	}
+	lintHeartbeat(c, cfg)
+	if cfg.Server.SSEMaxClients < 0 {
+		c.Errorf("sse-invalid", "server.sse_max_clients", "must not be negative, got %d", cfg.Server.SSEMaxClients)
+	}
+	if cfg.Server.SSEEvictAfter < 0 {
+		c.Errorf("sse-invalid", "server.sse_evict_after", "must not be negative, got %d", cfg.Server.SSEEvictAfter)
+	}
+	if cfg.Server.GzipMinSize < 0 {
+		c.Errorf("gzip-invalid", "server.gzip_min_size", "must not be negative, got %d", cfg.Server.GzipMinSize)
+	}
 	if cfg.Server.RateLimit < 1 {
 		c.Errorf("rate-limit-invalid", "server.rate_limit", "must be at least 1, got %d", cfg.Server.RateLimit)
 	}
 	if cfg.Server.RateBurst < 1 {
 		c.Errorf("rate-limit-invalid", "server.rate_burst", "must be at least 1, got %d", cfg.Server.RateBurst)
 	}
+	if cfg.Server.NodeID != "" && !nodeIDPattern.MatchString(cfg.Server.NodeID) {
+		c.Errorf("node-id-invalid", "server.node_id", "must match %s, got %q", nodeIDPattern, cfg.Server.NodeID)
+	}
+	if cfg.Server.AdminToken == "" {
+		c.Info("admin-disabled", "server.admin_token", "admin token not configured (admin endpoints are disabled)")
+	}
+}
+
+// nodeIDPattern restricts node IDs to names safe in headers and logs.
+var nodeIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+func lintHeartbeat(c *lint.Collector, cfg *Config) {
+	heartbeat := cfg.Server.SSEHeartbeat.Duration()
+	minHB, maxHB := cfg.Server.SSEHeartbeatMin.Duration(), cfg.Server.SSEHeartbeatMax.Duration()
+
+	if heartbeat <= 0 {
+		c.Error("heartbeat-invalid", "server.sse_heartbeat", "must be positive")
+	}
+	if minHB < 0 {
+		c.Error("heartbeat-invalid", "server.sse_heartbeat_min", "must not be negative")
+	}
+	if maxHB < 0 {
+		c.Error("heartbeat-invalid", "server.sse_heartbeat_max", "must not be negative")
+	}
+	if minHB > 0 && maxHB > 0 && maxHB < minHB {
+		c.Errorf("heartbeat-invalid", "server.sse_heartbeat_max", "must be at least sse_heartbeat_min (%s), got %s", minHB, maxHB)
+	}
+	if heartbeat > 0 && ((minHB > 0 && heartbeat < minHB) || (maxHB > 0 && heartbeat > maxHB)) {
+		c.Warnf("heartbeat-out-of-bounds", "server.sse_heartbeat", "default %s is outside the bounds clients may request (%s-%s)", heartbeat, minHB, maxHB)
+	}
 }
 
 func lintGame(c *lint.Collector, cfg *Config) {
 	if cfg.Game.PickCount < 1 {
 		c.Errorf("game-invalid", "game.pick_count", "must be at least 1, got %d", cfg.Game.PickCount)
 	}
-	if cfg.Game.MaxNumber < cfg.Game.PickCount {
-		c.Errorf("game-invalid", "game.max_number", "must be >= pick_count (%d), got %d", cfg.Game.PickCount, cfg.Game.MaxNumber)
+	if cfg.Game.MinNumber < 1 {
+		c.Errorf("game-invalid", "game.min_number", "must be at least 1, got %d", cfg.Game.MinNumber)
+	}
+	if cfg.Game.MaxNumber > 255 {
+		c.Errorf("game-invalid", "game.max_number", "must be at most 255, got %d", cfg.Game.MaxNumber)
+	}
+	if size := cfg.Game.MaxNumber - cfg.Game.MinNumber + 1; size < cfg.Game.PickCount {
+		c.Errorf("game-invalid", "game.max_number", "range %d-%d must hold at least pick_count (%d) numbers, got %d",
+			cfg.Game.MinNumber, cfg.Game.MaxNumber, cfg.Game.PickCount, max(size, 0))
 	}
 	if cfg.Game.DrawDuration.Duration() <= 0 {
 		c.Error("timeout-invalid", "game.draw_duration", "must be positive")
@@ -74,6 +131,115 @@ func lintGame(c *lint.Collector, cfg *Config) {
 	if cfg.Game.WaitDuration.Duration() <= 0 {
 		c.Error("timeout-invalid", "game.wait_duration", "must be positive")
 	}
+	if cfg.Game.WatchdogTolerance.Duration() < 0 {
+		c.Error("timeout-invalid", "game.watchdog_tolerance", "must not be negative")
+	}
+	switch timeout := cfg.Game.StoreTimeout.Duration(); {
+	case timeout < 0:
+		c.Error("timeout-invalid", "game.store_timeout", "must not be negative")
+	case timeout == 0:
+		c.Warn("engine-store-timeout-disabled", "game.store_timeout", "engine store calls are unbounded; a hung database can stall the game loop")
+	}
+
+	if cfg.Game.DuplicateWindow < 0 {
+		c.Errorf("game-invalid", "game.duplicate_window", "must not be negative, got %d", cfg.Game.DuplicateWindow)
+	}
+	if t := cfg.Game.DuplicateThreshold; cfg.Game.DuplicateWindow > 0 && (t <= 0 || t > 1) {
+		c.Errorf("game-invalid", "game.duplicate_threshold", "must be in (0, 1], got %g", t)
+	}
+
+	if cfg.Game.CallLocale != "" && !numwords.Supported(cfg.Game.CallLocale) {
+		c.Errorf("game-invalid", "game.call_locale", "must be one of %s, got %q",
+			strings.Join(numwords.Locales(), ", "), cfg.Game.CallLocale)
+	}
+
+	if cfg.Game.VoicePack != "" {
+		if info, err := os.Stat(cfg.Game.VoicePack); err != nil {
+			c.Errorf("voice-pack-missing", "game.voice_pack", "cannot read voice pack: %v", err)
+		} else if !info.IsDir() {
+			c.Errorf("voice-pack-missing", "game.voice_pack", "must be a directory, got file %q", cfg.Game.VoicePack)
+		}
+	}
+
+	if cfg.Game.IdleWhenEmpty && cfg.Game.IdleAfter.Duration() <= 0 {
+		c.Error("timeout-invalid", "game.idle_after", "must be positive when idle_when_empty is on")
+	}
+
+	lintVariant(c, cfg)
+	lintSource(c, cfg)
+
+	if cfg.Game.ReplayFile != "" {
+		if _, err := os.Stat(cfg.Game.ReplayFile); err != nil {
+			c.Errorf("replay-missing", "game.replay_file", "cannot read fixture: %v", err)
+		}
+		if !strings.EqualFold(cfg.Environment, "development") {
+			c.Warn("replay-enabled", "game.replay_file", "replaying a fixture instead of running games outside development")
+		}
+	}
+}
+
+// Variants lists the game.variant values.
+var Variants = []string{"classic", "heads_tails", "bingo"}
+
+// BingoCardSize is how many numbers a bingo card marks, and so the least a
+// bingo board can hold.
+const BingoCardSize = 25
+
+func lintVariant(c *lint.Collector, cfg *Config) {
+	board := cfg.Game.MaxNumber - cfg.Game.MinNumber + 1
+	switch cfg.Game.Variant {
+	case "", "classic":
+	case "heads_tails":
+		if board%2 != 0 {
+			c.Errorf("game-variant-invalid", "game.variant", "heads_tails splits the board in half, so needs an even number of numbers, got %d", board)
+		}
+	case "bingo":
+		if board < BingoCardSize {
+			c.Errorf("game-variant-invalid", "game.variant", "bingo cards mark %d numbers, so the board needs at least that many, got %d", BingoCardSize, board)
+		}
+	default:
+		c.Errorf("game-variant-invalid", "game.variant", "must be one of %s, got %q", strings.Join(Variants, ", "), cfg.Game.Variant)
+	}
+}
+
+// minIngestSecretLen is the shortest ingest secret accepted without a
+// warning; shorter HMAC keys are open to brute force.
+const minIngestSecretLen = 32
+
+func lintSource(c *lint.Collector, cfg *Config) {
+	switch cfg.Game.Source {
+	case "", "local":
+		return
+	case "external":
+	default:
+		c.Errorf("game-source-invalid", "game.source", "must be 'local' or 'external', got %q", cfg.Game.Source)
+		return
+	}
+
+	ext := cfg.Game.External
+	switch {
+	case ext.URL != "" && ext.IngestSecret != "":
+		c.Error("game-source-invalid", "game.external", "set either url to poll or ingest_secret to accept pushes, not both")
+	case ext.IngestSecret != "":
+		if len(ext.IngestSecret) < minIngestSecretLen {
+			c.Warnf("ingest-secret-short", "game.external.ingest_secret", "should be at least %d characters", minIngestSecretLen)
+		}
+		if ext.IngestMaxSkew.Duration() <= 0 {
+			c.Error("timeout-invalid", "game.external.ingest_max_skew", "must be positive")
+		}
+	case ext.URL == "":
+		c.Error("game-source-invalid", "game.external.url", "or ingest_secret is required when game.source is external")
+	default:
+		if u, err := url.Parse(ext.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.Errorf("game-source-invalid", "game.external.url", "must be an http(s) URL, got %q", ext.URL)
+		}
+		if ext.PollInterval.Duration() <= 0 {
+			c.Error("timeout-invalid", "game.external.poll_interval", "must be positive")
+		}
+	}
+	if cfg.Game.ReplayFile != "" {
+		c.Warn("game-source-ignored", "game.source", "replay_file is set, so the external source is not used")
+	}
 }
 
 func lintDatabase(c *lint.Collector, cfg *Config) {
@@ -89,6 +255,43 @@ func lintDatabase(c *lint.Collector, cfg *Config) {
 	} else if cfg.Database.DSN == ":memory:" {
 		c.Warn("db-memory", "database.dsn", "using in-memory database (data will be lost on restart)")
 	}
+
+	queryTimeout := cfg.Database.QueryTimeout.Duration()
+	switch {
+	case queryTimeout < 0:
+		c.Errorf("db-invalid", "database.query_timeout", "must not be negative, got %s", queryTimeout)
+	case queryTimeout == 0:
+		c.Info("db-query-timeout-disabled", "database.query_timeout", "query timeout disabled, queries are bounded only by the request timeout")
+	case queryTimeout > cfg.Server.RequestTimeout.Duration() && cfg.Server.RequestTimeout > 0:
+		c.Warnf("db-query-timeout", "database.query_timeout", "%s exceeds server.request_timeout (%s), so it never takes effect for requests", queryTimeout, cfg.Server.RequestTimeout.Duration())
+	}
+
+	if cfg.Database.LogQueries && !strings.EqualFold(cfg.Logging.Level, "debug") {
+		c.Warnf("db-log-queries", "database.log_queries", "queries are logged at debug, so nothing is written at logging.level %q until the level is lowered", cfg.Logging.Level)
+	}
+
+	lintShadow(c, cfg)
+}
+
+func lintShadow(c *lint.Collector, cfg *Config) {
+	shadow := cfg.Database.Shadow
+	if shadow.Driver == "" {
+		if shadow.DSN != "" {
+			c.Warn("db-shadow-ignored", "database.shadow.dsn", "is set without database.shadow.driver, so no shadow store is used")
+		}
+		return
+	}
+
+	if shadow.Driver != "sqlite" {
+		c.Errorf("db-invalid", "database.shadow.driver", "must be 'sqlite', got %q", shadow.Driver)
+	}
+	switch shadow.DSN {
+	case "":
+		c.Error("db-invalid", "database.shadow.dsn", "is required when database.shadow.driver is set")
+	case cfg.Database.DSN:
+		c.Error("db-invalid", "database.shadow.dsn", "must differ from database.dsn")
+	}
+	c.Info("db-shadow", "database.shadow", "every write goes to both databases, adding the shadow's latency to each")
 }
 
 func lintLogging(c *lint.Collector, cfg *Config) {
@@ -106,6 +309,15 @@ func lintLogging(c *lint.Collector, cfg *Config) {
 	if format != "text" && format != "json" {
 		c.Errorf("logging-invalid", "logging.format", "must be one of: text, json; got %q", cfg.Logging.Format)
 	}
+
+	if cfg.Logging.File != "" {
+		if cfg.Logging.MaxSizeMB < 1 {
+			c.Errorf("logging-invalid", "logging.max_size_mb", "must be at least 1, got %d", cfg.Logging.MaxSizeMB)
+		}
+		if cfg.Logging.MaxBackups < 0 {
+			c.Errorf("logging-invalid", "logging.max_backups", "must not be negative, got %d", cfg.Logging.MaxBackups)
+		}
+	}
 }
 
 func lintDiscord(c *lint.Collector, cfg *Config) {
@@ -113,3 +325,137 @@ func lintDiscord(c *lint.Collector, cfg *Config) {
 		c.Warn("discord-missing", "discord", "Discord credentials not configured (Discord Activity will not work)")
 	}
 }
+
+func lintCache(c *lint.Collector, cfg *Config) {
+	if !cfg.Cache.Enabled {
+		if cfg.Cache.PurgeURL != "" {
+			c.Warn("cache-purge-unused", "cache.purge_url", "purge URL is set but cache.enabled is false")
+		}
+		return
+	}
+
+	if cfg.Cache.MaxAge.Duration() < time.Second {
+		c.Errorf("cache-invalid", "cache.max_age", "must be at least 1s, got %s", cfg.Cache.MaxAge.Duration())
+	}
+	if cfg.Cache.PurgeURL != "" {
+		u, err := url.Parse(cfg.Cache.PurgeURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.Errorf("cache-invalid", "cache.purge_url", "must be an http(s) URL, got %q", cfg.Cache.PurgeURL)
+		}
+	} else {
+		c.Info("cache-no-purge", "cache.purge_url", "no purge URL; CDN rules that override origin TTLs may serve stale games")
+	}
+}
+
+func lintChaos(c *lint.Collector, cfg *Config) {
+	if !cfg.Chaos.Enabled {
+		return
+	}
+
+	if !strings.EqualFold(cfg.Environment, "development") {
+		c.Error("chaos-production", "chaos.enabled", "chaos can only be enabled in development")
+	} else {
+		c.Warn("chaos-enabled", "chaos", "chaos enabled (injecting latency, errors and SSE disconnects)")
+	}
+
+	if cfg.Chaos.LatencyMin.Duration() < 0 {
+		c.Error("chaos-invalid", "chaos.latency_min", "must not be negative")
+	}
+	if cfg.Chaos.LatencyMax.Duration() < cfg.Chaos.LatencyMin.Duration() {
+		c.Errorf("chaos-invalid", "chaos.latency_max", "must be >= latency_min (%s), got %s",
+			cfg.Chaos.LatencyMin.Duration(), cfg.Chaos.LatencyMax.Duration())
+	}
+	lintRate(c, "chaos.error_rate", cfg.Chaos.ErrorRate)
+	for route, rate := range cfg.Chaos.RouteErrorRates {
+		lintRate(c, "chaos.route_error_rates."+route, rate)
+	}
+	lintRate(c, "chaos.sse_disconnect_rate", cfg.Chaos.SSEDisconnectRate)
+}
+
+func lintNotifications(c *lint.Collector, cfg *Config) {
+	alerts := cfg.Notifications.Alerts
+	for _, dest := range []struct{ location, url string }{
+		{"notifications.alerts.webhook_url", alerts.WebhookURL},
+		{"notifications.alerts.discord_webhook_url", alerts.DiscordWebhookURL},
+	} {
+		if dest.url == "" {
+			continue
+		}
+		if u, err := url.Parse(dest.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.Errorf("alerts-invalid", dest.location, "must be an http(s) URL, got %q", dest.url)
+		}
+	}
+
+	if alerts.FailureThreshold < 0 {
+		c.Errorf("alerts-invalid", "notifications.alerts.failure_threshold", "must not be negative, got %d", alerts.FailureThreshold)
+	}
+	if alerts.DegradedFor.Duration() < 0 {
+		c.Error("alerts-invalid", "notifications.alerts.degraded_for", "must not be negative")
+	}
+
+	if !alerts.Enabled() && strings.EqualFold(cfg.Environment, "production") {
+		c.Info("alerts-disabled", "notifications.alerts", "no alert webhook configured; engine failures are only logged")
+	}
+}
+
+func lintLimits(c *lint.Collector, cfg *Config) {
+	limits := cfg.Limits
+	for _, limit := range []struct {
+		location string
+		value    int
+	}{
+		{"limits.max_page_size", limits.MaxPageSize},
+		{"limits.max_export_rows", limits.MaxExportRows},
+		{"limits.sse_buffer_size", limits.SSEBufferSize},
+		{"limits.event_history_size", limits.EventHistorySize},
+	} {
+		if limit.value < 1 {
+			c.Errorf("limits-invalid", limit.location, "must be at least 1, got %d", limit.value)
+		}
+	}
+
+	if limits.MaxPageSize > 0 && limits.MaxExportRows > 0 && limits.MaxExportRows < limits.MaxPageSize {
+		c.Warnf("limits-export-small", "limits.max_export_rows", "is below max_page_size (%d), so bulk endpoints return smaller batches than listings", limits.MaxPageSize)
+	}
+	if limits.EventHistorySize > 0 && limits.EventHistorySize < cfg.Game.PickCount+2 {
+		c.Warnf("limits-history-small", "limits.event_history_size", "holds fewer events than one game (%d); long-poll clients may miss picks", cfg.Game.PickCount+2)
+	}
+}
+
+func lintObservability(c *lint.Collector, cfg *Config) {
+	obs := cfg.Observability
+
+	if obs.Metrics.Enabled {
+		switch {
+		case obs.Metrics.Port < 1 || obs.Metrics.Port > 65535:
+			c.Errorf("metrics-invalid", "observability.metrics.port", "must be between 1 and 65535, got %d", obs.Metrics.Port)
+		case obs.Metrics.Port == cfg.Server.Port:
+			c.Errorf("metrics-invalid", "observability.metrics.port", "must differ from server.port (%d)", cfg.Server.Port)
+		}
+	}
+
+	if obs.Tracing.Endpoint != "" {
+		if u, err := url.Parse(obs.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.Errorf("tracing-invalid", "observability.tracing.endpoint", "must be an http(s) URL, got %q", obs.Tracing.Endpoint)
+		}
+		c.Warn("tracing-unsupported", "observability.tracing", "this build does not export spans yet; tracing settings are checked but unused")
+	}
+	if obs.Tracing.SampleRate < 0 || obs.Tracing.SampleRate > 1 {
+		c.Errorf("tracing-invalid", "observability.tracing.sample_rate", "must be between 0 and 1, got %g", obs.Tracing.SampleRate)
+	}
+
+	if obs.Profiling.Enabled {
+		if !obs.Metrics.Enabled {
+			c.Error("profiling-invalid", "observability.profiling.enabled", "requires observability.metrics.enabled, whose listener serves it")
+		}
+		if strings.EqualFold(cfg.Environment, "production") {
+			c.Warn("profiling-production", "observability.profiling", "pprof exposes heap and goroutine dumps; keep the metrics port private")
+		}
+	}
+}
+
+func lintRate(c *lint.Collector, location string, rate float64) {
+	if rate < 0 || rate > 1 {
+		c.Errorf("chaos-invalid", location, "must be between 0 and 1, got %g", rate)
+	}
+}
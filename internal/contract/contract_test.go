@@ -0,0 +1,561 @@
+package contract
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	taboohttp "github.com/aussiebroadwan/taboo/internal/http"
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+	"github.com/aussiebroadwan/taboo/pkg/ssetest"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// Object shapes published by the API: the fields always present, and those
+// that may be omitted.
+type shape struct {
+	required []string
+	optional []string
+}
+
+var (
+	gameShape     = shape{required: []string{"id", "picks", "created_at", "result_hash"}}
+	seasonShape   = shape{required: []string{"id", "name", "first_game_id", "current", "started_at"}, optional: []string{"end_game_id"}}
+	gameListShape = shape{required: []string{"games"}, optional: []string{"season", "next_cursor"}}
+	seasonsShape  = shape{required: []string{"seasons"}}
+	syncShape     = shape{required: []string{"games", "last_id", "has_more"}}
+	waitShape     = shape{required: []string{"events", "last_seq"}}
+	pacingShape   = shape{required: []string{"pick_count", "draw_duration_ms", "wait_duration_ms", "reveal_curve", "reveal_offsets_ms"}}
+	oddsShape     = shape{required: []string{"spots", "board_size", "pick_count", "hit_odds", "games", "numbers", "due"}}
+	numberShape   = shape{required: []string{"number", "drawn", "rate", "since"}}
+	envelopeShape = shape{required: []string{"seq", "type", "data"}, optional: []string{"node"}}
+	errorShape    = shape{required: []string{"error"}}
+	errorDetail   = shape{required: []string{"code", "message"}, optional: []string{"param"}}
+	responseShape = shape{required: []string{"data", "meta"}}
+
+	eventShapes = map[string]shape{
+		"game:state":      {required: []string{"game_id", "picks", "next_game"}, optional: []string{"season", "hints", "special"}},
+		"game:pick":       {required: []string{"pick", "revealed_at", "next_reveal_in_ms"}, optional: []string{"call_text", "call_audio"}},
+		"game:complete":   {required: []string{"game_id"}, optional: []string{"variant", "outcome"}},
+		"sys:heartbeat":   {},
+		"engine:degraded": {required: []string{"operation", "reason"}},
+		"server:notice":   {required: []string{"message", "severity", "ttl_seconds"}, optional: []string{"translations"}},
+	}
+
+	// streamEventShapes are events sent to a single stream rather than
+	// broadcast, so they are not seen on a healthy connection.
+	streamEventShapes = map[string]shape{
+		"stream:gap": {required: []string{"dropped", "latest_seq"}},
+	}
+
+	// legacyEventShapes are the old names renamed events are also sent
+	// under while server.legacy_event_names is on.
+	legacyEventShapes = map[string]shape{
+		"game:heartbeat": {},
+	}
+)
+
+// contractServer is the real server and engine with fast game timings.
+type contractServer struct {
+	URL         string
+	GameService *service.GameService
+}
+
+func newContractServer(t *testing.T) *contractServer {
+	t.Helper()
+
+	store, err := sqlite.New(filepath.Join(t.TempDir(), "contract.db"))
+	if err != nil {
+		t.Fatalf("opening store: %v", err)
+	}
+
+	cfg := &config.Config{
+		Environment: "development",
+		Server: config.ServerConfig{
+			Host:            "127.0.0.1",
+			ReadTimeout:     config.Duration(30 * time.Second),
+			WriteTimeout:    config.Duration(30 * time.Second),
+			ShutdownTimeout: config.Duration(5 * time.Second),
+			SSEHeartbeat:    config.Duration(100 * time.Millisecond),
+			RequestTimeout:  config.Duration(30 * time.Second),
+			CORSOrigins:     []string{"*"},
+			RateLimit:       1000,
+			RateBurst:       100,
+
+			LegacyEventNames: true,
+		},
+		Game: config.GameConfig{
+			DrawDuration: config.Duration(150 * time.Millisecond),
+			WaitDuration: config.Duration(50 * time.Millisecond),
+			PickCount:    3,
+			MinNumber:    1,
+			MaxNumber:    10,
+		},
+		Limits: config.Default().Limits,
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	gameService := service.NewGameService(store, &cfg.Game)
+	engine := service.NewEngine(gameService, &cfg.Game, logger)
+	srv := taboohttp.NewServer(cfg, logger, store, gameService, service.NewSettingsService(store), engine)
+	ts := httptest.NewServer(srv.Handler())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = engine.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		ts.Close()
+		_ = store.Close()
+	})
+
+	return &contractServer{URL: ts.URL, GameService: gameService}
+}
+
+// waitForGames blocks until at least n games have completed.
+func (s *contractServer) waitForGames(t *testing.T, n int) {
+	t.Helper()
+	client := sdk.NewClient(s.URL)
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := client.SyncGames(context.Background(), 0, n)
+		if err == nil && len(resp.Games) >= n {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d games", n)
+}
+
+// getJSON fetches path and decodes the body as a JSON object.
+func getJSON(t *testing.T, url string, header http.Header) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("GET %s: decoding body: %v", url, err)
+	}
+	return resp.StatusCode, body
+}
+
+// assertShape checks obj has exactly the fields s allows.
+func assertShape(t *testing.T, name string, obj any, s shape) {
+	t.Helper()
+	m, ok := obj.(map[string]any)
+	if !ok {
+		t.Errorf("%s = %v, want a JSON object", name, obj)
+		return
+	}
+	for _, key := range s.required {
+		if _, ok := m[key]; !ok {
+			t.Errorf("%s is missing %q: %v", name, key, m)
+		}
+	}
+	for key := range m {
+		if !slices.Contains(s.required, key) && !slices.Contains(s.optional, key) {
+			t.Errorf("%s has unexpected field %q: %v", name, key, m)
+		}
+	}
+}
+
+// first returns the first element of the array field key of obj.
+func first(t *testing.T, obj map[string]any, key string) any {
+	t.Helper()
+	items, _ := obj[key].([]any)
+	if len(items) == 0 {
+		t.Fatalf("%s is empty: %v", key, obj)
+	}
+	return items[0]
+}
+
+func TestContract_EventTypes(t *testing.T) {
+	types := map[string]string{
+		sdk.EventGameState:      "game:state",
+		sdk.EventGamePick:       "game:pick",
+		sdk.EventGameComplete:   "game:complete",
+		sdk.EventSysHeartbeat:   "sys:heartbeat",
+		sdk.EventEngineDegraded: "engine:degraded",
+		sdk.EventServerNotice:   "server:notice",
+		sdk.EventStreamGap:      "stream:gap",
+	}
+	for got, want := range types {
+		if got != want {
+			t.Errorf("event type %q, published as %q", got, want)
+		}
+	}
+	if pinned := len(eventShapes) + len(streamEventShapes); len(types) != pinned {
+		t.Errorf("%d event types, but %d event shapes are pinned", len(types), pinned)
+	}
+
+	var gap any
+	b, _ := json.Marshal(sdk.StreamGapEvent{Dropped: 2, LatestSeq: 5})
+	if err := json.Unmarshal(b, &gap); err != nil {
+		t.Fatalf("decoding stream:gap: %v", err)
+	}
+	assertShape(t, "stream:gap", gap, streamEventShapes["stream:gap"])
+
+	legacy := sdk.LegacyEventTypes()
+	if len(legacy) != len(legacyEventShapes) {
+		t.Errorf("%d renamed event types, but %d legacy names are pinned", len(legacy), len(legacyEventShapes))
+	}
+	for current, old := range legacy {
+		if _, ok := legacyEventShapes[old]; !ok {
+			t.Errorf("%s was renamed from %q, which is not pinned", current, old)
+		}
+		if sdk.CanonicalEventType(old) != current {
+			t.Errorf("CanonicalEventType(%q) = %q, want %q", old, sdk.CanonicalEventType(old), current)
+		}
+	}
+}
+
+func TestContract_REST(t *testing.T) {
+	s := newContractServer(t)
+	s.waitForGames(t, 2)
+
+	ctx := context.Background()
+	// Close the first season so both an ended and a current one are listed
+	if _, err := s.GameService.RollSeason(ctx, "Contract"); err != nil {
+		t.Fatalf("RollSeason() error: %v", err)
+	}
+
+	client := sdk.NewClient(s.URL)
+	tests := []struct {
+		name  string
+		path  string
+		call  func() error
+		check func(t *testing.T, body map[string]any)
+	}{
+		{
+			name: "ListGames", path: "/api/v1/games?limit=1",
+			call: func() error { _, err := client.ListGames(ctx, &sdk.ListGamesOptions{Limit: sdk.Ptr(1)}); return err },
+			check: func(t *testing.T, body map[string]any) {
+				assertShape(t, "game list", body, gameListShape)
+				assertShape(t, "game", first(t, body, "games"), gameShape)
+			},
+		},
+		{
+			name: "GetGame", path: "/api/v1/games/1",
+			call:  func() error { _, err := client.GetGame(ctx, 1); return err },
+			check: func(t *testing.T, body map[string]any) { assertShape(t, "game", body, gameShape) },
+		},
+		{
+			name: "GetLatestGame", path: "/api/v1/games/latest",
+			call:  func() error { _, err := client.GetLatestGame(ctx); return err },
+			check: func(t *testing.T, body map[string]any) { assertShape(t, "game", body, gameShape) },
+		},
+		{
+			name: "ListSeasons", path: "/api/v1/seasons",
+			call: func() error { _, err := client.ListSeasons(ctx); return err },
+			check: func(t *testing.T, body map[string]any) {
+				assertShape(t, "season list", body, seasonsShape)
+				season := first(t, body, "seasons")
+				assertShape(t, "season", season, seasonShape)
+				if _, ok := season.(map[string]any)["end_game_id"]; !ok {
+					t.Errorf("ended season has no end_game_id: %v", season)
+				}
+			},
+		},
+		{
+			name: "GetSeason", path: "/api/v1/seasons/1",
+			call:  func() error { _, err := client.GetSeason(ctx, 1); return err },
+			check: func(t *testing.T, body map[string]any) { assertShape(t, "season", body, seasonShape) },
+		},
+		{
+			name: "GetCurrentSeason", path: "/api/v1/seasons/current",
+			call:  func() error { _, err := client.GetCurrentSeason(ctx); return err },
+			check: func(t *testing.T, body map[string]any) { assertShape(t, "season", body, seasonShape) },
+		},
+		{
+			name: "GetPacing", path: "/api/v1/config/pacing",
+			call:  func() error { _, err := client.GetPacing(ctx); return err },
+			check: func(t *testing.T, body map[string]any) { assertShape(t, "pacing", body, pacingShape) },
+		},
+		{
+			name: "GetOdds", path: "/api/v1/stats/odds?spots=5",
+			call: func() error { _, err := client.GetOdds(ctx, 5, 0); return err },
+			check: func(t *testing.T, body map[string]any) {
+				assertShape(t, "odds", body, oddsShape)
+				assertShape(t, "number frequency", first(t, body, "numbers"), numberShape)
+			},
+		},
+		{
+			name: "SyncGames", path: "/api/v1/sync/games?after_id=0",
+			call: func() error { _, err := client.SyncGames(ctx, 0, 0); return err },
+			check: func(t *testing.T, body map[string]any) {
+				assertShape(t, "sync batch", body, syncShape)
+				assertShape(t, "game", first(t, body, "games"), gameShape)
+			},
+		},
+		{
+			name: "WaitForEvents", path: "/api/v1/games/current/wait?since_seq=0",
+			call: func() error { _, err := client.WaitForEvents(ctx, 0); return err },
+			check: func(t *testing.T, body map[string]any) {
+				assertShape(t, "wait response", body, waitShape)
+				envelope := first(t, body, "events")
+				assertShape(t, "event envelope", envelope, envelopeShape)
+				m := envelope.(map[string]any)
+				typ, _ := m["type"].(string)
+				shape, ok := eventShapes[typ]
+				if !ok {
+					t.Fatalf("event envelope has unknown type %q", typ)
+				}
+				assertShape(t, typ, m["data"], shape)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatalf("SDK call error: %v", err)
+			}
+
+			status, body := getJSON(t, s.URL+tt.path, nil)
+			if status != http.StatusOK {
+				t.Fatalf("GET %s = %d, want 200", tt.path, status)
+			}
+			tt.check(t, body)
+
+			// The opt-in envelope wraps the same body
+			status, body = getJSON(t, s.URL+tt.path, http.Header{"X-Taboo-Envelope": {"1"}})
+			if status != http.StatusOK {
+				t.Fatalf("enveloped GET %s = %d, want 200", tt.path, status)
+			}
+			assertShape(t, "response envelope", body, responseShape)
+			data, _ := body["data"].(map[string]any)
+			tt.check(t, data)
+		})
+	}
+
+	t.Run("WithEnvelope", func(t *testing.T) {
+		var meta sdk.Meta
+		enveloped := sdk.NewClient(s.URL, sdk.WithEnvelope(func(m sdk.Meta) { meta = m }))
+		game, err := enveloped.GetGame(ctx, 1)
+		if err != nil || game.ID != 1 {
+			t.Fatalf("GetGame(1) = %v, %v; want game 1", game, err)
+		}
+		if meta.RequestID == "" {
+			t.Error("enveloped response meta has no request_id")
+		}
+	})
+}
+
+func TestContract_Errors(t *testing.T) {
+	s := newContractServer(t)
+	client := sdk.NewClient(s.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		path   string
+		call   func() error
+		status int
+		code   string
+		param  string
+	}{
+		{
+			name: "game not found", path: "/api/v1/games/999999",
+			call:   func() error { _, err := client.GetGame(ctx, 999999); return err },
+			status: http.StatusNotFound, code: "NOT_FOUND",
+		},
+		{
+			name: "season not found", path: "/api/v1/seasons/999999",
+			call:   func() error { _, err := client.GetSeason(ctx, 999999); return err },
+			status: http.StatusNotFound, code: "NOT_FOUND",
+		},
+		{
+			name: "bad game id", path: "/api/v1/games/abc",
+			status: http.StatusBadRequest, code: "BAD_REQUEST",
+		},
+		{
+			name: "bad cursor", path: "/api/v1/games?cursor=abc",
+			call: func() error {
+				_, err := client.ListGames(ctx, &sdk.ListGamesOptions{Cursor: sdk.Ptr(int64(-1))})
+				return err
+			},
+			status: http.StatusBadRequest, code: "BAD_REQUEST", param: "cursor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := getJSON(t, s.URL+tt.path, nil)
+			if status != tt.status {
+				t.Errorf("GET %s = %d, want %d", tt.path, status, tt.status)
+			}
+			assertShape(t, "error response", body, errorShape)
+			assertShape(t, "error", body["error"], errorDetail)
+			detail, _ := body["error"].(map[string]any)
+			if detail["code"] != tt.code {
+				t.Errorf("error code = %v, want %s", detail["code"], tt.code)
+			}
+			if param, _ := detail["param"].(string); param != tt.param {
+				t.Errorf("error param = %q, want %q", param, tt.param)
+			}
+
+			if tt.call == nil {
+				return
+			}
+			var apiErr *sdk.APIError
+			if err := tt.call(); !errors.As(err, &apiErr) {
+				t.Fatalf("SDK call error = %v, want *sdk.APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Code != tt.code || apiErr.Param != tt.param || apiErr.RequestID == "" {
+				t.Errorf("SDK error = %+v, want status %d, code %s, param %q and a request ID", apiErr, tt.status, tt.code, tt.param)
+			}
+		})
+	}
+}
+
+// recordingHandler records every event type the SDK dispatches, including
+// the optional engine:degraded and server:notice.
+type recordingHandler struct {
+	sdk.BaseEventHandler
+	seen chan string
+}
+
+func (h *recordingHandler) record(typ string) {
+	select {
+	case h.seen <- typ:
+	default:
+	}
+}
+
+func (h *recordingHandler) OnGameState(sdk.GameStateEvent)       { h.record(sdk.EventGameState) }
+func (h *recordingHandler) OnGamePick(sdk.GamePickEvent)         { h.record(sdk.EventGamePick) }
+func (h *recordingHandler) OnGameComplete(sdk.GameCompleteEvent) { h.record(sdk.EventGameComplete) }
+func (h *recordingHandler) OnHeartbeat()                         { h.record(sdk.EventSysHeartbeat) }
+func (h *recordingHandler) OnEngineDegraded(sdk.EngineDegradedEvent) {
+	h.record(sdk.EventEngineDegraded)
+}
+func (h *recordingHandler) OnServerNotice(sdk.ServerNoticeEvent) {
+	h.record(sdk.EventServerNotice)
+}
+func (h *recordingHandler) OnConnect()         {}
+func (h *recordingHandler) OnDisconnect(error) {}
+
+// waitForTypes reads from seen until every current event type has arrived,
+// calling onFirst once the first does. The SDK translates legacy names, so
+// they are never seen.
+func waitForTypes(t *testing.T, seen <-chan string, onFirst func()) {
+	t.Helper()
+	missing := make(map[string]bool, len(eventShapes))
+	for typ := range eventShapes {
+		missing[typ] = true
+	}
+	timeout := time.After(10 * time.Second)
+	for len(missing) > 0 {
+		select {
+		case typ := <-seen:
+			if onFirst != nil {
+				onFirst()
+				onFirst = nil
+			}
+			delete(missing, typ)
+		case <-timeout:
+			t.Fatalf("timed out waiting for events %v", missing)
+		}
+	}
+}
+
+func TestContract_SSEClient(t *testing.T) {
+	s := newContractServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler := &recordingHandler{seen: make(chan string, 64)}
+	client := sdk.NewSSEClient(s.URL, handler, sdk.WithReconnectDelay(10*time.Millisecond))
+	go func() { _ = client.Connect(ctx) }()
+
+	waitForTypes(t, handler.seen, func() {
+		s.GameService.BroadcastDegraded(ctx, "create_game", "deadline exceeded")
+		_, _ = s.GameService.BroadcastNotice(ctx, "Maintenance at 10pm", sdk.NoticeWarning, time.Minute, nil)
+	})
+}
+
+func TestContract_SSEWire(t *testing.T) {
+	s := newContractServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/api/v1/events", nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /api/v1/events: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	// Every event type is sent, and renamed ones again under their legacy
+	// names straight after
+	shapes := maps.Clone(eventShapes)
+	maps.Copy(shapes, legacyEventShapes)
+	missing := make(map[string]bool, len(shapes))
+	for typ := range shapes {
+		missing[typ] = true
+	}
+	maps.Copy(shapes, streamEventShapes)
+	legacy := sdk.LegacyEventTypes()
+	r := bufio.NewReader(resp.Body)
+	var prev string
+	for degraded := false; len(missing) > 0; {
+		event, err := ssetest.ReadEvent(r)
+		if err != nil {
+			t.Fatalf("reading event, still waiting for %v: %v", missing, err)
+		}
+		if !degraded {
+			s.GameService.BroadcastDegraded(ctx, "create_game", "deadline exceeded")
+			_, _ = s.GameService.BroadcastNotice(ctx, "Maintenance at 10pm", sdk.NoticeWarning, time.Minute, nil)
+			degraded = true
+		}
+
+		if _, ok := legacyEventShapes[event.Type]; ok && legacy[prev] != event.Type {
+			t.Errorf("legacy event %q followed %q, not the event it was renamed to", event.Type, prev)
+		}
+		prev = event.Type
+
+		shape, ok := shapes[event.Type]
+		if !ok {
+			t.Errorf("unknown event type %q", event.Type)
+			continue
+		}
+		var data any
+		if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+			t.Errorf("%s data %q is not JSON: %v", event.Type, event.Data, err)
+			continue
+		}
+		assertShape(t, event.Type, data, shape)
+		delete(missing, event.Type)
+	}
+}
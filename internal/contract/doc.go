@@ -0,0 +1,7 @@
+// Package contract holds the contract tests between the server and the Go
+// SDK. They run the real HTTP server and game engine, call every SDK method
+// and receive every event type, and pin the wire format published SDK
+// versions depend on: JSON field names, event type strings and error
+// codes. A failure here means a server change would break deployed
+// clients, so update the SDK, not just the assertion.
+package contract
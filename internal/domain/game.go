@@ -1,12 +1,23 @@
 package domain
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
 
 // Game represents a single game round with its picks.
 type Game struct {
 	ID        int64     `json:"id"`
-	Picks     []uint8   `json:"picks"`
+	Picks     sdk.Picks `json:"picks"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// ResultHash is the Hash of the game as stored, so mirrors can compare
+	// results without comparing every pick.
+	ResultHash string `json:"result_hash"`
 }
 
 // NewGame creates a new Game with the given ID and picks.
@@ -17,3 +28,19 @@ func NewGame(id int64, picks []uint8) *Game {
 		CreatedAt: time.Now(),
 	}
 }
+
+// Hash returns the hex SHA-256 of the game's ID and picks in draw order,
+// formatted as "<id>:<pick>,<pick>,...". The same ID and picks always hash
+// the same, so any two copies of a game can be checked against each other.
+func (g *Game) Hash() string {
+	b := strconv.AppendInt(nil, g.ID, 10)
+	b = append(b, ':')
+	for i, pick := range g.Picks {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendUint(b, uint64(pick), 10)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestGame_Hash(t *testing.T) {
+	tests := []struct {
+		game Game
+		want string
+	}{
+		{Game{ID: 1, Picks: []uint8{1, 2, 3}}, "43011b9e0c61d894e3a986006bac5c2ec4fa3bcb932b595f2a94c8ed7b959381"},
+		{Game{ID: 42, Picks: []uint8{7, 80, 1}}, "197573d1d3bc9fae1fd8e13f24721a22c8e95c344f267208d28d6c182dea7908"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.game.Hash(); got != tt.want {
+			t.Errorf("game %d: Hash() = %s, want %s", tt.game.ID, got, tt.want)
+		}
+	}
+
+	// Draw order is part of the result
+	a := Game{ID: 1, Picks: []uint8{1, 2, 3}}
+	b := Game{ID: 1, Picks: []uint8{3, 2, 1}}
+	if a.Hash() == b.Hash() {
+		t.Error("expected reordered picks to hash differently")
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Season is a community season. It covers every game with an ID from
+// FirstGameID up to, but excluding, EndGameID.
+type Season struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	FirstGameID int64     `json:"first_game_id"`
+	EndGameID   int64     `json:"end_game_id"` // 0 while the season is current
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// Current reports whether the season is still open.
+func (s *Season) Current() bool {
+	return s.EndGameID == 0
+}
+
+// Contains reports whether the game with the given ID belongs to the season.
+func (s *Season) Contains(gameID int64) bool {
+	return gameID >= s.FirstGameID && (s.Current() || gameID < s.EndGameID)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// SpecialGame is a game scheduled as a special event. The engine runs it in
+// place of the regular game due at StartsAt, and its branding and payout
+// settings are sent with the game's state events for frontends to theme
+// and downstream consumers to settle by.
+type SpecialGame struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	StartsAt time.Time         `json:"starts_at"`
+	Branding map[string]string `json:"branding,omitempty"`
+	Payouts  map[string]string `json:"payouts,omitempty"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+)
+
+// Validate checks the game against the configured rules: a positive ID,
+// exactly PickCount picks, no duplicates, and every pick within
+// MinNumber..MaxNumber. A result hash, if set, must match the picks.
+func (g *Game) Validate(cfg config.GameConfig) lint.Issues {
+	c := lint.NewCollector()
+
+	if g.ID < 1 {
+		c.Errorf("game-id-invalid", "game.id", "must be at least 1, got %d", g.ID)
+	}
+
+	if len(g.Picks) != cfg.PickCount {
+		c.Errorf("pick-count", "game.picks", "expected %d picks, got %d", cfg.PickCount, len(g.Picks))
+	}
+
+	seen := make(map[uint8]bool, len(g.Picks))
+	for i, pick := range g.Picks {
+		location := fmt.Sprintf("game.picks[%d]", i)
+		if int(pick) < cfg.MinNumber || int(pick) > cfg.MaxNumber {
+			c.Errorf("pick-range", location, "must be between %d and %d, got %d", cfg.MinNumber, cfg.MaxNumber, pick)
+		}
+		if seen[pick] {
+			c.Errorf("pick-duplicate", location, "duplicate pick %d", pick)
+		}
+		seen[pick] = true
+	}
+
+	if g.ResultHash != "" && g.ResultHash != g.Hash() {
+		c.Error("result-hash", "game.result_hash", "does not match the game's picks")
+	}
+
+	return c.Issues()
+}
+
+// InvalidGameError reports a game that failed validation, either before
+// being persisted or after being read back from storage.
+type InvalidGameError struct {
+	Game   *Game
+	Issues lint.Issues
+}
+
+func (e *InvalidGameError) Error() string {
+	return fmt.Sprintf("game %d is invalid: %s", e.Game.ID, e.Issues.Error())
+}
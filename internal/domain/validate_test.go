@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestGame_Validate(t *testing.T) {
+	classic := config.GameConfig{PickCount: 3, MinNumber: 1, MaxNumber: 10}
+	offset := config.GameConfig{PickCount: 2, MinNumber: 10, MaxNumber: 70}
+
+	tests := []struct {
+		name      string
+		cfg       config.GameConfig
+		game      Game
+		wantRules []string
+	}{
+		{"valid", classic, Game{ID: 1, Picks: []uint8{1, 5, 10}}, nil},
+		{"zero id", classic, Game{ID: 0, Picks: []uint8{1, 2, 3}}, []string{"game-id-invalid"}},
+		{"too few picks", classic, Game{ID: 1, Picks: []uint8{1, 2}}, []string{"pick-count"}},
+		{"too many picks", classic, Game{ID: 1, Picks: []uint8{1, 2, 3, 4}}, []string{"pick-count"}},
+		{"duplicate", classic, Game{ID: 1, Picks: []uint8{1, 2, 2}}, []string{"pick-duplicate"}},
+		{"zero pick", classic, Game{ID: 1, Picks: []uint8{0, 2, 3}}, []string{"pick-range"}},
+		{"above max", classic, Game{ID: 1, Picks: []uint8{1, 2, 11}}, []string{"pick-range"}},
+		{"empty", classic, Game{ID: 1}, []string{"pick-count"}},
+		{"offset valid", offset, Game{ID: 1, Picks: []uint8{10, 70}}, nil},
+		{"offset below min", offset, Game{ID: 1, Picks: []uint8{9, 70}}, []string{"pick-range"}},
+		{"hash matches", classic, Game{ID: 1, Picks: []uint8{1, 2, 3}, ResultHash: "43011b9e0c61d894e3a986006bac5c2ec4fa3bcb932b595f2a94c8ed7b959381"}, nil},
+		{"hash mismatch", classic, Game{ID: 1, Picks: []uint8{1, 2, 4}, ResultHash: "43011b9e0c61d894e3a986006bac5c2ec4fa3bcb932b595f2a94c8ed7b959381"}, []string{"result-hash"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := tt.game.Validate(tt.cfg)
+
+			if len(issues) != len(tt.wantRules) {
+				t.Fatalf("expected %d issues, got %d: %v", len(tt.wantRules), len(issues), issues)
+			}
+			for i, rule := range tt.wantRules {
+				if issues[i].Rule != rule {
+					t.Errorf("issue %d: expected rule %q, got %q", i, rule, issues[i].Rule)
+				}
+			}
+		})
+	}
+}
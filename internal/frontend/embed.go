@@ -1,4 +1,5 @@
-// Package frontend provides embedded frontend assets.
+//go:build !nofrontend
+
 package frontend
 
 import (
@@ -0,0 +1,11 @@
+//go:build nofrontend
+
+package frontend
+
+import "io/fs"
+
+// GetFS always returns ErrNotEmbedded; the server answers / with a status
+// page or JSON service descriptor instead.
+func GetFS() (fs.FS, error) {
+	return nil, ErrNotEmbedded
+}
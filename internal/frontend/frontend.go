@@ -0,0 +1,9 @@
+// Package frontend provides embedded frontend assets. Building with the
+// nofrontend tag leaves them out for a smaller API-only binary.
+package frontend
+
+import "errors"
+
+// ErrNotEmbedded is returned by GetFS in binaries built with the nofrontend
+// tag.
+var ErrNotEmbedded = errors.New("frontend not embedded (built with nofrontend)")
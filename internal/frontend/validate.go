@@ -0,0 +1,87 @@
+package frontend
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+)
+
+// ManifestFile is the asset manifest Vite writes alongside index.html,
+// mapping each source entry to the hashed files built from it.
+const ManifestFile = "asset-manifest.json"
+
+// manifestChunk is an entry of ManifestFile.
+type manifestChunk struct {
+	File   string   `json:"file"`
+	CSS    []string `json:"css"`
+	Assets []string `json:"assets"`
+}
+
+// assetRef matches the src and href attributes in index.html.
+var assetRef = regexp.MustCompile(`(?:src|href)="([^"]*)"`)
+
+// Validate checks a frontend bundle: index.html must exist, the asset
+// manifest must parse, and every file either references must be in the
+// bundle. Bundles built before the manifest was added only get a warning
+// for it.
+func Validate(fsys fs.FS) lint.Issues {
+	c := lint.NewCollector()
+
+	index, err := fs.ReadFile(fsys, "index.html")
+	if err != nil {
+		c.Errorf("index-missing", "index.html", "cannot read index.html, is the frontend built? %v", err)
+	} else {
+		for _, m := range assetRef.FindAllSubmatch(index, -1) {
+			ref, ok := localAsset(string(m[1]))
+			if ok && !exists(fsys, ref) {
+				c.Errorf("index-asset-missing", "index.html", "references %s, which is not in the bundle", ref)
+			}
+		}
+	}
+
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		c.Warn("manifest-missing", ManifestFile, "no asset manifest, hashed assets can't be checked")
+	case err != nil:
+		c.Errorf("manifest-read", ManifestFile, "cannot read asset manifest: %v", err)
+	default:
+		var manifest map[string]manifestChunk
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			c.Errorf("manifest-json", ManifestFile, "cannot decode asset manifest: %v", err)
+			break
+		}
+		for entry, chunk := range manifest {
+			for _, file := range append([]string{chunk.File}, append(chunk.CSS, chunk.Assets...)...) {
+				if file != "" && !exists(fsys, file) {
+					c.Errorf("manifest-asset-missing", ManifestFile+"."+entry, "lists %s, which is not in the bundle", file)
+				}
+			}
+		}
+	}
+
+	return c.Issues()
+}
+
+// localAsset returns the bundle path of a src or href reference, reporting
+// false for references outside the bundle such as other sites, data URIs
+// and fragments.
+func localAsset(ref string) (string, bool) {
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") || strings.Contains(ref, ":") {
+		return "", false
+	}
+	ref, _, _ = strings.Cut(ref, "?")
+	ref, _, _ = strings.Cut(ref, "#")
+	ref = strings.TrimPrefix(path.Clean("/"+ref), "/")
+	return ref, ref != ""
+}
+
+func exists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
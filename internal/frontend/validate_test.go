@@ -0,0 +1,69 @@
+package frontend
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+)
+
+const testIndex = `<!doctype html>
+<html><head>
+<link rel="icon" href="/logo.svg" />
+<link rel="preconnect" href="https://fonts.example.com" />
+<script type="module" crossorigin src="/assets/index-BDqhSP_c.js"></script>
+<link rel="stylesheet" crossorigin href="/assets/index-a1b2c3d4.css">
+</head><body><a href="#board">Skip</a></body></html>`
+
+const testManifest = `{
+  "index.html": {
+    "file": "assets/index-BDqhSP_c.js",
+    "css": ["assets/index-a1b2c3d4.css"],
+    "assets": ["assets/ball-9f8e7d6c.png"]
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	bundle := func() fstest.MapFS {
+		return fstest.MapFS{
+			"index.html":                {Data: []byte(testIndex)},
+			ManifestFile:                {Data: []byte(testManifest)},
+			"logo.svg":                  {},
+			"assets/index-BDqhSP_c.js":  {},
+			"assets/index-a1b2c3d4.css": {},
+			"assets/ball-9f8e7d6c.png":  {},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(fstest.MapFS)
+		rule   string
+	}{
+		{"valid", func(fstest.MapFS) {}, ""},
+		{"no index", func(fs fstest.MapFS) { delete(fs, "index.html") }, "index-missing"},
+		{"missing script", func(fs fstest.MapFS) { delete(fs, "assets/index-BDqhSP_c.js") }, "index-asset-missing"},
+		{"missing icon", func(fs fstest.MapFS) { delete(fs, "logo.svg") }, "index-asset-missing"},
+		{"missing manifest asset", func(fs fstest.MapFS) { delete(fs, "assets/ball-9f8e7d6c.png") }, "manifest-asset-missing"},
+		{"bad manifest", func(fs fstest.MapFS) { fs[ManifestFile] = &fstest.MapFile{Data: []byte("{")} }, "manifest-json"},
+		{"no manifest", func(fs fstest.MapFS) { delete(fs, ManifestFile) }, "manifest-missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := bundle()
+			tt.modify(fs)
+			issues := Validate(fs)
+
+			if tt.rule == "" {
+				if len(issues) != 0 {
+					t.Errorf("expected no issues, got %v", issues)
+				}
+				return
+			}
+			if !slices.ContainsFunc(issues, func(issue lint.Issue) bool { return issue.Rule == tt.rule }) {
+				t.Errorf("expected a %s issue, got %v", tt.rule, issues)
+			}
+		})
+	}
+}
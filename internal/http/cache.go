@@ -0,0 +1,117 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// Surrogate keys tag cached history responses so a CDN can purge them
+// selectively. Each game and season is also tagged with gameKey or
+// seasonKey.
+const (
+	// keyGames tags every immutable history response.
+	keyGames = "games"
+
+	// keyGamesLatest and keyGamesTail tag responses that change when a game
+	// completes: the latest game and the last page of the game list.
+	keyGamesLatest = "games-latest"
+	keyGamesTail   = "games-tail"
+)
+
+// purgeTimeout bounds each purge request to the CDN.
+const purgeTimeout = 10 * time.Second
+
+func gameKey(id int64) string {
+	return "game-" + strconv.FormatInt(id, 10)
+}
+
+func seasonKey(id int64) string {
+	return "season-" + strconv.FormatInt(id, 10)
+}
+
+// setCacheHeaders tags a history response with surrogate keys. Immutable
+// responses may be held by shared caches for cache.max_age; the rest must
+// be revalidated. It does nothing unless caching is enabled.
+func (s *Server) setCacheHeaders(w http.ResponseWriter, immutable bool, keys ...string) {
+	if !s.cfg.Cache.Enabled {
+		return
+	}
+
+	h := w.Header()
+	if immutable {
+		h.Set("Cache-Control", fmt.Sprintf("public, s-maxage=%d", int(s.cfg.Cache.MaxAge.Duration().Seconds())))
+	} else {
+		h.Set("Cache-Control", "public, no-cache")
+	}
+	h.Set("Surrogate-Key", strings.Join(keys, " "))
+	h.Set("Cache-Tag", strings.Join(keys, ","))
+}
+
+// purgeRequest is the body POSTed to cache.purge_url, in the shape of the
+// Cloudflare purge-by-tag API.
+type purgeRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// runPurger asks the CDN to purge the responses made stale by each
+// completed game until ctx is cancelled. Failed purges are logged; cached
+// entries then expire on their own.
+func (s *Server) runPurger(ctx context.Context) {
+	client := &http.Client{Timeout: purgeTimeout}
+
+	for event := range s.gameService.Subscribe(ctx) {
+		complete, ok := event.Data.(sdk.GameCompleteEvent)
+		if event.Type != sdk.EventGameComplete || !ok {
+			continue
+		}
+
+		keys := []string{gameKey(complete.GameID), keyGamesLatest, keyGamesTail}
+		if err := s.purge(ctx, client, keys); err != nil {
+			s.logger.Warn("Failed to purge CDN cache",
+				slogx.Error(err),
+				slog.Int64("game_id", complete.GameID),
+			)
+			continue
+		}
+		s.logger.Debug("Purged CDN cache", slog.Any("keys", keys))
+	}
+}
+
+// purge POSTs keys to the configured purge URL.
+func (s *Server) purge(ctx context.Context, client *http.Client, keys []string) error {
+	body, err := json.Marshal(purgeRequest{Tags: keys})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Cache.PurgeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Surrogate-Key", strings.Join(keys, " "))
+	if s.cfg.Cache.PurgeToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Cache.PurgeToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("purge returned %s", resp.Status)
+	}
+	return nil
+}
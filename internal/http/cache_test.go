@@ -0,0 +1,175 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+)
+
+func newCachingTestServer(t *testing.T, games int64) *testServer {
+	t.Helper()
+	ts := newTestServer(t)
+	ts.cfg.Cache.Enabled = true
+	ts.cfg.Cache.MaxAge = config.Duration(time.Hour)
+	for i := int64(1); i <= games; i++ {
+		ts.mockStore.games[i] = &domain.Game{ID: i, Picks: testPicks(), CreatedAt: time.Now()}
+	}
+	return ts
+}
+
+func TestCacheHeaders_Disabled(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.games[1] = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	ts.handleGetGame(w, req)
+
+	if got := w.Header().Get("Cache-Control"); got != "" {
+		t.Errorf("Cache-Control = %q, want none", got)
+	}
+	if got := w.Header().Get("Surrogate-Key"); got != "" {
+		t.Errorf("Surrogate-Key = %q, want none", got)
+	}
+}
+
+func TestCacheHeaders_Game(t *testing.T) {
+	ts := newCachingTestServer(t, 1)
+
+	drawing := &domain.Game{ID: 2, Picks: testPicks(), CreatedAt: time.Now()}
+	if err := ts.gameService.CreateGame(context.Background(), drawing); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+
+	tests := []struct {
+		id           string
+		cacheControl string
+		keys         string
+	}{
+		{"1", "public, s-maxage=3600", "games game-1"},
+		{"2", "public, no-cache", "game-2"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/games/"+tt.id, nil)
+		req.SetPathValue("id", tt.id)
+		w := httptest.NewRecorder()
+		ts.handleGetGame(w, req)
+
+		if got := w.Header().Get("Cache-Control"); got != tt.cacheControl {
+			t.Errorf("game %s: Cache-Control = %q, want %q", tt.id, got, tt.cacheControl)
+		}
+		if got := w.Header().Get("Surrogate-Key"); got != tt.keys {
+			t.Errorf("game %s: Surrogate-Key = %q, want %q", tt.id, got, tt.keys)
+		}
+	}
+
+	// Once drawn the game becomes cacheable
+	ts.gameService.BroadcastComplete(context.Background(), &domain.Game{ID: 2})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/2", nil)
+	req.SetPathValue("id", "2")
+	w := httptest.NewRecorder()
+	ts.handleGetGame(w, req)
+
+	if got := w.Header().Get("Cache-Control"); got != "public, s-maxage=3600" {
+		t.Errorf("completed game: Cache-Control = %q", got)
+	}
+}
+
+func TestCacheHeaders_ListGames(t *testing.T) {
+	ts := newCachingTestServer(t, 5)
+
+	tests := []struct {
+		query        string
+		cacheControl string
+		keys         string
+	}{
+		{"?limit=2", "public, s-maxage=3600", "games"},
+		{"?cursor=4&limit=2", "public, no-cache", "games-tail"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/games"+tt.query, nil)
+		w := httptest.NewRecorder()
+		ts.handleListGames(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", tt.query, w.Code)
+		}
+		if got := w.Header().Get("Cache-Control"); got != tt.cacheControl {
+			t.Errorf("%s: Cache-Control = %q, want %q", tt.query, got, tt.cacheControl)
+		}
+		if got := w.Header().Get("Surrogate-Key"); got != tt.keys {
+			t.Errorf("%s: Surrogate-Key = %q, want %q", tt.query, got, tt.keys)
+		}
+	}
+}
+
+func TestCacheHeaders_Latest(t *testing.T) {
+	ts := newCachingTestServer(t, 3)
+	ts.mockStore.latestGame = ts.mockStore.games[3]
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/latest", nil)
+	w := httptest.NewRecorder()
+	ts.handleGetLatestGame(w, req)
+
+	if got := w.Header().Get("Cache-Control"); got != "public, no-cache" {
+		t.Errorf("Cache-Control = %q, want revalidation", got)
+	}
+	if got := w.Header().Get("Surrogate-Key"); got != "games-latest game-3" {
+		t.Errorf("Surrogate-Key = %q", got)
+	}
+}
+
+func TestRunPurger(t *testing.T) {
+	purged := make(chan purgeRequest, 1)
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var body purgeRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding purge body: %v", err)
+		}
+		select {
+		case purged <- body:
+		default:
+		}
+	}))
+	defer cdn.Close()
+
+	ts := newCachingTestServer(t, 0)
+	ts.cfg.Cache.PurgeURL = cdn.URL
+	ts.cfg.Cache.PurgeToken = "secret"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ts.runPurger(ctx)
+
+	// The purger subscribes asynchronously, so keep completing until the
+	// CDN hears about it
+	deadline := time.After(5 * time.Second)
+	tick := time.NewTicker(10 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		select {
+		case body := <-purged:
+			want := []string{"game-7", keyGamesLatest, keyGamesTail}
+			if !slices.Equal(body.Tags, want) {
+				t.Errorf("tags = %v, want %v", body.Tags, want)
+			}
+			return
+		case <-tick.C:
+			ts.gameService.BroadcastComplete(ctx, &domain.Game{ID: 7})
+		case <-deadline:
+			t.Fatal("timed out waiting for purge")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// handleGetCall handles GET /api/v1/calls/{pick}. It serves the voice pack's
+// recorded call for the pick, supporting range requests so players can
+// stream it.
+func (s *Server) handleGetCall(w http.ResponseWriter, r *http.Request) {
+	pick, err := strconv.ParseUint(r.PathValue("pick"), 10, 8)
+	if err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid pick"))
+		return
+	}
+
+	voice := s.gameService.VoicePack()
+	if voice == nil {
+		_ = httpx.WriteError(w, httpx.ErrNotFound("no voice pack configured"))
+		return
+	}
+
+	file, contentType, err := voice.Open(uint8(pick))
+	if err != nil {
+		_ = httpx.WriteError(w, httpx.ErrNotFound("no recorded call for "+strconv.FormatUint(pick, 10)))
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = httpx.WriteError(w, httpx.ErrInternal("failed to read recorded call"))
+		return
+	}
+
+	// Clips only change when the server restarts with another voice pack
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+
+	if seeker, ok := file.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, stat.Name(), stat.ModTime(), seeker)
+		return
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		slogx.FromContext(r.Context()).Debug("Failed to copy recorded call to response", slogx.Error(err))
+	}
+}
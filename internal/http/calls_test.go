@@ -0,0 +1,87 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+)
+
+func TestHandleGetCall(t *testing.T) {
+	ts := newTestServer(t)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "42.mp3"), []byte("forty-two"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	voice, err := service.LoadVoicePack(dir)
+	if err != nil {
+		t.Fatalf("LoadVoicePack() error: %v", err)
+	}
+	ts.gameService.SetVoicePack(voice)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/calls/42", nil)
+	req.SetPathValue("pick", "42")
+	w := httptest.NewRecorder()
+	ts.handleGetCall(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "audio/mpeg" {
+		t.Errorf("expected Content-Type audio/mpeg, got %q", ct)
+	}
+	if body := w.Body.String(); body != "forty-two" {
+		t.Errorf("expected clip body, got %q", body)
+	}
+
+	// Players stream clips with range requests
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/calls/42", nil)
+	req.SetPathValue("pick", "42")
+	req.Header.Set("Range", "bytes=0-4")
+	w = httptest.NewRecorder()
+	ts.handleGetCall(w, req)
+
+	if w.Code != http.StatusPartialContent || w.Body.String() != "forty" {
+		t.Errorf("expected 206 with first 5 bytes, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleGetCall_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		pick   string
+		voice  bool
+		status int
+	}{
+		{"no voice pack", "42", false, http.StatusNotFound},
+		{"no clip", "7", true, http.StatusNotFound},
+		{"invalid pick", "abc", true, http.StatusBadRequest},
+		{"out of range", "256", true, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.voice {
+				voice, err := service.LoadVoicePack(t.TempDir())
+				if err != nil {
+					t.Fatalf("LoadVoicePack() error: %v", err)
+				}
+				ts.gameService.SetVoicePack(voice)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/calls/"+tt.pick, nil)
+			req.SetPathValue("pick", tt.pick)
+			w := httptest.NewRecorder()
+			ts.handleGetCall(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// maxPeers bounds the peers tracked; the one seen longest ago is forgotten
+// to make room. Node IDs longer than maxPeerNodeLen are not tracked.
+const (
+	maxPeers       = 64
+	maxPeerNodeLen = 64
+)
+
+// peerTracker records the mirrors seen syncing from this node, keyed by
+// their node ID.
+type peerTracker struct {
+	mu    sync.Mutex
+	peers map[string]sdk.ClusterPeer
+}
+
+func newPeerTracker() *peerTracker {
+	return &peerTracker{peers: make(map[string]sdk.ClusterPeer)}
+}
+
+// record updates the peer with the given node ID.
+func (t *peerTracker) record(peer sdk.ClusterPeer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.peers[peer.Node]; !ok && len(t.peers) >= maxPeers {
+		oldest := slices.MinFunc(slices.Collect(maps.Values(t.peers)), func(a, b sdk.ClusterPeer) int {
+			return a.LastSeen.Compare(b.LastSeen)
+		})
+		delete(t.peers, oldest.Node)
+	}
+	t.peers[peer.Node] = peer
+}
+
+// list returns the peers ordered by node ID.
+func (t *peerTracker) list() []sdk.ClusterPeer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	peers := slices.AppendSeq(make([]sdk.ClusterPeer, 0, len(t.peers)), maps.Values(t.peers))
+	slices.SortFunc(peers, func(a, b sdk.ClusterPeer) int { return strings.Compare(a.Node, b.Node) })
+	return peers
+}
+
+// recordPeer records a sync request from the mirror node, which has been
+// sent every game up to syncedID. Its lag counts the completed games after
+// that.
+func (s *Server) recordPeer(ctx context.Context, node string, syncedID int64) {
+	peer := sdk.ClusterPeer{Node: node, LastSeen: time.Now(), SyncedID: syncedID}
+
+	latest, err := s.gameService.GetLatestGame(ctx)
+	switch {
+	case err != nil:
+		slogx.FromContext(ctx).Debug("Failed to get latest game for peer lag", slogx.Error(err))
+	default:
+		completed := latest.ID
+		if !s.gameService.IsComplete(completed) {
+			completed--
+		}
+		peer.LagGames = max(completed-syncedID, 0)
+	}
+
+	s.peers.record(peer)
+}
+
+// handleCluster handles GET /api/v1/admin/cluster. It lists this node and
+// the mirrors replicating from it.
+func (s *Server) handleCluster(w http.ResponseWriter, r *http.Request) {
+	resp := sdk.ClusterResponse{
+		Node:   s.cfg.Server.NodeID,
+		Leader: s.cfg.Server.NodeID,
+		Peers:  s.peers.list(),
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleCluster_ListsSyncingPeers(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.cfg.Server.NodeID = "primary-1"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	for i := int64(1); i <= 5; i++ {
+		ts.mockStore.games[i] = &domain.Game{ID: i, Picks: testPicks(), CreatedAt: time.Now()}
+	}
+	ts.mockStore.latestGame = ts.mockStore.games[5]
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/games?limit=3", nil)
+	req.Header.Set(sdk.NodeHeader, "mirror-a")
+	w := httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("sync: status %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get(sdk.NodeHeader); got != "primary-1" {
+		t.Errorf("%s header = %q, want %q", sdk.NodeHeader, got, "primary-1")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/cluster", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	w = httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("cluster: status %d: %s", w.Code, w.Body)
+	}
+
+	var resp sdk.ClusterResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Node != "primary-1" || resp.Leader != "primary-1" {
+		t.Errorf("node = %q, leader = %q, want primary-1 for both", resp.Node, resp.Leader)
+	}
+	if len(resp.Peers) != 1 {
+		t.Fatalf("expected 1 peer, got %+v", resp.Peers)
+	}
+	if p := resp.Peers[0]; p.Node != "mirror-a" || p.SyncedID != 3 || p.LagGames != 2 {
+		t.Errorf("unexpected peer: %+v", p)
+	}
+}
+
+func TestHandleCluster_NoPeers(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/cluster", nil)
+	w := httptest.NewRecorder()
+	ts.handleCluster(w, req)
+
+	var resp sdk.ClusterResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Peers == nil || len(resp.Peers) != 0 {
+		t.Errorf("expected an empty peer list, got %#v", resp.Peers)
+	}
+}
+
+func TestPeerTracker_EvictsOldest(t *testing.T) {
+	tracker := newPeerTracker()
+	start := time.Now()
+	for i := range maxPeers + 1 {
+		tracker.record(sdk.ClusterPeer{Node: string(rune('a'+i%26)) + string(rune('a'+i/26)), LastSeen: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	peers := tracker.list()
+	if len(peers) != maxPeers {
+		t.Fatalf("expected %d peers, got %d", maxPeers, len(peers))
+	}
+	for _, p := range peers {
+		if p.Node == "aa" {
+			t.Errorf("expected the oldest peer to be evicted")
+		}
+	}
+}
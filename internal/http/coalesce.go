@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// coalesce runs fn once for all concurrent callers sharing key and hands each
+// of them the same result. This collapses bursts of identical reads (e.g.
+// every client fetching the latest game after game:complete) into a single
+// store query.
+//
+// fn runs on a context detached from the leading request, bounded by timeout,
+// so one client disconnecting does not fail every other waiter. Each caller
+// still stops waiting when its own context is done.
+func coalesce[T any](ctx context.Context, g *singleflight.Group, key string, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	ch := g.DoChan(key, func() (any, error) {
+		callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		return fn(callCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			var zero T
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleDBStats handles GET /api/v1/admin/db/stats
+func (s *Server) handleDBStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.store.Stats(r.Context())
+	if err != nil {
+		slogx.FromContext(r.Context()).Error("Failed to read database stats", slogx.Error(err))
+		_ = httpx.WriteError(w, storeError(err, "failed to read database stats"))
+		return
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, toSDKDBStats(stats), sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
+func toSDKDBStats(stats *store.Stats) sdk.DBStatsResponse {
+	resp := sdk.DBStatsResponse{
+		FileSize:   stats.FileSize,
+		WALSize:    stats.WALSize,
+		FreeSize:   stats.FreeSize,
+		Rows:       stats.Rows,
+		LastVacuum: optionalTime(stats.LastVacuum),
+		LastBackup: optionalTime(stats.LastBackup),
+	}
+	if sh := stats.Shadow; sh != nil {
+		resp.Shadow = &sdk.ShadowStats{
+			Compared:    sh.Compared,
+			Diverged:    sh.Diverged,
+			Skipped:     sh.Skipped,
+			WriteErrors: sh.WriteErrors,
+			ReadErrors:  sh.ReadErrors,
+		}
+	}
+	return resp
+}
+
+// optionalTime returns nil for the zero time so it is omitted from JSON.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
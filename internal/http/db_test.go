@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleDBStats(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+	ts.mockStore.games[1] = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/db/stats", nil)
+	w := httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("without token: expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/db/stats", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	w = httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := raw["last_vacuum"]; ok {
+		t.Error("expected last_vacuum to be omitted when never run")
+	}
+
+	var resp sdk.DBStatsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.FileSize != 4096 {
+		t.Errorf("expected file_size 4096, got %d", resp.FileSize)
+	}
+	if resp.Rows["games"] != 1 {
+		t.Errorf("expected 1 game row, got %d", resp.Rows["games"])
+	}
+}
+
+func TestHandleDBStats_Error(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.statsErr = errMockDB
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/db/stats", nil)
+	w := httptest.NewRecorder()
+	ts.handleDBStats(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
@@ -1,15 +1,73 @@
 package http
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/aussiebroadwan/taboo/internal/service"
 	"github.com/aussiebroadwan/taboo/pkg/httpx"
 	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
 )
 
-// handleEvents handles GET /api/v1/events (SSE endpoint)
+// errSendPanic is returned by sendEvent when writing to the client panicked.
+var errSendPanic = errors.New("panic writing event")
+
+// filterableEvents are the event types a client may select with ?events=.
+// Heartbeats and stream:gap events are always sent.
+var filterableEvents = []string{
+	sdk.EventGameState,
+	sdk.EventGamePick,
+	sdk.EventGameComplete,
+	sdk.EventEngineDegraded,
+	sdk.EventServerNotice,
+}
+
+// handleEvents handles GET /api/v1/events (SSE endpoint). Clients may pick
+// their heartbeat interval with ?heartbeat=, e.g. a long one on mobile to
+// save battery or a short one behind a proxy with a tight idle timeout, and
+// the events they want with ?events=, e.g. game:state,game:complete for a
+// results ticker. Clients identify themselves and the event names they
+// understand with the sdk.ClientHeader header, which decides whether they
+// are also sent renamed events under their legacy names.
 func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	interval, err := s.heartbeatInterval(r)
+	if err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest(err.Error()))
+		return
+	}
+	only, apiErr := httpx.QueryList(r, "events", filterableEvents...)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+
+	ctx := r.Context()
+
+	// A malformed header is no reason to refuse a stream; the client is
+	// treated as one that didn't identify itself
+	client, err := sdk.ParseClientInfo(r.Header.Get(sdk.ClientHeader))
+	if err != nil {
+		slogx.FromContext(ctx).Warn("Ignoring malformed client header",
+			slog.String("header", sdk.ClientHeader), slogx.Error(err))
+	}
+
+	// Subscribe to game events before committing to a stream, so a client
+	// over the limit gets a proper error response
+	sub, err := s.gameService.Join(ctx)
+	if err != nil {
+		slogx.FromContext(ctx).Warn("Rejected SSE client, too many streams", slogx.Error(err))
+		w.Header().Set("Retry-After", strconv.Itoa(int(interval.Seconds())))
+		_ = httpx.WriteError(w, httpx.ErrUnavailable("too many event streams, retry later"))
+		return
+	}
+
 	// Disable write timeout for SSE (long-lived connection)
 	rc := http.NewResponseController(w)
 	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
@@ -17,40 +75,129 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	aliases := s.aliasesFor(client)
+	if aliases != nil {
+		httpx.Warn(w, legacyEventsWarning)
+	}
+
 	// Create SSE stream
 	stream := httpx.NewSSEStream(w)
 	if stream == nil {
 		_ = httpx.WriteError(w, httpx.ErrInternal("streaming not supported"))
 		return
 	}
+	stream.SetAliases(aliases)
 
-	ctx := r.Context()
-
-	// Subscribe to game events
-	events := s.gameService.Subscribe(ctx)
+	s.usage.recordClient(client)
+	if client.Name != "" {
+		slogx.FromContext(ctx).Info("Event stream opened",
+			slog.String("client", client.Name),
+			slog.String("client_version", client.Version),
+			slog.Int("events_version", client.Events),
+		)
+	}
 
-	slogx.FromContext(ctx).Debug("SSE client connected")
+	// Announce the client, and what it was sent once it leaves or is evicted
+	conn := s.gameService.Connect(service.TransportSSE, httpx.GetClientIP(r))
+	defer func() { conn.Close(sub.Stats()) }()
 
 	// Single-goroutine event loop: heartbeats and game events share one select
 	// so there is no concurrent access to the SSE stream.
-	heartbeat := time.NewTicker(s.cfg.Server.SSEHeartbeat.Duration())
+	heartbeat := time.NewTicker(interval)
 	defer heartbeat.Stop()
 
+	// Events reach every subscriber in sequence order, so a jump in sequence
+	// numbers means the broker dropped events for this stream
+	var lastSeq int64
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-heartbeat.C:
-			if err := stream.SendHeartbeat(); err != nil {
+			if err := sendEvent(r, stream.SendHeartbeat); err != nil {
 				return
 			}
-		case event, ok := <-events:
+		case event, ok := <-sub.C:
 			if !ok {
 				return
 			}
-			if err := stream.Send(event.Type, event.Data); err != nil {
+			if dropped := event.Seq - lastSeq - 1; lastSeq > 0 && dropped > 0 {
+				gap := sdk.StreamGapEvent{Dropped: dropped, LatestSeq: s.gameService.LastSeq()}
+				if err := sendEvent(r, func() error { return stream.Send(sdk.EventStreamGap, gap) }); err != nil {
+					return
+				}
+			}
+			lastSeq = event.Seq
+
+			if only != nil && !slices.Contains(only, event.Type) {
+				continue
+			}
+			err := sendEvent(r, func() error {
+				return stream.Send(event.Type, s.localizeEvent(ctx, event.Data))
+			})
+			if err != nil {
 				return
 			}
 		}
 	}
 }
+
+// legacyEventsWarning is sent to clients that are also sent events under
+// their legacy names.
+const legacyEventsWarning = "events are also sent under legacy names, which will be removed; " +
+	"match on the current event types and declare events=2 in " + sdk.ClientHeader
+
+// aliasesFor returns the legacy event names to send a client alongside the
+// current ones. Clients that declare the events version they understand
+// get exactly the names for it; the rest get the server's default.
+func (s *Server) aliasesFor(client sdk.ClientInfo) map[string]string {
+	switch {
+	case client.Events == 0:
+		return s.eventAliases
+	case client.Events < sdk.EventsVersion:
+		return sdk.LegacyEventTypes()
+	default:
+		return nil
+	}
+}
+
+// heartbeatInterval returns the heartbeat interval requested with
+// ?heartbeat=, clamped to the server's bounds, or the configured default
+// when none is requested.
+func (s *Server) heartbeatInterval(r *http.Request) (time.Duration, error) {
+	v := r.URL.Query().Get("heartbeat")
+	if v == "" {
+		return s.cfg.Server.SSEHeartbeat.Duration(), nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("heartbeat must be a positive duration such as 30s, got %q", v)
+	}
+
+	if minHB := s.cfg.Server.SSEHeartbeatMin.Duration(); minHB > 0 && d < minHB {
+		d = minHB
+	}
+	if maxHB := s.cfg.Server.SSEHeartbeatMax.Duration(); maxHB > 0 && d > maxHB {
+		d = maxHB
+	}
+	return d, nil
+}
+
+// sendEvent runs send, recovering a panic from the client's writer so that
+// only this subscription is dropped rather than the panic unwinding through
+// the server. The panic is logged with the client IP.
+func sendEvent(r *http.Request, send func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			slogx.FromContext(r.Context()).Error("Panic writing SSE event, dropping subscription",
+				slog.Any("error", p),
+				slog.String("client_ip", httpx.GetClientIP(r)),
+				slog.String("stack", string(debug.Stack())),
+			)
+			err = errSendPanic
+		}
+	}()
+	return send()
+}
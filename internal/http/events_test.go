@@ -1,284 +1,395 @@
 package http
 
 import (
-	"bufio"
 	"context"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
 	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/pkg/ssetest"
 	"github.com/aussiebroadwan/taboo/sdk"
 )
 
-// readSSEEvent reads a single SSE event from the reader.
-// Returns event type, data, and any error.
-func readSSEEvent(reader *bufio.Reader) (eventType, data string, err error) {
-	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			return "", "", err
-		}
-
-		line = strings.TrimSuffix(line, "\n")
-
-		if line == "" {
-			// Empty line marks end of event
-			if eventType != "" || data != "" {
-				return eventType, data, nil
-			}
-			continue
-		}
-
-		if strings.HasPrefix(line, "event: ") {
-			eventType = strings.TrimPrefix(line, "event: ")
-		} else if strings.HasPrefix(line, "data: ") {
-			data = strings.TrimPrefix(line, "data: ")
-		}
-	}
-}
-
-func TestSSE_ConnectionHeaders(t *testing.T) {
+// newSSETestServer creates a server with the given heartbeat interval for
+// exercising the events stream.
+func newSSETestServer(heartbeat time.Duration) (*Server, *service.GameService) {
 	store := newMockStore()
 	cfg := config.Default()
-	// Use a very short heartbeat for testing
-	cfg.Server.SSEHeartbeat = config.Duration(50 * time.Millisecond)
+	cfg.Server.SSEHeartbeat = config.Duration(heartbeat)
 	gameService := service.NewGameService(store, &cfg.Game)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	server := NewServer(cfg, logger, store, gameService, nil)
+	return NewServer(cfg, logger, store, gameService, nil, nil), gameService
+}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+// subscribe starts the events handler for a new client, returning once its
+// headers are written. Cancel the context to disconnect.
+func subscribe(t *testing.T, server *Server, ctx context.Context) (*ssetest.Recorder, <-chan struct{}) {
+	t.Helper()
 
-	// Use custom writer that supports SetWriteDeadline
-	pr, pw := io.Pipe()
-	defer pr.Close()
-	defer pw.Close()
+	rec := ssetest.NewRecorder()
+	t.Cleanup(func() { rec.Close() })
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	done := ssetest.Serve(http.HandlerFunc(server.handleEvents), rec, req)
+	rec.WaitForHeaders()
 
-	w := newSSEResponseWriter(pw)
+	// Small delay to ensure subscription is established after headers
+	time.Sleep(10 * time.Millisecond)
+	return rec, done
+}
+
+func TestSSE_ConnectionHeaders(t *testing.T) {
+	// Use a very short heartbeat for testing
+	server, _ := newSSETestServer(50 * time.Millisecond)
 
-	// Run handler in goroutine since it blocks
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
-	req = req.WithContext(ctx)
 
-	go server.handleEvents(w, req)
+	rec, _ := subscribe(t, server, ctx)
 
-	// Wait for headers to be set (WriteHeader called)
-	w.WaitForHeaders()
-
-	// Check headers - safe to read now since WriteHeader has been called
-	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
 		t.Errorf("expected Content-Type 'text/event-stream', got %q", ct)
 	}
-	if cc := w.Header().Get("Cache-Control"); cc != "no-cache" {
+	if cc := rec.Header().Get("Cache-Control"); cc != "no-cache" {
 		t.Errorf("expected Cache-Control 'no-cache', got %q", cc)
 	}
-	if conn := w.Header().Get("Connection"); conn != "keep-alive" {
+	if conn := rec.Header().Get("Connection"); conn != "keep-alive" {
 		t.Errorf("expected Connection 'keep-alive', got %q", conn)
 	}
 }
 
-func TestSSE_ReceiveEvent(t *testing.T) {
-	store := newMockStore()
-	cfg := config.Default()
-	cfg.Server.SSEHeartbeat = config.Duration(10 * time.Second) // Long heartbeat to avoid interference
-	gameService := service.NewGameService(store, &cfg.Game)
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	server := NewServer(cfg, logger, store, gameService, nil)
-
-	// Use a pipe to read SSE events
-	pr, pw := io.Pipe()
-	defer pr.Close()
-	defer pw.Close()
+func TestHeartbeatInterval(t *testing.T) {
+	server, _ := newSSETestServer(15 * time.Second)
+	server.cfg.Server.SSEHeartbeatMin = config.Duration(5 * time.Second)
+	server.cfg.Server.SSEHeartbeatMax = config.Duration(time.Minute)
+
+	tests := []struct {
+		query   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 15 * time.Second, false},
+		{"?heartbeat=30s", 30 * time.Second, false},
+		{"?heartbeat=1s", 5 * time.Second, false},
+		{"?heartbeat=1h", time.Minute, false},
+		{"?heartbeat=soon", 0, true},
+		{"?heartbeat=-5s", 0, true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events"+tt.query, nil)
+		got, err := server.heartbeatInterval(req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: interval = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
 
-	w := newSSEResponseWriter(pw)
+func TestSSE_RequestedHeartbeat(t *testing.T) {
+	// The default would never fire during the test; the requested one does
+	server, _ := newSSETestServer(time.Hour)
+	server.cfg.Server.SSEHeartbeatMin = 0
 
 	ctx, cancel := context.WithCancel(context.Background())
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	defer cancel()
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		server.handleEvents(w, req)
-	}()
+	rec := ssetest.NewRecorder()
+	t.Cleanup(func() { rec.Close() })
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?heartbeat=20ms", nil).WithContext(ctx)
+	ssetest.Serve(http.HandlerFunc(server.handleEvents), rec, req)
 
-	// Wait for headers to be set up before broadcasting
-	w.WaitForHeaders()
-	// Small delay to ensure subscription is established after headers
+	event, err := rec.NextTimeout(5 * time.Second)
+	if err != nil {
+		t.Fatalf("failed to read event: %v", err)
+	}
+	if event.Type != sdk.EventSysHeartbeat {
+		t.Errorf("expected %s, got %s", sdk.EventSysHeartbeat, event.Type)
+	}
+}
+
+func TestSSE_InvalidHeartbeat(t *testing.T) {
+	server, _ := newSSETestServer(time.Second)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?heartbeat=soon", nil)
+	w := httptest.NewRecorder()
+	server.handleEvents(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSSE_FilteredEvents(t *testing.T) {
+	server, gameService := newSSETestServer(10 * time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := ssetest.NewRecorder()
+	t.Cleanup(func() { rec.Close() })
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?events=game:complete", nil).WithContext(ctx)
+	done := ssetest.Serve(http.HandlerFunc(server.handleEvents), rec, req)
+	rec.WaitForHeaders()
 	time.Sleep(10 * time.Millisecond)
 
-	// Broadcast an event
-	gameService.BroadcastPick(42)
+	// Picks are skipped, so the first event seen is the completion
+	gameService.BroadcastPick(context.Background(), 1, 0)
+	gameService.BroadcastComplete(context.Background(), &domain.Game{ID: 7})
 
-	// Read the event
-	reader := bufio.NewReader(pr)
-	eventType, data, err := readSSEEvent(reader)
+	event, err := rec.Next()
 	if err != nil {
 		t.Fatalf("failed to read event: %v", err)
 	}
-
-	if eventType != sdk.EventGamePick {
-		t.Errorf("expected event type %q, got %q", sdk.EventGamePick, eventType)
-	}
-	if !strings.Contains(data, "42") {
-		t.Errorf("expected data to contain '42', got %q", data)
+	if event.Type != sdk.EventGameComplete {
+		t.Errorf("expected %s, got %s", sdk.EventGameComplete, event.Type)
 	}
 
 	cancel()
-	wg.Wait()
+	<-done
 }
 
-func TestSSE_MultipleEvents(t *testing.T) {
-	store := newMockStore()
-	cfg := config.Default()
-	cfg.Server.SSEHeartbeat = config.Duration(10 * time.Second)
-	gameService := service.NewGameService(store, &cfg.Game)
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	server := NewServer(cfg, logger, store, gameService, nil)
+func TestSSE_InvalidEventFilter(t *testing.T) {
+	server, _ := newSSETestServer(time.Second)
 
-	pr, pw := io.Pipe()
-	defer pr.Close()
-	defer pw.Close()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?events=game:pick,game:bogus", nil)
+	w := httptest.NewRecorder()
+	server.handleEvents(w, req)
 
-	w := newSSEResponseWriter(pw)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"param":"events"`) {
+		t.Errorf("expected error naming events, got %s", w.Body.String())
+	}
+}
+
+func TestSSE_TooManyClients(t *testing.T) {
+	store := newMockStore()
+	cfg := config.Default()
+	gameService := service.NewGameService(store, &cfg.Game, pubsub.WithMaxSubscribers[service.Event](1))
+	server := NewServer(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), store, gameService, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	defer cancel()
+	subscribe(t, server, ctx)
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		server.handleEvents(w, req)
-	}()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+	w := httptest.NewRecorder()
+	server.handleEvents(w, req)
 
-	w.WaitForHeaders()
-	// Small delay to ensure subscription is established after headers
-	time.Sleep(10 * time.Millisecond)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+	if got := gameService.StreamStats().Rejected; got != 1 {
+		t.Errorf("expected 1 rejected stream, got %d", got)
+	}
+}
 
-	// Broadcast multiple events
-	gameService.BroadcastPick(1)
-	gameService.BroadcastPick(2)
-	gameService.BroadcastPick(3)
+func TestSSE_ConnectionEvents(t *testing.T) {
+	server, gameService := newSSETestServer(10 * time.Second)
+	conns := gameService.SubscribeConnections(t.Context())
 
-	reader := bufio.NewReader(pr)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, done := subscribe(t, server, ctx)
 
-	// Read all three events
-	picks := make([]string, 0, 3)
-	for i := 0; i < 3; i++ {
-		eventType, data, err := readSSEEvent(reader)
-		if err != nil {
-			t.Fatalf("failed to read event %d: %v", i, err)
+	for _, want := range []string{service.EventClientConnected, service.EventClientDisconnected} {
+		if want == service.EventClientDisconnected {
+			cancel()
+			<-done
 		}
-		if eventType != sdk.EventGamePick {
-			t.Errorf("event %d: expected type %q, got %q", i, sdk.EventGamePick, eventType)
+		select {
+		case event := <-conns:
+			if event.Type != want || event.Transport != service.TransportSSE {
+				t.Errorf("expected sse %s, got %+v", want, event)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %s", want)
 		}
-		picks = append(picks, data)
 	}
+}
 
-	// Verify order
-	if !strings.Contains(picks[0], "1") {
-		t.Errorf("first event should contain '1', got %q", picks[0])
+func TestSSE_ReceiveEvent(t *testing.T) {
+	server, gameService := newSSETestServer(10 * time.Second) // Long heartbeat to avoid interference
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec, done := subscribe(t, server, ctx)
+
+	gameService.BroadcastPick(context.Background(), 42, 0)
+
+	event, err := rec.Next()
+	if err != nil {
+		t.Fatalf("failed to read event: %v", err)
 	}
-	if !strings.Contains(picks[1], "2") {
-		t.Errorf("second event should contain '2', got %q", picks[1])
+	if event.Type != sdk.EventGamePick {
+		t.Errorf("expected event type %q, got %q", sdk.EventGamePick, event.Type)
 	}
-	if !strings.Contains(picks[2], "3") {
-		t.Errorf("third event should contain '3', got %q", picks[2])
+	if !strings.Contains(event.Data, "42") {
+		t.Errorf("expected data to contain '42', got %q", event.Data)
 	}
 
 	cancel()
-	wg.Wait()
+	<-done
 }
 
-func TestSSE_Heartbeat(t *testing.T) {
-	store := newMockStore()
-	cfg := config.Default()
-	cfg.Server.SSEHeartbeat = config.Duration(50 * time.Millisecond) // Very short for testing
-	gameService := service.NewGameService(store, &cfg.Game)
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	server := NewServer(cfg, logger, store, gameService, nil)
+func TestSSE_MultipleEvents(t *testing.T) {
+	server, gameService := newSSETestServer(10 * time.Second)
 
-	pr, pw := io.Pipe()
-	defer pr.Close()
-	defer pw.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	rec, done := subscribe(t, server, ctx)
 
-	w := newSSEResponseWriter(pw)
+	// Broadcast multiple events
+	gameService.BroadcastPick(context.Background(), 1, 0)
+	gameService.BroadcastPick(context.Background(), 2, 0)
+	gameService.BroadcastPick(context.Background(), 3, 0)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	// Read all three events, in order
+	for i, want := range []string{"1", "2", "3"} {
+		event, err := rec.Next()
+		if err != nil {
+			t.Fatalf("failed to read event %d: %v", i, err)
+		}
+		if event.Type != sdk.EventGamePick {
+			t.Errorf("event %d: expected type %q, got %q", i, sdk.EventGamePick, event.Type)
+		}
+		if !strings.Contains(event.Data, want) {
+			t.Errorf("event %d should contain %q, got %q", i, want, event.Data)
+		}
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		server.handleEvents(w, req)
-	}()
+	cancel()
+	<-done
+}
 
-	reader := bufio.NewReader(pr)
+func TestSSE_Heartbeat(t *testing.T) {
+	server, _ := newSSETestServer(50 * time.Millisecond) // Very short for testing
 
-	// Wait for heartbeat
-	done := make(chan struct{})
-	go func() {
-		eventType, _, err := readSSEEvent(reader)
+	ctx, cancel := context.WithCancel(context.Background())
+	rec, done := subscribe(t, server, ctx)
+
+	// Sent under its current name, then its legacy one
+	for _, want := range []string{"sys:heartbeat", "game:heartbeat"} {
+		event, err := rec.NextTimeout(200 * time.Millisecond)
 		if err != nil {
-			t.Errorf("failed to read heartbeat: %v", err)
+			t.Fatalf("failed to read heartbeat: %v", err)
 		}
-		if eventType != "game:heartbeat" {
-			t.Errorf("expected heartbeat event, got %q", eventType)
+		if event.Type != want {
+			t.Errorf("expected %q event, got %q", want, event.Type)
 		}
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		// Got heartbeat
-	case <-time.After(200 * time.Millisecond):
-		t.Error("timeout waiting for heartbeat")
 	}
 
 	cancel()
-	wg.Wait()
+	<-done
 }
 
-func TestSSE_ClientDisconnect(t *testing.T) {
-	store := newMockStore()
-	cfg := config.Default()
-	cfg.Server.SSEHeartbeat = config.Duration(10 * time.Second)
-	gameService := service.NewGameService(store, &cfg.Game)
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	server := NewServer(cfg, logger, store, gameService, nil)
+func TestSSE_LegacyEventNamesOff(t *testing.T) {
+	server, _ := newSSETestServer(20 * time.Millisecond)
+	server.eventAliases = nil
 
-	pr, pw := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	rec, done := subscribe(t, server, ctx)
 
-	w := newSSEResponseWriter(pw)
+	for range 2 {
+		event, err := rec.NextTimeout(time.Second)
+		if err != nil {
+			t.Fatalf("failed to read heartbeat: %v", err)
+		}
+		if event.Type != sdk.EventSysHeartbeat {
+			t.Errorf("expected only %s events, got %q", sdk.EventSysHeartbeat, event.Type)
+		}
+	}
+
+	cancel()
+	<-done
+}
+
+func TestSSE_ClientHandshake(t *testing.T) {
+	server, _ := newSSETestServer(20 * time.Millisecond)
 
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := ssetest.NewRecorder()
+	t.Cleanup(func() { rec.Close() })
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	req.Header.Set(sdk.ClientHeader, "taboo-go/1.4.0; events=2")
+	done := ssetest.Serve(http.HandlerFunc(server.handleEvents), rec, req)
+	rec.WaitForHeaders()
+	if got := rec.Header().Values(sdk.WarningHeader); len(got) != 0 {
+		t.Errorf("expected no warnings for a current client, got %q", got)
+	}
 
-	handlerDone := make(chan struct{})
-	go func() {
-		server.handleEvents(w, req)
-		close(handlerDone)
-	}()
+	// The client understands the current names, so isn't sent legacy copies
+	for range 2 {
+		event, err := rec.NextTimeout(time.Second)
+		if err != nil {
+			t.Fatalf("failed to read heartbeat: %v", err)
+		}
+		if event.Type != sdk.EventSysHeartbeat {
+			t.Errorf("expected only %s events, got %q", sdk.EventSysHeartbeat, event.Type)
+		}
+	}
+
+	cancel()
+	<-done
 
-	w.WaitForHeaders()
+	if got := server.usage.report(defaultUsageLimit).Streams.Clients["taboo-go/1.4.0"]; got != 1 {
+		t.Errorf("expected 1 stream from taboo-go/1.4.0, got %d", got)
+	}
+}
+
+func TestAliasesFor(t *testing.T) {
+	server, _ := newSSETestServer(time.Second)
+	legacy := sdk.LegacyEventTypes()
+
+	tests := []struct {
+		name     string
+		client   sdk.ClientInfo
+		defaults map[string]string
+		want     int
+	}{
+		{"unknown client gets server default", sdk.ClientInfo{}, legacy, len(legacy)},
+		{"unknown client, aliases off", sdk.ClientInfo{}, nil, 0},
+		{"current client", sdk.ClientInfo{Name: "go", Events: sdk.EventsVersion}, legacy, 0},
+		{"newer client", sdk.ClientInfo{Name: "go", Events: sdk.EventsVersion + 1}, legacy, 0},
+		{"old client, aliases off", sdk.ClientInfo{Name: "go", Events: 1}, nil, len(legacy)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.eventAliases = tt.defaults
+			if got := server.aliasesFor(tt.client); len(got) != tt.want {
+				t.Errorf("expected %d aliases, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSSE_ClientDisconnect(t *testing.T) {
+	server, _ := newSSETestServer(10 * time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec, done := subscribe(t, server, ctx)
 
 	// Disconnect client
 	cancel()
-	pr.Close()
-	pw.Close()
+	rec.Close()
 
 	// Handler should exit
 	select {
-	case <-handlerDone:
+	case <-done:
 		// Handler exited as expected
 	case <-time.After(500 * time.Millisecond):
 		t.Error("handler did not exit after client disconnect")
@@ -286,119 +397,137 @@ func TestSSE_ClientDisconnect(t *testing.T) {
 }
 
 func TestSSE_MultipleClients(t *testing.T) {
-	store := newMockStore()
-	cfg := config.Default()
-	cfg.Server.SSEHeartbeat = config.Duration(10 * time.Second)
-	gameService := service.NewGameService(store, &cfg.Game)
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	server := NewServer(cfg, logger, store, gameService, nil)
+	server, gameService := newSSETestServer(10 * time.Second)
 
 	const clientCount = 3
-	readers := make([]*bufio.Reader, clientCount)
-	cancels := make([]context.CancelFunc, clientCount)
-	writers := make([]*sseResponseWriter, clientCount)
-	var wg sync.WaitGroup
-
-	for i := 0; i < clientCount; i++ {
-		pr, pw := io.Pipe()
-		defer pr.Close()
-		defer pw.Close()
-
-		w := newSSEResponseWriter(pw)
-		writers[i] = w
-
-		ctx, cancel := context.WithCancel(context.Background())
-		cancels[i] = cancel
-		readers[i] = bufio.NewReader(pr)
+	recs := make([]*ssetest.Recorder, clientCount)
+	dones := make([]<-chan struct{}, clientCount)
 
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
-
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			server.handleEvents(w, req)
-		}()
-	}
-
-	// Wait for all clients to be ready
-	for _, w := range writers {
-		w.WaitForHeaders()
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := range clientCount {
+		recs[i], dones[i] = subscribe(t, server, ctx)
 	}
-	// Small delay to ensure subscriptions are established after headers
-	time.Sleep(10 * time.Millisecond)
 
-	// Broadcast event
-	gameService.BroadcastComplete(123)
+	gameService.BroadcastComplete(context.Background(), &domain.Game{ID: 123})
 
 	// All clients should receive it
-	for i, reader := range readers {
-		eventType, data, err := readSSEEvent(reader)
+	for i, rec := range recs {
+		event, err := rec.Next()
 		if err != nil {
 			t.Errorf("client %d: failed to read event: %v", i, err)
 			continue
 		}
-		if eventType != sdk.EventGameComplete {
-			t.Errorf("client %d: expected type %q, got %q", i, sdk.EventGameComplete, eventType)
+		if event.Type != sdk.EventGameComplete {
+			t.Errorf("client %d: expected type %q, got %q", i, sdk.EventGameComplete, event.Type)
 		}
-		if !strings.Contains(data, "123") {
-			t.Errorf("client %d: expected data to contain '123', got %q", i, data)
+		if !strings.Contains(event.Data, "123") {
+			t.Errorf("client %d: expected data to contain '123', got %q", i, event.Data)
 		}
 	}
 
-	// Cleanup
-	for _, cancel := range cancels {
-		cancel()
+	cancel()
+	for _, done := range dones {
+		<-done
 	}
-	wg.Wait()
 }
 
-// sseResponseWriter implements http.ResponseWriter for SSE testing.
-type sseResponseWriter struct {
-	header      http.Header
-	writer      io.Writer
-	status      int
-	headersDone chan struct{} // closed when WriteHeader is called
-	headersOnce sync.Once
+// panicWriter is an SSE client whose writes panic once armed, standing in
+// for a ResponseWriter left in a broken state.
+type panicWriter struct {
+	*httptest.ResponseRecorder
+	armed atomic.Bool
 }
 
-func newSSEResponseWriter(w io.Writer) *sseResponseWriter {
-	return &sseResponseWriter{
-		header:      make(http.Header),
-		writer:      w,
-		headersDone: make(chan struct{}),
+func (w *panicWriter) Write(b []byte) (int, error) {
+	if w.armed.Load() {
+		panic("broken writer")
 	}
+	return w.ResponseRecorder.Write(b)
 }
 
-func (w *sseResponseWriter) Header() http.Header {
-	return w.header
+func (w *panicWriter) SetWriteDeadline(time.Time) error {
+	return nil
 }
 
-func (w *sseResponseWriter) Write(b []byte) (int, error) {
-	return w.writer.Write(b)
-}
+func TestSSE_WriterPanicDropsOnlySubscription(t *testing.T) {
+	server, gameService := newSSETestServer(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-func (w *sseResponseWriter) WriteHeader(status int) {
-	w.status = status
-	w.headersOnce.Do(func() {
-		close(w.headersDone)
+	healthy, _ := subscribe(t, server, ctx)
+
+	broken := &panicWriter{ResponseRecorder: httptest.NewRecorder()}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		server.handleEvents(broken, req)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	broken.armed.Store(true)
+	gameService.Broadcast(ctx, service.Event{
+		Type: sdk.EventGameComplete,
+		Data: sdk.GameCompleteEvent{GameID: 1},
 	})
-}
 
-func (w *sseResponseWriter) Flush() {
-	// No-op for pipe writer
-}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the broken subscription to be dropped")
+	}
 
-// SetWriteDeadline implements the interface required by http.ResponseController.
-func (w *sseResponseWriter) SetWriteDeadline(deadline time.Time) error {
-	return nil // No-op for testing
+	event, err := healthy.NextTimeout(time.Second)
+	if err != nil {
+		t.Fatalf("healthy client: %v", err)
+	}
+	if event.Type != sdk.EventGameComplete {
+		t.Errorf("healthy client got %q, want %q", event.Type, sdk.EventGameComplete)
+	}
 }
 
-// SetReadDeadline implements the interface required by http.ResponseController.
-func (w *sseResponseWriter) SetReadDeadline(deadline time.Time) error {
-	return nil // No-op for testing
-}
+func TestSSE_StreamGap(t *testing.T) {
+	store := newMockStore()
+	cfg := config.Default()
+	cfg.Server.SSEHeartbeat = config.Duration(time.Hour)
+	gameService := service.NewGameService(store, &cfg.Game, pubsub.WithBufferSize[service.Event](1))
+	server := NewServer(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), store, gameService, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec, done := subscribe(t, server, ctx)
+
+	// The handler blocks writing pick 1 until it is read, pick 2 fills the
+	// buffer, and picks 3 and 4 are dropped
+	gameService.BroadcastPick(context.Background(), 1, 0)
+	time.Sleep(10 * time.Millisecond)
+	for pick := uint8(2); pick <= 4; pick++ {
+		gameService.BroadcastPick(context.Background(), pick, 0)
+	}
+
+	for _, want := range []string{`"pick":1`, `"pick":2`} {
+		event, err := rec.NextTimeout(time.Second)
+		if err != nil {
+			t.Fatalf("reading %s: %v", want, err)
+		}
+		if !strings.Contains(event.Data, want) {
+			t.Errorf("expected %s, got %q", want, event.Data)
+		}
+	}
+
+	gameService.BroadcastPick(context.Background(), 5, 0)
 
-// WaitForHeaders blocks until WriteHeader has been called.
-func (w *sseResponseWriter) WaitForHeaders() {
-	<-w.headersDone
+	event, err := rec.NextTimeout(time.Second)
+	if err != nil {
+		t.Fatalf("reading gap: %v", err)
+	}
+	if event.Type != sdk.EventStreamGap || event.Data != `{"dropped":2,"latest_seq":5}` {
+		t.Errorf("expected a gap of 2 events up to seq 5, got %s %s", event.Type, event.Data)
+	}
+	if event, err := rec.NextTimeout(time.Second); err != nil || !strings.Contains(event.Data, `"pick":5`) {
+		t.Errorf("expected pick 5 after the gap, got %+v, %v", event, err)
+	}
+
+	cancel()
+	<-done
 }
@@ -1,52 +1,67 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
 
+	"github.com/aussiebroadwan/taboo/internal/domain"
 	"github.com/aussiebroadwan/taboo/internal/store"
 	"github.com/aussiebroadwan/taboo/pkg/httpx"
 	"github.com/aussiebroadwan/taboo/pkg/slogx"
 	"github.com/aussiebroadwan/taboo/sdk"
 )
 
+// defaultPageSize is the number of games listed when ?limit= is omitted.
+const defaultPageSize = 20
+
 // handleListGames handles GET /api/v1/games
 func (s *Server) handleListGames(w http.ResponseWriter, r *http.Request) {
-	// Parse cursor (default 0)
-	cursor := int64(0)
-	if c := r.URL.Query().Get("cursor"); c != "" {
-		parsed, err := strconv.ParseInt(c, 10, 64)
-		if err != nil || parsed < 0 {
-			_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid cursor parameter"))
-			return
-		}
-		cursor = parsed
+	// Parse cursor (default 0), limit (default 20, capped by the page size
+	// limit) and season (default 0, which lists across all seasons)
+	cursor, apiErr := httpx.QueryInt[int64](r, "cursor", 0, 0, math.MaxInt64)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
 	}
-
-	// Parse limit (default 20, max 100)
-	limit := 20
-	if l := r.URL.Query().Get("limit"); l != "" {
-		parsed, err := strconv.Atoi(l)
-		if err != nil || parsed < 1 || parsed > 100 {
-			_ = httpx.WriteError(w, httpx.ErrBadRequest("limit must be between 1 and 100"))
-			return
-		}
-		limit = parsed
+	maxLimit := s.cfg.Limits.MaxPageSize
+	limit, apiErr := httpx.QueryInt(r, "limit", min(defaultPageSize, maxLimit), 1, maxLimit)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+	seasonID, apiErr := httpx.QueryInt[int64](r, "season", 0, 1, math.MaxInt64)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
 	}
 
-	// Fetch games
-	games, err := s.gameService.ListGames(r.Context(), cursor, limit+1)
+	// Fetch games, coalescing identical concurrent page requests
+	key := fmt.Sprintf("games:list:%d:%d:%d", cursor, limit, seasonID)
+	games, err := coalesce(r.Context(), &s.flight, key, s.cfg.Server.RequestTimeout.Duration(),
+		func(ctx context.Context) ([]*domain.Game, error) {
+			if seasonID > 0 {
+				return s.gameService.ListSeasonGames(ctx, seasonID, cursor, limit+1)
+			}
+			return s.gameService.ListGames(ctx, cursor, limit+1)
+		})
 	if err != nil {
-		_ = httpx.WriteError(w, httpx.ErrInternal("failed to fetch games"))
+		if errors.Is(err, store.ErrNotFound) {
+			_ = httpx.WriteError(w, httpx.ErrNotFound(fmt.Sprintf("season %d not found", seasonID)))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch games"))
 		return
 	}
 
 	// Build response
 	resp := sdk.GameListResponse{
-		Games: make([]sdk.Game, 0, len(games)),
+		Games:  make([]sdk.Game, 0, len(games)),
+		Season: seasonID,
 	}
 
 	// Check if there's a next page
@@ -56,11 +71,7 @@ func (s *Server) handleListGames(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, g := range games {
-		resp.Games = append(resp.Games, sdk.Game{
-			ID:        g.ID,
-			Picks:     g.Picks,
-			CreatedAt: g.CreatedAt,
-		})
+		resp.Games = append(resp.Games, toSDKGame(g))
 	}
 
 	// Set next cursor if there are more results
@@ -70,7 +81,26 @@ func (s *Server) handleListGames(w http.ResponseWriter, r *http.Request) {
 		resp.NextCursor = &nextCursor
 	}
 
-	if err := httpx.JSON(w, http.StatusOK, resp); err != nil {
+	// A full page with more after it holds only completed games and never
+	// changes; the last page grows as games are drawn.
+	immutable := hasMore
+	for _, g := range games {
+		immutable = immutable && s.gameService.IsComplete(g.ID)
+	}
+	if immutable {
+		s.setCacheHeaders(w, true, keyGames)
+	} else {
+		s.setCacheHeaders(w, false, keyGamesTail)
+	}
+
+	meta := sdk.Meta{
+		Pagination: &sdk.Pagination{
+			Limit:      limit,
+			NextCursor: resp.NextCursor,
+		},
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, meta); err != nil {
 		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
 	}
 }
@@ -86,24 +116,82 @@ func (s *Server) handleGetGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch game
-	game, err := s.gameService.GetGame(r.Context(), id)
+	key := fmt.Sprintf("games:get:%d", id)
+	game, err := coalesce(r.Context(), &s.flight, key, s.cfg.Server.RequestTimeout.Duration(),
+		func(ctx context.Context) (*domain.Game, error) {
+			return s.gameService.GetGame(ctx, id)
+		})
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			_ = httpx.WriteError(w, httpx.ErrNotFound(fmt.Sprintf("game %d not found", id)))
 			return
 		}
-		_ = httpx.WriteError(w, httpx.ErrInternal("failed to fetch game"))
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch game"))
 		return
 	}
 
-	if err := httpx.JSON(w, http.StatusOK, sdk.Game{
-		ID:        game.ID,
-		Picks:     game.Picks,
-		CreatedAt: game.CreatedAt,
-	}); err != nil {
+	if s.gameService.IsComplete(game.ID) {
+		s.setCacheHeaders(w, true, keyGames, gameKey(game.ID))
+	} else {
+		s.setCacheHeaders(w, false, gameKey(game.ID))
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, toSDKGame(game), sdk.Meta{}); err != nil {
 		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
 			slogx.Error(err),
 			slog.Int64("game_id", id),
 		)
 	}
 }
+
+// handleGetLatestGame handles GET /api/v1/games/latest
+func (s *Server) handleGetLatestGame(w http.ResponseWriter, r *http.Request) {
+	// Every client refetches the latest game after game:complete, so
+	// concurrent requests share a single store query.
+	game, err := coalesce(r.Context(), &s.flight, "games:latest", s.cfg.Server.RequestTimeout.Duration(),
+		s.latestCompleteGame)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			_ = httpx.WriteError(w, httpx.ErrNotFound("no games found"))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch latest game"))
+		return
+	}
+
+	s.setCacheHeaders(w, false, keyGamesLatest, gameKey(game.ID))
+
+	if err := httpx.Respond(w, r, http.StatusOK, toSDKGame(game), sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
+			slogx.Error(err),
+			slog.Int64("game_id", game.ID),
+		)
+	}
+}
+
+// latestCompleteGame returns the most recent game whose draw has finished.
+// While a game is being drawn its picks are still secret, so the game
+// before it is returned instead.
+func (s *Server) latestCompleteGame(ctx context.Context) (*domain.Game, error) {
+	game, err := s.gameService.GetLatestGame(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.gameService.IsComplete(game.ID) {
+		return game, nil
+	}
+	if game.ID <= 1 {
+		return nil, store.ErrNotFound
+	}
+	return s.gameService.GetGame(ctx, game.ID-1)
+}
+
+// toSDKGame converts a domain game to its API representation.
+func toSDKGame(g *domain.Game) sdk.Game {
+	return sdk.Game{
+		ID:         g.ID,
+		Picks:      g.Picks,
+		CreatedAt:  g.CreatedAt,
+		ResultHash: g.ResultHash,
+	}
+}
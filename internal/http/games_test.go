@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,6 +20,7 @@ import (
 	"github.com/aussiebroadwan/taboo/internal/domain"
 	"github.com/aussiebroadwan/taboo/internal/service"
 	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
 	"github.com/aussiebroadwan/taboo/sdk"
 )
 
@@ -24,17 +30,30 @@ var errMockDB = errors.New("mock database error")
 type mockStore struct {
 	games      map[int64]*domain.Game
 	latestGame *domain.Game
+	seasons    []*domain.Season
+	settings   map[string]string
+	settingsMu sync.Mutex
 
 	pingErr   error
 	createErr error
 	getErr    error
 	listErr   error
 	latestErr error
+	statsErr  error
+
+	latestCalls atomic.Int32
+	latestDelay time.Duration
+
+	// onCreate, if set, runs once CreateGame has stored a game, before it
+	// returns.
+	onCreate func(*domain.Game)
 }
 
 func newMockStore() *mockStore {
 	return &mockStore{
-		games: make(map[int64]*domain.Game),
+		games:    make(map[int64]*domain.Game),
+		seasons:  []*domain.Season{{ID: 1, Name: "Season 1", FirstGameID: 1}},
+		settings: make(map[string]string),
 	}
 }
 
@@ -46,12 +65,31 @@ func (m *mockStore) Close() error {
 	return nil
 }
 
+func (m *mockStore) Stats(ctx context.Context) (*store.Stats, error) {
+	if m.statsErr != nil {
+		return nil, m.statsErr
+	}
+	m.settingsMu.Lock()
+	defer m.settingsMu.Unlock()
+	return &store.Stats{
+		FileSize: 4096,
+		Rows: map[string]int64{
+			"games":    int64(len(m.games)),
+			"seasons":  int64(len(m.seasons)),
+			"settings": int64(len(m.settings)),
+		},
+	}, nil
+}
+
 func (m *mockStore) CreateGame(ctx context.Context, game *domain.Game) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
 	m.games[game.ID] = game
 	m.latestGame = game
+	if m.onCreate != nil {
+		m.onCreate(game)
+	}
 	return nil
 }
 
@@ -67,6 +105,10 @@ func (m *mockStore) GetGame(ctx context.Context, id int64) (*domain.Game, error)
 }
 
 func (m *mockStore) GetLatestGame(ctx context.Context) (*domain.Game, error) {
+	m.latestCalls.Add(1)
+	if m.latestDelay > 0 {
+		time.Sleep(m.latestDelay)
+	}
 	if m.latestErr != nil {
 		return nil, m.latestErr
 	}
@@ -76,14 +118,70 @@ func (m *mockStore) GetLatestGame(ctx context.Context) (*domain.Game, error) {
 	return m.latestGame, nil
 }
 
+func (m *mockStore) CreateSeason(ctx context.Context, season *domain.Season) error {
+	m.seasons[len(m.seasons)-1].EndGameID = season.FirstGameID
+	m.seasons = append(m.seasons, season)
+	return nil
+}
+
+func (m *mockStore) GetSeason(ctx context.Context, id int64) (*domain.Season, error) {
+	for _, s := range m.seasons {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *mockStore) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	return m.seasons[len(m.seasons)-1], nil
+}
+
+func (m *mockStore) ListSeasons(ctx context.Context) ([]*domain.Season, error) {
+	return m.seasons, nil
+}
+
+func (m *mockStore) GetSetting(ctx context.Context, key string) (string, error) {
+	m.settingsMu.Lock()
+	defer m.settingsMu.Unlock()
+	value, ok := m.settings[key]
+	if !ok {
+		return "", store.ErrNotFound
+	}
+	return value, nil
+}
+
+func (m *mockStore) ListSettings(ctx context.Context) (map[string]string, error) {
+	m.settingsMu.Lock()
+	defer m.settingsMu.Unlock()
+	return maps.Clone(m.settings), nil
+}
+
+func (m *mockStore) SetSetting(ctx context.Context, key, value string) error {
+	m.settingsMu.Lock()
+	defer m.settingsMu.Unlock()
+	m.settings[key] = value
+	return nil
+}
+
+func (m *mockStore) DeleteSetting(ctx context.Context, key string) error {
+	m.settingsMu.Lock()
+	defer m.settingsMu.Unlock()
+	if _, ok := m.settings[key]; !ok {
+		return store.ErrNotFound
+	}
+	delete(m.settings, key)
+	return nil
+}
+
 func (m *mockStore) ListGames(ctx context.Context, startID int64, limit int) ([]*domain.Game, error) {
 	if m.listErr != nil {
 		return nil, m.listErr
 	}
 	var result []*domain.Game
-	for id := startID + 1; id <= startID+int64(limit)+1; id++ {
-		if game, ok := m.games[id]; ok {
-			result = append(result, game)
+	for _, id := range slices.Sorted(maps.Keys(m.games)) {
+		if g := m.games[id]; g.ID >= startID {
+			result = append(result, g)
 			if len(result) >= limit {
 				break
 			}
@@ -92,10 +190,20 @@ func (m *mockStore) ListGames(ctx context.Context, startID int64, limit int) ([]
 	return result, nil
 }
 
+// testPicks returns a valid set of picks for the default game config.
+func testPicks() []uint8 {
+	picks := make([]uint8, 20)
+	for i := range picks {
+		picks[i] = uint8(i + 1)
+	}
+	return picks
+}
+
 type testServer struct {
 	*Server
 	mockStore   *mockStore
 	gameService *service.GameService
+	settings    *service.SettingsService
 	engine      *service.Engine
 }
 
@@ -105,12 +213,14 @@ func newTestServer(t *testing.T) *testServer {
 	cfg := config.Default()
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	gameService := service.NewGameService(store, &cfg.Game)
+	settings := service.NewSettingsService(store)
 	engine := service.NewEngine(gameService, &cfg.Game, logger)
-	server := NewServer(cfg, logger, store, gameService, engine)
+	server := NewServer(cfg, logger, store, gameService, settings, engine)
 	return &testServer{
 		Server:      server,
 		mockStore:   store,
 		gameService: gameService,
+		settings:    settings,
 		engine:      engine,
 	}
 }
@@ -122,7 +232,7 @@ func TestHandleListGames_DefaultParams(t *testing.T) {
 	for i := int64(1); i <= 5; i++ {
 		ts.mockStore.games[i] = &domain.Game{
 			ID:        i,
-			Picks:     []uint8{uint8(i % 256)}, //nolint:gosec // test values are within uint8 range
+			Picks:     testPicks(),
 			CreatedAt: time.Now(),
 		}
 	}
@@ -152,12 +262,12 @@ func TestHandleListGames_WithCursor(t *testing.T) {
 	for i := int64(1); i <= 10; i++ {
 		ts.mockStore.games[i] = &domain.Game{
 			ID:        i,
-			Picks:     []uint8{uint8(i % 256)}, //nolint:gosec // test values are within uint8 range
+			Picks:     testPicks(),
 			CreatedAt: time.Now(),
 		}
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/games?cursor=5", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games?cursor=6", nil)
 	w := httptest.NewRecorder()
 
 	ts.handleListGames(w, req)
@@ -171,10 +281,13 @@ func TestHandleListGames_WithCursor(t *testing.T) {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	// Should only include games with ID > 5
+	// The cursor is the first ID of the page, so only games 6 and up
+	if len(resp.Games) == 0 || resp.Games[0].ID != 6 {
+		t.Fatalf("expected page to start at game 6, got %+v", resp.Games)
+	}
 	for _, g := range resp.Games {
-		if g.ID <= 5 {
-			t.Errorf("expected game ID > 5, got %d", g.ID)
+		if g.ID < 6 {
+			t.Errorf("expected game ID >= 6, got %d", g.ID)
 		}
 	}
 }
@@ -185,7 +298,7 @@ func TestHandleListGames_WithLimit(t *testing.T) {
 	for i := int64(1); i <= 10; i++ {
 		ts.mockStore.games[i] = &domain.Game{
 			ID:        i,
-			Picks:     []uint8{uint8(i % 256)}, //nolint:gosec // test values are within uint8 range
+			Picks:     testPicks(),
 			CreatedAt: time.Now(),
 		}
 	}
@@ -261,6 +374,41 @@ func TestHandleListGames_InvalidLimit(t *testing.T) {
 	}
 }
 
+func TestHandleListGames_ConfiguredPageSize(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Limits.MaxPageSize = 5
+
+	for i := int64(1); i <= 10; i++ {
+		ts.mockStore.games[i] = &domain.Game{ID: i, Picks: testPicks(), CreatedAt: time.Now()}
+	}
+
+	// The default page shrinks to fit the limit
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games", nil)
+	w := httptest.NewRecorder()
+	ts.handleListGames(w, req)
+
+	var resp sdk.GameListResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Games) != 5 {
+		t.Errorf("expected 5 games, got %d", len(resp.Games))
+	}
+
+	// and larger pages are rejected, naming the parameter
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/games?limit=6", nil)
+	w = httptest.NewRecorder()
+	ts.handleListGames(w, req)
+
+	var errResp sdk.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusBadRequest || errResp.Error.Param != "limit" {
+		t.Errorf("expected 400 for param limit, got %d %+v", w.Code, errResp.Error)
+	}
+}
+
 func TestHandleListGames_Pagination(t *testing.T) {
 	ts := newTestServer(t)
 
@@ -268,7 +416,7 @@ func TestHandleListGames_Pagination(t *testing.T) {
 	for i := int64(1); i <= 25; i++ {
 		ts.mockStore.games[i] = &domain.Game{
 			ID:        i,
-			Picks:     []uint8{uint8(i % 256)}, //nolint:gosec // test values are within uint8 range
+			Picks:     testPicks(),
 			CreatedAt: time.Now(),
 		}
 	}
@@ -289,6 +437,49 @@ func TestHandleListGames_Pagination(t *testing.T) {
 
 }
 
+func TestHandleListGames_Envelope(t *testing.T) {
+	ts := newTestServer(t)
+
+	for i := int64(1); i <= 25; i++ {
+		ts.mockStore.games[i] = &domain.Game{
+			ID:        i,
+			Picks:     testPicks(),
+			CreatedAt: time.Now(),
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games?limit=10", nil)
+	req.Header.Set(httpx.EnvelopeHeader, "1")
+	w := httptest.NewRecorder()
+
+	ts.handleListGames(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var env sdk.Envelope[sdk.GameListResponse]
+	if err := json.NewDecoder(w.Body).Decode(&env); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(env.Data.Games) != 10 {
+		t.Errorf("expected 10 games, got %d", len(env.Data.Games))
+	}
+	if env.Meta.Pagination == nil {
+		t.Fatal("expected pagination meta")
+	}
+	if env.Meta.Pagination.Limit != 10 {
+		t.Errorf("expected limit 10, got %d", env.Meta.Pagination.Limit)
+	}
+	if env.Data.NextCursor == nil || env.Meta.Pagination.NextCursor == nil {
+		t.Fatal("expected next_cursor in data and meta")
+	}
+	if *env.Meta.Pagination.NextCursor != *env.Data.NextCursor {
+		t.Errorf("expected meta next_cursor %d, got %d", *env.Data.NextCursor, *env.Meta.Pagination.NextCursor)
+	}
+}
+
 func TestHandleListGames_StoreError(t *testing.T) {
 	ts := newTestServer(t)
 	ts.mockStore.listErr = errors.New("database error")
@@ -303,12 +494,26 @@ func TestHandleListGames_StoreError(t *testing.T) {
 	}
 }
 
+func TestHandleListGames_StoreTimeout(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.listErr = fmt.Errorf("querying games: %w", store.ErrTimeout)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleListGames(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
 func TestHandleGetGame_Success(t *testing.T) {
 	ts := newTestServer(t)
 
 	game := &domain.Game{
 		ID:        42,
-		Picks:     []uint8{1, 2, 3, 4, 5},
+		Picks:     testPicks(),
 		CreatedAt: time.Now(),
 	}
 	ts.mockStore.games[42] = game
@@ -388,3 +593,116 @@ func TestHandleGetGame_StoreError(t *testing.T) {
 		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
 	}
 }
+
+func TestHandleGetLatestGame_Success(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.latestGame = &domain.Game{ID: 7, Picks: testPicks(), CreatedAt: time.Now()}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/latest", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleGetLatestGame(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var game sdk.Game
+	if err := json.NewDecoder(w.Body).Decode(&game); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if game.ID != 7 {
+		t.Errorf("expected game ID 7, got %d", game.ID)
+	}
+}
+
+func TestHandleGetLatestGame_MidDraw(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+	ts.mockStore.games[1] = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+
+	drawing := &domain.Game{ID: 2, Picks: testPicks(), CreatedAt: time.Now()}
+	if err := ts.gameService.CreateGame(ctx, drawing); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	ts.mockStore.latestGame = drawing
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/latest", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleGetLatestGame(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var game sdk.Game
+	if err := json.NewDecoder(w.Body).Decode(&game); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if game.ID != 1 {
+		t.Errorf("expected the last completed game 1 while game 2 draws, got %d", game.ID)
+	}
+}
+
+func TestHandleGetLatestGame_FirstGameDrawing(t *testing.T) {
+	ts := newTestServer(t)
+
+	drawing := &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+	if err := ts.gameService.CreateGame(context.Background(), drawing); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	ts.mockStore.latestGame = drawing
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/latest", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleGetLatestGame(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d before any draw completes, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleGetLatestGame_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/latest", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleGetLatestGame(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleGetLatestGame_CoalescesConcurrentRequests(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.latestGame = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+	ts.mockStore.latestDelay = 50 * time.Millisecond
+
+	const clients = 20
+	var wg sync.WaitGroup
+	codes := make([]int, clients)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/games/latest", nil)
+			w := httptest.NewRecorder()
+			ts.handleGetLatestGame(w, req)
+			codes[i] = w.Code
+		}()
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("client %d: expected status %d, got %d", i, http.StatusOK, code)
+		}
+	}
+	if calls := ts.mockStore.latestCalls.Load(); calls >= clients {
+		t.Errorf("expected concurrent requests to share store queries, got %d calls for %d clients", calls, clients)
+	}
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/aussiebroadwan/taboo/pkg/httpx"
@@ -17,35 +18,68 @@ func (s *Server) handleLivez(w http.ResponseWriter, r *http.Request) {
 // handleReadyz is a readiness probe endpoint.
 // It checks all dependencies and returns their status.
 func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	checks, ok := s.Ready(r.Context())
+
+	status := "ok"
+	statusCode := http.StatusOK
+	if !ok {
+		status = "degraded"
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	_ = httpx.JSON(w, statusCode, map[string]any{
+		"status": status,
+		"checks": checks,
+	})
+}
+
+// Ready checks the server's dependencies, returning the status of each and
+// whether all of them are ok.
+func (s *Server) Ready(ctx context.Context) (map[string]string, bool) {
 	checks := make(map[string]string)
 
 	// Check database
-	if err := s.store.Ping(r.Context()); err != nil {
+	if err := s.store.Ping(ctx); err != nil {
 		checks["database"] = "error: " + err.Error()
 	} else {
 		checks["database"] = "ok"
 	}
 
-	// Check game engine
-	if s.engine != nil && s.engine.IsRunning() {
-		checks["engine"] = "ok"
-	} else {
+	// Check game engine, or the sync loop on a mirror, which runs none
+	switch {
+	case s.mirror != nil:
+		checks["mirror"] = loopStatus(s.mirror.IsRunning(), s.mirror.Stalled())
+	case s.engine == nil:
 		checks["engine"] = "not running"
+	default:
+		checks["engine"] = loopStatus(s.engine.IsRunning(), s.engine.Stalled())
 	}
 
-	// Determine overall status
-	status := "ok"
-	statusCode := http.StatusOK
+	ok := true
 	for _, v := range checks {
-		if v != "ok" {
-			status = "degraded"
-			statusCode = http.StatusServiceUnavailable
-			break
+		ok = ok && v == "ok"
+	}
+
+	// Each worker, under its own name, with its status
+	if s.workers != nil {
+		workers, healthy := s.workers()
+		for name, status := range workers {
+			checks["worker:"+name] = status
 		}
+		ok = ok && healthy
 	}
 
-	_ = httpx.JSON(w, statusCode, map[string]any{
-		"status": status,
-		"checks": checks,
-	})
+	return checks, ok
+}
+
+// loopStatus reports the readiness of a background loop.
+func loopStatus(running, stalled bool) string {
+	switch {
+	case !running:
+		return "not running"
+	case stalled:
+		return "stalled"
+	default:
+		return "ok"
+	}
 }
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -126,3 +127,28 @@ func TestHandleReadyz_DatabaseError(t *testing.T) {
 		t.Error("expected database check to fail")
 	}
 }
+
+func TestReady_Workers(t *testing.T) {
+	ts := newTestServer(t)
+	ts.engine.SetRunning(true)
+
+	healthy := true
+	ts.SetWorkers(func() (map[string]string, bool) {
+		status := "ok"
+		if !healthy {
+			status = "restarting"
+		}
+		return map[string]string{"engine": status, "http": "ok"}, healthy
+	})
+
+	checks, ok := ts.Ready(context.Background())
+	if !ok || checks["worker:engine"] != "ok" || checks["worker:http"] != "ok" {
+		t.Errorf("expected every worker ok, got %v, %v", checks, ok)
+	}
+
+	healthy = false
+	checks, ok = ts.Ready(context.Background())
+	if ok || checks["worker:engine"] != "restarting" {
+		t.Errorf("expected a restarting worker to degrade readiness, got %v, %v", checks, ok)
+	}
+}
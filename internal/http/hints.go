@@ -0,0 +1,56 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleGetHints handles GET /api/v1/admin/hints
+func (s *Server) handleGetHints(w http.ResponseWriter, r *http.Request) {
+	s.writeHints(w, r, s.gameService.Hints())
+}
+
+// handleSetHints handles PUT /api/v1/admin/hints. The hints replace the
+// current set; an empty object clears them. They are persisted as the
+// ui.hints setting and reach clients once the game service applies the
+// change.
+func (s *Server) handleSetHints(w http.ResponseWriter, r *http.Request) {
+	var req sdk.HintsRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodySize)).Decode(&req); err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid request body"))
+		return
+	}
+	if req.Hints == nil {
+		req.Hints = map[string]string{}
+	}
+
+	if err := s.settings.Set(r.Context(), service.SettingUIHints, req.Hints); err != nil {
+		if errors.Is(err, service.ErrInvalidSetting) {
+			_ = httpx.WriteError(w, httpx.ErrBadRequest(err.Error()))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to set hints"))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("UI hints updated", slog.Int("count", len(req.Hints)))
+
+	s.writeHints(w, r, req.Hints)
+}
+
+func (s *Server) writeHints(w http.ResponseWriter, r *http.Request, hints map[string]string) {
+	if hints == nil {
+		hints = map[string]string{}
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, sdk.HintsResponse{Hints: hints}, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
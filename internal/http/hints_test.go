@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleSetHints(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := ts.gameService.Subscribe(ctx)
+	go ts.gameService.SyncHints(ctx, ts.settings)
+
+	ts.gameService.BroadcastState(ctx, sdk.GameStateEvent{GameID: 1, Picks: sdk.Picks{}})
+	<-events
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/hints",
+		strings.NewReader(`{"hints":{"highlight":"7,21","banner":"finals"}}`))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	w := httptest.NewRecorder()
+
+	ts.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+
+	var resp sdk.HintsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Hints["highlight"] != "7,21" || resp.Hints["banner"] != "finals" {
+		t.Errorf("unexpected hints: %v", resp.Hints)
+	}
+
+	// The engine's next state carries the new hints once they are applied
+	deadline := time.Now().Add(time.Second)
+	for ts.gameService.Hints()["banner"] != "finals" {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the hints to apply")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	ts.gameService.BroadcastState(ctx, sdk.GameStateEvent{GameID: 1, Picks: sdk.Picks{}})
+	if state := (<-events).Data.(sdk.GameStateEvent); state.Hints["banner"] != "finals" {
+		t.Errorf("expected state event with the new hints, got %+v", state)
+	}
+}
+
+func TestHandleSetHints_Invalid(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed", `{"hints":`},
+		{"empty key", `{"hints":{"":"x"}}`},
+		{"long value", `{"hints":{"banner":"` + strings.Repeat("x", 300) + `"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/hints", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer s3cret")
+			w := httptest.NewRecorder()
+
+			ts.Handler().ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
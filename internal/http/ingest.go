@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// maxNonceLen bounds the nonces remembered for replay protection.
+const maxNonceLen = 128
+
+// nonceCache remembers the nonces of accepted ingest requests until their
+// timestamps fall outside the allowed skew, after which the timestamp check
+// rejects a replay on its own.
+type nonceCache struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newNonceCache(window time.Duration) *nonceCache {
+	return &nonceCache{
+		window: window,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// use records nonce as seen at now, reporting false if it was already seen
+// within the window.
+func (c *nonceCache) use(nonce string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for n, expires := range c.seen {
+		if now.After(expires) {
+			delete(c.seen, n)
+		}
+	}
+	if _, ok := c.seen[nonce]; ok {
+		return false
+	}
+	// A timestamp may be up to window ahead, so keep it for twice as long
+	c.seen[nonce] = now.Add(2 * c.window)
+	return true
+}
+
+// requireIngestSignature is middleware that rejects ingest requests not
+// signed with the shared ingest secret, or stale or replayed ones. The body
+// is read to check its signature and handed on unchanged. Every request is
+// rejected while ingestion is disabled.
+func (s *Server) requireIngestSignature(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.ingest == nil {
+			_ = httpx.WriteError(w, httpx.ErrNotFound("result ingestion is not enabled"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAdminBodySize))
+		if err != nil {
+			_ = httpx.WriteError(w, httpx.ErrBadRequest("failed to read request body"))
+			return
+		}
+
+		if msg := s.verifyIngest(r, body, time.Now()); msg != "" {
+			slogx.FromContext(r.Context()).Warn("Rejected ingest request", slog.String("reason", msg))
+			_ = httpx.WriteError(w, httpx.ErrUnauthorized(msg))
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleIngestGame handles POST /api/v1/ingest/games. A results provider
+// pushes the picks for an upcoming game, signed with the shared ingest
+// secret and checked by requireIngestSignature; the engine draws queued
+// results in order.
+func (s *Server) handleIngestGame(w http.ResponseWriter, r *http.Request) {
+	var req sdk.IngestGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid request body"))
+		return
+	}
+
+	if err := s.ingest.Push(service.Result{Ref: req.Ref, Picks: req.Picks}); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidResult):
+			_ = httpx.WriteError(w, httpx.ErrBadRequest(err.Error()))
+		case errors.Is(err, service.ErrIngestBacklogFull):
+			_ = httpx.WriteError(w, httpx.ErrConflict("too many results are waiting to be drawn"))
+		default:
+			_ = httpx.WriteError(w, httpx.ErrInternal("failed to queue result"))
+		}
+		return
+	}
+
+	queued := s.ingest.Queued()
+	slogx.FromContext(r.Context()).Info("Result ingested",
+		slog.String("ref", req.Ref),
+		slog.Int("queued", queued),
+	)
+
+	resp := sdk.IngestGameResponse{Ref: req.Ref, Queued: queued}
+	if err := httpx.Respond(w, r, http.StatusAccepted, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
+// verifyIngest checks an ingest request's signature, timestamp and nonce,
+// returning why it was rejected or "" if it is authentic and fresh. The
+// nonce is only recorded once the signature checks out, so unsigned
+// requests can't fill the cache.
+func (s *Server) verifyIngest(r *http.Request, body []byte, now time.Time) string {
+	ext := s.cfg.Game.External
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(sdk.HeaderIngestTimestamp), 10, 64)
+	if err != nil {
+		return "missing or invalid " + sdk.HeaderIngestTimestamp
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)).Abs(); skew > ext.IngestMaxSkew.Duration() {
+		return "timestamp outside the allowed skew"
+	}
+
+	nonce := r.Header.Get(sdk.HeaderIngestNonce)
+	if nonce == "" || len(nonce) > maxNonceLen {
+		return "missing or invalid " + sdk.HeaderIngestNonce
+	}
+
+	signature, ok := strings.CutPrefix(r.Header.Get(sdk.HeaderIngestSignature), sdk.IngestSignaturePrefix)
+	if !ok {
+		return "missing or invalid " + sdk.HeaderIngestSignature
+	}
+	expected := sdk.SignIngest([]byte(ext.IngestSecret), timestamp, nonce, body)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "signature mismatch"
+	}
+
+	if !s.nonces.use(nonce, now) {
+		return "nonce already used"
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+const testIngestSecret = "0123456789abcdef0123456789abcdef"
+
+// newIngestTestServer returns a test server accepting pushed results.
+func newIngestTestServer(t *testing.T) (*testServer, *service.IngestSource) {
+	t.Helper()
+	ts := newTestServer(t)
+	ts.cfg.Game.Source = "external"
+	ts.cfg.Game.External.IngestSecret = testIngestSecret
+	ingest := service.NewIngestSource(&ts.cfg.Game)
+	ts.SetIngest(ingest)
+	return ts, ingest
+}
+
+// ingestRequest builds a push of body signed at timestamp with nonce.
+func ingestRequest(body string, timestamp int64, nonce string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/ingest/games", strings.NewReader(body))
+	req.Header.Set(sdk.HeaderIngestTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(sdk.HeaderIngestNonce, nonce)
+	req.Header.Set(sdk.HeaderIngestSignature, sdk.IngestSignaturePrefix+sdk.SignIngest([]byte(testIngestSecret), timestamp, nonce, []byte(body)))
+	return req
+}
+
+func ingestBody(t *testing.T, ref string, picks []uint8) string {
+	t.Helper()
+	body, err := json.Marshal(sdk.IngestGameRequest{Ref: ref, Picks: picks})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestHandleIngestGame(t *testing.T) {
+	ts, ingest := newIngestTestServer(t)
+
+	w := httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, ingestRequest(ingestBody(t, "r-1", testPicks()), time.Now().Unix(), "n-1"))
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body)
+	}
+	var resp sdk.IngestGameResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Ref != "r-1" || resp.Queued != 1 {
+		t.Errorf("expected ref r-1 with 1 queued, got %+v", resp)
+	}
+
+	result, err := ingest.Next(t.Context())
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if result.Ref != "r-1" || len(result.Picks) != len(testPicks()) {
+		t.Errorf("expected the pushed result, got %+v", result)
+	}
+}
+
+func TestHandleIngestGame_Rejected(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name   string
+		req    func(t *testing.T) *http.Request
+		status int
+	}{
+		{
+			name: "unsigned",
+			req: func(t *testing.T) *http.Request {
+				req := ingestRequest(ingestBody(t, "", testPicks()), now, "n")
+				req.Header.Del(sdk.HeaderIngestSignature)
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered body",
+			req: func(t *testing.T) *http.Request {
+				req := ingestRequest(ingestBody(t, "", testPicks()), now, "n")
+				req.Body = io.NopCloser(strings.NewReader(ingestBody(t, "forged", testPicks())))
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "stale timestamp",
+			req: func(t *testing.T) *http.Request {
+				return ingestRequest(ingestBody(t, "", testPicks()), now-int64(time.Hour/time.Second), "n")
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "missing nonce",
+			req: func(t *testing.T) *http.Request {
+				return ingestRequest(ingestBody(t, "", testPicks()), now, "")
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid picks",
+			req: func(t *testing.T) *http.Request {
+				return ingestRequest(ingestBody(t, "", []uint8{1, 1, 2}), now, "n")
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, ingest := newIngestTestServer(t)
+
+			w := httptest.NewRecorder()
+			ts.Handler().ServeHTTP(w, tt.req(t))
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d: %s", tt.status, w.Code, w.Body)
+			}
+			if ingest.Queued() != 0 {
+				t.Errorf("expected nothing queued, got %d", ingest.Queued())
+			}
+		})
+	}
+}
+
+func TestHandleIngestGame_Replay(t *testing.T) {
+	ts, ingest := newIngestTestServer(t)
+	body := ingestBody(t, "r-1", testPicks())
+	now := time.Now().Unix()
+
+	for i, want := range []int{http.StatusAccepted, http.StatusUnauthorized} {
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, ingestRequest(body, now, "same"))
+		if w.Code != want {
+			t.Fatalf("request %d: expected status %d, got %d: %s", i+1, want, w.Code, w.Body)
+		}
+	}
+	if ingest.Queued() != 1 {
+		t.Errorf("expected the replay not to be queued, got %d queued", ingest.Queued())
+	}
+}
+
+func TestHandleIngestGame_BacklogFull(t *testing.T) {
+	ts, _ := newIngestTestServer(t)
+	body := ingestBody(t, "", testPicks())
+	now := time.Now().Unix()
+
+	var last int
+	for i := range 100 {
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, ingestRequest(body, now, fmt.Sprintf("n-%d", i)))
+		if last = w.Code; last != http.StatusAccepted {
+			break
+		}
+	}
+	if last != http.StatusConflict {
+		t.Errorf("expected status %d once the backlog fills, got %d", http.StatusConflict, last)
+	}
+}
+
+func TestHandleIngestGame_Disabled(t *testing.T) {
+	ts := newTestServer(t)
+
+	w := httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, ingestRequest(ingestBody(t, "", testPicks()), time.Now().Unix(), "n"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
+	"github.com/aussiebroadwan/taboo/pkg/numwords"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// localizeEvent returns event data for a client in the locale negotiated
+// for its request: picks are spelled out in it where calls are on, and
+// notices use the operator's translation. Data for clients that didn't
+// negotiate a locale is returned as broadcast.
+func (s *Server) localizeEvent(ctx context.Context, data any) any {
+	locale, ok := i18n.FromContext(ctx)
+	if !ok {
+		return data
+	}
+
+	switch e := data.(type) {
+	case sdk.GamePickEvent:
+		if e.CallText == "" || locale == s.cfg.Game.CallLocale {
+			return data
+		}
+		if text, ok := numwords.Spell(locale, int(e.Pick)); ok {
+			e.CallText = text
+			return e
+		}
+	case sdk.ServerNoticeEvent:
+		if msg, ok := e.Translations[locale]; ok {
+			e.Message = msg
+			return e
+		}
+	}
+	return data
+}
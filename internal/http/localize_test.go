@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestLocalizeEvent(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Game.CallLocale = "en"
+
+	es := i18n.NewContext(context.Background(), "es")
+	notice := sdk.ServerNoticeEvent{Message: "Finals start soon", Translations: map[string]string{"es": "La final empieza pronto"}}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		data any
+		want any
+	}{
+		{"pick respelled", es, sdk.GamePickEvent{Pick: 42, CallText: "forty-two"}, sdk.GamePickEvent{Pick: 42, CallText: "cuarenta y dos"}},
+		{"pick without calls", es, sdk.GamePickEvent{Pick: 42}, sdk.GamePickEvent{Pick: 42}},
+		{"pick, no locale", context.Background(), sdk.GamePickEvent{Pick: 42, CallText: "forty-two"}, sdk.GamePickEvent{Pick: 42, CallText: "forty-two"}},
+		{"notice translated", es, notice, sdk.ServerNoticeEvent{Message: "La final empieza pronto", Translations: notice.Translations}},
+		{"notice, no translation", i18n.NewContext(context.Background(), "en"), notice, notice},
+		{"other events", es, sdk.GameCompleteEvent{GameID: 1}, sdk.GameCompleteEvent{GameID: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ts.localizeEvent(tt.ctx, tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestErrorMessagesTranslatable checks every fixed error message the API
+// sends is listed for translation, so catalogs can't silently fall behind.
+func TestErrorMessagesTranslatable(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, dir := range []string{".", "../../pkg/httpx"} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range files {
+			if strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			file, err := parser.ParseFile(fset, name, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || !isErrorConstructor(call.Fun) || len(call.Args) == 0 {
+					return true
+				}
+				lit, ok := call.Args[len(call.Args)-1].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				msg, _ := strconv.Unquote(lit.Value)
+				if !slices.Contains(i18n.Messages(), msg) {
+					t.Errorf("%s: %q is not in the i18n message catalog", fset.Position(lit.Pos()), msg)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// isErrorConstructor reports whether fn is one of the httpx.Err functions,
+// or storeError, which wraps them.
+func isErrorConstructor(fn ast.Expr) bool {
+	switch f := fn.(type) {
+	case *ast.SelectorExpr:
+		pkg, ok := f.X.(*ast.Ident)
+		return ok && pkg.Name == "httpx" && strings.HasPrefix(f.Sel.Name, "Err")
+	case *ast.Ident:
+		return strings.HasPrefix(f.Name, "Err") || f.Name == "storeError"
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleGetLogLevel handles GET /api/v1/admin/log-level
+func (s *Server) handleGetLogLevel(w http.ResponseWriter, r *http.Request) {
+	if s.logLevel == nil {
+		_ = httpx.WriteError(w, httpx.ErrNotFound("log level control is not enabled"))
+		return
+	}
+	s.writeLogLevel(w, r)
+}
+
+// handleSetLogLevel handles PUT /api/v1/admin/log-level. It changes the log
+// level, query logging, or both, until the server restarts, so an operator
+// can diagnose slow queries without a redeploy.
+func (s *Server) handleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	if s.logLevel == nil {
+		_ = httpx.WriteError(w, httpx.ErrNotFound("log level control is not enabled"))
+		return
+	}
+
+	var req sdk.LogLevelRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodySize)).Decode(&req); err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid request body"))
+		return
+	}
+
+	if req.Level != "" {
+		switch strings.ToLower(req.Level) {
+		case "debug", "info", "warn", "error":
+		default:
+			_ = httpx.WriteError(w, httpx.ErrInvalidParam("level", "level must be one of: debug, info, warn, error"))
+			return
+		}
+		s.logLevel.Set(slogx.ParseLevel(req.Level))
+	}
+	if req.LogQueries != nil {
+		s.logQueries.Store(*req.LogQueries)
+	}
+
+	slogx.FromContext(r.Context()).Warn("Log level changed",
+		slog.String("level", levelName(s.logLevel.Level())),
+		slog.Bool("log_queries", s.logQueries.Load()),
+	)
+
+	s.writeLogLevel(w, r)
+}
+
+func (s *Server) writeLogLevel(w http.ResponseWriter, r *http.Request) {
+	resp := sdk.LogLevelResponse{
+		Level:      levelName(s.logLevel.Level()),
+		LogQueries: s.logQueries.Load(),
+	}
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
+// levelName returns level as logging.level writes it.
+func levelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
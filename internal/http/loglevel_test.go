@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleSetLogLevel(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	level := new(slog.LevelVar)
+	logQueries := new(atomic.Bool)
+	ts.SetLogLevel(level, logQueries)
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/admin/log-level", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPut, `{"level":"debug","log_queries":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+	if level.Level() != slog.LevelDebug || !logQueries.Load() {
+		t.Errorf("expected debug with query logging, got %s, %v", level.Level(), logQueries.Load())
+	}
+
+	// Omitted fields are left as they are
+	w = do(http.MethodPut, `{"level":"WARN"}`)
+	var resp sdk.LogLevelResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Level != "warn" || !resp.LogQueries {
+		t.Errorf("expected warn with query logging, got %+v", resp)
+	}
+
+	w = do(http.MethodGet, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+
+	for _, body := range []string{`{"level":"trace"}`, `{"level":`} {
+		if w := do(http.MethodPut, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", body, http.StatusBadRequest, w.Code)
+		}
+	}
+	if level.Level() != slog.LevelWarn {
+		t.Errorf("expected a rejected change to leave warn, got %s", level.Level())
+	}
+}
+
+func TestHandleGetLogLevel_NotEnabled(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/log-level", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	w := httptest.NewRecorder()
+	ts.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
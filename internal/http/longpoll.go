@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// longPollTimeout is the maximum time a long-poll request waits for a new
+// event. It is kept below the default request timeout so the handler, not the
+// timeout middleware, ends the request.
+const longPollTimeout = 25 * time.Second
+
+// handleWaitEvents handles GET /api/v1/games/current/wait (long-poll endpoint).
+// It returns immediately if events newer than since_seq are retained,
+// otherwise it waits for the next event or until the poll times out.
+func (s *Server) handleWaitEvents(w http.ResponseWriter, r *http.Request) {
+	// Parse since_seq (default 0)
+	sinceSeq, apiErr := httpx.QueryInt[int64](r, "since_seq", 0, 0, math.MaxInt64)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+
+	// A sequence ahead of the server means the server restarted since the
+	// client last polled; resync from the retained events.
+	if sinceSeq > s.gameService.LastSeq() {
+		sinceSeq = 0
+	}
+
+	// Stay inside any deadline applied by the timeout middleware
+	wait := longPollTimeout
+	if deadline, ok := r.Context().Deadline(); ok {
+		if remaining := time.Until(deadline) - time.Second; remaining < wait {
+			wait = max(remaining, 0)
+		}
+	}
+
+	reqCtx := r.Context()
+	ctx, cancel := context.WithTimeout(reqCtx, wait)
+	defer cancel()
+
+	conn := s.gameService.Connect(service.TransportLongPoll, httpx.GetClientIP(r))
+	defer conn.Close(pubsub.SubscriptionStats{})
+
+	// Subscribe before checking retained events so nothing published in
+	// between is missed.
+	events := s.gameService.Subscribe(ctx)
+
+	if missed := s.gameService.EventsSince(sinceSeq); len(missed) > 0 {
+		s.writeWaitEvents(w, r, missed)
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Subscription ended because the poll timed out or the
+				// client went away.
+				if reqCtx.Err() != nil {
+					return
+				}
+				s.writeWaitEvents(w, r, s.gameService.EventsSince(sinceSeq))
+				return
+			}
+			if event.Seq > sinceSeq {
+				s.writeWaitEvents(w, r, []service.Event{event})
+				return
+			}
+		case <-ctx.Done():
+			if reqCtx.Err() != nil {
+				return
+			}
+			s.writeWaitEvents(w, r, s.gameService.EventsSince(sinceSeq))
+			return
+		}
+	}
+}
+
+// writeWaitEvents writes a long-poll response containing the given events.
+func (s *Server) writeWaitEvents(w http.ResponseWriter, r *http.Request, events []service.Event) {
+	resp := sdk.WaitEventsResponse{
+		Events:  make([]sdk.EventEnvelope, 0, len(events)),
+		LastSeq: s.gameService.LastSeq(),
+	}
+
+	for _, e := range events {
+		data, err := json.Marshal(s.localizeEvent(r.Context(), e.Data))
+		if err != nil {
+			_ = httpx.WriteError(w, httpx.ErrInternal("failed to encode event"))
+			return
+		}
+		resp.Events = append(resp.Events, sdk.EventEnvelope{
+			Seq:  e.Seq,
+			Type: e.Type,
+			Data: data,
+			Node: s.cfg.Server.NodeID,
+		})
+		if alias, ok := s.eventAliases[e.Type]; ok {
+			resp.Events = append(resp.Events, sdk.EventEnvelope{
+				Seq:  e.Seq,
+				Type: alias,
+				Data: data,
+				Node: s.cfg.Server.NodeID,
+			})
+		}
+	}
+
+	// Report the newest delivered sequence so clients never skip events
+	// published after this response was assembled.
+	if n := len(resp.Events); n > 0 {
+		resp.LastSeq = resp.Events[n-1].Seq
+	}
+
+	if s.eventAliases != nil {
+		httpx.Warn(w, legacyEventsWarning)
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleWaitEvents_ReturnsRetainedEvents(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.gameService.BroadcastPick(context.Background(), 7, 0)
+	ts.gameService.BroadcastPick(context.Background(), 8, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/current/wait?since_seq=1", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleWaitEvents(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp sdk.WaitEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(resp.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(resp.Events))
+	}
+	if resp.Events[0].Seq != 2 || resp.Events[0].Type != sdk.EventGamePick {
+		t.Errorf("unexpected event: %+v", resp.Events[0])
+	}
+	if resp.LastSeq != 2 {
+		t.Errorf("expected last_seq 2, got %d", resp.LastSeq)
+	}
+
+	decoded, err := resp.Events[0].Decode()
+	if err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if pick, ok := decoded.(sdk.GamePickEvent); !ok || pick.Pick != 8 {
+		t.Errorf("expected GamePickEvent{Pick: 8}, got %#v", decoded)
+	}
+}
+
+func TestHandleWaitEvents_LegacyEventNames(t *testing.T) {
+	ts := newTestServer(t)
+	ts.eventAliases = map[string]string{sdk.EventGamePick: "legacy:pick"}
+
+	ts.gameService.BroadcastPick(context.Background(), 7, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/current/wait?since_seq=0", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleWaitEvents(w, req)
+
+	var resp sdk.WaitEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// The renamed event is repeated under its old name with the same seq
+	if len(resp.Events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", resp.Events)
+	}
+	if resp.Events[0].Type != sdk.EventGamePick || resp.Events[1].Type != "legacy:pick" {
+		t.Errorf("expected game:pick then legacy:pick, got %s then %s", resp.Events[0].Type, resp.Events[1].Type)
+	}
+	if resp.Events[1].Seq != resp.Events[0].Seq || string(resp.Events[1].Data) != string(resp.Events[0].Data) {
+		t.Errorf("legacy copy differs from the event: %+v", resp.Events)
+	}
+	if resp.LastSeq != 1 {
+		t.Errorf("expected last_seq 1, got %d", resp.LastSeq)
+	}
+	if got := sdk.Warnings(w.Header()); len(got) != 1 || got[0] != legacyEventsWarning {
+		t.Errorf("expected the legacy events warning, got %q", got)
+	}
+}
+
+func TestHandleWaitEvents_WaitsForNextEvent(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/current/wait?since_seq=0", nil)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		ts.handleWaitEvents(w, req)
+		close(done)
+	}()
+
+	// Give the handler time to subscribe before broadcasting
+	time.Sleep(20 * time.Millisecond)
+	ts.gameService.BroadcastComplete(context.Background(), &domain.Game{ID: 99})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler did not return after event was broadcast")
+	}
+
+	var resp sdk.WaitEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Type != sdk.EventGameComplete {
+		t.Fatalf("expected one game:complete event, got %+v", resp.Events)
+	}
+}
+
+func TestHandleWaitEvents_Timeout(t *testing.T) {
+	ts := newTestServer(t)
+
+	// A near-expired request deadline bounds the poll duration
+	ctx, cancel := context.WithTimeout(context.Background(), 1100*time.Millisecond)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games/current/wait", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	ts.handleWaitEvents(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp sdk.WaitEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Events) != 0 {
+		t.Errorf("expected no events, got %d", len(resp.Events))
+	}
+}
+
+func TestHandleWaitEvents_InvalidSinceSeq(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, v := range []string{"abc", "-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/games/current/wait?since_seq="+v, nil)
+		w := httptest.NewRecorder()
+
+		ts.handleWaitEvents(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("since_seq=%s: expected status %d, got %d", v, http.StatusBadRequest, w.Code)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleBroadcastNotice handles POST /api/v1/admin/broadcast. The notice is
+// sent as a server:notice event to every client connected now; clients
+// connecting later don't see it.
+func (s *Server) handleBroadcastNotice(w http.ResponseWriter, r *http.Request) {
+	var req sdk.BroadcastNoticeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodySize)).Decode(&req); err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid request body"))
+		return
+	}
+	if req.TTLSeconds < 0 {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("ttl_seconds must not be negative"))
+		return
+	}
+
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	notice, err := s.gameService.BroadcastNotice(r.Context(), req.Message, req.Severity, ttl, req.Translations)
+	if err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest(err.Error()))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("Notice broadcast",
+		slog.String("severity", notice.Severity),
+		slog.Int64("ttl_seconds", notice.TTLSeconds),
+		slog.Int("subscribers", s.gameService.StreamStats().Subscribers),
+	)
+
+	if err := httpx.Respond(w, r, http.StatusOK, notice, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleBroadcastNotice(t *testing.T) {
+	ts := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := ts.gameService.Join(ctx)
+	if err != nil {
+		t.Fatalf("Join() error: %v", err)
+	}
+
+	body := `{"message":"Maintenance at 10pm","severity":"warning","ttl_seconds":600}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/broadcast", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	ts.handleBroadcastNotice(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+	want := sdk.ServerNoticeEvent{Message: "Maintenance at 10pm", Severity: sdk.NoticeWarning, TTLSeconds: 600}
+	var got sdk.ServerNoticeEvent
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("response = %+v, want %+v", got, want)
+	}
+
+	select {
+	case event := <-sub.C:
+		if event.Type != sdk.EventServerNotice || !reflect.DeepEqual(event.Data, want) {
+			t.Errorf("broadcast %s %+v, want %s %+v", event.Type, event.Data, sdk.EventServerNotice, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a server:notice event")
+	}
+}
+
+func TestHandleBroadcastNotice_Defaults(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/broadcast", strings.NewReader(`{"message":"Finals start soon"}`))
+	w := httptest.NewRecorder()
+	ts.handleBroadcastNotice(w, req)
+
+	var got sdk.ServerNoticeEvent
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.Severity != sdk.NoticeInfo || got.TTLSeconds != 300 {
+		t.Errorf("expected info severity and a 300s TTL, got %+v", got)
+	}
+}
+
+func TestHandleBroadcastNotice_Invalid(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not json", `{`},
+		{"no message", `{"severity":"info"}`},
+		{"long message", `{"message":"` + strings.Repeat("x", 281) + `"}`},
+		{"unknown severity", `{"message":"hi","severity":"urgent"}`},
+		{"negative ttl", `{"message":"hi","ttl_seconds":-1}`},
+		{"ttl too long", `{"message":"hi","ttl_seconds":90000}`},
+		{"unsupported translation", `{"message":"hi","translations":{"xx":"hi"}}`},
+		{"empty translation", `{"message":"hi","translations":{"es":""}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/broadcast", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			ts.handleBroadcastNotice(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+// ObservabilityServer is the observability listener: runtime metrics at
+// /debug/vars and, with observability.profiling, pprof under /debug/pprof/.
+// It runs on its own port so none of it is reachable through the public
+// server.
+type ObservabilityServer struct {
+	server          *http.Server
+	logger          *slog.Logger
+	shutdownTimeout time.Duration
+}
+
+// NewObservabilityServer creates the observability listener configured by
+// cfg.Observability.
+func NewObservabilityServer(cfg *config.Config, logger *slog.Logger) *ObservabilityServer {
+	return &ObservabilityServer{
+		server: &http.Server{
+			Addr:              cfg.Observability.Metrics.Addr(cfg.Server.Host),
+			Handler:           observabilityHandler(cfg.Observability),
+			ReadHeaderTimeout: cfg.Server.ReadTimeout.Duration(),
+		},
+		logger:          logger.With(slog.String("component", "observability")),
+		shutdownTimeout: cfg.Server.ShutdownTimeout.Duration(),
+	}
+}
+
+// observabilityHandler routes the observability listener's endpoints.
+func observabilityHandler(cfg config.ObservabilityConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	if cfg.Profiling.Enabled {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	return mux
+}
+
+// Run serves the observability endpoints until the context is cancelled.
+func (s *ObservabilityServer) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("Observability listener started", slog.String("addr", s.server.Addr))
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	//nolint:contextcheck // Intentionally using Background - parent ctx is cancelled during shutdown
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(shutdownCtx) //nolint:contextcheck // Intentionally using Background for shutdown
+}
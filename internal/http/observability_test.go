@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestObservabilityHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		profiling bool
+		path      string
+		want      int
+	}{
+		{"metrics", false, "/debug/vars", http.StatusOK},
+		{"pprof off", false, "/debug/pprof/", http.StatusNotFound},
+		{"pprof on", true, "/debug/pprof/", http.StatusOK},
+		{"pprof symbol", true, "/debug/pprof/symbol", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default().Observability
+			cfg.Metrics.Enabled = true
+			cfg.Profiling.Enabled = tt.profiling
+
+			w := httptest.NewRecorder()
+			observabilityHandler(cfg).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("GET %s: expected %d, got %d", tt.path, tt.want, w.Code)
+			}
+		})
+	}
+}
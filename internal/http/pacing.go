@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleGetPacing handles GET /api/v1/config/pacing. It reports the timing
+// the engine runs each game cycle with, derived from the game config.
+func (s *Server) handleGetPacing(w http.ResponseWriter, r *http.Request) {
+	pacing := service.NewPacing(&s.cfg.Game)
+
+	resp := sdk.PacingResponse{
+		PickCount:       len(pacing.Reveals),
+		DrawDurationMS:  pacing.Draw.Milliseconds(),
+		WaitDurationMS:  pacing.Wait.Milliseconds(),
+		RevealCurve:     pacing.Curve,
+		RevealOffsetsMS: make([]int64, 0, len(pacing.Reveals)),
+	}
+	for _, offset := range pacing.Reveals {
+		resp.RevealOffsetsMS = append(resp.RevealOffsetsMS, offset.Milliseconds())
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
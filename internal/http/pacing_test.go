@@ -0,0 +1,38 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleGetPacing(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/config/pacing", nil)
+	w := httptest.NewRecorder()
+	ts.handleGetPacing(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp sdk.PacingResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	game := ts.cfg.Game
+	if resp.PickCount != game.PickCount || len(resp.RevealOffsetsMS) != game.PickCount {
+		t.Fatalf("expected %d picks and offsets, got %+v", game.PickCount, resp)
+	}
+	if resp.DrawDurationMS != game.DrawDuration.Duration().Milliseconds() ||
+		resp.WaitDurationMS != game.WaitDuration.Duration().Milliseconds() {
+		t.Errorf("unexpected phase durations: %+v", resp)
+	}
+	if last := resp.RevealOffsetsMS[len(resp.RevealOffsetsMS)-1]; last != resp.DrawDurationMS {
+		t.Errorf("expected the last pick at the end of the draw, got %dms of %dms", last, resp.DrawDurationMS)
+	}
+}
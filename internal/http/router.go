@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+)
+
+// layer is a named middleware, so the chain wrapping each route can be
+// listed by taboo routes.
+type layer struct {
+	name string
+	wrap httpx.Middleware
+
+	// auth names the credential the layer requires, if it enforces one.
+	auth string
+}
+
+// RouteInfo describes a registered route and the middleware a request to it
+// passes through, outermost first.
+type RouteInfo struct {
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	Auth       string   `json:"auth"`
+	Middleware []string `json:"middleware"`
+}
+
+// router registers routes on a ServeMux, each wrapped in its own middleware
+// chain so routes opt in to timeouts, compression and auth declaratively
+// instead of global middleware skipping them by path.
+type router struct {
+	mux    *http.ServeMux
+	routes []RouteInfo
+}
+
+func newRouter() *router {
+	return &router{mux: http.NewServeMux()}
+}
+
+// handle registers h for pattern wrapped in layers, outermost first.
+func (rt *router) handle(pattern string, h http.Handler, layers ...layer) {
+	wrap, names := compose(layers)
+	rt.mux.Handle(pattern, wrap(h))
+
+	info := RouteInfo{Auth: "none", Middleware: names}
+	info.Method, info.Path, _ = strings.Cut(pattern, " ")
+	for _, l := range layers {
+		if l.auth != "" {
+			info.Auth = l.auth
+		}
+	}
+	rt.routes = append(rt.routes, info)
+}
+
+// handleFunc registers h for pattern wrapped in layers, outermost first.
+func (rt *router) handleFunc(pattern string, h http.HandlerFunc, layers ...layer) {
+	rt.handle(pattern, h, layers...)
+}
+
+// chain concatenates layer sets, for building route classes from shared
+// pieces.
+func chain(sets ...[]layer) []layer {
+	var out []layer
+	for _, set := range sets {
+		out = append(out, set...)
+	}
+	return out
+}
+
+// compose chains layers into a single middleware, returning it with the
+// layer names in order.
+func compose(layers []layer) (httpx.Middleware, []string) {
+	names := make([]string, len(layers))
+	wraps := make([]httpx.Middleware, len(layers))
+	for i, l := range layers {
+		names[i] = l.name
+		wraps[i] = l.wrap
+	}
+	return httpx.Chain(wraps...), names
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestServer_Routes(t *testing.T) {
+	ts := newTestServer(t)
+
+	chains := make(map[string][]string)
+	for _, r := range ts.Routes() {
+		chains[r.Method+" "+r.Path] = r.Middleware
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+		notWant []string
+	}{
+		{"GET /readyz", []string{"cors", "log(quiet)", "recover"}, []string{"ratelimit", "gzip", "usage"}},
+		{"GET /api/v1/games", []string{"cors", "usage", "ratelimit", "gzip", "timeout(30s)", "log", "recover"}, []string{"auth"}},
+		{"GET /api/v1/events", []string{"cors", "usage", "ratelimit", "log", "recover"}, []string{"gzip", "timeout(30s)"}},
+		{"PUT /api/v1/admin/hints", []string{"cors", "ratelimit", "log", "auth"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			chain, ok := chains[tt.pattern]
+			if !ok {
+				t.Fatalf("route %q not registered", tt.pattern)
+			}
+			for _, name := range tt.want {
+				if !slices.Contains(chain, name) {
+					t.Errorf("chain %s missing %q", strings.Join(chain, " > "), name)
+				}
+			}
+			for _, name := range tt.notWant {
+				if slices.Contains(chain, name) {
+					t.Errorf("chain %s should not contain %q", strings.Join(chain, " > "), name)
+				}
+			}
+		})
+	}
+
+	// Auth runs inside logging so rejected admin requests are still logged
+	admin := chains["PUT /api/v1/admin/hints"]
+	if slices.Index(admin, "auth") < slices.Index(admin, "log") {
+		t.Errorf("auth should run after log, got %s", strings.Join(admin, " > "))
+	}
+}
+
+func TestServer_RoutesAuth(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, r := range ts.Routes() {
+		want := "none"
+		switch {
+		case strings.HasPrefix(r.Path, "/api/v1/admin/"):
+			want = "bearer"
+		case strings.HasPrefix(r.Path, "/api/v1/ingest/"):
+			want = "hmac"
+		}
+		if r.Auth != want {
+			t.Errorf("%s %s auth = %q, want %q", r.Method, r.Path, r.Auth, want)
+		}
+	}
+}
+
+func TestServer_RoutesChaos(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Environment = "development"
+	ts.cfg.Chaos.Enabled = true
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	// Chaos wraps each route class rather than the mux, so stream routes
+	// are recognised by class instead of by path
+	for _, r := range ts.Routes() {
+		if !slices.Contains(r.Middleware, "chaos") {
+			t.Errorf("%s %s: chain %s missing chaos", r.Method, r.Path, strings.Join(r.Middleware, " > "))
+		}
+	}
+}
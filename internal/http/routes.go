@@ -1,18 +1,120 @@
 package http
 
-import "net/http"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// registerRoutes sets up all HTTP routes and the middleware each one runs
+// through. Routes are grouped into classes rather than skipping global
+// middleware by path: health probes are neither rate limited nor logged at
+// INFO, streams are not compressed or timed out, and admin routes add auth.
+// A new route only needs registering with the right class.
+func (s *Server) registerRoutes(rt *router) {
+	timeout := s.cfg.Server.RequestTimeout.Duration()
+
+	rateLimit := layer{name: "ratelimit", wrap: httpx.RateLimit(httpx.RateLimitConfig{
+		Rate:  s.cfg.Server.RateLimit,
+		Burst: s.cfg.Server.RateBurst,
+	})}
+	logged := layer{name: "log", wrap: slogx.Middleware(s.logger)}
+
+	// Usage is counted before rate limiting so rejected requests still show
+	// who the heavy consumers are
+	usage := layer{name: "usage", wrap: s.usage.middleware(false)}
+	streamUsage := layer{name: "usage", wrap: s.usage.middleware(true)}
+	recoverer := layer{name: "recover", wrap: httpx.Recoverer}
+
+	// Chaos wraps every route when enabled; streams are also disconnected
+	var chaos, streamChaos []layer
+	if s.chaosEnabled() {
+		chaos = []layer{{name: "chaos", wrap: httpx.Chaos(s.chaosConfig(nil))}}
+		streamChaos = []layer{{name: "chaos", wrap: httpx.Chaos(s.chaosConfig(httpx.MatchAll))}}
+	}
+
+	health := chain(chaos, []layer{{name: "log(quiet)", wrap: slogx.QuietMiddleware(s.logger)}, recoverer})
+	stream := chain(streamChaos, []layer{streamUsage, rateLimit, logged, recoverer})
+	api := chain(chaos, []layer{
+		usage,
+		rateLimit,
+		{name: "gzip", wrap: httpx.GzipWithConfig(httpx.GzipConfig{MinSize: s.cfg.Server.GzipMinSize})},
+		{name: fmt.Sprintf("timeout(%s)", timeout), wrap: httpx.Timeout(timeout)},
+		logged,
+		recoverer,
+	})
+	// Ingest requests are authenticated by their HMAC signature
+	ingest := chain(api, []layer{{
+		name: "auth",
+		wrap: s.requireIngestSignature,
+		auth: "hmac",
+	}})
+	// Every admin request is rejected when no token is configured
+	admin := chain(api, []layer{{
+		name: "auth",
+		wrap: httpx.RequireBearer(s.cfg.Server.AdminToken),
+		auth: "bearer",
+	}})
 
-// registerRoutes sets up all HTTP routes.
-func (s *Server) registerRoutes(mux *http.ServeMux) {
 	// Health endpoints
-	mux.HandleFunc("GET /livez", s.handleLivez)
-	mux.HandleFunc("GET /readyz", s.handleReadyz)
+	rt.handleFunc("GET /livez", s.handleLivez, health...)
+	rt.handleFunc("GET /readyz", s.handleReadyz, health...)
 
 	// API v1 endpoints
-	mux.HandleFunc("GET /api/v1/games", s.handleListGames)
-	mux.HandleFunc("GET /api/v1/games/{id}", s.handleGetGame)
-	mux.HandleFunc("GET /api/v1/events", s.handleEvents)
+	rt.handleFunc("GET /api/v1/games", s.handleListGames, api...)
+	rt.handleFunc("GET /api/v1/games/latest", s.handleGetLatestGame, api...)
+	rt.handleFunc("GET /api/v1/games/{id}", s.handleGetGame, api...)
+	rt.handleFunc("GET /api/v1/games/current/wait", s.handleWaitEvents, api...)
+	rt.handleFunc("GET /api/v1/events", s.handleEvents, stream...)
+	rt.handleFunc("GET /api/v1/seasons", s.handleListSeasons, api...)
+	rt.handleFunc("GET /api/v1/seasons/current", s.handleGetCurrentSeason, api...)
+	rt.handleFunc("GET /api/v1/seasons/{id}", s.handleGetSeason, api...)
+	rt.handleFunc("GET /api/v1/sync/games", s.handleSyncGames, api...)
+	rt.handleFunc("GET /api/v1/calls/{pick}", s.handleGetCall, api...)
+	rt.handleFunc("GET /api/v1/config/pacing", s.handleGetPacing, api...)
+	rt.handleFunc("GET /api/v1/stats/odds", s.handleGetOdds, api...)
+
+	// Ingest endpoints
+	rt.handleFunc("POST /api/v1/ingest/games", s.handleIngestGame, ingest...)
+
+	// Admin endpoints
+	rt.handleFunc("POST /api/v1/admin/seasons", s.handleRollSeason, admin...)
+	rt.handleFunc("GET /api/v1/admin/hints", s.handleGetHints, admin...)
+	rt.handleFunc("PUT /api/v1/admin/hints", s.handleSetHints, admin...)
+	rt.handleFunc("GET /api/v1/admin/settings", s.handleListSettings, admin...)
+	rt.handleFunc("PUT /api/v1/admin/settings/{key}", s.handleSetSetting, admin...)
+	rt.handleFunc("DELETE /api/v1/admin/settings/{key}", s.handleDeleteSetting, admin...)
+	rt.handleFunc("GET /api/v1/admin/specials", s.handleListSpecials, admin...)
+	rt.handleFunc("POST /api/v1/admin/specials", s.handleScheduleSpecial, admin...)
+	rt.handleFunc("DELETE /api/v1/admin/specials/{id}", s.handleCancelSpecial, admin...)
+	rt.handleFunc("GET /api/v1/admin/cluster", s.handleCluster, admin...)
+	rt.handleFunc("POST /api/v1/admin/broadcast", s.handleBroadcastNotice, admin...)
+	rt.handleFunc("GET /api/v1/admin/db/stats", s.handleDBStats, admin...)
+	rt.handleFunc("GET /api/v1/admin/usage", s.handleUsage, admin...)
+	rt.handleFunc("GET /api/v1/admin/log-level", s.handleGetLogLevel, admin...)
+	rt.handleFunc("PUT /api/v1/admin/log-level", s.handleSetLogLevel, admin...)
 
 	// Static files (catch-all, must be last)
-	mux.Handle("GET /", s.staticHandler())
+	rt.handle("GET /", s.staticHandler(), api...)
+}
+
+// chaosEnabled reports whether fault injection is on. Chaos is
+// development-only; config validation rejects it elsewhere.
+func (s *Server) chaosEnabled() bool {
+	return s.cfg.Chaos.Enabled && strings.EqualFold(s.cfg.Environment, "development")
+}
+
+// chaosConfig builds the fault injection settings for a route class,
+// disconnecting the requests stream selects.
+func (s *Server) chaosConfig(stream httpx.RequestMatcher) httpx.ChaosConfig {
+	return httpx.ChaosConfig{
+		LatencyMin:        s.cfg.Chaos.LatencyMin.Duration(),
+		LatencyMax:        s.cfg.Chaos.LatencyMax.Duration(),
+		ErrorRate:         s.cfg.Chaos.ErrorRate,
+		RouteErrorRates:   s.cfg.Chaos.RouteErrorRates,
+		Stream:            stream,
+		SSEDisconnectRate: s.cfg.Chaos.SSEDisconnectRate,
+	}
 }
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleListSeasons handles GET /api/v1/seasons
+func (s *Server) handleListSeasons(w http.ResponseWriter, r *http.Request) {
+	seasons, err := s.gameService.ListSeasons(r.Context())
+	if err != nil {
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch seasons"))
+		return
+	}
+
+	resp := sdk.SeasonListResponse{
+		Seasons: make([]sdk.Season, 0, len(seasons)),
+	}
+	for _, season := range seasons {
+		resp.Seasons = append(resp.Seasons, toSDKSeason(season))
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
+// handleGetCurrentSeason handles GET /api/v1/seasons/current
+func (s *Server) handleGetCurrentSeason(w http.ResponseWriter, r *http.Request) {
+	season, err := s.gameService.CurrentSeason(r.Context())
+	if err != nil {
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch current season"))
+		return
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, toSDKSeason(season), sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
+			slogx.Error(err),
+			slog.Int64("season_id", season.ID),
+		)
+	}
+}
+
+// handleGetSeason handles GET /api/v1/seasons/{id}
+func (s *Server) handleGetSeason(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id < 1 {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid season ID"))
+		return
+	}
+
+	season, err := s.gameService.GetSeason(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			_ = httpx.WriteError(w, httpx.ErrNotFound(fmt.Sprintf("season %d not found", id)))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch season"))
+		return
+	}
+
+	// A closed season's game range is fixed
+	s.setCacheHeaders(w, !season.Current(), keyGames, seasonKey(season.ID))
+
+	if err := httpx.Respond(w, r, http.StatusOK, toSDKSeason(season), sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
+			slogx.Error(err),
+			slog.Int64("season_id", id),
+		)
+	}
+}
+
+// handleRollSeason handles POST /api/v1/admin/seasons. It closes the
+// current season and starts a new one from the next game. The body is
+// optional.
+func (s *Server) handleRollSeason(w http.ResponseWriter, r *http.Request) {
+	var req sdk.RollSeasonRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodySize)).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid request body"))
+		return
+	}
+
+	season, err := s.gameService.RollSeason(r.Context(), req.Name)
+	if err != nil {
+		if errors.Is(err, service.ErrSeasonEmpty) {
+			_ = httpx.WriteError(w, httpx.ErrConflict("current season has no games yet"))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to start new season"))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("Season started",
+		slog.Int64("season_id", season.ID),
+		slog.String("name", season.Name),
+		slog.Int64("first_game_id", season.FirstGameID),
+	)
+
+	if err := httpx.Respond(w, r, http.StatusCreated, toSDKSeason(season), sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
+			slogx.Error(err),
+			slog.Int64("season_id", season.ID),
+		)
+	}
+}
+
+// toSDKSeason converts a domain season to its API representation.
+func toSDKSeason(season *domain.Season) sdk.Season {
+	return sdk.Season{
+		ID:          season.ID,
+		Name:        season.Name,
+		FirstGameID: season.FirstGameID,
+		EndGameID:   season.EndGameID,
+		Current:     season.Current(),
+		StartedAt:   season.StartedAt,
+	}
+}
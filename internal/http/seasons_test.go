@@ -0,0 +1,192 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// seedSeasons adds games 1-10 split across season 1 (1-5) and season 2 (6-).
+func seedSeasons(ts *testServer) {
+	for i := int64(1); i <= 10; i++ {
+		ts.mockStore.games[i] = &domain.Game{ID: i, Picks: testPicks(), CreatedAt: time.Now()}
+	}
+	ts.mockStore.latestGame = ts.mockStore.games[10]
+	ts.mockStore.seasons[0].EndGameID = 6
+	ts.mockStore.seasons = append(ts.mockStore.seasons, &domain.Season{ID: 2, Name: "Season 2", FirstGameID: 6})
+}
+
+func TestHandleListGames_Season(t *testing.T) {
+	ts := newTestServer(t)
+	seedSeasons(ts)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games?season=1", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleListGames(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp sdk.GameListResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Season != 1 {
+		t.Errorf("expected season 1, got %d", resp.Season)
+	}
+	if len(resp.Games) == 0 {
+		t.Fatal("expected some games in response")
+	}
+	for _, g := range resp.Games {
+		if g.ID >= 6 {
+			t.Errorf("game %d is outside season 1", g.ID)
+		}
+	}
+	if resp.NextCursor != nil {
+		t.Errorf("expected no next cursor at the end of the season, got %d", *resp.NextCursor)
+	}
+}
+
+func TestHandleListGames_AllSeasons(t *testing.T) {
+	ts := newTestServer(t)
+	seedSeasons(ts)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleListGames(w, req)
+
+	var resp map[string]json.RawMessage
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if season, ok := resp["season"]; ok {
+		t.Errorf("expected no season across all seasons, got %s", season)
+	}
+}
+
+func TestHandleListGames_InvalidSeason(t *testing.T) {
+	tests := []struct {
+		name   string
+		season string
+		want   int
+	}{
+		{"not a number", "abc", http.StatusBadRequest},
+		{"zero", "0", http.StatusBadRequest},
+		{"unknown", "9", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/games?season="+tt.season, nil)
+			w := httptest.NewRecorder()
+
+			ts.handleListGames(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandleListSeasons(t *testing.T) {
+	ts := newTestServer(t)
+	seedSeasons(ts)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/seasons", nil)
+	w := httptest.NewRecorder()
+
+	ts.handleListSeasons(w, req)
+
+	var resp sdk.SeasonListResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Seasons) != 2 {
+		t.Fatalf("expected 2 seasons, got %d", len(resp.Seasons))
+	}
+	if resp.Seasons[0].Current || resp.Seasons[0].EndGameID != 6 {
+		t.Errorf("expected season 1 to be archived ending at game 6, got %+v", resp.Seasons[0])
+	}
+	if !resp.Seasons[1].Current {
+		t.Errorf("expected season 2 to be current, got %+v", resp.Seasons[1])
+	}
+}
+
+func TestHandleGetSeason_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/seasons/9", nil)
+	w := httptest.NewRecorder()
+
+	ts.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleRollSeason(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+	ts.mockStore.latestGame = &domain.Game{ID: 4, Picks: testPicks(), CreatedAt: time.Now()}
+
+	roll := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/seasons", strings.NewReader(`{"name":"Winter"}`))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := roll(""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d without a token, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	w := roll("s3cret")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body)
+	}
+
+	var season sdk.Season
+	if err := json.NewDecoder(w.Body).Decode(&season); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if season.ID != 2 || season.Name != "Winter" || season.FirstGameID != 5 || !season.Current {
+		t.Errorf("unexpected season: %+v", season)
+	}
+
+	// No games have been played in the new season yet
+	if w := roll("s3cret"); w.Code != http.StatusConflict {
+		t.Errorf("expected status %d for an empty season, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestHandleRollSeason_DisabledWithoutToken(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/seasons", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+
+	ts.Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
@@ -6,14 +6,21 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"slices"
+	"sync/atomic"
 
 	"github.com/aussiebroadwan/taboo/internal/config"
 	"github.com/aussiebroadwan/taboo/internal/service"
 	"github.com/aussiebroadwan/taboo/internal/store"
 	"github.com/aussiebroadwan/taboo/pkg/httpx"
 	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+	"golang.org/x/sync/singleflight"
 )
 
+// maxAdminBodySize bounds admin request bodies.
+const maxAdminBodySize = 16 << 10
+
 // Server represents the HTTP server.
 type Server struct {
 	server      *http.Server
@@ -21,43 +28,87 @@ type Server struct {
 	store       store.Store
 	cfg         *config.Config
 	gameService *service.GameService
+	settings    *service.SettingsService
+	specials    *service.SpecialSchedule
 	engine      *service.Engine
+
+	// mirror, if set, replaces the engine in readiness checks on a
+	// read-only replica.
+	mirror *service.Mirror
+
+	// workers, if set, reports the health of the long-running workers for
+	// readiness checks.
+	workers func() (map[string]string, bool)
+
+	// logLevel and logQueries, if set, are changed by the admin log-level
+	// endpoints.
+	logLevel   *slog.LevelVar
+	logQueries *atomic.Bool
+
+	// ingest, if set, receives results pushed to the ingest endpoint;
+	// nonces guards it against replayed requests.
+	ingest *service.IngestSource
+	nonces *nonceCache
+
+	// flight coalesces concurrent identical reads in the games handlers.
+	flight singleflight.Group
+
+	// peers records the mirrors seen syncing from this node.
+	peers *peerTracker
+
+	// usage aggregates requests per client and route for the admin API,
+	// and viewers counts the clients connected for game events.
+	usage   *usageTracker
+	viewers *service.Viewers
+
+	// eventAliases maps renamed event types to the old names they are also
+	// sent under, or is nil when server.legacy_event_names is off.
+	eventAliases map[string]string
+
+	// global and routes record the middleware chains for Routes.
+	global []string
+	routes []RouteInfo
 }
 
 // NewServer creates a new HTTP server.
-func NewServer(cfg *config.Config, logger *slog.Logger, store store.Store, gameService *service.GameService, engine *service.Engine) *Server {
+func NewServer(cfg *config.Config, logger *slog.Logger, store store.Store, gameService *service.GameService, settings *service.SettingsService, engine *service.Engine) *Server {
 	s := &Server{
 		logger:      logger,
 		store:       store,
 		cfg:         cfg,
 		gameService: gameService,
+		settings:    settings,
+		specials:    service.NewSpecialSchedule(settings),
 		engine:      engine,
+		usage:       newUsageTracker(),
+		peers:       newPeerTracker(),
+		viewers:     service.NewViewers(),
+		nonces:      newNonceCache(cfg.Game.External.IngestMaxSkew.Duration()),
+	}
+	if cfg.Server.LegacyEventNames {
+		s.eventAliases = sdk.LegacyEventTypes()
 	}
 
-	mux := http.NewServeMux()
-	s.registerRoutes(mux)
-
-	// Configure CORS
-	corsConfig := httpx.CORSFromConfig(cfg.Environment, cfg.Server.CORSOrigins)
+	rt := newRouter()
+	s.registerRoutes(rt)
+	s.routes = rt.routes
 
-	// Configure rate limiting
-	rateLimitConfig := httpx.RateLimitConfig{
-		Rate:  cfg.Server.RateLimit,
-		Burst: cfg.Server.RateBurst,
+	// Server-wide middleware runs for every request, before routing
+	global := []layer{
+		{name: "cors", wrap: httpx.CORS(httpx.CORSFromConfig(cfg.Environment, cfg.Server.CORSOrigins))},
+		{name: "language", wrap: httpx.Language},
+	}
+	if cfg.Server.NodeID != "" {
+		global = append([]layer{{name: "node", wrap: httpx.SetHeader(sdk.NodeHeader, cfg.Server.NodeID)}}, global...)
 	}
 
-	// SSE endpoint should skip timeout and gzip
-	sseEndpoint := "/api/v1/events"
+	if s.chaosEnabled() {
+		logger.Warn("Chaos enabled, injecting faults into requests")
+	}
 
-	// Apply middleware chain
-	handler := httpx.Chain(
-		httpx.CORS(corsConfig),
-		httpx.RateLimit(rateLimitConfig),
-		httpx.Gzip(sseEndpoint),
-		httpx.TimeoutWithSkip(cfg.Server.RequestTimeout.Duration(), sseEndpoint),
-		slogx.Middleware(logger, "/livez", "/readyz"),
-		httpx.Recoverer,
-	)(mux)
+	wrap, names := compose(global)
+	s.global = names
+	handler := wrap(rt.mux)
 
 	s.server = &http.Server{
 		Addr:         cfg.Server.Addr(),
@@ -74,6 +125,42 @@ func (s *Server) Handler() http.Handler {
 	return s.server.Handler
 }
 
+// Routes lists the registered routes with the full middleware chain each
+// runs through, server-wide middleware first.
+func (s *Server) Routes() []RouteInfo {
+	routes := make([]RouteInfo, len(s.routes))
+	for i, r := range s.routes {
+		r.Middleware = append(slices.Clone(s.global), r.Middleware...)
+		routes[i] = r
+	}
+	return routes
+}
+
+// SetMirror marks the server as a read-only replica kept in sync by m, which
+// /readyz then checks in place of the game engine.
+func (s *Server) SetMirror(m *service.Mirror) {
+	s.mirror = m
+}
+
+// SetWorkers adds the long-running workers reported by health, with the
+// status of each and whether all are healthy, to readiness checks.
+func (s *Server) SetWorkers(health func() (map[string]string, bool)) {
+	s.workers = health
+}
+
+// SetLogLevel enables the admin log-level endpoints, changing level and
+// whether the store logs its queries.
+func (s *Server) SetLogLevel(level *slog.LevelVar, logQueries *atomic.Bool) {
+	s.logLevel = level
+	s.logQueries = logQueries
+}
+
+// SetIngest enables POST /api/v1/ingest/games, queueing verified results
+// on src for the engine to draw.
+func (s *Server) SetIngest(src *service.IngestSource) {
+	s.ingest = src
+}
+
 // Run starts the HTTP server and blocks until the context is cancelled.
 // It performs graceful shutdown when the context is cancelled.
 func (s *Server) Run(ctx context.Context) error {
@@ -83,6 +170,17 @@ func (s *Server) Run(ctx context.Context) error {
 		return ctx
 	}
 
+	if s.cfg.Cache.Enabled && s.cfg.Cache.PurgeURL != "" {
+		go s.runPurger(ctx)
+	}
+
+	// Consume connection events for the viewer count and the audit log
+	go s.viewers.Run(s.gameService.SubscribeConnections(ctx))
+	go service.LogConnections(
+		slogx.NewContext(ctx, s.logger.With(slog.String("component", "audit"))),
+		s.gameService.SubscribeConnections(ctx),
+	)
+
 	// Start server in a goroutine
 	errCh := make(chan error, 1)
 	go func() {
@@ -115,3 +213,13 @@ func (s *Server) Run(ctx context.Context) error {
 	s.logger.Info("HTTP server stopped")
 	return nil
 }
+
+// storeError returns the API error for a failed store call. A query that
+// timed out is a 503, as the store is overloaded rather than broken and the
+// request may succeed on retry; anything else is a 500 with message.
+func storeError(err error, message string) *httpx.APIError {
+	if errors.Is(err, store.ErrTimeout) {
+		return httpx.ErrUnavailable("database query timed out, retry later")
+	}
+	return httpx.ErrInternal(message)
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleListSettings handles GET /api/v1/admin/settings
+func (s *Server) handleListSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := s.settings.List(r.Context())
+	if err != nil {
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch settings"))
+		return
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, sdk.SettingsResponse{Settings: settings}, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
+// handleSetSetting handles PUT /api/v1/admin/settings/{key}. The body is
+// the setting's JSON value.
+func (s *Server) handleSetSetting(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAdminBodySize))
+	if err != nil || !json.Valid(body) {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("request body must be a JSON value"))
+		return
+	}
+	value := json.RawMessage(body)
+
+	if err := s.settings.Set(r.Context(), key, value); err != nil {
+		if errors.Is(err, service.ErrInvalidSetting) {
+			_ = httpx.WriteError(w, httpx.ErrBadRequest(err.Error()))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to set setting"))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("Setting updated", slog.String("key", key))
+
+	if err := httpx.Respond(w, r, http.StatusOK, sdk.Setting{Key: key, Value: value}, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
+			slogx.Error(err),
+			slog.String("key", key),
+		)
+	}
+}
+
+// handleDeleteSetting handles DELETE /api/v1/admin/settings/{key}
+func (s *Server) handleDeleteSetting(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+
+	if err := s.settings.Delete(r.Context(), key); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			_ = httpx.WriteError(w, httpx.ErrNotFound(fmt.Sprintf("setting %q not found", key)))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to delete setting"))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("Setting deleted", slog.String("key", key))
+
+	w.WriteHeader(http.StatusNoContent)
+}
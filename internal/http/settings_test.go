@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleSettings(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do(http.MethodPut, "/api/v1/admin/settings/maintenance.enabled", `true`); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+
+	w := do(http.MethodGet, "/api/v1/admin/settings", "")
+	var resp sdk.SettingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if string(resp.Settings["maintenance.enabled"]) != "true" {
+		t.Errorf("expected maintenance.enabled=true, got %s", resp.Settings["maintenance.enabled"])
+	}
+
+	if w := do(http.MethodDelete, "/api/v1/admin/settings/maintenance.enabled", ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := do(http.MethodDelete, "/api/v1/admin/settings/maintenance.enabled", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d deleting a missing setting, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleSetSetting_Invalid(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	tests := []struct {
+		name string
+		key  string
+		body string
+	}{
+		{"not json", "feature.x", `{`},
+		{"bad key", "Feature X", `true`},
+		{"hints wrong shape", "ui.hints", `["banner"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/settings/"+strings.ReplaceAll(tt.key, " ", "%20"), strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer s3cret")
+			w := httptest.NewRecorder()
+
+			ts.Handler().ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// handleListSpecials handles GET /api/v1/admin/specials
+func (s *Server) handleListSpecials(w http.ResponseWriter, r *http.Request) {
+	specials, err := s.specials.List(r.Context())
+	if err != nil {
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch special games"))
+		return
+	}
+
+	resp := sdk.SpecialGameListResponse{
+		Specials: make([]sdk.SpecialGame, 0, len(specials)),
+	}
+	for _, special := range specials {
+		resp.Specials = append(resp.Specials, toSDKSpecialGame(special))
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
+// handleScheduleSpecial handles POST /api/v1/admin/specials. The special
+// runs in place of the regular game due at starts_at, which must be in the
+// future.
+func (s *Server) handleScheduleSpecial(w http.ResponseWriter, r *http.Request) {
+	var req sdk.ScheduleSpecialRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodySize)).Decode(&req); err != nil {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid request body"))
+		return
+	}
+
+	special := &domain.SpecialGame{
+		Name:     req.Name,
+		StartsAt: req.StartsAt,
+		Branding: req.Branding,
+		Payouts:  req.Payouts,
+	}
+	if err := s.specials.Add(r.Context(), special); err != nil {
+		if errors.Is(err, service.ErrInvalidSpecial) {
+			_ = httpx.WriteError(w, httpx.ErrBadRequest(err.Error()))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to schedule special game"))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("Special game scheduled",
+		slog.Int64("special_id", special.ID),
+		slog.String("name", special.Name),
+		slog.Time("starts_at", special.StartsAt),
+	)
+
+	if err := httpx.Respond(w, r, http.StatusCreated, toSDKSpecialGame(special), sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response",
+			slogx.Error(err),
+			slog.Int64("special_id", special.ID),
+		)
+	}
+}
+
+// handleCancelSpecial handles DELETE /api/v1/admin/specials/{id}
+func (s *Server) handleCancelSpecial(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id < 1 {
+		_ = httpx.WriteError(w, httpx.ErrBadRequest("invalid special game ID"))
+		return
+	}
+
+	if err := s.specials.Cancel(r.Context(), id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			_ = httpx.WriteError(w, httpx.ErrNotFound(fmt.Sprintf("special game %d not found", id)))
+			return
+		}
+		_ = httpx.WriteError(w, storeError(err, "failed to cancel special game"))
+		return
+	}
+
+	slogx.FromContext(r.Context()).Info("Special game cancelled", slog.Int64("special_id", id))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toSDKSpecialGame converts a domain special game to its API representation.
+func toSDKSpecialGame(special *domain.SpecialGame) sdk.SpecialGame {
+	return sdk.SpecialGame{
+		ID:       special.ID,
+		Name:     special.Name,
+		StartsAt: special.StartsAt,
+		Branding: special.Branding,
+		Payouts:  special.Payouts,
+	}
+}
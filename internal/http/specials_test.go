@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleSpecials(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	startsAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	body := fmt.Sprintf(`{"name":"Summer Finals","starts_at":%q,"branding":{"accent":"#EF8E3C"},"payouts":{"multiplier":"2"}}`, startsAt)
+	w := do(http.MethodPost, "/api/v1/admin/specials", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body)
+	}
+	var created sdk.SpecialGame
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID != 1 || created.Name != "Summer Finals" || created.Branding["accent"] != "#EF8E3C" {
+		t.Errorf("unexpected special game: %+v", created)
+	}
+
+	w = do(http.MethodGet, "/api/v1/admin/specials", "")
+	var list sdk.SpecialGameListResponse
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Specials) != 1 || list.Specials[0].Payouts["multiplier"] != "2" {
+		t.Errorf("expected the scheduled special, got %+v", list.Specials)
+	}
+
+	if w := do(http.MethodDelete, "/api/v1/admin/specials/1", ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if w := do(http.MethodDelete, "/api/v1/admin/specials/1", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d cancelling a missing special, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleScheduleSpecial_Invalid(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not json", `{`},
+		{"no name", `{"starts_at":"2099-01-01T00:00:00Z"}`},
+		{"in the past", `{"name":"Finals","starts_at":"2000-01-01T00:00:00Z"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/specials", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			ts.handleScheduleSpecial(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body)
+			}
+		})
+	}
+}
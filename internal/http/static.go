@@ -1,29 +1,42 @@
 package http
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
 	"io/fs"
 	"log/slog"
 	"net/http"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/aussiebroadwan/taboo/internal/frontend"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
 	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
 )
 
 // staticHandler returns an http.Handler that serves static files from the
-// embedded frontend filesystem with SPA fallback support.
+// embedded frontend filesystem with SPA fallback support. Binaries built
+// without the frontend, or whose embedded frontend can't be served, such as
+// one built before the frontend was, serve a service descriptor instead.
 func (s *Server) staticHandler() http.Handler {
 	frontendFS, err := frontend.GetFS()
+	if errors.Is(err, frontend.ErrNotEmbedded) {
+		return http.HandlerFunc(s.handleDescriptor)
+	}
+	if err == nil {
+		_, err = fs.Stat(frontendFS, "index.html")
+	}
 	if err != nil {
-		s.logger.Error("Failed to get frontend filesystem",
+		s.logger.Warn("Frontend not available, serving a status page at /",
 			slogx.Error(err),
 			slog.String("component", "frontend"),
 		)
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			http.Error(w, "Frontend not available", http.StatusInternalServerError)
-		})
+		return http.HandlerFunc(s.handleDescriptor)
 	}
 
 	return &spaHandler{
@@ -31,10 +44,44 @@ func (s *Server) staticHandler() http.Handler {
 	}
 }
 
+// handleDescriptor handles GET / on servers without a frontend, describing
+// the service and its endpoints. Browsers get a status page with the
+// current game and the public endpoints instead. Any other unmatched path
+// is not found.
+func (s *Server) handleDescriptor(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		_ = httpx.WriteError(w, httpx.ErrNotFound("not found"))
+		return
+	}
+	if acceptsHTML(r) {
+		s.writeStatusPage(w, r)
+		return
+	}
+
+	desc := sdk.ServiceDescriptor{Name: "taboo", Endpoints: []sdk.Endpoint{}}
+	for _, route := range s.routes {
+		if route.Path == "/" {
+			continue
+		}
+		desc.Endpoints = append(desc.Endpoints, sdk.Endpoint{
+			Method: route.Method,
+			Path:   route.Path,
+			Auth:   route.Auth,
+		})
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, desc, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
+
 // spaHandler serves static files with SPA fallback.
 // Unknown paths that don't match a file return index.html.
 type spaHandler struct {
 	fs fs.FS
+
+	// etags caches the ETag of each file served, by path
+	etags sync.Map
 }
 
 func (h *spaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -86,18 +133,7 @@ func (h *spaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", contentType)
 	}
 
-	// Serve the file
-	if seeker, ok := file.(io.ReadSeeker); ok {
-		http.ServeContent(w, r, stat.Name(), stat.ModTime(), seeker)
-	} else {
-		w.WriteHeader(http.StatusOK)
-		if _, err := io.Copy(w, file); err != nil {
-			slogx.FromContext(r.Context()).Debug("Failed to copy file to response",
-				slogx.Error(err),
-				slog.String("file_path", filePath),
-			)
-		}
-	}
+	h.serveContent(w, r, filePath, file, stat)
 }
 
 // serveIndex serves the index.html file for SPA routing.
@@ -119,14 +155,61 @@ func (h *spaHandler) serveIndex(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
-	if seeker, ok := file.(io.ReadSeeker); ok {
-		http.ServeContent(w, r, stat.Name(), stat.ModTime(), seeker)
-	} else {
-		w.WriteHeader(http.StatusOK)
-		if _, err := io.Copy(w, file); err != nil {
-			slogx.FromContext(r.Context()).Debug("Failed to copy index.html to response", slogx.Error(err))
+	h.serveContent(w, r, "index.html", file, stat)
+}
+
+// serveContent serves file through http.ServeContent, so Range requests
+// and conditional GETs work for every file. Files that can't seek are read
+// into memory first; embedded files are small enough for that. Embedded
+// files have no modification time, so each carries an ETag of its content
+// for conditional GETs instead.
+func (h *spaHandler) serveContent(w http.ResponseWriter, r *http.Request, filePath string, file fs.File, stat fs.FileInfo) {
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			slogx.FromContext(r.Context()).Warn("Failed to read file",
+				slogx.Error(err),
+				slog.String("file_path", filePath),
+			)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
 		}
+		content = bytes.NewReader(data)
+	}
+
+	etag, err := h.etag(filePath, content)
+	if err != nil {
+		slogx.FromContext(r.Context()).Debug("Failed to hash file for ETag",
+			slogx.Error(err),
+			slog.String("file_path", filePath),
+		)
+	} else {
+		w.Header().Set("ETag", etag)
 	}
+
+	http.ServeContent(w, r, stat.Name(), stat.ModTime(), content)
+}
+
+// etag returns the ETag of the file at filePath, hashing content the first
+// time and leaving it rewound. The files can't change while the server
+// runs, so each is hashed once.
+func (h *spaHandler) etag(filePath string, content io.ReadSeeker) (string, error) {
+	if etag, ok := h.etags.Load(filePath); ok {
+		return etag.(string), nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	h.etags.Store(filePath, etag)
+	return etag, nil
 }
 
 // setCacheHeaders sets appropriate cache headers based on file type.
@@ -1,11 +1,17 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
 	"io"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"testing/fstest"
+
+	"github.com/aussiebroadwan/taboo/sdk"
 )
 
 func TestSpaHandler_ServeIndex(t *testing.T) {
@@ -212,6 +218,70 @@ func TestSpaHandler_MissingIndex(t *testing.T) {
 	}
 }
 
+// noSeekFS hides the Seek method of the files it opens, like filesystems
+// that stream their content.
+type noSeekFS struct {
+	fs.FS
+}
+
+func (f noSeekFS) Open(name string) (fs.File, error) {
+	file, err := f.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return struct{ fs.File }{file}, nil
+}
+
+func TestSpaHandler_RangeWithoutSeek(t *testing.T) {
+	handler := &spaHandler{fs: noSeekFS{fstest.MapFS{
+		"index.html":     &fstest.MapFile{Data: []byte("<!DOCTYPE html><html></html>")},
+		"media/call.mp3": &fstest.MapFile{Data: []byte("0123456789")},
+	}}}
+
+	req := httptest.NewRequest(http.MethodGet, "/media/call.mp3", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPartialContent {
+		t.Fatalf("expected status %d, got %d", http.StatusPartialContent, w.Code)
+	}
+	if body := w.Body.String(); body != "2345" {
+		t.Errorf("expected bytes 2-5, got %q", body)
+	}
+	if cr := w.Header().Get("Content-Range"); cr != "bytes 2-5/10" {
+		t.Errorf("expected Content-Range bytes 2-5/10, got %q", cr)
+	}
+}
+
+func TestSpaHandler_ConditionalGet(t *testing.T) {
+	for name, fsys := range map[string]fs.FS{
+		"seekable":   fstest.MapFS{"logo.svg": &fstest.MapFile{Data: []byte("<svg/>")}},
+		"unseekable": noSeekFS{fstest.MapFS{"logo.svg": &fstest.MapFile{Data: []byte("<svg/>")}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			handler := &spaHandler{fs: fsys}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logo.svg", nil))
+			etag := w.Header().Get("ETag")
+			if w.Code != http.StatusOK || etag == "" {
+				t.Fatalf("expected 200 with an ETag, got %d with %q", w.Code, etag)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/logo.svg", nil)
+			req.Header.Set("If-None-Match", etag)
+			w = httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusNotModified {
+				t.Errorf("expected status %d, got %d", http.StatusNotModified, w.Code)
+			}
+		})
+	}
+}
+
 func TestIsHashedAsset(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -267,3 +337,75 @@ func TestGetContentType(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleDescriptor(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	ts.handleDescriptor(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var desc sdk.ServiceDescriptor
+	if err := json.NewDecoder(w.Body).Decode(&desc); err != nil {
+		t.Fatalf("failed to decode descriptor: %v", err)
+	}
+	if desc.Name != "taboo" || desc.Frontend {
+		t.Errorf("unexpected descriptor: %+v", desc)
+	}
+
+	found := false
+	for _, e := range desc.Endpoints {
+		if e.Path == "/" {
+			t.Error("descriptor should not list the catch-all route")
+		}
+		if e.Method == http.MethodGet && e.Path == "/api/v1/games" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected GET /api/v1/games in endpoints, got %+v", desc.Endpoints)
+	}
+
+	// Unknown paths are not answered with the descriptor
+	req = httptest.NewRequest(http.MethodGet, "/index.html", nil)
+	w = httptest.NewRecorder()
+	ts.handleDescriptor(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for unknown path, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandleDescriptor_StatusPage(t *testing.T) {
+	ts := newTestServer(t)
+	ts.gameService.BroadcastState(context.Background(), sdk.GameStateEvent{GameID: 7, Picks: sdk.Picks{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	w := httptest.NewRecorder()
+	ts.handleDescriptor(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("expected Content-Type text/html; charset=utf-8, got %s", ct)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{"#7", "<dd>waiting</dd>", `<a href="/api/v1/games/latest">`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected status page to contain %q, got:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "/api/v1/admin/") {
+		t.Error("status page should not list admin endpoints")
+	}
+	if strings.Contains(body, `<a href="/api/v1/games/{id}">`) {
+		t.Error("status page should not link endpoints with path parameters")
+	}
+}
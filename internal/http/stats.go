@@ -0,0 +1,78 @@
+package http
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/aussiebroadwan/taboo/internal/service"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// dueCount is how many of the numbers gone longest undrawn are listed as
+// due in GET /api/v1/stats/odds.
+const dueCount = 10
+
+// handleGetOdds handles GET /api/v1/stats/odds. It returns the odds of a
+// ticket marking ?spots= numbers under the board configuration, alongside
+// how often each number was drawn over the last ?games= completed games,
+// which defaults to and is capped by limits.max_export_rows.
+func (s *Server) handleGetOdds(w http.ResponseWriter, r *http.Request) {
+	game := s.cfg.Game
+	spots, apiErr := httpx.QueryInt(r, "spots", 1, 1, game.MaxNumber-game.MinNumber+1)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+	maxGames := s.cfg.Limits.MaxExportRows
+	window, apiErr := httpx.QueryInt(r, "games", maxGames, 1, maxGames)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+
+	key := fmt.Sprintf("stats:odds:%d:%d", spots, window)
+	odds, err := coalesce(r.Context(), &s.flight, key, s.cfg.Server.RequestTimeout.Duration(),
+		func(ctx context.Context) (*service.Odds, error) {
+			return s.gameService.Odds(ctx, spots, window)
+		})
+	if err != nil {
+		slogx.FromContext(r.Context()).Error("Failed to calculate odds", slogx.Error(err))
+		_ = httpx.WriteError(w, storeError(err, "failed to calculate odds"))
+		return
+	}
+
+	resp := sdk.OddsResponse{
+		Spots:     odds.Spots,
+		BoardSize: odds.Pool,
+		PickCount: odds.Drawn,
+		HitOdds:   odds.Hits,
+		Games:     odds.Games,
+		Numbers:   make([]sdk.NumberFrequency, 0, len(odds.Numbers)),
+	}
+	for _, n := range odds.Numbers {
+		freq := sdk.NumberFrequency{Number: n.Number, Drawn: n.Drawn, Since: n.Since}
+		if odds.Games > 0 {
+			freq.Rate = float64(n.Drawn) / float64(odds.Games)
+		}
+		resp.Numbers = append(resp.Numbers, freq)
+	}
+
+	// Ties go to the lower number, so the list is stable between requests
+	resp.Due = slices.Clone(resp.Numbers)
+	slices.SortStableFunc(resp.Due, func(a, b sdk.NumberFrequency) int {
+		return cmp.Compare(b.Since, a.Since)
+	})
+	resp.Due = resp.Due[:min(dueCount, len(resp.Due))]
+
+	// Frequencies change as each game completes
+	s.setCacheHeaders(w, false, keyGamesTail)
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleGetOdds(t *testing.T) {
+	ts := newTestServer(t)
+
+	for i := int64(1); i <= 4; i++ {
+		ts.mockStore.CreateGame(t.Context(), &domain.Game{ID: i, Picks: testPicks(), CreatedAt: time.Now()})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/odds?spots=5", nil)
+	w := httptest.NewRecorder()
+	ts.handleGetOdds(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp sdk.OddsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Spots != 5 || len(resp.HitOdds) != 6 {
+		t.Fatalf("expected odds for 0-5 hits, got %+v", resp)
+	}
+	if resp.Games != 4 || len(resp.Numbers) != resp.BoardSize {
+		t.Fatalf("expected %d numbers over 4 games, got %d over %d", resp.BoardSize, len(resp.Numbers), resp.Games)
+	}
+	if first := resp.Numbers[0]; first.Drawn != 4 || first.Rate != 1 || first.Since != 0 {
+		t.Errorf("expected number 1 drawn every game, got %+v", first)
+	}
+
+	// Every undrawn number has waited all 4 games; the lowest come first
+	if len(resp.Due) != dueCount {
+		t.Fatalf("expected %d due numbers, got %d", dueCount, len(resp.Due))
+	}
+	if due := resp.Due[0]; due.Number != 21 || due.Since != 4 {
+		t.Errorf("expected 21 due first, got %+v", due)
+	}
+}
+
+func TestHandleGetOdds_InvalidSpots(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, query := range []string{"spots=0", "spots=81", "spots=five", "spots=5&games=0"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/odds?"+query, nil)
+		w := httptest.NewRecorder()
+		ts.handleGetOdds(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
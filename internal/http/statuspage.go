@@ -0,0 +1,117 @@
+package http
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// Game phases shown on the status page.
+const (
+	phaseStarting = "starting"
+	phaseIdle     = "idle"
+	phaseDrawing  = "drawing"
+	phaseWaiting  = "waiting"
+)
+
+// statusPage is the page served at / to browsers when the server has no
+// frontend to serve, so an API-only deployment doesn't look broken.
+var statusPage = template.Must(template.New("status").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Taboo</title>
+<style>
+body { font: 16px/1.5 system-ui, sans-serif; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; }
+dt { font-weight: bold; }
+code { font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Taboo</h1>
+<p>This server is running without its web frontend. Games are still drawn and served by the API below.</p>
+<dl>
+<dt>Game</dt>
+<dd>{{if .GameID}}#{{.GameID}}{{else}}none yet{{end}}</dd>
+<dt>Phase</dt>
+<dd>{{.Phase}}</dd>
+{{- if not .NextGame.IsZero}}
+<dt>Next game</dt>
+<dd><time datetime="{{.NextGame.Format "2006-01-02T15:04:05Z07:00"}}">{{.NextGame.Format "15:04:05 MST"}}</time></dd>
+{{- end}}
+</dl>
+<h2>API</h2>
+<ul>
+{{- range .Endpoints}}
+<li>{{if .Link}}<a href="{{.Path}}"><code>{{.Method}} {{.Path}}</code></a>{{else}}<code>{{.Method}} {{.Path}}</code>{{end}}</li>
+{{- end}}
+</ul>
+<p>Request <code>/</code> with <code>Accept: application/json</code> for every endpoint, including admin ones, as JSON.</p>
+</body>
+</html>
+`))
+
+// statusPageData fills statusPage.
+type statusPageData struct {
+	GameID    int64
+	Phase     string
+	NextGame  time.Time
+	Endpoints []statusPageEndpoint
+}
+
+// statusPageEndpoint is a public endpoint listed on the status page. GET
+// endpoints without path parameters are linked.
+type statusPageEndpoint struct {
+	Method string
+	Path   string
+	Link   bool
+}
+
+// acceptsHTML reports whether r comes from a browser, which lists
+// text/html in Accept; API clients rarely do.
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// writeStatusPage writes the status page, with the current game and the
+// public endpoints.
+func (s *Server) writeStatusPage(w http.ResponseWriter, r *http.Request) {
+	data := statusPageData{Phase: phaseStarting}
+	if state := s.gameService.LastState(); state != nil {
+		data.GameID = state.GameID
+		data.NextGame = state.NextGame
+		data.Phase = phaseWaiting
+		if !s.gameService.IsComplete(state.GameID) {
+			data.Phase = phaseDrawing
+		}
+	} else if game, err := s.gameService.GetLatestGame(r.Context()); game != nil {
+		// Nothing broadcast yet, so the latest stored game is the last known
+		data.GameID = game.ID
+	} else if err != nil {
+		slogx.FromContext(r.Context()).Debug("Failed to get latest game for status page", slogx.Error(err))
+	}
+	if s.engine != nil && s.engine.Idle() {
+		data.Phase = phaseIdle
+	}
+
+	for _, route := range s.routes {
+		if route.Path == "/" || route.Auth != "none" {
+			continue
+		}
+		data.Endpoints = append(data.Endpoints, statusPageEndpoint{
+			Method: route.Method,
+			Path:   route.Path,
+			Link:   route.Method == http.MethodGet && !strings.Contains(route.Path, "{"),
+		})
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := statusPage.Execute(w, data); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write status page", slogx.Error(err))
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// defaultSyncLimit is the batch size for GET /api/v1/sync/games when ?limit=
+// is omitted. Larger batches are capped by limits.max_export_rows.
+const defaultSyncLimit = 100
+
+// handleSyncGames handles GET /api/v1/sync/games. It returns completed games
+// after after_id, oldest first, for mirrors to replicate history. The game
+// being drawn is held back until it completes.
+func (s *Server) handleSyncGames(w http.ResponseWriter, r *http.Request) {
+	afterID, apiErr := httpx.QueryInt[int64](r, "after_id", 0, 0, math.MaxInt64)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+	maxLimit := s.cfg.Limits.MaxExportRows
+	limit, apiErr := httpx.QueryInt(r, "limit", min(defaultSyncLimit, maxLimit), 1, maxLimit)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+
+	key := fmt.Sprintf("sync:games:%d:%d", afterID, limit)
+	games, err := coalesce(r.Context(), &s.flight, key, s.cfg.Server.RequestTimeout.Duration(),
+		func(ctx context.Context) ([]*domain.Game, error) {
+			return s.gameService.ListGames(ctx, afterID+1, limit+1)
+		})
+	if err != nil {
+		_ = httpx.WriteError(w, storeError(err, "failed to fetch games"))
+		return
+	}
+
+	resp := sdk.SyncGamesResponse{
+		Games:   make([]sdk.Game, 0, len(games)),
+		LastID:  afterID,
+		HasMore: len(games) > limit,
+	}
+	if resp.HasMore {
+		games = games[:limit]
+	}
+	for _, g := range games {
+		if !s.gameService.IsComplete(g.ID) {
+			resp.HasMore = false
+			break
+		}
+		resp.Games = append(resp.Games, toSDKGame(g))
+		resp.LastID = g.ID
+	}
+
+	if node := r.Header.Get(sdk.NodeHeader); node != "" && len(node) <= maxPeerNodeLen {
+		s.recordPeer(r.Context(), node, resp.LastID)
+	}
+
+	// A full batch never changes; the last one grows as games complete
+	if resp.HasMore {
+		s.setCacheHeaders(w, true, keyGames)
+	} else {
+		s.setCacheHeaders(w, false, keyGamesTail)
+	}
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleSyncGames(t *testing.T) {
+	ts := newTestServer(t)
+	for i := int64(1); i <= 5; i++ {
+		ts.mockStore.games[i] = &domain.Game{ID: i, Picks: testPicks(), CreatedAt: time.Now()}
+	}
+
+	tests := []struct {
+		query   string
+		wantIDs []int64
+		lastID  int64
+		hasMore bool
+	}{
+		{"", []int64{1, 2, 3, 4, 5}, 5, false},
+		{"?after_id=1&limit=2", []int64{2, 3}, 3, true},
+		{"?after_id=3&limit=2", []int64{4, 5}, 5, false},
+		{"?after_id=5", nil, 5, false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/games"+tt.query, nil)
+		w := httptest.NewRecorder()
+		ts.handleSyncGames(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: status %d", tt.query, w.Code)
+		}
+		var resp sdk.SyncGamesResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("%q: decoding response: %v", tt.query, err)
+		}
+
+		var ids []int64
+		for _, g := range resp.Games {
+			ids = append(ids, g.ID)
+		}
+		if len(ids) != len(tt.wantIDs) {
+			t.Errorf("%q: games = %v, want %v", tt.query, ids, tt.wantIDs)
+		} else {
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("%q: games = %v, want %v", tt.query, ids, tt.wantIDs)
+					break
+				}
+			}
+		}
+		if resp.LastID != tt.lastID || resp.HasMore != tt.hasMore {
+			t.Errorf("%q: last_id = %d, has_more = %v; want %d, %v", tt.query, resp.LastID, resp.HasMore, tt.lastID, tt.hasMore)
+		}
+	}
+}
+
+func TestHandleSyncGames_HoldsBackDrawingGame(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.games[1] = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+	if err := ts.gameService.CreateGame(context.Background(), &domain.Game{ID: 2, Picks: testPicks()}); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/games?limit=1", nil)
+	w := httptest.NewRecorder()
+	ts.handleSyncGames(w, req)
+
+	var resp sdk.SyncGamesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(resp.Games) != 1 || resp.Games[0].ID != 1 || !resp.HasMore {
+		t.Fatalf("unexpected first batch: %+v", resp)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/sync/games?after_id=1", nil)
+	w = httptest.NewRecorder()
+	ts.handleSyncGames(w, req)
+
+	resp = sdk.SyncGamesResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(resp.Games) != 0 || resp.LastID != 1 || resp.HasMore {
+		t.Errorf("expected game 2 held back while drawing, got %+v", resp)
+	}
+}
+
+func TestHandleSyncGames_DuringCreateGame(t *testing.T) {
+	ts := newTestServer(t)
+	ts.mockStore.games[1] = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+
+	// Sync lands after the store has the new game but before CreateGame
+	// returns
+	var resp sdk.SyncGamesResponse
+	ts.mockStore.onCreate = func(*domain.Game) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/games?after_id=1", nil)
+		w := httptest.NewRecorder()
+		ts.handleSyncGames(w, req)
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Errorf("decoding response: %v", err)
+		}
+	}
+	if err := ts.gameService.CreateGame(context.Background(), domain.NewGame(2, testPicks())); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+
+	if len(resp.Games) != 0 || resp.LastID != 1 {
+		t.Errorf("expected game 2 held back while being created, got %+v", resp)
+	}
+}
+
+func TestHandleSyncGames_InvalidParams(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, query := range []string{"?after_id=-1", "?after_id=abc", "?limit=0", "?limit=1001"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/games"+query, nil)
+		w := httptest.NewRecorder()
+		ts.handleSyncGames(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: status %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"cmp"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/httpx"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// maxUsageClients bounds the clients tracked individually; once reached,
+// new clients are counted under usageOverflow so a scan across many
+// addresses can't grow the table without limit.
+const maxUsageClients = 10000
+
+// maxUsageSDKs bounds the client versions counted individually for event
+// streams; the rest are counted under usageOverflow.
+const maxUsageSDKs = 100
+
+// usageUnknown is the client version of streams opened without an
+// sdk.ClientHeader.
+const usageUnknown = "unknown"
+
+// usageOverflow is the client every untracked client is counted under.
+const usageOverflow = "other"
+
+// defaultUsageLimit is the number of clients and routes reported by GET
+// /api/v1/admin/usage when ?limit= is omitted. Larger reports are capped by
+// limits.max_export_rows.
+const defaultUsageLimit = 20
+
+// clientUsage is the aggregate usage of one client.
+type clientUsage struct {
+	requests   int64
+	streams    int64
+	streamTime time.Duration
+}
+
+// usageTracker aggregates request counts per client and route in memory
+// since the server started. Clients are identified by IP; taboo has no API
+// keys to attribute requests to.
+type usageTracker struct {
+	since time.Time
+
+	mu      sync.Mutex
+	clients map[string]*clientUsage
+	routes  map[string]int64
+	sdks    map[string]int64
+}
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{
+		since:   time.Now(),
+		clients: make(map[string]*clientUsage),
+		routes:  make(map[string]int64),
+		sdks:    make(map[string]int64),
+	}
+}
+
+// middleware counts each request against its client and matched route.
+// For streams it also records how long the client stayed connected.
+func (u *usageTracker) middleware(stream bool) httpx.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := httpx.GetClientIP(r)
+			u.record(client, r.Pattern)
+			if !stream {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			defer func() { u.recordStream(client, time.Since(start)) }()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (u *usageTracker) record(client, route string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.client(client).requests++
+	u.routes[route]++
+}
+
+func (u *usageTracker) recordStream(client string, d time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	c := u.client(client)
+	c.streams++
+	c.streamTime += d
+}
+
+// recordClient counts an event stream opened by client, keyed by its name
+// and version.
+func (u *usageTracker) recordClient(client sdk.ClientInfo) {
+	key := usageUnknown
+	if client.Name != "" {
+		key = client.Name
+		if client.Version != "" {
+			key += "/" + client.Version
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.sdks[key]; !ok && len(u.sdks) >= maxUsageSDKs {
+		key = usageOverflow
+	}
+	u.sdks[key]++
+}
+
+// client returns the usage entry for client, falling back to the overflow
+// entry when the table is full. The caller must hold u.mu.
+func (u *usageTracker) client(client string) *clientUsage {
+	if c, ok := u.clients[client]; ok {
+		return c
+	}
+	if len(u.clients) >= maxUsageClients {
+		client = usageOverflow
+		if c, ok := u.clients[client]; ok {
+			return c
+		}
+	}
+	c := &clientUsage{}
+	u.clients[client] = c
+	return c
+}
+
+// report returns the limit heaviest clients and routes by request count.
+func (u *usageTracker) report(limit int) sdk.UsageResponse {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	resp := sdk.UsageResponse{
+		Since:   u.since,
+		Clients: make([]sdk.ClientUsage, 0, len(u.clients)),
+		Routes:  make([]sdk.RouteUsage, 0, len(u.routes)),
+	}
+	for client, c := range u.clients {
+		resp.Clients = append(resp.Clients, sdk.ClientUsage{
+			Client:        client,
+			Requests:      c.requests,
+			Streams:       c.streams,
+			StreamSeconds: c.streamTime.Seconds(),
+		})
+		resp.Requests += c.requests
+	}
+	for route, n := range u.routes {
+		resp.Routes = append(resp.Routes, sdk.RouteUsage{Route: route, Requests: n})
+	}
+	resp.Streams.Clients = maps.Clone(u.sdks)
+
+	slices.SortFunc(resp.Clients, func(a, b sdk.ClientUsage) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), cmp.Compare(a.Client, b.Client))
+	})
+	slices.SortFunc(resp.Routes, func(a, b sdk.RouteUsage) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), cmp.Compare(a.Route, b.Route))
+	})
+	resp.Clients = resp.Clients[:min(limit, len(resp.Clients))]
+	resp.Routes = resp.Routes[:min(limit, len(resp.Routes))]
+
+	return resp
+}
+
+// handleUsage handles GET /api/v1/admin/usage. It reports the heaviest
+// clients and routes since the server started, up to limit of each, and
+// event delivery to streaming clients.
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	maxLimit := s.cfg.Limits.MaxExportRows
+	limit, apiErr := httpx.QueryInt(r, "limit", min(defaultUsageLimit, maxLimit), 1, maxLimit)
+	if apiErr != nil {
+		_ = httpx.WriteError(w, apiErr)
+		return
+	}
+
+	resp := s.usage.report(limit)
+	streams := s.gameService.StreamStats()
+	resp.Streams.Viewers = s.viewers.Counts()
+	resp.Streams.Subscribers = streams.Subscribers
+	resp.Streams.Delivered = streams.Delivered
+	resp.Streams.Dropped = streams.Dropped
+	resp.Streams.Evicted = streams.Evicted
+	resp.Streams.Rejected = streams.Rejected
+
+	if err := httpx.Respond(w, r, http.StatusOK, resp, sdk.Meta{}); err != nil {
+		slogx.FromContext(r.Context()).Warn("Failed to write JSON response", slogx.Error(err))
+	}
+}
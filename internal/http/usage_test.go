@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestHandleUsage(t *testing.T) {
+	ts := newTestServer(t)
+	ts.cfg.Server.AdminToken = "s3cret"
+	ts.Server = NewServer(ts.cfg, ts.logger, ts.mockStore, ts.gameService, ts.settings, ts.engine)
+	ts.mockStore.games[1] = &domain.Game{ID: 1, Picks: testPicks(), CreatedAt: time.Now()}
+
+	get := func(path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Real-IP", ip)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		ts.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	for range 3 {
+		get("/api/v1/games/1", "10.0.0.1")
+	}
+	get("/api/v1/games", "10.0.0.2")
+
+	// Health probes are not counted
+	get("/readyz", "10.0.0.3")
+
+	w := get("/api/v1/admin/usage?limit=1", "10.0.0.9")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+	}
+
+	var resp sdk.UsageResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// The usage request itself is counted before it is served
+	if resp.Requests != 5 {
+		t.Errorf("expected 5 requests, got %d", resp.Requests)
+	}
+	if len(resp.Clients) != 1 || resp.Clients[0].Client != "10.0.0.1" || resp.Clients[0].Requests != 3 {
+		t.Errorf("expected top client 10.0.0.1 with 3 requests, got %+v", resp.Clients)
+	}
+	if len(resp.Routes) != 1 || resp.Routes[0].Route != "GET /api/v1/games/{id}" {
+		t.Errorf("expected top route GET /api/v1/games/{id}, got %+v", resp.Routes)
+	}
+}
+
+func TestHandleUsage_InvalidLimit(t *testing.T) {
+	ts := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/usage?limit=0", nil)
+	w := httptest.NewRecorder()
+	ts.handleUsage(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUsageTracker_Streams(t *testing.T) {
+	u := newUsageTracker()
+	h := u.middleware(true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil).WithContext(context.Background())
+	req.RemoteAddr = "10.0.0.1:1234"
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	report := u.report(defaultUsageLimit)
+	if len(report.Clients) != 1 {
+		t.Fatalf("expected 1 client, got %d", len(report.Clients))
+	}
+	c := report.Clients[0]
+	if c.Streams != 1 || c.StreamSeconds < 0.02 {
+		t.Errorf("expected 1 stream of at least 20ms, got %+v", c)
+	}
+}
+
+func TestUsageTracker_Overflow(t *testing.T) {
+	u := newUsageTracker()
+	for i := range maxUsageClients + 5 {
+		u.record(fmt.Sprintf("10.0.%d.%d", i/256, i%256), "GET /")
+	}
+
+	if n := len(u.clients); n != maxUsageClients+1 {
+		t.Errorf("expected %d tracked clients, got %d", maxUsageClients+1, n)
+	}
+	if got := u.clients[usageOverflow].requests; got != 5 {
+		t.Errorf("expected 5 overflow requests, got %d", got)
+	}
+}
+
+func TestUsageTracker_StreamClients(t *testing.T) {
+	u := newUsageTracker()
+	u.recordClient(sdk.ClientInfo{Name: "taboo-go", Version: "1.4.0", Events: 2})
+	u.recordClient(sdk.ClientInfo{Name: "taboo-go", Version: "1.4.0", Events: 2})
+	u.recordClient(sdk.ClientInfo{})
+	for i := range maxUsageSDKs {
+		u.recordClient(sdk.ClientInfo{Name: "bot", Version: fmt.Sprint(i)})
+	}
+
+	clients := u.report(defaultUsageLimit).Streams.Clients
+	if clients["taboo-go/1.4.0"] != 2 || clients[usageUnknown] != 1 {
+		t.Errorf("unexpected client counts: %v", clients)
+	}
+	if len(clients) != maxUsageSDKs+1 || clients[usageOverflow] != 2 {
+		t.Errorf("expected %d tracked versions with 2 overflowed, got %d with %d",
+			maxUsageSDKs+1, len(clients), clients[usageOverflow])
+	}
+}
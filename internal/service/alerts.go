@@ -0,0 +1,227 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// Alert kinds.
+const (
+	AlertEngineFailing     = "engine_failing"
+	AlertEngineRecovered   = "engine_recovered"
+	AlertReadinessDegraded = "readiness_degraded"
+	AlertReadinessRestored = "readiness_restored"
+	AlertDuplicateDraw     = "duplicate_draw"
+)
+
+// alertTimeout bounds each notification request.
+const alertTimeout = 10 * time.Second
+
+// maxReadinessInterval caps how often the readiness watcher checks.
+const maxReadinessInterval = 15 * time.Second
+
+// Alert is a notification to the operator.
+type Alert struct {
+	Kind    string    `json:"kind"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
+
+// Notifier delivers alerts to an operator.
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// WebhookNotifier POSTs alerts to a URL, either as the Alert JSON or as a
+// Discord webhook message.
+type WebhookNotifier struct {
+	url     string
+	discord bool
+	client  *http.Client
+}
+
+// NewWebhookNotifier creates a notifier POSTing each Alert as JSON to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: alertTimeout}}
+}
+
+// NewDiscordNotifier creates a notifier posting each alert as a message to
+// a Discord channel webhook.
+func NewDiscordNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, discord: true, client: &http.Client{Timeout: alertTimeout}}
+}
+
+// discordMessage is the body of a Discord webhook execution.
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+// Notify sends alert to the webhook.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	var payload any = alert
+	if n.discord {
+		payload = discordMessage{Content: fmt.Sprintf("**taboo** `%s`: %s", alert.Kind, alert.Message)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Alerter notifies the operator when game cycles keep failing or readiness
+// stays degraded. Each condition alerts once when it starts and again when
+// it clears, rather than on every failure.
+type Alerter struct {
+	notifiers        []Notifier
+	failureThreshold int
+	degradedFor      time.Duration
+
+	mu       sync.Mutex
+	failures int
+	failing  bool
+
+	wg sync.WaitGroup
+}
+
+// NewAlerter creates an alerter sending to the destinations in cfg.
+func NewAlerter(cfg config.AlertsConfig) *Alerter {
+	var notifiers []Notifier
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.WebhookURL))
+	}
+	if cfg.DiscordWebhookURL != "" {
+		notifiers = append(notifiers, NewDiscordNotifier(cfg.DiscordWebhookURL))
+	}
+	return newAlerter(cfg, notifiers...)
+}
+
+func newAlerter(cfg config.AlertsConfig, notifiers ...Notifier) *Alerter {
+	return &Alerter{
+		notifiers:        notifiers,
+		failureThreshold: cfg.FailureThreshold,
+		degradedFor:      cfg.DegradedFor.Duration(),
+	}
+}
+
+// CycleFailed records a failed game cycle, alerting once FailureThreshold
+// cycles in a row have failed.
+func (a *Alerter) CycleFailed(ctx context.Context, err error) {
+	if a.failureThreshold <= 0 {
+		return
+	}
+
+	a.mu.Lock()
+	a.failures++
+	failures := a.failures
+	alert := failures >= a.failureThreshold && !a.failing
+	if alert {
+		a.failing = true
+	}
+	a.mu.Unlock()
+
+	if alert {
+		a.notify(ctx, AlertEngineFailing, fmt.Sprintf("%d game cycles in a row have failed, last: %v", failures, err))
+	}
+}
+
+// CycleSucceeded records a completed game cycle, sending a recovery notice
+// if failures had been alerted.
+func (a *Alerter) CycleSucceeded(ctx context.Context) {
+	a.mu.Lock()
+	recovered := a.failing
+	a.failures = 0
+	a.failing = false
+	a.mu.Unlock()
+
+	if recovered {
+		a.notify(ctx, AlertEngineRecovered, "game cycles are completing again")
+	}
+}
+
+// DuplicateDraw alerts that game shared shared of its picks with the
+// earlier game match, too many to be chance. Every duplicate is alerted.
+func (a *Alerter) DuplicateDraw(ctx context.Context, game, match int64, shared, picks int) {
+	a.notify(ctx, AlertDuplicateDraw, fmt.Sprintf("game %d shares %d of %d picks with game %d; check the RNG and store", game, shared, picks, match))
+}
+
+// WatchReadiness polls ready until ctx is cancelled, alerting once it has
+// reported not ready for DegradedFor.
+func (a *Alerter) WatchReadiness(ctx context.Context, ready func(context.Context) bool) {
+	if a.degradedFor <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(min(a.degradedFor/4, maxReadinessInterval))
+	defer ticker.Stop()
+
+	var degradedSince time.Time
+	alerted := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		switch ok := ready(ctx); {
+		case ok && alerted:
+			a.notify(ctx, AlertReadinessRestored, fmt.Sprintf("readiness restored after %s", time.Since(degradedSince).Round(time.Second)))
+			fallthrough
+		case ok:
+			degradedSince, alerted = time.Time{}, false
+		case degradedSince.IsZero():
+			degradedSince = time.Now()
+		case !alerted && time.Since(degradedSince) >= a.degradedFor:
+			alerted = true
+			a.notify(ctx, AlertReadinessDegraded, fmt.Sprintf("readiness has been degraded for %s", time.Since(degradedSince).Round(time.Second)))
+		}
+	}
+}
+
+// notify sends an alert to every notifier in the background, so a slow
+// webhook doesn't hold up the game loop. Failures are logged.
+func (a *Alerter) notify(ctx context.Context, kind, message string) {
+	alert := Alert{Kind: kind, Message: message, At: time.Now().UTC()}
+	logger := slogx.FromContext(ctx)
+	logger.Warn("Sending operator alert", slog.String("kind", kind), slog.String("message", message))
+
+	ctx = context.WithoutCancel(ctx)
+	for _, n := range a.notifiers {
+		a.wg.Go(func() {
+			if err := n.Notify(ctx, alert); err != nil {
+				logger.Error("Failed to send operator alert", slog.String("kind", kind), slogx.Error(err))
+			}
+		})
+	}
+}
+
+// Wait blocks until alerts being sent have been delivered or failed.
+func (a *Alerter) Wait() {
+	a.wg.Wait()
+}
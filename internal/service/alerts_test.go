@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+// recordingNotifier records the kinds of the alerts it is sent.
+type recordingNotifier struct {
+	mu    sync.Mutex
+	kinds []string
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, alert Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.kinds = append(n.kinds, alert.Kind)
+	return nil
+}
+
+func (n *recordingNotifier) Kinds() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return slices.Clone(n.kinds)
+}
+
+func TestAlerter_CycleFailures(t *testing.T) {
+	ctx := context.Background()
+	n := &recordingNotifier{}
+	a := newAlerter(config.AlertsConfig{FailureThreshold: 3}, n)
+
+	failed := errors.New("store unavailable")
+	a.CycleFailed(ctx, failed)
+	a.CycleFailed(ctx, failed)
+	a.CycleSucceeded(ctx)
+
+	// The streak was broken, so it takes three more to alert, and only once
+	for range 5 {
+		a.CycleFailed(ctx, failed)
+	}
+	a.Wait()
+	if got := n.Kinds(); !slices.Equal(got, []string{AlertEngineFailing}) {
+		t.Fatalf("alerts after failures = %v, want one %s", got, AlertEngineFailing)
+	}
+
+	a.CycleSucceeded(ctx)
+	a.CycleSucceeded(ctx)
+	a.Wait()
+	want := []string{AlertEngineFailing, AlertEngineRecovered}
+	if got := n.Kinds(); !slices.Equal(got, want) {
+		t.Errorf("alerts after recovery = %v, want %v", got, want)
+	}
+}
+
+func TestAlerter_WatchReadiness(t *testing.T) {
+	n := &recordingNotifier{}
+	a := newAlerter(config.AlertsConfig{DegradedFor: config.Duration(20 * time.Millisecond)}, n)
+
+	var ready atomic.Bool
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.WatchReadiness(ctx, func(context.Context) bool { return ready.Load() })
+	}()
+
+	waitFor := func(want []string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !slices.Equal(n.Kinds(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("alerts = %v, want %v", n.Kinds(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	waitFor([]string{AlertReadinessDegraded})
+	ready.Store(true)
+	waitFor([]string{AlertReadinessDegraded, AlertReadinessRestored})
+
+	cancel()
+	<-done
+	a.Wait()
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var got map[string]any
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding alert: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(receiver.Close)
+
+	alert := Alert{Kind: AlertEngineFailing, Message: "3 game cycles in a row have failed", At: time.Now()}
+	if err := NewWebhookNotifier(receiver.URL).Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if got["kind"] != AlertEngineFailing || got["message"] != alert.Message {
+		t.Errorf("webhook body = %v, want the alert", got)
+	}
+
+	if err := NewDiscordNotifier(receiver.URL).Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if content, _ := got["content"].(string); content == "" {
+		t.Errorf("discord body = %v, want message content", got)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(receiver.Close)
+
+	if err := NewWebhookNotifier(receiver.URL).Notify(context.Background(), Alert{}); err == nil {
+		t.Error("Notify() error = nil, want an error for a 502")
+	}
+}
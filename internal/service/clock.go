@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// clockSkewTolerance is how far the published next_game may drift from the
+// engine's monotonic deadline before it is treated as a wall-clock jump.
+const clockSkewTolerance = time.Second
+
+// clockCheckInterval is how often the wait phase, which otherwise broadcasts
+// nothing, checks for wall-clock jumps.
+const clockCheckInterval = time.Second
+
+// correctNextGame maps the monotonic deadline onto the wall clock reading
+// now. It returns the corrected time and true when that differs from
+// published by more than clockSkewTolerance, meaning the system clock was
+// stepped (NTP correction, manual change) since published was computed.
+func correctNextGame(published, deadline, now time.Time) (time.Time, bool) {
+	// time.Until reads the monotonic clock, so only now's wall reading
+	// reflects the jump.
+	actual := now.Add(time.Until(deadline)).Round(0)
+
+	skew := actual.Sub(published)
+	if skew < 0 {
+		skew = -skew
+	}
+	return actual, skew > clockSkewTolerance
+}
+
+// checkClock returns nextGame corrected for any wall-clock jump, logging
+// when a correction is made.
+func (e *Engine) checkClock(ctx context.Context, nextGame, deadline time.Time) (time.Time, bool) {
+	corrected, jumped := correctNextGame(nextGame, deadline, e.gameService.now())
+	if !jumped {
+		return nextGame, false
+	}
+
+	slogx.FromContext(ctx).Warn("Wall clock jump detected, correcting next game time",
+		slog.Time("published", nextGame),
+		slog.Time("corrected", corrected),
+		slog.Duration("skew", corrected.Sub(nextGame)),
+	)
+	return corrected, true
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCorrectNextGame(t *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	published := deadline.Round(0)
+
+	if _, jumped := correctNextGame(published, deadline, time.Now()); jumped {
+		t.Error("expected no jump when the wall clock is unchanged")
+	}
+
+	// A published time computed before the clock was stepped back an hour
+	// is an hour ahead of where the deadline now falls.
+	corrected, jumped := correctNextGame(published.Add(time.Hour), deadline, time.Now())
+	if !jumped {
+		t.Fatal("expected a jump to be detected")
+	}
+	if diff := corrected.Sub(published); diff < -clockSkewTolerance || diff > clockSkewTolerance {
+		t.Errorf("corrected = %v, want about %v", corrected, published)
+	}
+
+	// Small drift is tolerated
+	if _, jumped := correctNextGame(published.Add(-clockSkewTolerance/2), deadline, time.Now()); jumped {
+		t.Error("expected drift within tolerance to be ignored")
+	}
+}
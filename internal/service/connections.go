@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// Connection lifecycle event types.
+const (
+	EventClientConnected    = "client:connected"
+	EventClientDisconnected = "client:disconnected"
+)
+
+// Transports clients receive game events over.
+const (
+	TransportSSE      = "sse"
+	TransportLongPoll = "longpoll"
+)
+
+// connectionBufferSize is the buffer of each connection event subscriber.
+// It is generous so a burst of reconnects doesn't skew the viewer count.
+const connectionBufferSize = 256
+
+// ConnectionEvent reports a client starting or ending a connection for
+// game events. It is published on its own topic, separate from game events,
+// and never sent to clients.
+type ConnectionEvent struct {
+	Type      string
+	Transport string
+
+	// IPHash identifies the client's IP within this process without
+	// recording the address itself.
+	IPHash string
+
+	// Duration, and the delivery counters for streaming transports, are set
+	// on disconnect.
+	Duration time.Duration
+	Delivery pubsub.SubscriptionStats
+}
+
+// connections publishes connection lifecycle events and keeps its own
+// count of open connections, which unlike Viewers can't drift when a
+// subscriber falls behind.
+type connections struct {
+	broker *pubsub.Broker[ConnectionEvent]
+	salt   []byte
+
+	// mu guards open and emptySince, when open last fell to zero. joined is
+	// signalled on every connect.
+	mu         sync.Mutex
+	open       int
+	emptySince time.Time
+	joined     chan struct{}
+}
+
+func newConnections() *connections {
+	// rand.Read never returns an error
+	salt := make([]byte, 16)
+	_, _ = rand.Read(salt)
+	return &connections{
+		broker:     pubsub.New(pubsub.WithBufferSize[ConnectionEvent](connectionBufferSize)),
+		salt:       salt,
+		emptySince: time.Now(),
+		joined:     make(chan struct{}, 1),
+	}
+}
+
+// hashIP returns a short salted hash of ip. The salt is random per process,
+// so hashes can't be matched against a list of addresses.
+func (c *connections) hashIP(ip string) string {
+	h := sha256.New()
+	h.Write(c.salt)
+	h.Write([]byte(ip))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Connection is a client's open connection, announced by Connect.
+type Connection struct {
+	conns     *connections
+	transport string
+	ipHash    string
+	start     time.Time
+	once      sync.Once
+}
+
+// Connect publishes a client:connected event for a client at ip and
+// returns the connection, to be closed when the client leaves.
+func (s *GameService) Connect(transport, ip string) *Connection {
+	conn := &Connection{
+		conns:     s.conns,
+		transport: transport,
+		ipHash:    s.conns.hashIP(ip),
+		start:     time.Now(),
+	}
+
+	s.conns.mu.Lock()
+	s.conns.open++
+	s.conns.mu.Unlock()
+	select {
+	case s.conns.joined <- struct{}{}:
+	default:
+	}
+
+	s.conns.broker.Publish(ConnectionEvent{
+		Type:      EventClientConnected,
+		Transport: transport,
+		IPHash:    conn.ipHash,
+	})
+	return conn
+}
+
+// Close publishes a client:disconnected event with how long the client was
+// connected and what it was sent. Only the first call has any effect.
+func (c *Connection) Close(delivery pubsub.SubscriptionStats) {
+	c.once.Do(func() {
+		c.conns.mu.Lock()
+		c.conns.open--
+		if c.conns.open == 0 {
+			c.conns.emptySince = time.Now()
+		}
+		c.conns.mu.Unlock()
+
+		c.conns.broker.Publish(ConnectionEvent{
+			Type:      EventClientDisconnected,
+			Transport: c.transport,
+			IPHash:    c.ipHash,
+			Duration:  time.Since(c.start),
+			Delivery:  delivery,
+		})
+	})
+}
+
+// SubscribeConnections returns a channel that receives connection events.
+// The caller should cancel the context when done to unsubscribe.
+func (s *GameService) SubscribeConnections(ctx context.Context) <-chan ConnectionEvent {
+	return s.conns.broker.Subscribe(ctx)
+}
+
+// EmptySince returns when the last client connected for game events left,
+// or the service started if none ever connected. ok is false while any
+// client is connected.
+func (s *GameService) EmptySince() (since time.Time, ok bool) {
+	s.conns.mu.Lock()
+	defer s.conns.mu.Unlock()
+	return s.conns.emptySince, s.conns.open == 0
+}
+
+// WaitForClient blocks until a client is connected for game events, or
+// returns ctx's error once it is cancelled.
+func (s *GameService) WaitForClient(ctx context.Context) error {
+	for {
+		if _, empty := s.EmptySince(); !empty {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.conns.joined:
+		}
+	}
+}
+
+// Viewers counts the clients currently connected over each transport from
+// connection events.
+type Viewers struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewViewers creates an empty viewer count.
+func NewViewers() *Viewers {
+	return &Viewers{counts: make(map[string]int)}
+}
+
+// Run applies events to the count until the channel is closed.
+func (v *Viewers) Run(events <-chan ConnectionEvent) {
+	for event := range events {
+		v.mu.Lock()
+		switch event.Type {
+		case EventClientConnected:
+			v.counts[event.Transport]++
+		case EventClientDisconnected:
+			// A dropped connect event mustn't drive the count negative
+			v.counts[event.Transport] = max(v.counts[event.Transport]-1, 0)
+		}
+		v.mu.Unlock()
+	}
+}
+
+// Counts returns the number of connected clients keyed by transport.
+func (v *Viewers) Counts() map[string]int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return maps.Clone(v.counts)
+}
+
+// LogConnections writes each connection event to the logger from ctx until
+// the channel is closed, as the audit trail of who was watching. Clients
+// evicted for falling behind are logged as warnings.
+func LogConnections(ctx context.Context, events <-chan ConnectionEvent) {
+	logger := slogx.FromContext(ctx)
+	for event := range events {
+		attrs := []slog.Attr{
+			slog.String("event", event.Type),
+			slog.String("transport", event.Transport),
+			slog.String("ip_hash", event.IPHash),
+		}
+		if event.Type == EventClientConnected {
+			logger.LogAttrs(ctx, slog.LevelInfo, "Client connected", attrs...)
+			continue
+		}
+
+		attrs = append(attrs, slog.Duration("duration", event.Duration))
+		if event.Transport == TransportSSE {
+			attrs = append(attrs,
+				slog.Int64("delivered", event.Delivery.Delivered),
+				slog.Int64("dropped", event.Delivery.Dropped),
+			)
+		}
+		if event.Delivery.Evicted {
+			logger.LogAttrs(ctx, slog.LevelWarn, "Client evicted for falling behind", attrs...)
+			continue
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "Client disconnected", attrs...)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+func nextConnectionEvent(t *testing.T, events <-chan ConnectionEvent) ConnectionEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for connection event")
+		return ConnectionEvent{}
+	}
+}
+
+func TestGameService_Connect(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+	events := svc.SubscribeConnections(t.Context())
+
+	conn := svc.Connect(TransportSSE, "203.0.113.7")
+	connected := nextConnectionEvent(t, events)
+	if connected.Type != EventClientConnected || connected.Transport != TransportSSE {
+		t.Errorf("expected sse client:connected, got %+v", connected)
+	}
+	if connected.IPHash == "" || strings.Contains(connected.IPHash, "203.0.113.7") {
+		t.Errorf("expected a hash in place of the IP, got %q", connected.IPHash)
+	}
+
+	conn.Close(pubsub.SubscriptionStats{Delivered: 3})
+	conn.Close(pubsub.SubscriptionStats{}) // no second event
+	disconnected := nextConnectionEvent(t, events)
+	if disconnected.Type != EventClientDisconnected || disconnected.IPHash != connected.IPHash {
+		t.Errorf("expected client:disconnected for the same client, got %+v", disconnected)
+	}
+	if disconnected.Delivery.Delivered != 3 {
+		t.Errorf("expected delivery stats on disconnect, got %+v", disconnected.Delivery)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("expected one disconnect event, also got %+v", event)
+	default:
+	}
+
+	// The same IP hashes the same within the process, others differ
+	if svc.conns.hashIP("203.0.113.7") != connected.IPHash || svc.conns.hashIP("203.0.113.8") == connected.IPHash {
+		t.Error("expected IP hashes to be stable per address and distinct across addresses")
+	}
+}
+
+func TestGameService_EmptySince(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	started, empty := svc.EmptySince()
+	if !empty || started.IsZero() {
+		t.Fatalf("EmptySince() = %v, %t, want service start while empty", started, empty)
+	}
+
+	// A client connecting wakes a waiter
+	done := make(chan error, 1)
+	go func() { done <- svc.WaitForClient(t.Context()) }()
+	conn := svc.Connect(TransportLongPoll, "203.0.113.7")
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("WaitForClient() error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitForClient() did not return once a client connected")
+	}
+	if _, empty := svc.EmptySince(); empty {
+		t.Error("EmptySince() reports empty with a client connected")
+	}
+
+	conn.Close(pubsub.SubscriptionStats{})
+	if since, empty := svc.EmptySince(); !empty || !since.After(started) {
+		t.Errorf("EmptySince() = %v, %t, want when the client left", since, empty)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := svc.WaitForClient(ctx); err != context.DeadlineExceeded {
+		t.Errorf("WaitForClient() error = %v, want deadline exceeded with no clients", err)
+	}
+}
+
+func TestViewers(t *testing.T) {
+	events := make(chan ConnectionEvent, 8)
+	events <- ConnectionEvent{Type: EventClientConnected, Transport: TransportSSE}
+	events <- ConnectionEvent{Type: EventClientConnected, Transport: TransportSSE}
+	events <- ConnectionEvent{Type: EventClientConnected, Transport: TransportLongPoll}
+	events <- ConnectionEvent{Type: EventClientDisconnected, Transport: TransportSSE}
+	events <- ConnectionEvent{Type: EventClientDisconnected, Transport: TransportLongPoll}
+	events <- ConnectionEvent{Type: EventClientDisconnected, Transport: TransportLongPoll}
+	close(events)
+
+	v := NewViewers()
+	v.Run(events)
+
+	counts := v.Counts()
+	if counts[TransportSSE] != 1 || counts[TransportLongPoll] != 0 {
+		t.Errorf("expected 1 sse and 0 longpoll viewers, got %v", counts)
+	}
+}
+
+func TestLogConnections(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := slogx.NewContext(context.Background(), slog.New(slog.NewTextHandler(&buf, nil)))
+
+	events := make(chan ConnectionEvent, 2)
+	events <- ConnectionEvent{Type: EventClientConnected, Transport: TransportSSE, IPHash: "abc"}
+	events <- ConnectionEvent{
+		Type:      EventClientDisconnected,
+		Transport: TransportSSE,
+		IPHash:    "abc",
+		Duration:  time.Minute,
+		Delivery:  pubsub.SubscriptionStats{Delivered: 5, Dropped: 8, Evicted: true},
+	}
+	close(events)
+
+	LogConnections(ctx, events)
+
+	out := buf.String()
+	for _, want := range []string{"Client connected", "level=WARN", "Client evicted", "ip_hash=abc", "dropped=8", "duration=1m0s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// checkDuplicates compares game's picks against the game.duplicate_window
+// games before it, warning and alerting on the closest match if it shares
+// at least game.duplicate_threshold of the picks. Fair draws almost never
+// do, so a match points at a broken RNG or a store replaying old rows. A
+// failed lookup is logged and skips the check.
+func (e *Engine) checkDuplicates(ctx context.Context, game *domain.Game) {
+	window := e.config.DuplicateWindow
+	if window <= 0 || len(game.Picks) == 0 || game.ID <= 1 {
+		return
+	}
+
+	start := max(game.ID-int64(window), 1)
+	recent, err := storeCall(ctx, e, "list_recent_games", func(ctx context.Context) ([]*domain.Game, error) {
+		return e.gameService.store.ListGames(ctx, start, int(game.ID-start))
+	})
+	if err != nil {
+		slogx.FromContext(ctx).Warn("Failed to check for duplicate draws", slogx.Error(err))
+		return
+	}
+
+	drawn := make(map[uint8]bool, len(game.Picks))
+	for _, p := range game.Picks {
+		drawn[p] = true
+	}
+	var match *domain.Game
+	shared := 0
+	for _, g := range recent {
+		if g.ID >= game.ID {
+			break
+		}
+		n := 0
+		for _, p := range g.Picks {
+			if drawn[p] {
+				n++
+			}
+		}
+		if n > shared {
+			match, shared = g, n
+		}
+	}
+
+	if match == nil || float64(shared) < e.config.DuplicateThreshold*float64(len(game.Picks)) {
+		return
+	}
+
+	total := e.duplicates.Add(1)
+	slogx.FromContext(ctx).Warn("Draw duplicates an earlier game",
+		slog.Int64("match_id", match.ID),
+		slog.Int("shared", shared),
+		slog.Int("picks", len(game.Picks)),
+		slog.Int64("duplicate_draws", total),
+	)
+	if e.alerter != nil {
+		e.alerter.DuplicateDraw(ctx, game.ID, match.ID, shared, len(game.Picks))
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+)
+
+func TestEngine_CheckDuplicates(t *testing.T) {
+	// Game 2 draws 1-20; game 4 draws 6-25, sharing 15 of those picks
+	shifted := make([]uint8, 20)
+	for i := range shifted {
+		shifted[i] = uint8(i + 6)
+	}
+
+	tests := []struct {
+		name      string
+		window    int
+		threshold float64
+		want      int64
+	}{
+		{"above threshold", 10, 0.75, 1},
+		{"below threshold", 10, 0.8, 0},
+		{"outside window", 1, 0.75, 0},
+		{"disabled", 0, 0.75, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cfg := defaultGameConfig()
+			cfg.DuplicateWindow = tt.window
+			cfg.DuplicateThreshold = tt.threshold
+
+			store := newMockStore()
+			store.games[1] = &domain.Game{ID: 1, Picks: []uint8{61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80}}
+			store.games[2] = &domain.Game{ID: 2, Picks: testPicks()}
+			store.games[3] = &domain.Game{ID: 3, Picks: []uint8{41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60}}
+
+			n := &recordingNotifier{}
+			engine := NewEngine(NewGameService(store, cfg), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			engine.SetAlerter(newAlerter(config.AlertsConfig{}, n))
+
+			game := &domain.Game{ID: 4, Picks: shifted}
+			store.games[4] = game
+			engine.checkDuplicates(ctx, game)
+			engine.alerter.Wait()
+
+			if got := engine.duplicates.Load(); got != tt.want {
+				t.Errorf("duplicates = %d, want %d", got, tt.want)
+			}
+			var want []string
+			if tt.want > 0 {
+				want = []string{AlertDuplicateDraw}
+			}
+			if got := n.Kinds(); !slices.Equal(got, want) {
+				t.Errorf("alerts = %v, want %v", got, want)
+			}
+		})
+	}
+}
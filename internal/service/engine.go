@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"log/slog"
 	"math/big"
+	mrand "math/rand/v2"
 	"sync/atomic"
 	"time"
 
@@ -23,7 +25,36 @@ type Engine struct {
 	config      *config.GameConfig
 	logger      *slog.Logger
 
+	// source, if set, supplies each game's picks instead of drawing them.
+	source ResultSource
+
+	// alerter, if set, is told the outcome of each game cycle.
+	alerter *Alerter
+
+	// specials, if set, holds special games to run in place of regular ones.
+	specials *SpecialSchedule
+
+	// seed, if seeded, derives each game's picks from its ID instead of
+	// drawing them securely.
+	seed   uint64
+	seeded bool
+
 	running atomic.Bool
+	stalled atomic.Bool
+	idle    atomic.Bool
+
+	// awaiting is set while the game loop waits on the external source,
+	// which may publish less often than a game cycle.
+	awaiting atomic.Bool
+
+	// progressed is when the game loop last moved a game on, in Unix
+	// nanoseconds. Only the loop sets it, so the watchdog isn't fooled by
+	// notices, hints or other events broadcast while the loop is stuck.
+	progressed atomic.Int64
+
+	// duplicates counts the games found duplicating an earlier draw since
+	// startup.
+	duplicates atomic.Int64
 }
 
 // NewEngine creates a new game engine.
@@ -35,6 +66,43 @@ func NewEngine(gameService *GameService, cfg *config.GameConfig, logger *slog.Lo
 	}
 }
 
+// SetSource makes the engine reveal results from src instead of drawing
+// its own, for game.source: external. It must be called before Run.
+func (e *Engine) SetSource(src ResultSource) {
+	e.source = src
+}
+
+// SetAlerter makes the engine report each game cycle's outcome to a, so
+// operators hear about repeated failures. It must be called before Run.
+func (e *Engine) SetAlerter(a *Alerter) {
+	e.alerter = a
+}
+
+// SetSpecials makes the engine run the special games scheduled in sched in
+// place of the regular game due when each starts. It must be called before
+// Run.
+func (e *Engine) SetSpecials(sched *SpecialSchedule) {
+	e.specials = sched
+}
+
+// SetSeed makes the engine draw each game's picks from a PRNG seeded with
+// seed and the game's ID, so a given game always has the same picks. It is
+// for reproducible end-to-end tests and demo recordings, never production,
+// and must be called before Run.
+func (e *Engine) SetSeed(seed uint64) {
+	e.seed = seed
+	e.seeded = true
+}
+
+// SetClockSkew offsets the wall clock behind every time the engine
+// publishes (next_game, revealed_at and created_at) by skew, as if the
+// server's clock disagreed with its clients'. Game timing still follows the
+// real monotonic clock. It is for testing how clients cope with skew, and
+// must be called before Run.
+func (e *Engine) SetClockSkew(skew time.Duration) {
+	e.gameService.skew = skew
+}
+
 // IsRunning returns whether the engine is currently running.
 func (e *Engine) IsRunning() bool {
 	return e.running.Load()
@@ -50,10 +118,29 @@ func (e *Engine) Run(ctx context.Context) error {
 	e.running.Store(true)
 	defer e.running.Store(false)
 
+	ctx = slogx.NewContext(ctx, e.logger)
+
+	go e.watch(ctx)
+
+	if path := e.config.ReplayFile; path != "" {
+		events, err := LoadFixture(path)
+		if err != nil {
+			return err
+		}
+		e.logger.Info("Game engine started in replay mode", slog.String("fixture", path))
+		return e.replay(ctx, events)
+	}
+
+	source := "local"
+	if e.source != nil {
+		source = "external"
+	}
 	e.logger.Info("Game engine started",
+		slog.String("source", source),
 		slog.Duration("draw_duration", e.config.DrawDuration.Duration()),
 		slog.Duration("wait_duration", e.config.WaitDuration.Duration()),
 		slog.Int("pick_count", e.config.PickCount),
+		slog.Int("min_number", e.config.MinNumber),
 		slog.Int("max_number", e.config.MaxNumber),
 	)
 
@@ -63,30 +150,79 @@ func (e *Engine) Run(ctx context.Context) error {
 			e.logger.Info("Game engine stopped")
 			return ctx.Err()
 		default:
-			if err := e.runGame(ctx); err != nil {
-				if ctx.Err() != nil {
-					return ctx.Err()
-				}
-				e.logger.Warn("Game cycle failed", slogx.Error(err))
+			if err := e.idleWhileEmpty(ctx); err != nil {
+				return err
+			}
+			err := e.runGame(ctx)
+			if err != nil && ctx.Err() != nil {
+				return ctx.Err()
 			}
+			e.reportCycle(ctx, err)
 		}
 	}
 }
 
-// runGame executes a single game cycle: draw phase -> complete -> wait phase.
-func (e *Engine) runGame(ctx context.Context) error {
-	// Generate all picks at the start
-	picks := e.generatePicks()
+// progress records that the game loop moved a game on, for the watchdog.
+func (e *Engine) progress() {
+	e.progressed.Store(time.Now().UnixNano())
+}
 
-	// Calculate timing
-	drawDuration := e.config.DrawDuration.Duration()
-	waitDuration := e.config.WaitDuration.Duration()
-	pickInterval := drawDuration / time.Duration(e.config.PickCount)
-	nextGame := time.Now().Add(drawDuration + waitDuration)
+// Idle reports whether the engine is paused by game.idle_when_empty.
+func (e *Engine) Idle() bool {
+	return e.idle.Load()
+}
+
+// idleWhileEmpty pauses the game loop when game.idle_when_empty is on and
+// no client has been connected for game.idle_after, until one connects. The
+// game that follows broadcasts fresh state to it. It returns ctx's error if
+// cancelled while idle.
+func (e *Engine) idleWhileEmpty(ctx context.Context) error {
+	if !e.config.IdleWhenEmpty {
+		return nil
+	}
+	since, empty := e.gameService.EmptySince()
+	if !empty || time.Since(since) < e.config.IdleAfter.Duration() {
+		return nil
+	}
+
+	e.idle.Store(true)
+	defer e.idle.Store(false)
+	e.logger.Info("Game engine idle, no clients connected", slog.Duration("empty_for", time.Since(since).Round(time.Second)))
+
+	if err := e.gameService.WaitForClient(ctx); err != nil {
+		e.logger.Info("Game engine stopped")
+		return err
+	}
+	e.logger.Info("Game engine resumed, client connected")
+	return nil
+}
+
+// reportCycle tells the alerter, if any, how a game cycle ended. Cycles
+// cut short by shutdown are not reported.
+func (e *Engine) reportCycle(ctx context.Context, err error) {
+	switch {
+	case e.alerter == nil || ctx.Err() != nil:
+	case err != nil:
+		e.alerter.CycleFailed(ctx, err)
+	default:
+		e.alerter.CycleSucceeded(ctx)
+	}
+}
+
+// runGame executes a single game cycle: draw phase -> complete -> wait phase.
+// Once the game ID is known, ctx carries a logger scoped to the game and its
+// current phase, so everything logged beneath it can be correlated. Failures
+// are logged here with that logger.
+func (e *Engine) runGame(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil && ctx.Err() == nil {
+			slogx.FromContext(ctx).Warn("Game cycle failed", slogx.Error(err))
+		}
+	}()
 
 	// Get next game ID
 	nextID := int64(1)
-	latestGame, err := e.gameService.GetLatestGame(ctx)
+	latestGame, err := storeCall(ctx, e, "get_latest_game", e.gameService.GetLatestGame)
 	if err != nil && !errors.Is(err, store.ErrNotFound) {
 		return err
 	}
@@ -94,69 +230,256 @@ func (e *Engine) runGame(ctx context.Context) error {
 		nextID = latestGame.ID + 1
 	}
 
+	ctx = slogx.With(ctx, slog.Int64("game_id", nextID))
+
+	// Get all picks at the start
+	picks, err := e.nextPicks(ctx, nextID)
+	if err != nil {
+		return err
+	}
+
+	// Calculate timing
+	pacing := NewPacing(e.config)
+
+	// Phases are timed against deadline's monotonic reading; nextGame is its
+	// wall-clock equivalent as published to clients, and is corrected if the
+	// system clock jumps mid-game.
+	start := time.Now()
+	deadline := start.Add(pacing.Draw + pacing.Wait)
+	special, deadline := e.scheduleSpecial(ctx, start, deadline)
+	nextGame := deadline.Add(e.gameService.skew).Round(0)
+
+	drawCtx := slogx.With(ctx, slog.String("phase", "draw"))
+
 	// Create and persist the game
 	game := domain.NewGame(nextID, picks)
-	if err := e.gameService.CreateGame(ctx, game); err != nil {
+	game.CreatedAt = e.gameService.now()
+	_, err = storeCall(drawCtx, e, "create_game", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, e.gameService.CreateGame(ctx, game)
+	})
+	if err != nil {
 		return err
 	}
 
-	e.logger.Info("Game started",
-		slog.Int64("game_id", game.ID),
-		slog.Int("picks", len(picks)),
-	)
+	var seasonID int64
+	if season, err := storeCall(drawCtx, e, "current_season", e.gameService.CurrentSeason); err != nil {
+		slogx.FromContext(drawCtx).Warn("Failed to get current season", slogx.Error(err))
+	} else {
+		seasonID = season.ID
+	}
+
+	var specialInfo *sdk.SpecialGameInfo
+	attrs := []any{slog.Int("picks", len(picks)), slog.Int64("season", seasonID)}
+	if special != nil {
+		specialInfo = &sdk.SpecialGameInfo{
+			ID:       special.ID,
+			Name:     special.Name,
+			Branding: special.Branding,
+			Payouts:  special.Payouts,
+		}
+		attrs = append(attrs, slog.Int64("special_id", special.ID), slog.String("special", special.Name))
+	}
+	slogx.FromContext(drawCtx).Info("Game started", attrs...)
 
 	// Broadcast initial state (no picks revealed yet)
-	e.gameService.BroadcastState(sdk.GameStateEvent{
+	e.gameService.BroadcastState(drawCtx, sdk.GameStateEvent{
 		GameID:   game.ID,
+		Season:   seasonID,
 		Picks:    []uint8{},
 		NextGame: nextGame,
+		Special:  specialInfo,
 	})
+	e.progress()
 
-	// Draw phase: reveal picks one by one
+	// Draw phase: reveal picks one by one, each scheduled from the start of
+	// the game so time spent broadcasting doesn't push later picks back
 	for i, pick := range picks {
+		revealAt := start.Add(pacing.RevealAt(i))
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(pickInterval):
-			e.gameService.BroadcastPick(pick)
+		case <-time.After(time.Until(revealAt)):
+			var nextReveal time.Duration
+			if i+1 < len(picks) {
+				nextReveal = time.Until(start.Add(pacing.RevealAt(i + 1)))
+			}
+			e.gameService.BroadcastPick(drawCtx, pick, nextReveal)
+			nextGame, _ = e.checkClock(drawCtx, nextGame, deadline)
 
 			// Also broadcast updated state with all revealed picks so far
-			e.gameService.BroadcastState(sdk.GameStateEvent{
+			e.gameService.BroadcastState(drawCtx, sdk.GameStateEvent{
 				GameID:   game.ID,
+				Season:   seasonID,
 				Picks:    picks[:i+1],
 				NextGame: nextGame,
+				Special:  specialInfo,
 			})
+			e.progress()
 		}
 	}
 
 	// Game complete
-	e.logger.Info("Game complete", slog.Int64("game_id", game.ID))
-	e.gameService.BroadcastComplete(game.ID)
+	waitCtx := slogx.With(ctx, slog.String("phase", "wait"))
+	slogx.FromContext(waitCtx).Info("Game complete")
+	e.gameService.BroadcastComplete(waitCtx, game)
+	e.progress()
+	e.checkDuplicates(waitCtx, game)
+
+	// Wait phase: runs until the deadline rather than for waitDuration, so
+	// the next game starts when nextGame said it would.
+	wait := time.NewTimer(time.Until(deadline))
+	defer wait.Stop()
+	clock := time.NewTicker(clockCheckInterval)
+	defer clock.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait.C:
+			return nil
+		case <-clock.C:
+			corrected, jumped := e.checkClock(waitCtx, nextGame, deadline)
+			if !jumped {
+				continue
+			}
+			nextGame = corrected
+			e.gameService.BroadcastState(waitCtx, sdk.GameStateEvent{
+				GameID:   game.ID,
+				Season:   seasonID,
+				Picks:    picks,
+				NextGame: nextGame,
+				Special:  specialInfo,
+			})
+			e.progress()
+		}
+	}
+}
+
+// scheduleSpecial returns the special game to run as the game starting at
+// start, removing it from the schedule, or nil to run a regular game. A
+// special runs as the game being drawn when it starts; if the next one
+// starts after this game's draw but before deadline, the wait is cut short
+// so it starts on time, and the shortened deadline is returned. A schedule
+// that can't be read is logged and ignored.
+func (e *Engine) scheduleSpecial(ctx context.Context, start, deadline time.Time) (*domain.SpecialGame, time.Time) {
+	if e.specials == nil {
+		return nil, deadline
+	}
+
+	drawEnd := start.Add(e.config.DrawDuration.Duration())
+	special, err := storeCall(ctx, e, "take_special", func(ctx context.Context) (*domain.SpecialGame, error) {
+		return e.specials.Take(ctx, drawEnd)
+	})
+	if err != nil {
+		slogx.FromContext(ctx).Warn("Failed to take due special game", slogx.Error(err))
+	}
 
-	// Wait phase
+	next, err := storeCall(ctx, e, "next_special", e.specials.Next)
+	if err != nil {
+		slogx.FromContext(ctx).Warn("Failed to get next special game", slogx.Error(err))
+		return special, deadline
+	}
+	if next != nil && !next.StartsAt.Before(drawEnd) && next.StartsAt.Before(deadline) {
+		deadline = start.Add(next.StartsAt.Sub(start))
+	}
+	return special, deadline
+}
+
+// storeCall runs fn, a store operation named op, under a context bounded
+// by game.store_timeout. If the deadline passes first, storeCall returns
+// without waiting for fn, so a hung database can't wedge the game loop, and
+// broadcasts an engine:degraded event. fn's result is then discarded.
+func storeCall[T any](ctx context.Context, e *Engine, op string, fn func(context.Context) (T, error)) (T, error) {
+	timeout := e.config.StoreTimeout.Duration()
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn(callCtx)
+		done <- result{v, err}
+	}()
+
+	var res result
 	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-time.After(waitDuration):
-		return nil
+	case res = <-done:
+	case <-callCtx.Done():
+		res.err = callCtx.Err()
 	}
+
+	timedOut := errors.Is(res.err, store.ErrTimeout) || errors.Is(callCtx.Err(), context.DeadlineExceeded)
+	if res.err != nil && timedOut && ctx.Err() == nil {
+		slogx.FromContext(ctx).Error("Engine store call exceeded deadline",
+			slog.String("operation", op),
+			slog.Duration("timeout", timeout),
+			slogx.Error(res.err),
+		)
+		e.gameService.BroadcastDegraded(ctx, op, "timeout")
+	}
+	return res.v, res.err
+}
+
+// nextPicks returns the picks for game id: drawn locally, or from the
+// external source once it publishes them. External picks are validated
+// against the game config when the game is created, like local ones.
+func (e *Engine) nextPicks(ctx context.Context, id int64) ([]uint8, error) {
+	if e.source == nil {
+		return e.generatePicks(id), nil
+	}
+
+	e.awaiting.Store(true)
+	result, err := e.source.Next(ctx)
+	e.awaiting.Store(false)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for external result: %w", err)
+	}
+	slogx.FromContext(ctx).Info("External result received", slog.String("ref", result.Ref))
+	return result.Picks, nil
+}
+
+// generatePicks draws the picks for game id under the configured variant,
+// seeded by the game ID when the engine has a seed.
+func (e *Engine) generatePicks(id int64) []uint8 {
+	if e.seeded {
+		rng := mrand.New(mrand.NewPCG(e.seed, uint64(id))) //nolint:gosec // deterministic mode is for tests and demos
+		return variantFor(e.config).Draw(e.config, rng.IntN)
+	}
+	return drawPicks(e.config)
+}
+
+// drawPicks draws a game's picks under cfg's variant, in draw order.
+func drawPicks(cfg *config.GameConfig) []uint8 {
+	// crypto/rand for secure randomness
+	return variantFor(cfg).Draw(cfg, func(n int) int {
+		v, _ := rand.Int(rand.Reader, big.NewInt(int64(n)))
+		return int(v.Int64())
+	})
 }
 
-// generatePicks generates random unique picks for a game.
-func (e *Engine) generatePicks() []uint8 {
+// shufflePicks draws cfg.PickCount unique numbers from cfg's range, in draw
+// order, with intN returning a random number in [0, n).
+func shufflePicks(cfg *config.GameConfig, intN func(n int) int) []uint8 {
 	// Create a pool of all possible numbers
-	pool := make([]uint8, e.config.MaxNumber)
+	pool := make([]uint8, cfg.MaxNumber-cfg.MinNumber+1)
 	for i := range pool {
-		pool[i] = uint8(i + 1) //nolint:gosec // MaxNumber is validated <= 80, fits in uint8
+		pool[i] = uint8(cfg.MinNumber + i) //nolint:gosec // MaxNumber is validated <= 255, fits in uint8
 	}
 
-	// Fisher-Yates shuffle using crypto/rand for secure randomness
+	// Fisher-Yates shuffle
 	for i := len(pool) - 1; i > 0; i-- {
-		n, _ := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
-		j := int(n.Int64())
+		j := intN(i + 1)
 		pool[i], pool[j] = pool[j], pool[i]
 	}
 
 	// Take the first PickCount numbers
-	return pool[:e.config.PickCount]
+	return pool[:cfg.PickCount]
 }
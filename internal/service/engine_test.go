@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestEngine_GeneratePicks(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.GameConfig
+	}{
+		{"classic", config.GameConfig{PickCount: 20, MinNumber: 1, MaxNumber: 80}},
+		{"offset", config.GameConfig{PickCount: 20, MinNumber: 10, MaxNumber: 70}},
+		{"exact range", config.GameConfig{PickCount: 5, MinNumber: 50, MaxNumber: 54}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(NewGameService(newMockStore(), &tt.cfg), &tt.cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			for range 50 {
+				picks := engine.generatePicks(1)
+				if len(picks) != tt.cfg.PickCount {
+					t.Fatalf("expected %d picks, got %d", tt.cfg.PickCount, len(picks))
+				}
+
+				seen := make(map[uint8]bool)
+				for _, p := range picks {
+					if int(p) < tt.cfg.MinNumber || int(p) > tt.cfg.MaxNumber {
+						t.Fatalf("pick %d outside %d-%d", p, tt.cfg.MinNumber, tt.cfg.MaxNumber)
+					}
+					if seen[p] {
+						t.Fatalf("duplicate pick %d in %v", p, picks)
+					}
+					seen[p] = true
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_SeededPicks(t *testing.T) {
+	cfg := defaultGameConfig()
+	newEngine := func(seed uint64) *Engine {
+		engine := NewEngine(NewGameService(newMockStore(), cfg), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		engine.SetSeed(seed)
+		return engine
+	}
+
+	a, b := newEngine(42), newEngine(42)
+	if !slices.Equal(a.generatePicks(7), b.generatePicks(7)) {
+		t.Error("expected the same seed and game ID to draw the same picks")
+	}
+	if slices.Equal(a.generatePicks(7), a.generatePicks(8)) {
+		t.Error("expected different game IDs to draw different picks")
+	}
+	if slices.Equal(a.generatePicks(7), newEngine(43).generatePicks(7)) {
+		t.Error("expected different seeds to draw different picks")
+	}
+}
+
+func TestStoreCall(t *testing.T) {
+	cfg := defaultGameConfig()
+	cfg.StoreTimeout = config.Duration(20 * time.Millisecond)
+	gameService := NewGameService(newMockStore(), cfg)
+	engine := NewEngine(gameService, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := gameService.Subscribe(ctx)
+
+	got, err := storeCall(ctx, engine, "fast", func(context.Context) (int, error) {
+		return 42, nil
+	})
+	if got != 42 || err != nil {
+		t.Fatalf("storeCall() = %d, %v; want 42, nil", got, err)
+	}
+
+	// A call that ignores its context is abandoned at the deadline
+	hung := make(chan struct{})
+	defer close(hung)
+	start := time.Now()
+	_, err = storeCall(ctx, engine, "create_game", func(context.Context) (int, error) {
+		<-hung
+		return 0, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("storeCall() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("storeCall() took %s, want it bounded by the store timeout", elapsed)
+	}
+
+	select {
+	case event := <-events:
+		degraded, ok := event.Data.(sdk.EngineDegradedEvent)
+		if event.Type != sdk.EventEngineDegraded || !ok || degraded.Operation != "create_game" {
+			t.Errorf("unexpected event %s: %+v", event.Type, event.Data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for engine:degraded")
+	}
+}
+
+// fakeSource is a ResultSource returning queued results.
+type fakeSource struct {
+	results []Result
+}
+
+func (f *fakeSource) Next(ctx context.Context) (Result, error) {
+	if len(f.results) == 0 {
+		<-ctx.Done()
+		return Result{}, ctx.Err()
+	}
+	r := f.results[0]
+	f.results = f.results[1:]
+	return r, nil
+}
+
+func TestEngine_NextPicksFromSource(t *testing.T) {
+	cfg := defaultGameConfig()
+	engine := NewEngine(NewGameService(newMockStore(), cfg), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	engine.SetSource(&fakeSource{results: []Result{{Ref: "42", Picks: sdk.Picks{3, 1, 2}}}})
+
+	picks, err := engine.nextPicks(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("nextPicks: %v", err)
+	}
+	if !slices.Equal(picks, []uint8{3, 1, 2}) {
+		t.Errorf("expected source picks [3 1 2], got %v", picks)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := engine.nextPicks(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded while waiting, got %v", err)
+	}
+}
+
+func TestEngine_ScheduleSpecial(t *testing.T) {
+	cfg := defaultGameConfig()
+	ms := newMockStore()
+	settings := NewSettingsService(ms)
+	engine := NewEngine(NewGameService(ms, cfg), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	engine.SetSpecials(NewSpecialSchedule(settings))
+	ctx := context.Background()
+
+	start := time.Now()
+	deadline := start.Add(3 * time.Minute)
+	specials := []*domain.SpecialGame{
+		// Starts during this game's draw, so this game runs as it
+		{ID: 1, Name: "Launch", StartsAt: start.Add(30 * time.Second)},
+		// Starts during the wait, so the wait is cut short for it
+		{ID: 2, Name: "Finals", StartsAt: start.Add(2 * time.Minute)},
+	}
+	if err := settings.Set(ctx, SettingSpecialGames, specials); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+
+	special, got := engine.scheduleSpecial(ctx, start, deadline)
+	if special == nil || special.Name != "Launch" {
+		t.Errorf("scheduleSpecial() special = %+v, want Launch", special)
+	}
+	if want := start.Add(2 * time.Minute); !got.Equal(want) {
+		t.Errorf("scheduleSpecial() deadline = %v, want %v", got.Sub(start), want.Sub(start))
+	}
+
+	// The next game starts as Finals does
+	start = start.Add(2 * time.Minute)
+	if special, _ := engine.scheduleSpecial(ctx, start, start.Add(3*time.Minute)); special == nil || special.Name != "Finals" {
+		t.Errorf("scheduleSpecial() special = %+v, want Finals", special)
+	}
+
+	// With none left, the game is regular and keeps its deadline
+	special, got = engine.scheduleSpecial(ctx, start, start.Add(3*time.Minute))
+	if special != nil || !got.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("scheduleSpecial() = %+v, %v, want regular game with full cycle", special, got.Sub(start))
+	}
+}
+
+func TestEngine_IdleWhileEmpty(t *testing.T) {
+	cfg := defaultGameConfig()
+	cfg.IdleWhenEmpty = true
+	cfg.IdleAfter = config.Duration(time.Millisecond)
+	svc := NewGameService(newMockStore(), cfg)
+	engine := NewEngine(svc, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	time.Sleep(2 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() { done <- engine.idleWhileEmpty(t.Context()) }()
+
+	deadline := time.Now().Add(time.Second)
+	for !engine.Idle() {
+		if time.Now().After(deadline) {
+			t.Fatal("engine did not idle with no clients connected")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	conn := svc.Connect(TransportSSE, "203.0.113.7")
+	defer conn.Close(pubsub.SubscriptionStats{})
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("idleWhileEmpty() error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("engine did not resume once a client connected")
+	}
+	if engine.Idle() {
+		t.Error("Idle() = true after resuming")
+	}
+
+	// With a client connected the loop runs on
+	if err := engine.idleWhileEmpty(t.Context()); err != nil || engine.Idle() {
+		t.Errorf("idleWhileEmpty() = %v with a client connected, want no pause", err)
+	}
+}
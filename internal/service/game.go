@@ -2,87 +2,562 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aussiebroadwan/taboo/internal/config"
 	"github.com/aussiebroadwan/taboo/internal/domain"
 	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/numwords"
 	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
 	"github.com/aussiebroadwan/taboo/sdk"
 )
 
+var (
+	// ErrSeasonEmpty is returned when rolling a new season before the
+	// current season has any games.
+	ErrSeasonEmpty = errors.New("current season has no games")
+
+	// ErrInvalidHints is returned when UI hints exceed the configured bounds.
+	ErrInvalidHints = errors.New("invalid hints")
+
+	// ErrMissingHash is returned when importing a game without a result
+	// hash.
+	ErrMissingHash = errors.New("game has no result hash")
+)
+
+// Bounds on operator-set UI hints, which are sent with every state event.
+const (
+	maxHints        = 32
+	maxHintKeyLen   = 64
+	maxHintValueLen = 256
+)
+
+// recentEventsSize is the default number of broadcast events retained for
+// catch-up by transports that cannot hold a stream open (e.g. long-polling).
+const recentEventsSize = 64
+
 // Event represents a game event to be broadcast to subscribers.
 type Event struct {
+	Seq  int64
 	Type string
 	Data any
 }
 
+// IsLifecycleEvent reports whether event is a game:state or game:complete
+// event. Clients settling tickets off the stream need every one, so they
+// are given priority over picks and heartbeats when a client falls behind.
+func IsLifecycleEvent(event Event) bool {
+	return event.Type == sdk.EventGameState || event.Type == sdk.EventGameComplete
+}
+
 // GameService handles game business logic and event broadcasting.
 type GameService struct {
 	store  store.Store
 	config *config.GameConfig
 	broker *pubsub.Broker[Event]
+
+	// conns publishes client connection lifecycle events.
+	conns *connections
+
+	mu          sync.RWMutex
+	seq         int64
+	recent      []Event
+	recentSize  int
+	lastEventAt time.Time
+
+	// seasonMu guards season, the cached current season, and serializes
+	// season rollovers.
+	seasonMu sync.Mutex
+	season   *domain.Season
+
+	// hintsMu guards hints and lastState, the most recent state broadcast
+	// with the hints it carried.
+	hintsMu   sync.RWMutex
+	hints     map[string]string
+	lastState *sdk.GameStateEvent
+
+	// guard drops pick and state broadcasts that would show an invalid draw.
+	guard *drawGuard
+
+	// drawing is the ID of the game being drawn, or 0 between games.
+	drawing atomic.Int64
+
+	// skew offsets the wall-clock times stamped on events; see
+	// Engine.SetClockSkew.
+	skew time.Duration
+
+	// voice, if set, holds recorded calls that pick events link to.
+	voice *VoicePack
 }
 
-// NewGameService creates a new GameService.
-func NewGameService(store store.Store, cfg *config.GameConfig) *GameService {
+// NewGameService creates a new GameService. opts configure the broker
+// events are broadcast through, e.g. its subscriber limit.
+func NewGameService(store store.Store, cfg *config.GameConfig, opts ...pubsub.Option[Event]) *GameService {
 	return &GameService{
-		store:  store,
-		config: cfg,
-		broker: pubsub.New[Event](),
+		store:      store,
+		config:     cfg,
+		broker:     pubsub.New(opts...),
+		conns:      newConnections(),
+		guard:      &drawGuard{config: cfg},
+		recentSize: recentEventsSize,
 	}
 }
 
+// SetVoicePack makes pick events link to the recorded call for each pick in
+// p, as call_audio. It must be called before the first broadcast.
+func (s *GameService) SetVoicePack(p *VoicePack) {
+	s.voice = p
+}
+
+// VoicePack returns the voice pack set with SetVoicePack, or nil.
+func (s *GameService) VoicePack() *VoicePack {
+	return s.voice
+}
+
+// SetHistorySize sets how many broadcast events are retained for EventsSince.
+// It must be called before the first broadcast.
+func (s *GameService) SetHistorySize(n int) {
+	s.recentSize = n
+}
+
 // Subscribe returns a channel that receives game events.
 // The caller should cancel the context when done to unsubscribe.
 func (s *GameService) Subscribe(ctx context.Context) <-chan Event {
 	return s.broker.Subscribe(ctx)
 }
 
-// Broadcast sends an event to all subscribers.
-func (s *GameService) Broadcast(event Event) {
-	s.broker.Publish(event)
+// Join subscribes a client stream to game events, subject to the broker's
+// subscriber limit, returning the subscription so its delivery can be
+// reported when the client leaves.
+func (s *GameService) Join(ctx context.Context) (*pubsub.Subscription[Event], error) {
+	return s.broker.Join(ctx)
+}
+
+// StreamStats returns event delivery counters across all subscribers.
+func (s *GameService) StreamStats() pubsub.Stats {
+	return s.broker.Stats()
+}
+
+// Broadcast assigns the next sequence number to an event and sends it to
+// all subscribers. Events are published under the lock so subscribers always
+// observe them in sequence order. Drops for slow subscribers are logged with
+// the logger from ctx.
+func (s *GameService) Broadcast(ctx context.Context, event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	event.Seq = s.seq
+	s.lastEventAt = time.Now()
+
+	s.recent = append(s.recent, event)
+	if len(s.recent) > s.recentSize {
+		s.recent = s.recent[len(s.recent)-s.recentSize:]
+	}
+
+	if dropped := s.broker.Publish(event); dropped > 0 {
+		slogx.FromContext(ctx).Warn("Dropped event for slow subscribers",
+			slog.String("event", event.Type),
+			slog.Int64("seq", event.Seq),
+			slog.Int("dropped", dropped),
+		)
+	}
+}
+
+// LastSeq returns the sequence number of the most recently broadcast event,
+// or 0 if nothing has been broadcast yet.
+func (s *GameService) LastSeq() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.seq
+}
+
+// LastEventAt returns when the most recent event was broadcast, or the zero
+// time if nothing has been broadcast yet.
+func (s *GameService) LastEventAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastEventAt
+}
+
+// EventsSince returns the retained events with a sequence number greater
+// than seq, oldest first. Events older than the retention window are not
+// returned, so callers far behind should resync from the REST API.
+func (s *GameService) EventsSince(seq int64) []Event {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i, e := range s.recent {
+		if e.Seq > seq {
+			return append([]Event(nil), s.recent[i:]...)
+		}
+	}
+	return nil
 }
 
-// BroadcastState broadcasts a game state event.
-func (s *GameService) BroadcastState(state sdk.GameStateEvent) {
-	s.Broadcast(Event{
+// BroadcastState broadcasts a game state event with the current UI hints
+// attached. States that repeat or rewrite picks for the current game are
+// logged and dropped.
+func (s *GameService) BroadcastState(ctx context.Context, state sdk.GameStateEvent) {
+	if err := s.guard.checkState(state); err != nil {
+		logViolation(ctx, sdk.EventGameState, err)
+		return
+	}
+
+	s.hintsMu.Lock()
+	state.Hints = s.hints
+	s.lastState = &state
+	s.hintsMu.Unlock()
+
+	s.Broadcast(ctx, Event{
 		Type: sdk.EventGameState,
 		Data: state,
 	})
 }
 
-// BroadcastPick broadcasts a pick event.
-func (s *GameService) BroadcastPick(pick uint8) {
-	s.Broadcast(Event{
+// LastState returns the most recent game state broadcast, or nil if there
+// hasn't been one yet.
+func (s *GameService) LastState() *sdk.GameStateEvent {
+	s.hintsMu.RLock()
+	defer s.hintsMu.RUnlock()
+	if s.lastState == nil {
+		return nil
+	}
+	state := *s.lastState
+	return &state
+}
+
+// Hints returns a copy of the current UI hints.
+func (s *GameService) Hints() map[string]string {
+	s.hintsMu.RLock()
+	defer s.hintsMu.RUnlock()
+	return maps.Clone(s.hints)
+}
+
+// ValidateHints checks UI hints against the size bounds.
+func ValidateHints(hints map[string]string) error {
+	if len(hints) > maxHints {
+		return fmt.Errorf("%w: at most %d hints allowed, got %d", ErrInvalidHints, maxHints, len(hints))
+	}
+	for k, v := range hints {
+		if k == "" || len(k) > maxHintKeyLen {
+			return fmt.Errorf("%w: key %q must be 1-%d bytes", ErrInvalidHints, k, maxHintKeyLen)
+		}
+		if len(v) > maxHintValueLen {
+			return fmt.Errorf("%w: value for %q exceeds %d bytes", ErrInvalidHints, k, maxHintValueLen)
+		}
+	}
+	return nil
+}
+
+// SetHints replaces the UI hints, which reach clients with the engine's next
+// state event. Only the engine broadcasts states, so a change can't resend
+// one older than the engine has already sent. An empty map clears them.
+func (s *GameService) SetHints(hints map[string]string) error {
+	if err := ValidateHints(hints); err != nil {
+		return err
+	}
+
+	if len(hints) == 0 {
+		hints = nil
+	}
+
+	s.hintsMu.Lock()
+	s.hints = maps.Clone(hints)
+	s.hintsMu.Unlock()
+	return nil
+}
+
+// BroadcastPick broadcasts a pick event revealed now, with nextReveal until
+// the following pick (zero after the last pick of a game). Picks that are
+// out of range or already revealed this game are logged and dropped.
+func (s *GameService) BroadcastPick(ctx context.Context, pick uint8, nextReveal time.Duration) {
+	if err := s.guard.checkPick(pick); err != nil {
+		logViolation(ctx, sdk.EventGamePick, err)
+		return
+	}
+
+	// An unsupported locale leaves call_text out
+	callText, _ := numwords.Spell(s.config.CallLocale, int(pick))
+	var callAudio string
+	if s.voice != nil && s.voice.Has(pick) {
+		callAudio = CallAudioPath(pick)
+	}
+
+	s.Broadcast(ctx, Event{
 		Type: sdk.EventGamePick,
-		Data: sdk.GamePickEvent{Pick: pick},
+		Data: sdk.GamePickEvent{
+			Pick:           pick,
+			RevealedAt:     s.now().Round(0),
+			NextRevealInMS: nextReveal.Milliseconds(),
+			CallText:       callText,
+			CallAudio:      callAudio,
+		},
 	})
 }
 
-// BroadcastComplete broadcasts a game complete event.
-func (s *GameService) BroadcastComplete(gameID int64) {
-	s.Broadcast(Event{
+// now returns the wall-clock time stamped on events.
+func (s *GameService) now() time.Time {
+	return time.Now().Add(s.skew)
+}
+
+// BroadcastComplete broadcasts a game complete event, with the outcome of
+// the game's picks under the configured variant.
+func (s *GameService) BroadcastComplete(ctx context.Context, game *domain.Game) {
+	s.drawing.CompareAndSwap(game.ID, 0)
+	variant := variantFor(s.config)
+	s.Broadcast(ctx, Event{
 		Type: sdk.EventGameComplete,
-		Data: sdk.GameCompleteEvent{GameID: gameID},
+		Data: sdk.GameCompleteEvent{
+			GameID:  game.ID,
+			Variant: variant.Name(),
+			Outcome: variant.Outcome(s.config, game.Picks),
+		},
 	})
 }
 
-// GetGame retrieves a game by ID.
+// BroadcastDegraded broadcasts an engine degraded event for a store call
+// that failed to complete in time.
+func (s *GameService) BroadcastDegraded(ctx context.Context, operation, reason string) {
+	s.Broadcast(ctx, Event{
+		Type: sdk.EventEngineDegraded,
+		Data: sdk.EngineDegradedEvent{Operation: operation, Reason: reason},
+	})
+}
+
+// SyncHints loads the UI hints persisted in settings and applies every
+// later change until ctx is cancelled. Invalid stored hints are logged and
+// ignored.
+func (s *GameService) SyncHints(ctx context.Context, settings *SettingsService) {
+	changes := settings.Subscribe(ctx)
+
+	var hints map[string]string
+	err := settings.Get(ctx, SettingUIHints, &hints)
+	switch {
+	case err == nil:
+		s.applyHints(ctx, hints)
+	case !errors.Is(err, store.ErrNotFound):
+		slogx.FromContext(ctx).Warn("Failed to load UI hints", slogx.Error(err))
+	}
+
+	for change := range changes {
+		if change.Key != SettingUIHints {
+			continue
+		}
+
+		hints = nil
+		if change.Value != nil {
+			if err := json.Unmarshal(change.Value, &hints); err != nil {
+				slogx.FromContext(ctx).Warn("Ignoring malformed UI hints", slogx.Error(err))
+				continue
+			}
+		}
+		s.applyHints(ctx, hints)
+	}
+}
+
+func (s *GameService) applyHints(ctx context.Context, hints map[string]string) {
+	if err := s.SetHints(hints); err != nil {
+		slogx.FromContext(ctx).Warn("Ignoring invalid UI hints", slogx.Error(err))
+	}
+}
+
+// GetGame retrieves a game by ID. A stored game that fails validation is
+// logged and still returned; see checkStored.
 func (s *GameService) GetGame(ctx context.Context, id int64) (*domain.Game, error) {
-	return s.store.GetGame(ctx, id)
+	game, err := s.store.GetGame(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.checkStored(ctx, game)
+	return game, nil
 }
 
-// ListGames retrieves games with cursor pagination.
+// ListGames retrieves games with cursor pagination. Stored games that fail
+// validation are logged and still returned.
 func (s *GameService) ListGames(ctx context.Context, cursor int64, limit int) ([]*domain.Game, error) {
-	return s.store.ListGames(ctx, cursor, limit)
+	games, err := s.store.ListGames(ctx, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, game := range games {
+		s.checkStored(ctx, game)
+	}
+	return games, nil
 }
 
-// CreateGame persists a new game.
+// CreateGame hashes, validates and persists a new game, marking it as
+// drawing first so it is never reported complete once it can be read.
 func (s *GameService) CreateGame(ctx context.Context, game *domain.Game) error {
-	return s.store.CreateGame(ctx, game)
+	if game.ResultHash == "" {
+		game.ResultHash = game.Hash()
+	}
+	if err := s.validate(game); err != nil {
+		return err
+	}
+	prev := s.drawing.Swap(game.ID)
+	if err := s.store.CreateGame(ctx, game); err != nil {
+		s.drawing.CompareAndSwap(game.ID, prev)
+		return err
+	}
+	return nil
+}
+
+// ImportGame validates and persists a completed game replicated from
+// another instance, then broadcasts its completion. The game must carry a
+// result hash matching its picks.
+func (s *GameService) ImportGame(ctx context.Context, game *domain.Game) error {
+	if game.ResultHash == "" {
+		return fmt.Errorf("game %d: %w", game.ID, ErrMissingHash)
+	}
+	if err := s.validate(game); err != nil {
+		return err
+	}
+	if err := s.store.CreateGame(ctx, game); err != nil {
+		return err
+	}
+	s.BroadcastComplete(ctx, game)
+	return nil
 }
 
-// GetLatestGame retrieves the most recent game.
+// IsComplete reports whether the game with the given ID has finished
+// drawing, i.e. it is not the game this process is currently drawing. Its
+// picks will not change again.
+func (s *GameService) IsComplete(id int64) bool {
+	return s.drawing.Load() != id
+}
+
+// GetLatestGame retrieves the most recent game. A stored game that fails
+// validation is logged and still returned.
 func (s *GameService) GetLatestGame(ctx context.Context) (*domain.Game, error) {
-	return s.store.GetLatestGame(ctx)
+	game, err := s.store.GetLatestGame(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.checkStored(ctx, game)
+	return game, nil
+}
+
+// CurrentSeason returns the current season. It is cached after the first
+// lookup and refreshed by RollSeason.
+func (s *GameService) CurrentSeason(ctx context.Context) (*domain.Season, error) {
+	s.seasonMu.Lock()
+	defer s.seasonMu.Unlock()
+
+	if s.season != nil {
+		return s.season, nil
+	}
+
+	season, err := s.store.GetCurrentSeason(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.season = season
+	return season, nil
+}
+
+// GetSeason retrieves a season by ID.
+func (s *GameService) GetSeason(ctx context.Context, id int64) (*domain.Season, error) {
+	return s.store.GetSeason(ctx, id)
+}
+
+// ListSeasons retrieves all seasons, oldest first.
+func (s *GameService) ListSeasons(ctx context.Context) ([]*domain.Season, error) {
+	return s.store.ListSeasons(ctx)
+}
+
+// RollSeason closes the current season and starts a new one beginning with
+// the next game. The game in progress, if any, stays in the old season. An
+// empty name defaults to "Season N".
+func (s *GameService) RollSeason(ctx context.Context, name string) (*domain.Season, error) {
+	s.seasonMu.Lock()
+	defer s.seasonMu.Unlock()
+
+	current, err := s.store.GetCurrentSeason(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastGameID int64
+	latest, err := s.store.GetLatestGame(ctx)
+	switch {
+	case err == nil:
+		lastGameID = latest.ID
+	case !errors.Is(err, store.ErrNotFound):
+		return nil, err
+	}
+
+	if lastGameID < current.FirstGameID {
+		return nil, ErrSeasonEmpty
+	}
+
+	next := &domain.Season{
+		ID:          current.ID + 1,
+		Name:        name,
+		FirstGameID: lastGameID + 1,
+		StartedAt:   time.Now(),
+	}
+	if next.Name == "" {
+		next.Name = fmt.Sprintf("Season %d", next.ID)
+	}
+
+	if err := s.store.CreateSeason(ctx, next); err != nil {
+		return nil, err
+	}
+
+	s.season = next
+	return next, nil
+}
+
+// ListSeasonGames retrieves games from a single season with cursor
+// pagination. The cursor is clamped to the start of the season and results
+// stop at the season's end.
+func (s *GameService) ListSeasonGames(ctx context.Context, seasonID, cursor int64, limit int) ([]*domain.Game, error) {
+	season, err := s.store.GetSeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.ListGames(ctx, max(cursor, season.FirstGameID), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, game := range games {
+		if !season.Contains(game.ID) {
+			return games[:i], nil
+		}
+	}
+	return games, nil
+}
+
+// validate checks a game against the game config, returning an
+// *domain.InvalidGameError if it has any error-level issues. Only games
+// being written are validated; see checkStored for those read back.
+func (s *GameService) validate(game *domain.Game) error {
+	if issues := game.Validate(*s.config); issues.HasErrors() {
+		return &domain.InvalidGameError{Game: game, Issues: issues}
+	}
+	return nil
+}
+
+// checkStored logs a stored game that fails validation against the current
+// config. Games were validated when written, and one drawn before
+// pick_count or the number range changed fails now without being wrong,
+// so reads never reject it; taboo db audit checks stored games in full.
+func (s *GameService) checkStored(ctx context.Context, game *domain.Game) {
+	if err := s.validate(game); err != nil {
+		slogx.FromContext(ctx).Debug("Stored game does not match the current game config",
+			slog.Int64("game_id", game.ID),
+			slogx.Error(err),
+		)
+	}
 }
@@ -1,14 +1,20 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aussiebroadwan/taboo/internal/config"
 	"github.com/aussiebroadwan/taboo/internal/domain"
 	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
 	"github.com/aussiebroadwan/taboo/sdk"
 )
 
@@ -16,16 +22,24 @@ import (
 type mockStore struct {
 	games      map[int64]*domain.Game
 	latestGame *domain.Game
+	seasons    []*domain.Season
+	settings   map[string]string
 
 	createErr error
 	getErr    error
 	listErr   error
 	latestErr error
+
+	// onCreate, if set, runs once CreateGame has stored a game, before it
+	// returns.
+	onCreate func(*domain.Game)
 }
 
 func newMockStore() *mockStore {
 	return &mockStore{
-		games: make(map[int64]*domain.Game),
+		games:    make(map[int64]*domain.Game),
+		seasons:  []*domain.Season{{ID: 1, Name: "Season 1", FirstGameID: 1}},
+		settings: make(map[string]string),
 	}
 }
 
@@ -37,12 +51,19 @@ func (m *mockStore) Close() error {
 	return nil
 }
 
+func (m *mockStore) Stats(ctx context.Context) (*store.Stats, error) {
+	return &store.Stats{Rows: map[string]int64{}}, nil
+}
+
 func (m *mockStore) CreateGame(ctx context.Context, game *domain.Game) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
 	m.games[game.ID] = game
 	m.latestGame = game
+	if m.onCreate != nil {
+		m.onCreate(game)
+	}
 	return nil
 }
 
@@ -67,13 +88,62 @@ func (m *mockStore) GetLatestGame(ctx context.Context) (*domain.Game, error) {
 	return m.latestGame, nil
 }
 
+func (m *mockStore) CreateSeason(ctx context.Context, season *domain.Season) error {
+	m.seasons[len(m.seasons)-1].EndGameID = season.FirstGameID
+	m.seasons = append(m.seasons, season)
+	return nil
+}
+
+func (m *mockStore) GetSeason(ctx context.Context, id int64) (*domain.Season, error) {
+	for _, s := range m.seasons {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *mockStore) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	return m.seasons[len(m.seasons)-1], nil
+}
+
+func (m *mockStore) ListSeasons(ctx context.Context) ([]*domain.Season, error) {
+	return m.seasons, nil
+}
+
+func (m *mockStore) GetSetting(ctx context.Context, key string) (string, error) {
+	value, ok := m.settings[key]
+	if !ok {
+		return "", store.ErrNotFound
+	}
+	return value, nil
+}
+
+func (m *mockStore) ListSettings(ctx context.Context) (map[string]string, error) {
+	return maps.Clone(m.settings), nil
+}
+
+func (m *mockStore) SetSetting(ctx context.Context, key, value string) error {
+	m.settings[key] = value
+	return nil
+}
+
+func (m *mockStore) DeleteSetting(ctx context.Context, key string) error {
+	if _, ok := m.settings[key]; !ok {
+		return store.ErrNotFound
+	}
+	delete(m.settings, key)
+	return nil
+}
+
+// ListGames mirrors the sqlite store: games with ID >= startID, in ID order.
 func (m *mockStore) ListGames(ctx context.Context, startID int64, limit int) ([]*domain.Game, error) {
 	if m.listErr != nil {
 		return nil, m.listErr
 	}
 	var result []*domain.Game
-	for _, g := range m.games {
-		if g.ID > startID {
+	for _, id := range slices.Sorted(maps.Keys(m.games)) {
+		if g := m.games[id]; g.ID >= startID {
 			result = append(result, g)
 			if len(result) >= limit {
 				break
@@ -83,11 +153,21 @@ func (m *mockStore) ListGames(ctx context.Context, startID int64, limit int) ([]
 	return result, nil
 }
 
+// testPicks returns a valid set of picks for the default game config.
+func testPicks() []uint8 {
+	picks := make([]uint8, 20)
+	for i := range picks {
+		picks[i] = uint8(i + 1)
+	}
+	return picks
+}
+
 func defaultGameConfig() *config.GameConfig {
 	return &config.GameConfig{
 		DrawDuration: config.Duration(90 * time.Second),
 		WaitDuration: config.Duration(90 * time.Second),
 		PickCount:    20,
+		MinNumber:    1,
 		MaxNumber:    80,
 	}
 }
@@ -98,7 +178,7 @@ func TestGameService_GetGame_Success(t *testing.T) {
 
 	game := &domain.Game{
 		ID:        1,
-		Picks:     []uint8{1, 2, 3, 4, 5},
+		Picks:     testPicks(),
 		CreatedAt: time.Now(),
 	}
 	store.games[1] = game
@@ -127,7 +207,7 @@ func TestGameService_ListGames_Success(t *testing.T) {
 	svc := NewGameService(store, defaultGameConfig())
 
 	for i := int64(1); i <= 5; i++ {
-		store.games[i] = &domain.Game{ID: i, Picks: []uint8{uint8(i % 256)}} //nolint:gosec // test values are within uint8 range
+		store.games[i] = &domain.Game{ID: i, Picks: testPicks()}
 	}
 
 	games, err := svc.ListGames(context.Background(), 0, 10)
@@ -158,7 +238,7 @@ func TestGameService_CreateGame_Success(t *testing.T) {
 
 	game := &domain.Game{
 		ID:        1,
-		Picks:     []uint8{10, 20, 30},
+		Picks:     testPicks(),
 		CreatedAt: time.Now(),
 	}
 
@@ -172,13 +252,76 @@ func TestGameService_CreateGame_Success(t *testing.T) {
 	}
 }
 
+func TestGameService_CreateGame_Invalid(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	game := &domain.Game{
+		ID:        1,
+		Picks:     []uint8{1, 1, 81},
+		CreatedAt: time.Now(),
+	}
+
+	err := svc.CreateGame(context.Background(), game)
+
+	var invalid *domain.InvalidGameError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected InvalidGameError, got %v", err)
+	}
+	if len(invalid.Issues) != 3 {
+		t.Errorf("expected 3 issues, got %d: %v", len(invalid.Issues), invalid.Issues)
+	}
+	if store.games[1] != nil {
+		t.Error("invalid game should not be persisted")
+	}
+}
+
+// Games drawn before the game config changed no longer validate against
+// it, but are still read back.
+func TestGameService_GetGame_InvalidStored(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	store.games[1] = &domain.Game{ID: 1, Picks: []uint8{1, 2, 3}}
+
+	game, err := svc.GetGame(context.Background(), 1)
+	if err != nil || game == nil || game.ID != 1 {
+		t.Fatalf("expected game 1, got %v, %v", game, err)
+	}
+}
+
+func TestGameService_ListGames_InvalidStored(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	store.games[1] = &domain.Game{ID: 1, Picks: []uint8{1, 2, 3}}
+	store.games[2] = &domain.Game{ID: 2, Picks: testPicks()}
+
+	games, err := svc.ListGames(context.Background(), 1, 10)
+	if err != nil || len(games) != 2 {
+		t.Fatalf("expected both games, got %d, %v", len(games), err)
+	}
+}
+
+func TestGameService_GetLatestGame_InvalidStored(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	store.latestGame = &domain.Game{ID: 9, Picks: []uint8{1}}
+
+	game, err := svc.GetLatestGame(context.Background())
+	if err != nil || game == nil || game.ID != 9 {
+		t.Errorf("expected game 9, got %v, %v", game, err)
+	}
+}
+
 func TestGameService_GetLatestGame(t *testing.T) {
 	store := newMockStore()
 	svc := NewGameService(store, defaultGameConfig())
 
 	game := &domain.Game{
 		ID:        42,
-		Picks:     []uint8{1, 2, 3},
+		Picks:     testPicks(),
 		CreatedAt: time.Now(),
 	}
 	store.latestGame = game
@@ -219,7 +362,7 @@ func TestGameService_BroadcastState(t *testing.T) {
 		Picks:    sdk.Picks{1, 2, 3},
 		NextGame: time.Now().Add(90 * time.Second),
 	}
-	svc.BroadcastState(state)
+	svc.BroadcastState(context.Background(), state)
 
 	select {
 	case event := <-ch:
@@ -247,7 +390,8 @@ func TestGameService_BroadcastPick(t *testing.T) {
 
 	ch := svc.Subscribe(ctx)
 
-	svc.BroadcastPick(42)
+	before := time.Now()
+	svc.BroadcastPick(context.Background(), 42, 4500*time.Millisecond)
 
 	select {
 	case event := <-ch:
@@ -261,6 +405,36 @@ func TestGameService_BroadcastPick(t *testing.T) {
 		if data.Pick != 42 {
 			t.Errorf("expected Pick 42, got %d", data.Pick)
 		}
+		if data.RevealedAt.Before(before.Round(0)) {
+			t.Errorf("RevealedAt %v is before the broadcast", data.RevealedAt)
+		}
+		if data.NextRevealInMS != 4500 {
+			t.Errorf("expected NextRevealInMS 4500, got %d", data.NextRevealInMS)
+		}
+		if data.CallText != "" {
+			t.Errorf("expected no CallText without a call locale, got %q", data.CallText)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("timeout waiting for event")
+	}
+}
+
+func TestGameService_BroadcastPick_CallText(t *testing.T) {
+	cfg := defaultGameConfig()
+	cfg.CallLocale = "en"
+	svc := NewGameService(newMockStore(), cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := svc.Subscribe(ctx)
+	svc.BroadcastPick(context.Background(), 42, 0)
+
+	select {
+	case event := <-ch:
+		if data := event.Data.(sdk.GamePickEvent); data.CallText != "forty-two" {
+			t.Errorf("expected CallText forty-two, got %q", data.CallText)
+		}
 	case <-time.After(100 * time.Millisecond):
 		t.Fatal("timeout waiting for event")
 	}
@@ -275,7 +449,7 @@ func TestGameService_BroadcastComplete(t *testing.T) {
 
 	ch := svc.Subscribe(ctx)
 
-	svc.BroadcastComplete(123)
+	svc.BroadcastComplete(context.Background(), &domain.Game{ID: 123})
 
 	select {
 	case event := <-ch:
@@ -305,6 +479,31 @@ func TestGameService_CreateGame_StoreError(t *testing.T) {
 	}
 }
 
+func TestGameService_CreateGame_DrawingOnceStored(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	// Readers may see the game as soon as the store has it
+	var complete bool
+	store.onCreate = func(game *domain.Game) { complete = svc.IsComplete(game.ID) }
+	if err := svc.CreateGame(context.Background(), domain.NewGame(1, testPicks())); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if complete {
+		t.Error("expected the game reported as drawing while it was being stored")
+	}
+
+	// A game that fails to store leaves the one being drawn as it was
+	store.onCreate = nil
+	store.createErr = errors.New("database error")
+	if err := svc.CreateGame(context.Background(), domain.NewGame(2, testPicks())); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if svc.IsComplete(1) || !svc.IsComplete(2) {
+		t.Errorf("expected game 1 still drawing and game 2 not, got %v, %v", !svc.IsComplete(1), !svc.IsComplete(2))
+	}
+}
+
 func TestGameService_GetGame_StoreError(t *testing.T) {
 	store := newMockStore()
 	store.getErr = errors.New("database error")
@@ -337,3 +536,153 @@ func TestGameService_GetLatestGame_StoreError(t *testing.T) {
 		t.Error("expected error, got nil")
 	}
 }
+
+func TestGameService_Broadcast_AssignsSequence(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	if got := svc.LastSeq(); got != 0 {
+		t.Fatalf("expected initial LastSeq 0, got %d", got)
+	}
+
+	svc.BroadcastPick(context.Background(), 1, 0)
+	svc.BroadcastPick(context.Background(), 2, 0)
+	svc.BroadcastComplete(context.Background(), &domain.Game{ID: 1})
+
+	if got := svc.LastSeq(); got != 3 {
+		t.Errorf("expected LastSeq 3, got %d", got)
+	}
+
+	events := svc.EventsSince(1)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events since seq 1, got %d", len(events))
+	}
+	if events[0].Seq != 2 || events[1].Seq != 3 {
+		t.Errorf("expected seqs [2 3], got [%d %d]", events[0].Seq, events[1].Seq)
+	}
+	if events[1].Type != sdk.EventGameComplete {
+		t.Errorf("expected type %s, got %s", sdk.EventGameComplete, events[1].Type)
+	}
+
+	if events := svc.EventsSince(3); len(events) != 0 {
+		t.Errorf("expected no events since latest seq, got %d", len(events))
+	}
+}
+
+func TestGameService_EventsSince_RetentionWindow(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	for i := 0; i < recentEventsSize+10; i++ {
+		svc.BroadcastPick(context.Background(), uint8(i%80), 0) //nolint:gosec // test values are within uint8 range
+	}
+
+	events := svc.EventsSince(0)
+	if len(events) != recentEventsSize {
+		t.Fatalf("expected %d retained events, got %d", recentEventsSize, len(events))
+	}
+	if events[0].Seq != 11 {
+		t.Errorf("expected oldest retained seq 11, got %d", events[0].Seq)
+	}
+}
+
+func TestGameService_SetHistorySize(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+	svc.SetHistorySize(5)
+
+	for i := range 8 {
+		svc.BroadcastPick(context.Background(), uint8(i+1), 0) //nolint:gosec // test values are within uint8 range
+	}
+
+	events := svc.EventsSince(0)
+	if len(events) != 5 {
+		t.Fatalf("expected 5 retained events, got %d", len(events))
+	}
+	if events[0].Seq != 4 {
+		t.Errorf("expected oldest retained seq 4, got %d", events[0].Seq)
+	}
+}
+
+func TestGameService_Broadcast_LogsDropsWithContextLogger(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	subCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = svc.Subscribe(subCtx) // never drained
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	ctx := slogx.With(slogx.NewContext(context.Background(), logger), slog.Int64("game_id", 42))
+
+	// Overflow the subscriber buffer so later events are dropped
+	for range 200 {
+		svc.BroadcastPick(ctx, 1, 0)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Dropped event for slow subscribers") {
+		t.Fatalf("expected drop warning, got: %s", out)
+	}
+	if !strings.Contains(out, "game_id=42") {
+		t.Errorf("expected drop warning to carry game_id, got: %s", out)
+	}
+}
+
+func TestGameService_RollSeason(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+	ctx := context.Background()
+
+	if _, err := svc.RollSeason(ctx, ""); !errors.Is(err, ErrSeasonEmpty) {
+		t.Fatalf("expected ErrSeasonEmpty with no games, got %v", err)
+	}
+
+	// Prime the cache so the roll must refresh it
+	if _, err := svc.CurrentSeason(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store.latestGame = &domain.Game{ID: 7, Picks: testPicks()}
+
+	season, err := svc.RollSeason(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if season.ID != 2 || season.FirstGameID != 8 || season.Name != "Season 2" {
+		t.Errorf("unexpected season: %+v", season)
+	}
+
+	current, err := svc.CurrentSeason(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.ID != 2 {
+		t.Errorf("expected current season 2, got %d", current.ID)
+	}
+
+	if _, err := svc.RollSeason(ctx, "Finals"); !errors.Is(err, ErrSeasonEmpty) {
+		t.Errorf("expected ErrSeasonEmpty before the new season has games, got %v", err)
+	}
+}
+
+func TestGameService_ListSeasonGames(t *testing.T) {
+	ms := newMockStore()
+	svc := NewGameService(ms, defaultGameConfig())
+	ctx := context.Background()
+
+	for i := int64(1); i <= 10; i++ {
+		ms.games[i] = &domain.Game{ID: i, Picks: testPicks()}
+	}
+	ms.seasons[0].EndGameID = 5
+	ms.seasons = append(ms.seasons, &domain.Season{ID: 2, FirstGameID: 5})
+
+	games, err := svc.ListSeasonGames(ctx, 1, 0, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(games) != 4 || games[len(games)-1].ID != 4 {
+		t.Errorf("expected games 1-4 in season 1, got %d games", len(games))
+	}
+
+	if _, err := svc.ListSeasonGames(ctx, 9, 0, 20); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown season, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// drawGuard tracks the picks revealed so far in the current game, so that
+// broadcasts showing clients an impossible draw (a repeated or out-of-range
+// number, or picks that rewrite earlier ones) are caught before they go
+// out. Checks only apply once a state event has started a game.
+type drawGuard struct {
+	config *config.GameConfig
+
+	mu     sync.Mutex
+	gameID int64
+	picks  []uint8
+}
+
+// checkState validates state against the current game, adopting it as the
+// current game's picks if valid. A state for a different game starts a new
+// game; one for the same game must extend the picks already revealed.
+func (g *drawGuard) checkState(state sdk.GameStateEvent) error {
+	if err := g.checkPicks(state.Picks); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if state.GameID == g.gameID {
+		if len(state.Picks) < len(g.picks) || !slices.Equal(state.Picks[:len(g.picks)], g.picks) {
+			return fmt.Errorf("picks %v do not extend %v already revealed for game %d", []uint8(state.Picks), g.picks, g.gameID)
+		}
+	}
+
+	g.gameID = state.GameID
+	g.picks = slices.Clone(state.Picks)
+	return nil
+}
+
+// checkPick validates a newly revealed pick against the current game,
+// recording it if valid.
+func (g *drawGuard) checkPick(pick uint8) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.gameID == 0 {
+		return nil
+	}
+	if err := g.checkPicks(append(slices.Clone(g.picks), pick)); err != nil {
+		return err
+	}
+
+	g.picks = append(g.picks, pick)
+	return nil
+}
+
+// checkPicks reports picks that are repeated, outside the configured range
+// or more than a game draws.
+func (g *drawGuard) checkPicks(picks []uint8) error {
+	if g.config.PickCount > 0 && len(picks) > g.config.PickCount {
+		return fmt.Errorf("%d picks exceed pick_count %d", len(picks), g.config.PickCount)
+	}
+
+	seen := make(map[uint8]bool, len(picks))
+	for _, p := range picks {
+		if int(p) < g.config.MinNumber || int(p) > g.config.MaxNumber {
+			return fmt.Errorf("pick %d outside %d-%d", p, g.config.MinNumber, g.config.MaxNumber)
+		}
+		if seen[p] {
+			return fmt.Errorf("pick %d repeated", p)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+// reset forgets the current game, so the next state event starts afresh even
+// if it repeats the game ID (as a replayed fixture does when it loops).
+func (g *drawGuard) reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.gameID = 0
+	g.picks = nil
+}
+
+// logViolation logs a broadcast dropped by the guard.
+func logViolation(ctx context.Context, event string, err error) {
+	slogx.FromContext(ctx).Error("Dropped broadcast violating draw invariants",
+		slog.String("event", event),
+		slogx.Error(err),
+	)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestGameService_DropsInvalidBroadcasts(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	var buf bytes.Buffer
+	ctx := slogx.NewContext(context.Background(), slog.New(slog.NewTextHandler(&buf, nil)))
+
+	state := func(gameID int64, picks ...uint8) sdk.GameStateEvent {
+		return sdk.GameStateEvent{GameID: gameID, Picks: picks}
+	}
+
+	steps := []struct {
+		name      string
+		broadcast func()
+		wantSent  bool
+	}{
+		{"game starts", func() { svc.BroadcastState(ctx, state(1)) }, true},
+		{"first pick", func() { svc.BroadcastPick(ctx, 5, 0) }, true},
+		{"state extends", func() { svc.BroadcastState(ctx, state(1, 5)) }, true},
+		{"repeated pick", func() { svc.BroadcastPick(ctx, 5, 0) }, false},
+		{"pick out of range", func() { svc.BroadcastPick(ctx, 81, 0) }, false},
+		{"state rewrites picks", func() { svc.BroadcastState(ctx, state(1, 6)) }, false},
+		{"state loses picks", func() { svc.BroadcastState(ctx, state(1)) }, false},
+		{"state repeats pick", func() { svc.BroadcastState(ctx, state(2, 3, 3)) }, false},
+		{"state resent unchanged", func() { svc.BroadcastState(ctx, state(1, 5)) }, true},
+		{"next game", func() { svc.BroadcastState(ctx, state(2)) }, true},
+		{"earlier pick allowed in new game", func() { svc.BroadcastPick(ctx, 5, 0) }, true},
+	}
+
+	for _, step := range steps {
+		before := svc.LastSeq()
+		step.broadcast()
+		if sent := svc.LastSeq() > before; sent != step.wantSent {
+			t.Errorf("%s: sent = %v, want %v", step.name, sent, step.wantSent)
+		}
+	}
+
+	if !strings.Contains(buf.String(), "Dropped broadcast violating draw invariants") {
+		t.Errorf("expected violations to be logged, got: %s", buf.String())
+	}
+}
+
+func TestGameService_PicksUnguardedBeforeGame(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	// Without a state event there is no game to check picks against
+	svc.BroadcastPick(context.Background(), 1, 0)
+	svc.BroadcastPick(context.Background(), 1, 0)
+
+	if got := svc.LastSeq(); got != 2 {
+		t.Errorf("expected both picks sent, LastSeq = %d", got)
+	}
+}
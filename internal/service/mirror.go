@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// mirrorBatchSize is the number of games requested per sync call.
+const mirrorBatchSize = 500
+
+// mirrorStallIntervals is the number of sync intervals without a successful
+// sync after which the mirror reports itself stalled.
+const mirrorStallIntervals = 3
+
+// Mirror replicates completed games from another taboo server into the
+// local store, for the read-only replicas run by taboo mirror.
+type Mirror struct {
+	gameService *GameService
+	client      *sdk.Client
+	interval    time.Duration
+	logger      *slog.Logger
+
+	running  atomic.Bool
+	lastSync atomic.Int64 // unix nanoseconds of the last successful sync
+}
+
+// NewMirror creates a mirror that syncs from client every interval.
+func NewMirror(gameService *GameService, client *sdk.Client, interval time.Duration, logger *slog.Logger) *Mirror {
+	return &Mirror{
+		gameService: gameService,
+		client:      client,
+		interval:    interval,
+		logger:      logger.With(slog.String("component", "mirror")),
+	}
+}
+
+// IsRunning returns whether the mirror is currently running.
+func (m *Mirror) IsRunning() bool {
+	return m.running.Load()
+}
+
+// Stalled reports whether no sync has succeeded for several intervals,
+// e.g. because the source is unreachable or has diverged.
+func (m *Mirror) Stalled() bool {
+	last := time.Unix(0, m.lastSync.Load())
+	return time.Since(last) > mirrorStallIntervals*m.interval
+}
+
+// Run syncs immediately and then every interval until ctx is cancelled.
+// Failed syncs are logged and retried on the next tick.
+func (m *Mirror) Run(ctx context.Context) error {
+	m.running.Store(true)
+	defer m.running.Store(false)
+	m.lastSync.Store(time.Now().UnixNano())
+
+	ctx = slogx.NewContext(ctx, m.logger)
+	m.logger.Info("Mirror started", slog.Duration("interval", m.interval))
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := m.Sync(ctx)
+		switch {
+		case ctx.Err() != nil:
+		case err != nil:
+			m.logger.Warn("Mirror sync failed", slogx.Error(err), slog.Int("imported", n))
+		default:
+			m.lastSync.Store(time.Now().UnixNano())
+			if n > 0 {
+				m.logger.Info("Mirror synced", slog.Int("imported", n))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			m.logger.Info("Mirror stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync imports every completed game on the source newer than the latest
+// local game, returning the number imported. It stops at the first game
+// that fails validation, including a result hash that does not match its
+// picks.
+func (m *Mirror) Sync(ctx context.Context) (int, error) {
+	afterID, err := m.latestID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	for {
+		batch, err := m.client.SyncGames(ctx, afterID, mirrorBatchSize)
+		if err != nil {
+			return imported, fmt.Errorf("fetching games after %d: %w", afterID, err)
+		}
+
+		for _, g := range batch.Games {
+			game := &domain.Game{
+				ID:         g.ID,
+				Picks:      g.Picks,
+				CreatedAt:  g.CreatedAt,
+				ResultHash: g.ResultHash,
+			}
+			if err := m.gameService.ImportGame(ctx, game); err != nil {
+				return imported, fmt.Errorf("importing game %d: %w", g.ID, err)
+			}
+			imported++
+		}
+
+		if !batch.HasMore || batch.LastID <= afterID {
+			return imported, nil
+		}
+		afterID = batch.LastID
+	}
+}
+
+// latestID returns the ID of the latest local game, or 0 if there is none.
+func (m *Mirror) latestID(ctx context.Context) (int64, error) {
+	game, err := m.gameService.GetLatestGame(ctx)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("getting latest game: %w", err)
+	}
+	return game.ID, nil
+}
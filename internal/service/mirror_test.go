@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// newSyncSource serves games from /api/v1/sync/games two at a time.
+func newSyncSource(t *testing.T, games []sdk.Game) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		afterID, _ := strconv.ParseInt(r.URL.Query().Get("after_id"), 10, 64)
+		resp := sdk.SyncGamesResponse{Games: []sdk.Game{}, LastID: afterID}
+		for _, g := range games {
+			if g.ID <= afterID {
+				continue
+			}
+			if len(resp.Games) == 2 {
+				resp.HasMore = true
+				break
+			}
+			resp.Games = append(resp.Games, g)
+			resp.LastID = g.ID
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func sourceGame(id int64) sdk.Game {
+	game := &domain.Game{ID: id, Picks: testPicks()}
+	return sdk.Game{ID: id, Picks: game.Picks, CreatedAt: time.Now(), ResultHash: game.Hash()}
+}
+
+func newTestMirror(t *testing.T, source *httptest.Server) (*Mirror, *mockStore) {
+	t.Helper()
+	store := newMockStore()
+	gameService := NewGameService(store, defaultGameConfig())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewMirror(gameService, sdk.NewClient(source.URL), time.Second, logger), store
+}
+
+func TestMirror_Sync(t *testing.T) {
+	games := []sdk.Game{sourceGame(1), sourceGame(2), sourceGame(3), sourceGame(4), sourceGame(5)}
+	mirror, store := newTestMirror(t, newSyncSource(t, games))
+	ctx := context.Background()
+
+	n, err := mirror.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	if n != 5 || len(store.games) != 5 {
+		t.Fatalf("imported %d games, stored %d; want 5", n, len(store.games))
+	}
+	if store.games[3].ResultHash != games[2].ResultHash {
+		t.Errorf("game 3 hash = %q, want %q", store.games[3].ResultHash, games[2].ResultHash)
+	}
+
+	// Nothing new on the next sync
+	if n, err := mirror.Sync(ctx); err != nil || n != 0 {
+		t.Errorf("second Sync() = %d, %v; want 0, nil", n, err)
+	}
+}
+
+func TestMirror_Sync_RejectsTamperedGame(t *testing.T) {
+	tampered := sourceGame(2)
+	tampered.Picks = append(sdk.Picks{}, tampered.Picks...)
+	tampered.Picks[0], tampered.Picks[1] = tampered.Picks[1], tampered.Picks[0]
+
+	mirror, store := newTestMirror(t, newSyncSource(t, []sdk.Game{sourceGame(1), tampered, sourceGame(3)}))
+
+	n, err := mirror.Sync(context.Background())
+	var invalid *domain.InvalidGameError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Sync() error = %v, want InvalidGameError", err)
+	}
+	if n != 1 || len(store.games) != 1 {
+		t.Errorf("imported %d games, stored %d; want only game 1", n, len(store.games))
+	}
+}
+
+func TestMirror_Sync_RequiresHash(t *testing.T) {
+	unhashed := sourceGame(1)
+	unhashed.ResultHash = ""
+
+	mirror, _ := newTestMirror(t, newSyncSource(t, []sdk.Game{unhashed}))
+
+	if _, err := mirror.Sync(context.Background()); !errors.Is(err, ErrMissingHash) {
+		t.Errorf("Sync() error = %v, want ErrMissingHash", err)
+	}
+}
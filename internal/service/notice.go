@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// ErrInvalidNotice is returned when an operator notice is malformed.
+var ErrInvalidNotice = errors.New("invalid notice")
+
+const (
+	// maxNoticeLen bounds a notice's message.
+	maxNoticeLen = 280
+
+	// defaultNoticeTTL is how long a notice is shown when no TTL is given,
+	// and maxNoticeTTL the longest one allowed.
+	defaultNoticeTTL = 5 * time.Minute
+	maxNoticeTTL     = 24 * time.Hour
+)
+
+// noticeSeverities are the accepted notice severities.
+var noticeSeverities = []string{sdk.NoticeInfo, sdk.NoticeWarning, sdk.NoticeCritical}
+
+// BroadcastNotice validates an operator notice and broadcasts it as a
+// server:notice event. An empty severity is info and a zero ttl is
+// defaultNoticeTTL. translations holds the message in other languages,
+// keyed by i18n locale, for clients that ask for one. It returns the
+// notice as sent.
+func (s *GameService) BroadcastNotice(ctx context.Context, message, severity string, ttl time.Duration, translations map[string]string) (sdk.ServerNoticeEvent, error) {
+	if severity == "" {
+		severity = sdk.NoticeInfo
+	}
+	if ttl == 0 {
+		ttl = defaultNoticeTTL
+	}
+
+	switch {
+	case message == "" || len(message) > maxNoticeLen:
+		return sdk.ServerNoticeEvent{}, fmt.Errorf("%w: message must be 1-%d bytes", ErrInvalidNotice, maxNoticeLen)
+	case !slices.Contains(noticeSeverities, severity):
+		return sdk.ServerNoticeEvent{}, fmt.Errorf("%w: severity must be one of %v, got %q", ErrInvalidNotice, noticeSeverities, severity)
+	case ttl < time.Second || ttl > maxNoticeTTL:
+		return sdk.ServerNoticeEvent{}, fmt.Errorf("%w: ttl must be 1s-%s, got %s", ErrInvalidNotice, maxNoticeTTL, ttl)
+	}
+	for locale, translated := range translations {
+		switch {
+		case locale == i18n.Source || !i18n.Supported(locale):
+			return sdk.ServerNoticeEvent{}, fmt.Errorf("%w: translations must be keyed by one of %v, got %q", ErrInvalidNotice, i18n.Locales()[1:], locale)
+		case translated == "" || len(translated) > maxNoticeLen:
+			return sdk.ServerNoticeEvent{}, fmt.Errorf("%w: %s translation must be 1-%d bytes", ErrInvalidNotice, locale, maxNoticeLen)
+		}
+	}
+
+	notice := sdk.ServerNoticeEvent{
+		Message:      message,
+		Severity:     severity,
+		TTLSeconds:   int64(ttl / time.Second),
+		Translations: translations,
+	}
+	s.Broadcast(ctx, Event{Type: sdk.EventServerNotice, Data: notice})
+	return notice, nil
+}
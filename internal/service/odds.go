@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/combin"
+)
+
+// oddsPageSize is how many games are read from the store at a time while
+// tallying pick frequencies.
+const oddsPageSize = 500
+
+// Odds describes the chance of an N-spot ticket matching the draw, both in
+// theory under the board configuration and as drawn in recent history.
+type Odds struct {
+	// Pool is how many numbers are on the board, Drawn how many are picked
+	// each game and Spots how many the ticket marks.
+	Pool  int
+	Drawn int
+	Spots int
+
+	// Hits holds the probability of the ticket matching each number of
+	// picks, indexed by hits, from 0 to Spots.
+	Hits []float64
+
+	// Games is how many completed games the frequencies were tallied over.
+	Games int
+
+	// Numbers holds how each board number has been drawn across those
+	// games, lowest number first.
+	Numbers []NumberFrequency
+}
+
+// NumberFrequency is how often a board number has been drawn.
+type NumberFrequency struct {
+	Number uint8
+	Drawn  int
+
+	// Since is how many games have been drawn since the number last came
+	// up, or Games if it hasn't come up in the window at all.
+	Since int
+}
+
+// Odds returns the odds of a ticket marking spots numbers, with pick
+// frequencies over the last window completed games. The game being drawn
+// is left out so its unrevealed picks don't leak into the frequencies.
+func (s *GameService) Odds(ctx context.Context, spots, window int) (*Odds, error) {
+	pool := s.config.MaxNumber - s.config.MinNumber + 1
+	if spots < 1 || spots > pool {
+		return nil, fmt.Errorf("spots must be between 1 and %d, got %d", pool, spots)
+	}
+
+	odds := &Odds{
+		Pool:    pool,
+		Drawn:   s.config.PickCount,
+		Spots:   spots,
+		Hits:    combin.HitDistribution(pool, s.config.PickCount, spots),
+		Numbers: make([]NumberFrequency, pool),
+	}
+	for i := range odds.Numbers {
+		odds.Numbers[i].Number = uint8(s.config.MinNumber + i)
+	}
+
+	latest, err := s.store.GetLatestGame(ctx)
+	if errors.Is(err, store.ErrNotFound) {
+		return odds, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting latest game: %w", err)
+	}
+
+	// Tally oldest first, so the last game a number is seen in is its most
+	// recent
+	lastSeen := make([]int, pool)
+	cursor := max(latest.ID-int64(window)+1, 1)
+	for cursor <= latest.ID {
+		games, err := s.store.ListGames(ctx, cursor, oddsPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("listing games: %w", err)
+		}
+		if len(games) == 0 {
+			break
+		}
+		for _, game := range games {
+			if !s.IsComplete(game.ID) {
+				continue
+			}
+			odds.Games++
+			for _, pick := range game.Picks {
+				i := int(pick) - s.config.MinNumber
+				if i < 0 || i >= pool {
+					continue
+				}
+				odds.Numbers[i].Drawn++
+				lastSeen[i] = odds.Games
+			}
+		}
+		cursor = games[len(games)-1].ID + 1
+	}
+
+	for i := range odds.Numbers {
+		odds.Numbers[i].Since = odds.Games - lastSeen[i]
+	}
+	return odds, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+)
+
+func TestGameService_Odds(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	// Games 1-4 draw 1-20; game 5 draws 21-40
+	for i := int64(1); i <= 4; i++ {
+		store.CreateGame(context.Background(), &domain.Game{ID: i, Picks: testPicks()})
+	}
+	picks := make([]uint8, 20)
+	for i := range picks {
+		picks[i] = uint8(i + 21)
+	}
+	store.CreateGame(context.Background(), &domain.Game{ID: 5, Picks: picks})
+
+	odds, err := svc.Odds(context.Background(), 5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if odds.Pool != 80 || odds.Drawn != 20 || len(odds.Hits) != 6 {
+		t.Fatalf("unexpected board: %+v", odds)
+	}
+	if math.Abs(odds.Hits[5]-0.000644924695557607) > 1e-12 {
+		t.Errorf("expected 5/5 odds of 0.000645, got %g", odds.Hits[5])
+	}
+
+	// Only games 3-5 fall in the window
+	if odds.Games != 3 || len(odds.Numbers) != 80 {
+		t.Fatalf("expected 80 numbers over 3 games, got %d over %d", len(odds.Numbers), odds.Games)
+	}
+	tests := []struct {
+		number       uint8
+		drawn, since int
+	}{
+		{1, 2, 1},
+		{21, 1, 0},
+		{80, 0, 3},
+	}
+	for _, tt := range tests {
+		got := odds.Numbers[tt.number-1]
+		if got.Number != tt.number || got.Drawn != tt.drawn || got.Since != tt.since {
+			t.Errorf("number %d: got %+v, want drawn %d since %d", tt.number, got, tt.drawn, tt.since)
+		}
+	}
+}
+
+func TestGameService_Odds_SkipsDrawingGame(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	store.CreateGame(context.Background(), &domain.Game{ID: 1, Picks: testPicks()})
+	svc.drawing.Store(1)
+
+	odds, err := svc.Odds(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if odds.Games != 0 || odds.Numbers[0].Drawn != 0 {
+		t.Errorf("expected the game being drawn to be left out, got %d games", odds.Games)
+	}
+}
+
+func TestGameService_Odds_InvalidSpots(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+
+	for _, spots := range []int{0, 81} {
+		if _, err := svc.Odds(context.Background(), spots, 10); err == nil {
+			t.Errorf("expected error for %d spots", spots)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+// RevealCurveLinear spaces picks evenly over the draw phase, the last one
+// revealed as it ends.
+const RevealCurveLinear = "linear"
+
+// Pacing is the timing of a game cycle as the engine runs it: a draw phase
+// revealing one pick at each offset, then a wait until the next game.
+type Pacing struct {
+	Draw  time.Duration
+	Wait  time.Duration
+	Curve string
+
+	// Reveals holds each pick's reveal time, as an offset from the start
+	// of the game, in draw order.
+	Reveals []time.Duration
+}
+
+// NewPacing returns the pacing of games run under cfg.
+func NewPacing(cfg *config.GameConfig) Pacing {
+	p := Pacing{
+		Draw:    cfg.DrawDuration.Duration(),
+		Wait:    cfg.WaitDuration.Duration(),
+		Curve:   RevealCurveLinear,
+		Reveals: make([]time.Duration, cfg.PickCount),
+	}
+	for i := range p.Reveals {
+		p.Reveals[i] = p.RevealAt(i)
+	}
+	return p
+}
+
+// RevealAt returns the offset from the start of the game at which the
+// i-th pick, counting from zero, is revealed.
+func (p Pacing) RevealAt(i int) time.Duration {
+	if len(p.Reveals) == 0 {
+		return p.Draw
+	}
+	return time.Duration(i+1) * (p.Draw / time.Duration(len(p.Reveals)))
+}
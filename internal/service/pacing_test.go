@@ -0,0 +1,27 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestNewPacing(t *testing.T) {
+	cfg := &config.GameConfig{
+		PickCount:    4,
+		DrawDuration: config.Duration(20 * time.Second),
+		WaitDuration: config.Duration(10 * time.Second),
+	}
+
+	pacing := NewPacing(cfg)
+
+	if pacing.Draw != 20*time.Second || pacing.Wait != 10*time.Second || pacing.Curve != RevealCurveLinear {
+		t.Errorf("unexpected pacing: %+v", pacing)
+	}
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 15 * time.Second, 20 * time.Second}
+	if !slices.Equal(pacing.Reveals, want) {
+		t.Errorf("Reveals = %v, want %v", pacing.Reveals, want)
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// replayLoopGap is the pause between the end of a fixture and replaying it
+// again from the start.
+const replayLoopGap = time.Second
+
+// FixtureEvent is one line of an event fixture, as written by taboo record.
+type FixtureEvent struct {
+	// OffsetMS is when the event arrived, in milliseconds since the start
+	// of the recording.
+	OffsetMS int64 `json:"offset_ms"`
+
+	// Time is when the event arrived by the recorder's wall clock. Replay
+	// uses it to shift timestamps inside the event to the present.
+	Time time.Time `json:"time"`
+
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// LoadFixture reads a fixture file of newline-delimited FixtureEvents.
+func LoadFixture(path string) ([]FixtureEvent, error) {
+	f, err := os.Open(path) //nolint:gosec // path comes from operator config
+	if err != nil {
+		return nil, fmt.Errorf("opening fixture: %w", err)
+	}
+	defer f.Close()
+
+	return ReadFixture(f)
+}
+
+// ReadFixture parses newline-delimited FixtureEvents from r. Events must be
+// in offset order.
+func ReadFixture(r io.Reader) ([]FixtureEvent, error) {
+	var events []FixtureEvent
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e FixtureEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if e.Type == "" {
+			return nil, fmt.Errorf("line %d: missing event type", line)
+		}
+		if n := len(events); n > 0 && e.OffsetMS < events[n-1].OffsetMS {
+			return nil, fmt.Errorf("line %d: offset %dms is before the previous event", line, e.OffsetMS)
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, errors.New("fixture has no events")
+	}
+
+	return events, nil
+}
+
+// replay broadcasts the fixture's events on their recorded schedule in
+// place of the game loop, starting over each time it reaches the end, until
+// ctx is cancelled. Games are not persisted, so the REST API does not see
+// them.
+func (e *Engine) replay(ctx context.Context, events []FixtureEvent) error {
+	for loop := 1; ; loop++ {
+		loopCtx := slogx.With(ctx, slog.Int("loop", loop))
+		slogx.FromContext(loopCtx).Info("Replaying fixture", slog.Int("events", len(events)))
+
+		// The fixture may revisit a game it already showed
+		e.gameService.guard.reset()
+
+		start := time.Now()
+		for _, event := range events {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Until(start.Add(time.Duration(event.OffsetMS) * time.Millisecond))):
+				e.replayEvent(loopCtx, event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(replayLoopGap):
+		}
+	}
+}
+
+// replayEvent broadcasts a single fixture event. State events go through
+// BroadcastState, with next_game shifted by however long ago the event was
+// recorded, so hints apply and countdowns stay meaningful.
+func (e *Engine) replayEvent(ctx context.Context, event FixtureEvent) {
+	e.progress()
+
+	if event.Type != sdk.EventGameState {
+		e.gameService.Broadcast(ctx, Event{Type: event.Type, Data: event.Data})
+		return
+	}
+
+	var state sdk.GameStateEvent
+	if err := json.Unmarshal(event.Data, &state); err != nil {
+		slogx.FromContext(ctx).Warn("Skipping invalid state event in fixture", slogx.Error(err))
+		return
+	}
+	if !state.NextGame.IsZero() && !event.Time.IsZero() {
+		state.NextGame = state.NextGame.Add(time.Since(event.Time)).Round(0)
+	}
+	e.gameService.BroadcastState(ctx, state)
+}
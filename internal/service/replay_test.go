@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestReadFixture(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", `{"offset_ms":0,"type":"game:pick","data":{"pick":1}}` + "\n\n" + `{"offset_ms":5,"type":"game:pick","data":{"pick":2}}`, false},
+		{"empty", "\n", true},
+		{"missing type", `{"offset_ms":0,"data":{}}`, true},
+		{"out of order", `{"offset_ms":5,"type":"game:pick"}` + "\n" + `{"offset_ms":1,"type":"game:pick"}`, true},
+		{"invalid json", `{"offset_ms":`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadFixture(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadFixture() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEngine_Replay(t *testing.T) {
+	cfg := &config.GameConfig{}
+	svc := NewGameService(newMockStore(), cfg)
+	engine := NewEngine(svc, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	recordedAt := time.Now().Add(-time.Hour).Round(0)
+	state, _ := json.Marshal(sdk.GameStateEvent{GameID: 7, Picks: sdk.Picks{}, NextGame: recordedAt.Add(time.Minute)})
+	events := []FixtureEvent{
+		{OffsetMS: 0, Time: recordedAt, Type: sdk.EventGameState, Data: state},
+		{OffsetMS: 10, Time: recordedAt, Type: sdk.EventGamePick, Data: json.RawMessage(`{"pick":9}`)},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := svc.Subscribe(ctx)
+	go func() { _ = engine.replay(ctx, events) }()
+
+	first := <-sub
+	got, ok := first.Data.(sdk.GameStateEvent)
+	if first.Type != sdk.EventGameState || !ok {
+		t.Fatalf("first event = %s %T, want game state", first.Type, first.Data)
+	}
+	// next_game is shifted from an hour ago to about a minute from now
+	if until := time.Until(got.NextGame); until < 50*time.Second || until > time.Minute {
+		t.Errorf("next_game in %v, want about 1m", until)
+	}
+
+	second := <-sub
+	if second.Type != sdk.EventGamePick || string(second.Data.(json.RawMessage)) != `{"pick":9}` {
+		t.Errorf("second event = %s %v, want pick 9", second.Type, second.Data)
+	}
+
+	// The fixture loops
+	if again := <-sub; again.Type != sdk.EventGameState {
+		t.Errorf("expected the fixture to restart, got %s", again.Type)
+	}
+}
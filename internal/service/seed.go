@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+)
+
+// SeedGames stores n completed games with random picks, drawn under the
+// game config, so demo and staging environments have history to show. The
+// games follow the latest stored game and are spread across from to to,
+// never closer together than one draw and wait cycle, with some jitter so
+// they look like a server that was not always up. It returns the games
+// stored.
+func (s *GameService) SeedGames(ctx context.Context, n int, from, to time.Time) ([]*domain.Game, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("game count must be at least 1, got %d", n)
+	}
+
+	cycle := s.config.DrawDuration.Duration() + s.config.WaitDuration.Duration()
+
+	nextID := int64(1)
+	latest, err := s.store.GetLatestGame(ctx)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("getting latest game: %w", err)
+	default:
+		nextID = latest.ID + 1
+		if after := latest.CreatedAt.Add(cycle); from.Before(after) {
+			from = after
+		}
+	}
+
+	span := to.Sub(from)
+	if need := cycle * time.Duration(n); span < need {
+		return nil, fmt.Errorf("%d games at one per %s cycle need %s after the latest game, only %s available",
+			n, cycle, need, max(span, 0).Round(time.Second))
+	}
+
+	// Each game gets an equal slot, starting somewhere in it that leaves a
+	// full cycle before the next slot begins
+	step := span / time.Duration(n)
+	games := make([]*domain.Game, 0, n)
+	for i := range n {
+		jitter := time.Duration(rand.Int64N(int64(step-cycle) + 1)) //nolint:gosec // demo timestamps do not need secure randomness
+
+		game := &domain.Game{
+			ID:        nextID + int64(i),
+			Picks:     drawPicks(s.config),
+			CreatedAt: from.Add(step*time.Duration(i) + jitter).UTC().Truncate(time.Millisecond),
+		}
+		game.ResultHash = game.Hash()
+		if err := s.validate(game); err != nil {
+			return games, err
+		}
+		if err := s.store.CreateGame(ctx, game); err != nil {
+			return games, fmt.Errorf("storing game %d: %w", game.ID, err)
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+)
+
+func TestGameService_SeedGames(t *testing.T) {
+	store := newMockStore()
+	cfg := defaultGameConfig()
+	svc := NewGameService(store, cfg)
+
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	from := to.Add(-30 * 24 * time.Hour)
+
+	games, err := svc.SeedGames(context.Background(), 500, from, to)
+	if err != nil {
+		t.Fatalf("SeedGames() error: %v", err)
+	}
+	if len(games) != 500 || len(store.games) != 500 {
+		t.Fatalf("seeded %d games, stored %d, want 500", len(games), len(store.games))
+	}
+
+	cycle := cfg.DrawDuration.Duration() + cfg.WaitDuration.Duration()
+	for i, game := range games {
+		if game.ID != int64(i+1) {
+			t.Fatalf("game %d has ID %d, want %d", i, game.ID, i+1)
+		}
+		if err := svc.validate(game); err != nil {
+			t.Errorf("game %d is invalid: %v", game.ID, err)
+		}
+		if game.ResultHash != game.Hash() {
+			t.Errorf("game %d hash = %s, want %s", game.ID, game.ResultHash, game.Hash())
+		}
+		if game.CreatedAt.Before(from) || game.CreatedAt.After(to) {
+			t.Errorf("game %d created at %v, outside %v to %v", game.ID, game.CreatedAt, from, to)
+		}
+		if i > 0 {
+			if gap := game.CreatedAt.Sub(games[i-1].CreatedAt); gap < cycle-time.Millisecond {
+				t.Errorf("game %d is %s after the previous game, want at least %s", game.ID, gap, cycle)
+			}
+		}
+	}
+}
+
+func TestGameService_SeedGames_FollowsLatestGame(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	latest := &domain.Game{ID: 41, Picks: testPicks(), CreatedAt: to.Add(-time.Hour)}
+	latest.ResultHash = latest.Hash()
+	store.games[latest.ID] = latest
+	store.latestGame = latest
+
+	games, err := svc.SeedGames(context.Background(), 10, to.Add(-24*time.Hour), to)
+	if err != nil {
+		t.Fatalf("SeedGames() error: %v", err)
+	}
+	if games[0].ID != 42 {
+		t.Errorf("first seeded game ID = %d, want 42", games[0].ID)
+	}
+	if !games[0].CreatedAt.After(latest.CreatedAt) {
+		t.Errorf("first seeded game created at %v, want after latest game at %v", games[0].CreatedAt, latest.CreatedAt)
+	}
+}
+
+func TestGameService_SeedGames_WindowTooShort(t *testing.T) {
+	store := newMockStore()
+	svc := NewGameService(store, defaultGameConfig())
+
+	// 3 minute cycles fit 20 to the hour
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := svc.SeedGames(context.Background(), 21, to.Add(-time.Hour), to); err == nil {
+		t.Fatal("SeedGames() expected error for too many games in the window")
+	}
+	if len(store.games) != 0 {
+		t.Errorf("stored %d games, want none", len(store.games))
+	}
+}
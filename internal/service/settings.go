@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/pubsub"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// Setting keys used by runtime-tunable features.
+const (
+	// SettingUIHints holds the UI hints sent with game state events.
+	SettingUIHints = "ui.hints"
+
+	// SettingSpecialGames holds the special games waiting to run.
+	SettingSpecialGames = "game.specials"
+)
+
+// ErrInvalidSetting is returned for malformed setting keys or values.
+var ErrInvalidSetting = errors.New("invalid setting")
+
+// settingKeyPattern restricts keys to dotted lowercase names.
+var settingKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// SettingChange is published when a setting is set or deleted. Value is nil
+// when the setting was deleted.
+type SettingChange struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// SettingsService stores runtime-tunable settings as JSON values and
+// notifies subscribers when they change, so features can be adjusted
+// without editing the config file and restarting.
+type SettingsService struct {
+	store  store.Store
+	broker *pubsub.Broker[SettingChange]
+
+	// updateMu serializes Update so concurrent read-modify-writes of a
+	// setting are not lost.
+	updateMu sync.Mutex
+}
+
+// NewSettingsService creates a new SettingsService.
+func NewSettingsService(store store.Store) *SettingsService {
+	return &SettingsService{
+		store:  store,
+		broker: pubsub.New[SettingChange](),
+	}
+}
+
+// Subscribe returns a channel that receives setting changes.
+// The caller should cancel the context when done to unsubscribe.
+func (s *SettingsService) Subscribe(ctx context.Context) <-chan SettingChange {
+	return s.broker.Subscribe(ctx)
+}
+
+// Get decodes the setting stored under key into v. It returns
+// store.ErrNotFound when the setting is unset.
+func (s *SettingsService) Get(ctx context.Context, key string, v any) error {
+	raw, err := s.store.GetSetting(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		return fmt.Errorf("decoding setting %q: %w", key, err)
+	}
+	return nil
+}
+
+// List returns every setting as raw JSON keyed by name.
+func (s *SettingsService) List(ctx context.Context) (map[string]json.RawMessage, error) {
+	settings, err := s.store.ListSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]json.RawMessage, len(settings))
+	for k, v := range settings {
+		result[k] = json.RawMessage(v)
+	}
+	return result, nil
+}
+
+// Set encodes v as JSON, stores it under key and notifies subscribers.
+func (s *SettingsService) Set(ctx context.Context, key string, v any) error {
+	if !settingKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: key %q must match %s", ErrInvalidSetting, key, settingKeyPattern)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSetting, err)
+	}
+	if err := validateSetting(key, raw); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSetting, err)
+	}
+
+	if err := s.store.SetSetting(ctx, key, string(raw)); err != nil {
+		return err
+	}
+
+	s.publish(ctx, SettingChange{Key: key, Value: raw})
+	return nil
+}
+
+// Update decodes the setting stored under key into v, leaving v as is when
+// the setting is unset, calls fn to modify it, then stores v. If fn returns
+// an error, the setting is left unchanged and the error returned.
+func (s *SettingsService) Update(ctx context.Context, key string, v any, fn func() error) error {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	if err := s.Get(ctx, key, v); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	return s.Set(ctx, key, v)
+}
+
+// Delete removes the setting stored under key and notifies subscribers.
+func (s *SettingsService) Delete(ctx context.Context, key string) error {
+	if err := s.store.DeleteSetting(ctx, key); err != nil {
+		return err
+	}
+
+	s.publish(ctx, SettingChange{Key: key})
+	return nil
+}
+
+// validateSetting checks the value of settings with a known shape.
+func validateSetting(key string, raw json.RawMessage) error {
+	switch key {
+	case SettingUIHints:
+		var hints map[string]string
+		if err := json.Unmarshal(raw, &hints); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidHints, err)
+		}
+		return ValidateHints(hints)
+	case SettingSpecialGames:
+		var specials []*domain.SpecialGame
+		if err := json.Unmarshal(raw, &specials); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSpecial, err)
+		}
+		ids := make(map[int64]bool, len(specials))
+		for _, special := range specials {
+			if err := validateSpecial(special); err != nil {
+				return err
+			}
+			if special.ID < 1 || ids[special.ID] {
+				return fmt.Errorf("%w: id %d must be positive and unique", ErrInvalidSpecial, special.ID)
+			}
+			ids[special.ID] = true
+		}
+	}
+	return nil
+}
+
+func (s *SettingsService) publish(ctx context.Context, change SettingChange) {
+	if dropped := s.broker.Publish(change); dropped > 0 {
+		slogx.FromContext(ctx).Warn("Dropped setting change for slow subscribers",
+			slog.String("key", change.Key),
+			slog.Int("dropped", dropped),
+		)
+	}
+}
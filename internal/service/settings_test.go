@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestSettingsService_SetGet(t *testing.T) {
+	svc := NewSettingsService(newMockStore())
+	ctx := context.Background()
+
+	type flags struct {
+		Enabled bool `json:"enabled"`
+		Percent int  `json:"percent"`
+	}
+
+	if err := svc.Set(ctx, "feature.rollout", flags{Enabled: true, Percent: 25}); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+
+	var got flags
+	if err := svc.Get(ctx, "feature.rollout", &got); err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if !got.Enabled || got.Percent != 25 {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	if err := svc.Get(ctx, "feature.missing", &got); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected store.ErrNotFound for unset key, got %v", err)
+	}
+}
+
+func TestSettingsService_SetInvalid(t *testing.T) {
+	svc := NewSettingsService(newMockStore())
+	ctx := context.Background()
+
+	if err := svc.Set(ctx, "Has Spaces", true); !errors.Is(err, ErrInvalidSetting) {
+		t.Errorf("expected ErrInvalidSetting for bad key, got %v", err)
+	}
+	if err := svc.Set(ctx, SettingUIHints, map[string]string{"": "x"}); !errors.Is(err, ErrInvalidHints) {
+		t.Errorf("expected ErrInvalidHints for bad hints, got %v", err)
+	}
+}
+
+func TestSettingsService_NotifiesChanges(t *testing.T) {
+	svc := NewSettingsService(newMockStore())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := svc.Subscribe(ctx)
+
+	if err := svc.Set(ctx, "maintenance.enabled", true); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if err := svc.Delete(ctx, "maintenance.enabled"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+
+	set := <-changes
+	if set.Key != "maintenance.enabled" || string(set.Value) != "true" {
+		t.Errorf("unexpected set change: %+v", set)
+	}
+	deleted := <-changes
+	if deleted.Key != "maintenance.enabled" || deleted.Value != nil {
+		t.Errorf("unexpected delete change: %+v", deleted)
+	}
+}
+
+func TestGameService_SyncHints(t *testing.T) {
+	ms := newMockStore()
+	ms.settings[SettingUIHints] = `{"banner":"launch"}`
+	svc := NewGameService(ms, defaultGameConfig())
+	settings := NewSettingsService(ms)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go svc.SyncHints(ctx, settings)
+
+	waitFor(t, "stored hints to load", func() bool { return svc.Hints()["banner"] == "launch" })
+
+	events := svc.Subscribe(ctx)
+	svc.BroadcastState(ctx, sdk.GameStateEvent{GameID: 3})
+	if state := (<-events).Data.(sdk.GameStateEvent); state.Hints["banner"] != "launch" {
+		t.Errorf("expected state with the stored hints, got %+v", state)
+	}
+
+	if err := settings.Delete(ctx, SettingUIHints); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	waitFor(t, "hints to clear", func() bool { return svc.Hints() == nil })
+
+	// Clearing the hints doesn't resend a state; the next one goes without
+	select {
+	case event := <-events:
+		t.Fatalf("expected no event when hints change, got %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+	svc.BroadcastState(ctx, sdk.GameStateEvent{GameID: 3})
+	if state := (<-events).Data.(sdk.GameStateEvent); state.Hints != nil {
+		t.Errorf("expected state without hints, got %+v", state)
+	}
+}
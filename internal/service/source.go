@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// pollRequestTimeout bounds each request a PollSource makes.
+const pollRequestTimeout = 10 * time.Second
+
+// pollPageSize is how many games a PollSource asks for per page when
+// catching up on games it missed.
+const pollPageSize = 100
+
+// ingestBacklog is how many pushed results an IngestSource holds before
+// rejecting more. Each waits a full game cycle to be drawn.
+const ingestBacklog = 16
+
+var (
+	// ErrInvalidResult is returned when pushed picks break the game rules.
+	ErrInvalidResult = errors.New("invalid result")
+
+	// ErrIngestBacklogFull is returned when results are pushed faster than
+	// games are drawn.
+	ErrIngestBacklogFull = errors.New("ingest backlog full")
+)
+
+// Result is a game's picks published by an external results provider.
+type Result struct {
+	// Ref identifies the result at the provider, for logs.
+	Ref   string
+	Picks sdk.Picks
+}
+
+// ResultSource supplies the picks for each game in place of the engine's
+// own draws, when game.source is external. The engine validates, persists
+// and reveals them exactly as it does picks it draws itself.
+type ResultSource interface {
+	// Next blocks until the provider publishes the next game's picks.
+	Next(ctx context.Context) (Result, error)
+}
+
+// PollSource is a ResultSource that polls a provider for its latest game.
+// The provider must respond in the shape of GET /api/v1/games/latest: a
+// JSON object with an increasing numeric id and its picks. Only games
+// published after the first poll are returned, so a restart doesn't draw
+// the provider's current game a second time.
+//
+// Games published while the engine is busy with one are caught up on, in
+// order, by paging through the provider's game list after the last one
+// returned, in the shape of GET /api/v1/games?cursor=. The list is found
+// by dropping /latest from the URL; providers without one have those games
+// logged and skipped.
+type PollSource struct {
+	url      string
+	listURL  string
+	interval time.Duration
+	client   *http.Client
+
+	lastID int64
+	seeded bool
+
+	// pending holds games fetched but not yet returned, oldest first.
+	pending []sdk.Game
+}
+
+// NewPollSource creates a source that polls url every interval.
+func NewPollSource(url string, interval time.Duration) *PollSource {
+	p := &PollSource{
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: pollRequestTimeout},
+	}
+	if list, ok := strings.CutSuffix(url, "/latest"); ok {
+		p.listURL = list
+	}
+	return p
+}
+
+// Next returns the oldest game not yet returned, polling until the
+// provider publishes one. Failed polls are logged and retried on the next
+// interval.
+func (p *PollSource) Next(ctx context.Context) (Result, error) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if len(p.pending) > 0 {
+			game := p.pending[0]
+			p.pending = p.pending[1:]
+			p.lastID = game.ID
+			return Result{Ref: strconv.FormatInt(game.ID, 10), Picks: game.Picks}, nil
+		}
+
+		var game sdk.Game
+		err := p.get(ctx, p.url, &game)
+		switch {
+		case ctx.Err() != nil:
+			return Result{}, ctx.Err()
+		case err != nil:
+			slogx.FromContext(ctx).Warn("Polling results provider failed", slogx.Error(err))
+		case !p.seeded:
+			p.seeded = true
+			p.lastID = game.ID
+			slogx.FromContext(ctx).Info("Waiting for the provider's next game", slog.Int64("provider_game_id", game.ID))
+		case game.ID > p.lastID:
+			p.pending = p.gamesUpTo(ctx, game)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return Result{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// gamesUpTo returns the games after the last one returned up to latest,
+// oldest first. If the ones in between can't be listed, they are logged as
+// skipped and only latest is returned.
+func (p *PollSource) gamesUpTo(ctx context.Context, latest sdk.Game) []sdk.Game {
+	if latest.ID == p.lastID+1 {
+		return []sdk.Game{latest}
+	}
+
+	games, err := p.list(ctx, p.lastID, latest.ID)
+	if err != nil {
+		slogx.FromContext(ctx).Warn("Skipping provider games that could not be listed",
+			slog.Int64("from_provider_game_id", p.lastID+1),
+			slog.Int64("to_provider_game_id", latest.ID-1),
+			slogx.Error(err),
+		)
+		return []sdk.Game{latest}
+	}
+	return append(games, latest)
+}
+
+// list pages through the provider's games after after and before before.
+func (p *PollSource) list(ctx context.Context, after, before int64) ([]sdk.Game, error) {
+	if p.listURL == "" {
+		return nil, errors.New("provider URL doesn't end in /latest, so its game list is unknown")
+	}
+
+	var games []sdk.Game
+	cursor := after + 1
+	for {
+		var page sdk.GameListResponse
+		u := fmt.Sprintf("%s?cursor=%d&limit=%d", p.listURL, cursor, pollPageSize)
+		if err := p.get(ctx, u, &page); err != nil {
+			return nil, err
+		}
+		for _, game := range page.Games {
+			if game.ID >= before {
+				return games, nil
+			}
+			if game.ID > after {
+				games = append(games, game)
+			}
+		}
+		if page.NextCursor == nil || *page.NextCursor <= cursor {
+			return games, nil
+		}
+		cursor = *page.NextCursor
+	}
+}
+
+// get requests url from the provider, decoding its JSON response into v.
+func (p *PollSource) get(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding provider response: %w", err)
+	}
+	return nil
+}
+
+// IngestSource is a ResultSource fed by a provider pushing results to the
+// ingest endpoint. Results are drawn in the order they were pushed, one per
+// game, and revealed at the engine's usual cadence.
+type IngestSource struct {
+	config  *config.GameConfig
+	results chan Result
+}
+
+// NewIngestSource creates a source accepting results valid under cfg.
+func NewIngestSource(cfg *config.GameConfig) *IngestSource {
+	return &IngestSource{
+		config:  cfg,
+		results: make(chan Result, ingestBacklog),
+	}
+}
+
+// Push queues a result for the next free game. It returns ErrInvalidResult
+// for picks the engine would refuse, and ErrIngestBacklogFull rather than
+// blocking when the queue is full.
+func (s *IngestSource) Push(result Result) error {
+	// The game ID isn't known until the result is drawn; any valid one will
+	// do for checking the picks
+	game := domain.NewGame(1, result.Picks)
+	if issues := game.Validate(*s.config); issues.HasErrors() {
+		return fmt.Errorf("%w: %s", ErrInvalidResult, issues.Error())
+	}
+
+	select {
+	case s.results <- result:
+		return nil
+	default:
+		return ErrIngestBacklogFull
+	}
+}
+
+// Queued returns how many pushed results are waiting to be drawn.
+func (s *IngestSource) Queued() int {
+	return len(s.results)
+}
+
+// Next blocks until a result has been pushed.
+func (s *IngestSource) Next(ctx context.Context) (Result, error) {
+	select {
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	case result := <-s.results:
+		return result, nil
+	}
+}
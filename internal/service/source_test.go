@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestPollSource_Next(t *testing.T) {
+	// The provider fails once, then serves its current game twice before
+	// publishing a new one.
+	var calls atomic.Int32
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		game := sdk.Game{ID: 7, Picks: sdk.Picks{1, 2, 3}}
+		switch n := calls.Add(1); {
+		case n == 2:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case n >= 4:
+			game = sdk.Game{ID: 8, Picks: sdk.Picks{4, 5, 6}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(game)
+	}))
+	t.Cleanup(provider.Close)
+
+	src := NewPollSource(provider.URL, time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := src.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if result.Ref != "8" {
+		t.Errorf("expected game 8, the first published after seeding, got %s", result.Ref)
+	}
+	if !slices.Equal(result.Picks, sdk.Picks{4, 5, 6}) {
+		t.Errorf("expected picks [4 5 6], got %v", result.Picks)
+	}
+}
+
+func TestPollSource_NextCatchesUp(t *testing.T) {
+	// The provider publishes games 8-10 between the first poll and the
+	// next, and lists them two to a page.
+	var polls atomic.Int32
+	games := map[int64]sdk.Game{
+		8:  {ID: 8, Picks: sdk.Picks{8}},
+		9:  {ID: 9, Picks: sdk.Picks{9}},
+		10: {ID: 10, Picks: sdk.Picks{10}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /games/latest", func(w http.ResponseWriter, r *http.Request) {
+		game := sdk.Game{ID: 7, Picks: sdk.Picks{7}}
+		if polls.Add(1) > 1 {
+			game = games[10]
+		}
+		json.NewEncoder(w).Encode(game)
+	})
+	mux.HandleFunc("GET /games", func(w http.ResponseWriter, r *http.Request) {
+		cursor, _ := strconv.ParseInt(r.URL.Query().Get("cursor"), 10, 64)
+		var resp sdk.GameListResponse
+		for id := cursor; id < cursor+2; id++ {
+			if game, ok := games[id]; ok {
+				resp.Games = append(resp.Games, game)
+			}
+		}
+		if next := cursor + 2; next <= 10 {
+			resp.NextCursor = &next
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	provider := httptest.NewServer(mux)
+	t.Cleanup(provider.Close)
+
+	src := NewPollSource(provider.URL+"/games/latest", time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, want := range []string{"8", "9", "10"} {
+		result, err := src.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if result.Ref != want {
+			t.Errorf("expected game %s, got %s", want, result.Ref)
+		}
+	}
+}
+
+func TestPollSource_NextCanceled(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(sdk.Game{ID: 1, Picks: sdk.Picks{1}})
+	}))
+	t.Cleanup(provider.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := NewPollSource(provider.URL, time.Millisecond).Next(ctx); err == nil {
+		t.Fatal("expected an error once the context ends without a new game")
+	}
+}
+
+func TestIngestSource(t *testing.T) {
+	cfg := defaultGameConfig()
+	src := NewIngestSource(cfg)
+
+	valid := make(sdk.Picks, cfg.PickCount)
+	for i := range valid {
+		valid[i] = uint8(cfg.MinNumber + i)
+	}
+
+	if err := src.Push(Result{Ref: "bad", Picks: sdk.Picks{1, 1}}); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("expected ErrInvalidResult for duplicate picks, got %v", err)
+	}
+
+	for i := range ingestBacklog {
+		if err := src.Push(Result{Ref: strconv.Itoa(i), Picks: valid}); err != nil {
+			t.Fatalf("Push %d: %v", i, err)
+		}
+	}
+	if err := src.Push(Result{Picks: valid}); !errors.Is(err, ErrIngestBacklogFull) {
+		t.Errorf("expected ErrIngestBacklogFull, got %v", err)
+	}
+
+	result, err := src.Next(context.Background())
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if result.Ref != "0" {
+		t.Errorf("expected results in push order, got ref %s first", result.Ref)
+	}
+	if src.Queued() != ingestBacklog-1 {
+		t.Errorf("expected %d queued, got %d", ingestBacklog-1, src.Queued())
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+)
+
+// ErrInvalidSpecial is returned when a special game is malformed or
+// scheduled in the past.
+var ErrInvalidSpecial = errors.New("invalid special game")
+
+// maxSpecialNameLen bounds the name of a special game.
+const maxSpecialNameLen = 64
+
+// SpecialSchedule holds the special games waiting to run, persisted as the
+// game.specials setting so the schedule survives restarts. A special is
+// removed once the engine runs it.
+type SpecialSchedule struct {
+	settings *SettingsService
+}
+
+// NewSpecialSchedule creates a SpecialSchedule stored in settings.
+func NewSpecialSchedule(settings *SettingsService) *SpecialSchedule {
+	return &SpecialSchedule{settings: settings}
+}
+
+// List returns the scheduled special games, soonest first.
+func (s *SpecialSchedule) List(ctx context.Context) ([]*domain.SpecialGame, error) {
+	var specials []*domain.SpecialGame
+	err := s.settings.Get(ctx, SettingSpecialGames, &specials)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	sortSpecials(specials)
+	return specials, nil
+}
+
+// Add schedules special, assigning its ID. It must start in the future.
+func (s *SpecialSchedule) Add(ctx context.Context, special *domain.SpecialGame) error {
+	if err := validateSpecial(special); err != nil {
+		return err
+	}
+	if !special.StartsAt.After(time.Now()) {
+		return fmt.Errorf("%w: starts_at must be in the future", ErrInvalidSpecial)
+	}
+
+	var specials []*domain.SpecialGame
+	return s.settings.Update(ctx, SettingSpecialGames, &specials, func() error {
+		special.ID = 1
+		for _, existing := range specials {
+			special.ID = max(special.ID, existing.ID+1)
+		}
+		specials = append(specials, special)
+		sortSpecials(specials)
+		return nil
+	})
+}
+
+// Cancel removes the special game with the given ID from the schedule. It
+// returns store.ErrNotFound if no such special is waiting to run.
+func (s *SpecialSchedule) Cancel(ctx context.Context, id int64) error {
+	var specials []*domain.SpecialGame
+	return s.settings.Update(ctx, SettingSpecialGames, &specials, func() error {
+		i := slices.IndexFunc(specials, func(special *domain.SpecialGame) bool { return special.ID == id })
+		if i < 0 {
+			return store.ErrNotFound
+		}
+		specials = slices.Delete(specials, i, i+1)
+		return nil
+	})
+}
+
+// Next returns the special game due soonest, or nil if none is scheduled.
+func (s *SpecialSchedule) Next(ctx context.Context) (*domain.SpecialGame, error) {
+	specials, err := s.List(ctx)
+	if err != nil || len(specials) == 0 {
+		return nil, err
+	}
+	return specials[0], nil
+}
+
+// Take removes and returns the special game due soonest if it starts at or
+// before now, or returns nil if none is due. A special missed while the
+// server was down is still run, late.
+func (s *SpecialSchedule) Take(ctx context.Context, now time.Time) (*domain.SpecialGame, error) {
+	next, err := s.Next(ctx)
+	if err != nil || next == nil || next.StartsAt.After(now) {
+		return nil, err
+	}
+
+	var taken *domain.SpecialGame
+	var specials []*domain.SpecialGame
+	err = s.settings.Update(ctx, SettingSpecialGames, &specials, func() error {
+		sortSpecials(specials)
+		if len(specials) == 0 || specials[0].StartsAt.After(now) {
+			return nil
+		}
+		taken, specials = specials[0], specials[1:]
+		return nil
+	})
+	return taken, err
+}
+
+// validateSpecial checks a special game's name, start time and settings,
+// which are bounded like UI hints as they are sent with every state event.
+func validateSpecial(special *domain.SpecialGame) error {
+	if special.Name == "" || len(special.Name) > maxSpecialNameLen {
+		return fmt.Errorf("%w: name must be 1-%d bytes", ErrInvalidSpecial, maxSpecialNameLen)
+	}
+	if special.StartsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at is required", ErrInvalidSpecial)
+	}
+	if err := ValidateHints(special.Branding); err != nil {
+		return fmt.Errorf("%w: branding: %w", ErrInvalidSpecial, err)
+	}
+	if err := ValidateHints(special.Payouts); err != nil {
+		return fmt.Errorf("%w: payouts: %w", ErrInvalidSpecial, err)
+	}
+	return nil
+}
+
+// sortSpecials orders specials soonest first, then in the order they were
+// scheduled.
+func sortSpecials(specials []*domain.SpecialGame) {
+	slices.SortFunc(specials, func(a, b *domain.SpecialGame) int {
+		return cmp.Or(a.StartsAt.Compare(b.StartsAt), cmp.Compare(a.ID, b.ID))
+	})
+}
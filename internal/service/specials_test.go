@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+)
+
+func TestSpecialSchedule_AddListCancel(t *testing.T) {
+	sched := NewSpecialSchedule(NewSettingsService(newMockStore()))
+	ctx := context.Background()
+	now := time.Now()
+
+	later := &domain.SpecialGame{Name: "Finals", StartsAt: now.Add(2 * time.Hour)}
+	sooner := &domain.SpecialGame{Name: "Launch", StartsAt: now.Add(time.Hour), Payouts: map[string]string{"multiplier": "2"}}
+	for _, special := range []*domain.SpecialGame{later, sooner} {
+		if err := sched.Add(ctx, special); err != nil {
+			t.Fatalf("Add(%s) error: %v", special.Name, err)
+		}
+	}
+	if later.ID != 1 || sooner.ID != 2 {
+		t.Errorf("IDs = %d, %d, want 1, 2", later.ID, sooner.ID)
+	}
+
+	specials, err := sched.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(specials) != 2 || specials[0].Name != "Launch" || specials[0].Payouts["multiplier"] != "2" {
+		t.Errorf("List() = %+v, want Launch first", specials)
+	}
+
+	if err := sched.Cancel(ctx, sooner.ID); err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+	if err := sched.Cancel(ctx, sooner.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Cancel() twice error = %v, want store.ErrNotFound", err)
+	}
+	if next, err := sched.Next(ctx); err != nil || next == nil || next.ID != later.ID {
+		t.Errorf("Next() = %+v, %v, want Finals", next, err)
+	}
+}
+
+func TestSpecialSchedule_AddInvalid(t *testing.T) {
+	sched := NewSpecialSchedule(NewSettingsService(newMockStore()))
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		special domain.SpecialGame
+	}{
+		{"no name", domain.SpecialGame{StartsAt: future}},
+		{"no start", domain.SpecialGame{Name: "Finals"}},
+		{"in the past", domain.SpecialGame{Name: "Finals", StartsAt: time.Now().Add(-time.Minute)}},
+		{"empty branding key", domain.SpecialGame{Name: "Finals", StartsAt: future, Branding: map[string]string{"": "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := sched.Add(ctx, &tt.special); !errors.Is(err, ErrInvalidSpecial) {
+				t.Errorf("Add() error = %v, want ErrInvalidSpecial", err)
+			}
+		})
+	}
+}
+
+func TestSpecialSchedule_Take(t *testing.T) {
+	ms := newMockStore()
+	ms.settings[SettingSpecialGames] = `[{"id":1,"name":"Finals","starts_at":"2024-05-01T10:00:00Z"},{"id":2,"name":"Launch","starts_at":"2024-05-01T09:00:00Z"}]`
+	sched := NewSpecialSchedule(NewSettingsService(ms))
+	ctx := context.Background()
+
+	if special, err := sched.Take(ctx, time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)); err != nil || special != nil {
+		t.Errorf("Take() before any start = %+v, %v, want nil", special, err)
+	}
+
+	// Overdue specials are still run, soonest first
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, want := range []string{"Launch", "Finals"} {
+		special, err := sched.Take(ctx, at)
+		if err != nil || special == nil || special.Name != want {
+			t.Fatalf("Take() = %+v, %v, want %s", special, err, want)
+		}
+	}
+	if special, err := sched.Take(ctx, at); err != nil || special != nil {
+		t.Errorf("Take() once empty = %+v, %v, want nil", special, err)
+	}
+}
+
+func TestSettingsService_SetInvalidSpecials(t *testing.T) {
+	svc := NewSettingsService(newMockStore())
+	ctx := context.Background()
+
+	for _, raw := range []string{
+		`{"name":"Finals"}`,
+		`[{"id":1,"name":"","starts_at":"2024-05-01T10:00:00Z"}]`,
+		`[{"id":1,"name":"A","starts_at":"2024-05-01T10:00:00Z"},{"id":1,"name":"B","starts_at":"2024-05-01T11:00:00Z"}]`,
+	} {
+		if err := svc.Set(ctx, SettingSpecialGames, json.RawMessage(raw)); !errors.Is(err, ErrInvalidSpecial) {
+			t.Errorf("Set(%s) error = %v, want ErrInvalidSpecial", raw, err)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// ErrInvalidTicket is returned when a ticket doesn't follow the rules of
+// the variant it is settled under.
+var ErrInvalidTicket = errors.New("invalid ticket")
+
+// Ticket is a player's entry to a game: the numbers they marked, or for
+// variants played on an outcome, such as heads_tails, the outcome they
+// backed.
+type Ticket struct {
+	Marks []uint8
+	Side  string
+}
+
+// Variant is a game played on the engine's draws, chosen with
+// game.variant. Each defines how a game's picks are drawn, what its
+// game:complete event reports, and how tickets settle against it, so other
+// games can run on the same engine. Pay tables are left to the consumers
+// that settle tickets.
+type Variant interface {
+	// Name is the variant's game.variant value.
+	Name() string
+
+	// Draw returns a game's picks in draw order, with intN returning a
+	// random number in [0, n).
+	Draw(cfg *config.GameConfig, intN func(n int) int) []uint8
+
+	// Outcome summarises a completed game's picks for its game:complete
+	// event, or returns nil when the picks say it all.
+	Outcome(cfg *config.GameConfig, picks []uint8) map[string]string
+
+	// Settle returns how many of the ticket's selections won against a
+	// game's picks, or ErrInvalidTicket if the variant can't settle it.
+	Settle(cfg *config.GameConfig, picks []uint8, ticket Ticket) (int, error)
+}
+
+// variants holds every Variant by name, matching config.Variants.
+var variants = map[string]Variant{
+	"classic":     classic{},
+	"heads_tails": headsTails{},
+	"bingo":       bingo{},
+}
+
+// LookupVariant returns the variant named by game.variant, which is classic
+// when empty.
+func LookupVariant(name string) (Variant, error) {
+	if name == "" {
+		return classic{}, nil
+	}
+	v, ok := variants[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown game variant %q", name)
+	}
+	return v, nil
+}
+
+// variantFor returns the variant cfg plays. cfg is validated at load, so
+// an unknown variant falls back to classic.
+func variantFor(cfg *config.GameConfig) Variant {
+	v, err := LookupVariant(cfg.Variant)
+	if err != nil {
+		return classic{}
+	}
+	return v
+}
+
+// classic is Keno: a ticket marks up to a board's worth of numbers and wins
+// on each one drawn.
+type classic struct{}
+
+func (classic) Name() string { return "classic" }
+
+func (classic) Draw(cfg *config.GameConfig, intN func(n int) int) []uint8 {
+	return shufflePicks(cfg, intN)
+}
+
+func (classic) Outcome(*config.GameConfig, []uint8) map[string]string { return nil }
+
+func (classic) Settle(cfg *config.GameConfig, picks []uint8, ticket Ticket) (int, error) {
+	if err := checkMarks(cfg, ticket.Marks); err != nil {
+		return 0, err
+	}
+	return sdk.Picks(picks).Matches(ticket.Marks), nil
+}
+
+// Heads or tails outcomes.
+const (
+	sideHeads = "heads"
+	sideTails = "tails"
+	sideEvens = "evens"
+)
+
+// headsTails splits the board in half: heads is the lower half and tails
+// the upper, and a game is won by the half with more picks, or is evens.
+// Tickets back one of the three.
+type headsTails struct{}
+
+func (headsTails) Name() string { return "heads_tails" }
+
+func (headsTails) Draw(cfg *config.GameConfig, intN func(n int) int) []uint8 {
+	return shufflePicks(cfg, intN)
+}
+
+func (headsTails) Outcome(cfg *config.GameConfig, picks []uint8) map[string]string {
+	heads, tails := countSides(cfg, picks)
+	return map[string]string{
+		"result": headsTailsResult(heads, tails),
+		"heads":  strconv.Itoa(heads),
+		"tails":  strconv.Itoa(tails),
+	}
+}
+
+func (headsTails) Settle(cfg *config.GameConfig, picks []uint8, ticket Ticket) (int, error) {
+	switch ticket.Side {
+	case sideHeads, sideTails, sideEvens:
+	default:
+		return 0, fmt.Errorf("%w: side must be %s, %s or %s, got %q", ErrInvalidTicket, sideHeads, sideTails, sideEvens, ticket.Side)
+	}
+	if headsTailsResult(countSides(cfg, picks)) != ticket.Side {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// countSides returns how many picks fell in each half of the board.
+func countSides(cfg *config.GameConfig, picks []uint8) (heads, tails int) {
+	mid := cfg.MinNumber + (cfg.MaxNumber-cfg.MinNumber+1)/2
+	for _, p := range picks {
+		if int(p) < mid {
+			heads++
+		} else {
+			tails++
+		}
+	}
+	return heads, tails
+}
+
+func headsTailsResult(heads, tails int) string {
+	switch {
+	case heads > tails:
+		return sideHeads
+	case tails > heads:
+		return sideTails
+	default:
+		return sideEvens
+	}
+}
+
+// bingoSide is the width of a bingo card.
+const bingoSide = 5
+
+// bingo settles 5x5 cards, marked row by row with numbers from the board,
+// by the lines completed: each row, column and diagonal whose numbers were
+// all drawn.
+type bingo struct{}
+
+func (bingo) Name() string { return "bingo" }
+
+func (bingo) Draw(cfg *config.GameConfig, intN func(n int) int) []uint8 {
+	return shufflePicks(cfg, intN)
+}
+
+func (bingo) Outcome(*config.GameConfig, []uint8) map[string]string { return nil }
+
+func (bingo) Settle(cfg *config.GameConfig, picks []uint8, ticket Ticket) (int, error) {
+	if len(ticket.Marks) != config.BingoCardSize {
+		return 0, fmt.Errorf("%w: a bingo card marks %d numbers, got %d", ErrInvalidTicket, config.BingoCardSize, len(ticket.Marks))
+	}
+	if err := checkMarks(cfg, ticket.Marks); err != nil {
+		return 0, err
+	}
+
+	drawn := func(row, col int) bool {
+		return slices.Contains(picks, ticket.Marks[row*bingoSide+col])
+	}
+	complete := func(cell func(i int) (row, col int)) int {
+		for i := range bingoSide {
+			if !drawn(cell(i)) {
+				return 0
+			}
+		}
+		return 1
+	}
+
+	lines := 0
+	for n := range bingoSide {
+		lines += complete(func(i int) (int, int) { return n, i })
+		lines += complete(func(i int) (int, int) { return i, n })
+	}
+	lines += complete(func(i int) (int, int) { return i, i })
+	lines += complete(func(i int) (int, int) { return i, bingoSide - 1 - i })
+	return lines, nil
+}
+
+// checkMarks checks a ticket marks at least one number, each on the board
+// and none twice.
+func checkMarks(cfg *config.GameConfig, marks []uint8) error {
+	if len(marks) == 0 {
+		return fmt.Errorf("%w: no numbers marked", ErrInvalidTicket)
+	}
+	for i, n := range marks {
+		if int(n) < cfg.MinNumber || int(n) > cfg.MaxNumber {
+			return fmt.Errorf("%w: %d is not on the board", ErrInvalidTicket, n)
+		}
+		if slices.Contains(marks[:i], n) {
+			return fmt.Errorf("%w: %d is marked twice", ErrInvalidTicket, n)
+		}
+	}
+	return nil
+}
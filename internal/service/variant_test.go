@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestLookupVariant_CoversConfig(t *testing.T) {
+	for _, name := range config.Variants {
+		v, err := LookupVariant(name)
+		if err != nil {
+			t.Errorf("game.variant %q has no implementation: %v", name, err)
+			continue
+		}
+		if v.Name() != name {
+			t.Errorf("variant %q is named %q", name, v.Name())
+		}
+	}
+	if _, err := LookupVariant("roulette"); err == nil {
+		t.Error("expected error for unknown variant")
+	}
+}
+
+func TestVariant_DrawIsValid(t *testing.T) {
+	for _, name := range config.Variants {
+		cfg := defaultGameConfig()
+		cfg.Variant = name
+		rng := rand.New(rand.NewPCG(1, 2))
+
+		picks := variantFor(cfg).Draw(cfg, rng.IntN)
+		game := &domain.Game{ID: 1, Picks: picks}
+		if err := game.Validate(*cfg).Err(); err != nil {
+			t.Errorf("%s: invalid draw: %v", name, err)
+		}
+	}
+}
+
+func TestClassic_Settle(t *testing.T) {
+	cfg := defaultGameConfig()
+
+	hits, err := classic{}.Settle(cfg, testPicks(), Ticket{Marks: []uint8{1, 2, 3, 79, 80}})
+	if err != nil || hits != 3 {
+		t.Errorf("expected 3 hits, got %d, %v", hits, err)
+	}
+
+	for _, marks := range [][]uint8{nil, {0}, {81}, {5, 5}} {
+		if _, err := (classic{}).Settle(cfg, testPicks(), Ticket{Marks: marks}); !errors.Is(err, ErrInvalidTicket) {
+			t.Errorf("marks %v: expected ErrInvalidTicket, got %v", marks, err)
+		}
+	}
+}
+
+func TestHeadsTails(t *testing.T) {
+	cfg := defaultGameConfig()
+
+	// testPicks draws 1-20, all heads
+	outcome := headsTails{}.Outcome(cfg, testPicks())
+	if outcome["result"] != "heads" || outcome["heads"] != "20" || outcome["tails"] != "0" {
+		t.Errorf("unexpected outcome: %v", outcome)
+	}
+
+	evens := []uint8{1, 40, 41, 80}
+	if got := (headsTails{}).Outcome(cfg, evens)["result"]; got != "evens" {
+		t.Errorf("expected evens, got %q", got)
+	}
+
+	tests := []struct {
+		side string
+		want int
+	}{
+		{"heads", 1},
+		{"tails", 0},
+		{"evens", 0},
+	}
+	for _, tt := range tests {
+		got, err := headsTails{}.Settle(cfg, testPicks(), Ticket{Side: tt.side})
+		if err != nil || got != tt.want {
+			t.Errorf("%s: expected %d, got %d, %v", tt.side, tt.want, got, err)
+		}
+	}
+	if _, err := (headsTails{}).Settle(cfg, testPicks(), Ticket{Side: "edge"}); !errors.Is(err, ErrInvalidTicket) {
+		t.Errorf("expected ErrInvalidTicket, got %v", err)
+	}
+}
+
+func TestBingo_Settle(t *testing.T) {
+	cfg := defaultGameConfig()
+
+	// Card rows are 1-5, 6-10, ... 21-25; testPicks draws 1-20, completing
+	// the first four rows and no column or diagonal
+	card := make([]uint8, config.BingoCardSize)
+	for i := range card {
+		card[i] = uint8(i + 1)
+	}
+	lines, err := bingo{}.Settle(cfg, testPicks(), Ticket{Marks: card})
+	if err != nil || lines != 4 {
+		t.Errorf("expected 4 lines, got %d, %v", lines, err)
+	}
+
+	// Drawing the last row too completes every line
+	picks := append(testPicks(), 21, 22, 23, 24, 25)
+	if lines, _ := (bingo{}).Settle(cfg, picks, Ticket{Marks: card}); lines != 12 {
+		t.Errorf("expected 12 lines, got %d", lines)
+	}
+
+	if _, err := (bingo{}).Settle(cfg, testPicks(), Ticket{Marks: card[:24]}); !errors.Is(err, ErrInvalidTicket) {
+		t.Errorf("expected ErrInvalidTicket for a short card, got %v", err)
+	}
+}
+
+func TestGameService_BroadcastComplete_Outcome(t *testing.T) {
+	cfg := defaultGameConfig()
+	cfg.Variant = "heads_tails"
+	svc := NewGameService(newMockStore(), cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := svc.Subscribe(ctx)
+
+	svc.BroadcastComplete(context.Background(), &domain.Game{ID: 1, Picks: testPicks()})
+
+	event := (<-ch).Data.(sdk.GameCompleteEvent)
+	if event.Variant != "heads_tails" || event.Outcome["result"] != "heads" {
+		t.Errorf("expected a heads_tails result of heads, got %+v", event)
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// voiceClipTypes maps the audio file extensions a voice pack may use to
+// their content types.
+var voiceClipTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".ogg":  "audio/ogg",
+	".opus": "audio/ogg",
+	".wav":  "audio/wav",
+	".m4a":  "audio/mp4",
+}
+
+// VoicePack is a directory of recorded number calls with one clip per
+// number, named after it, e.g. 42.mp3. Other files are ignored.
+type VoicePack struct {
+	fsys  fs.FS
+	clips map[uint8]string
+}
+
+// LoadVoicePack indexes the clips in dir.
+func LoadVoicePack(dir string) (*VoicePack, error) {
+	return newVoicePack(os.DirFS(dir))
+}
+
+func newVoicePack(fsys fs.FS) (*VoicePack, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading voice pack: %w", err)
+	}
+
+	p := &VoicePack{fsys: fsys, clips: make(map[uint8]string)}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := path.Ext(name)
+		if entry.IsDir() || voiceClipTypes[strings.ToLower(ext)] == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 8)
+		if err != nil {
+			continue
+		}
+		if existing, ok := p.clips[uint8(n)]; ok {
+			return nil, fmt.Errorf("voice pack has two clips for %d: %s and %s", n, existing, name)
+		}
+		p.clips[uint8(n)] = name
+	}
+	return p, nil
+}
+
+// Has reports whether the pack has a clip for n.
+func (p *VoicePack) Has(n uint8) bool {
+	_, ok := p.clips[n]
+	return ok
+}
+
+// Missing returns the numbers from minNum to maxNum that have no clip.
+func (p *VoicePack) Missing(minNum, maxNum int) []int {
+	var missing []int
+	for n := minNum; n <= maxNum; n++ {
+		if n < 0 || n > 255 || !p.Has(uint8(n)) {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// Open opens the clip for n, returning it with its content type.
+func (p *VoicePack) Open(n uint8) (fs.File, string, error) {
+	name, ok := p.clips[n]
+	if !ok {
+		return nil, "", fs.ErrNotExist
+	}
+	f, err := p.fsys.Open(name)
+	if err != nil {
+		return nil, "", err
+	}
+	return f, voiceClipTypes[strings.ToLower(path.Ext(name))], nil
+}
+
+// CallAudioPath is the path of the endpoint serving the recorded call for
+// pick, sent as call_audio in pick events.
+func CallAudioPath(pick uint8) string {
+	return "/api/v1/calls/" + strconv.Itoa(int(pick))
+}
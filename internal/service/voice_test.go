@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func testVoicePack(t *testing.T) *VoicePack {
+	t.Helper()
+	p, err := newVoicePack(fstest.MapFS{
+		"1.mp3":     {Data: []byte("one")},
+		"2.OGG":     {Data: []byte("two")},
+		"42.wav":    {Data: []byte("forty-two")},
+		"README.md": {Data: []byte("not a clip")},
+		"intro.mp3": {Data: []byte("not a number")},
+		"300.mp3":   {Data: []byte("out of range")},
+		"3":         {Mode: fs.ModeDir | 0o755},
+	})
+	if err != nil {
+		t.Fatalf("newVoicePack() error: %v", err)
+	}
+	return p
+}
+
+func TestVoicePack(t *testing.T) {
+	p := testVoicePack(t)
+
+	for _, n := range []uint8{1, 2, 42} {
+		if !p.Has(n) {
+			t.Errorf("Has(%d) = false, want true", n)
+		}
+	}
+	if p.Has(3) {
+		t.Error("Has(3) = true, want false")
+	}
+
+	if got := p.Missing(1, 5); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("Missing(1, 5) = %v, want [3 4 5]", got)
+	}
+
+	f, contentType, err := p.Open(42)
+	if err != nil {
+		t.Fatalf("Open(42) error: %v", err)
+	}
+	defer f.Close()
+	data, _ := io.ReadAll(f)
+	if string(data) != "forty-two" || contentType != "audio/wav" {
+		t.Errorf("Open(42) = %q as %s, want forty-two as audio/wav", data, contentType)
+	}
+
+	if _, contentType, _ := p.Open(2); contentType != "audio/ogg" {
+		t.Errorf("Open(2) content type = %s, want audio/ogg", contentType)
+	}
+	if _, _, err := p.Open(7); err == nil {
+		t.Error("Open(7) expected error for missing clip")
+	}
+}
+
+func TestVoicePack_DuplicateClip(t *testing.T) {
+	_, err := newVoicePack(fstest.MapFS{
+		"7.mp3": {Data: []byte("a")},
+		"7.ogg": {Data: []byte("b")},
+	})
+	if err == nil {
+		t.Fatal("newVoicePack() expected error for two clips of one number")
+	}
+}
+
+func TestGameService_BroadcastPick_CallAudio(t *testing.T) {
+	svc := NewGameService(newMockStore(), defaultGameConfig())
+	svc.SetVoicePack(testVoicePack(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := svc.Subscribe(ctx)
+
+	svc.BroadcastPick(context.Background(), 42, 0)
+	svc.BroadcastPick(context.Background(), 43, 0)
+
+	for _, want := range []string{"/api/v1/calls/42", ""} {
+		select {
+		case event := <-ch:
+			if got := event.Data.(sdk.GamePickEvent).CallAudio; got != want {
+				t.Errorf("CallAudio = %q, want %q", got, want)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// maxWatchdogInterval caps how often the watchdog checks for progress.
+const maxWatchdogInterval = 5 * time.Second
+
+// watchdogDeadline is the longest the game loop may go without progress:
+// one full game cycle plus the configured tolerance.
+func (e *Engine) watchdogDeadline() time.Duration {
+	return e.config.DrawDuration.Duration() +
+		e.config.WaitDuration.Duration() +
+		e.config.WatchdogTolerance.Duration()
+}
+
+// Stalled reports whether the game loop has made no progress within the
+// deadline, meaning it is wedged even though the process is up.
+func (e *Engine) Stalled() bool {
+	return e.stalled.Load()
+}
+
+// watch checks that the game loop keeps moving games on, flagging the
+// engine as stalled (and logging an error) when it hasn't within the
+// deadline, and clearing the flag once it does. Events broadcast from
+// elsewhere, such as notices, don't count. It runs on its own goroutine so
+// it keeps working when the game loop is stuck.
+func (e *Engine) watch(ctx context.Context) {
+	deadline := e.watchdogDeadline()
+	interval := min(deadline/4, maxWatchdogInterval)
+	started := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Games don't progress while idle or waiting on the external
+			// source, so the deadline runs from when the engine resumes
+			if e.idle.Load() || e.awaiting.Load() {
+				started = time.Now()
+				e.stalled.Store(false)
+				continue
+			}
+
+			last := time.Unix(0, e.progressed.Load())
+			if last.Before(started) {
+				last = started
+			}
+			silent := time.Since(last)
+
+			switch stalled := silent > deadline; {
+			case stalled && !e.stalled.Load():
+				e.logger.Error("Engine stalled, no game progress within deadline",
+					slog.Duration("silent_for", silent),
+					slog.Duration("deadline", deadline),
+				)
+			case !stalled && e.stalled.Load():
+				e.logger.Info("Engine recovered, games resumed")
+			}
+			e.stalled.Store(silent > deadline)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestEngine_Watchdog(t *testing.T) {
+	cfg := &config.GameConfig{
+		DrawDuration:      config.Duration(20 * time.Millisecond),
+		WaitDuration:      config.Duration(20 * time.Millisecond),
+		PickCount:         20,
+		MaxNumber:         80,
+		WatchdogTolerance: config.Duration(20 * time.Millisecond),
+	}
+	svc := NewGameService(newMockStore(), cfg)
+	engine := NewEngine(svc, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go engine.watch(ctx)
+
+	// No events at all: should be flagged once the deadline passes
+	waitFor(t, "engine to be flagged stalled", engine.Stalled)
+
+	// Events from outside the game loop don't count as progress
+	if _, err := svc.BroadcastNotice(context.Background(), "maintenance", "info", time.Minute, nil); err != nil {
+		t.Fatalf("BroadcastNotice: %v", err)
+	}
+	time.Sleep(2 * engine.watchdogDeadline())
+	if !engine.Stalled() {
+		t.Error("expected a notice not to clear the stall")
+	}
+
+	// The game loop resumes: should recover on the next check
+	engine.progress()
+	waitFor(t, "engine to recover", func() bool { return !engine.Stalled() })
+}
+
+func TestEngine_WatchdogAwaitingSource(t *testing.T) {
+	cfg := &config.GameConfig{
+		DrawDuration:      config.Duration(20 * time.Millisecond),
+		WaitDuration:      config.Duration(20 * time.Millisecond),
+		PickCount:         20,
+		MaxNumber:         80,
+		WatchdogTolerance: config.Duration(20 * time.Millisecond),
+	}
+	engine := NewEngine(NewGameService(newMockStore(), cfg), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	engine.SetSource(NewIngestSource(cfg))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go engine.watch(ctx)
+	go func() { _, _ = engine.nextPicks(ctx, 1) }()
+
+	// Nothing is pushed, so the loop waits on the source well past the deadline
+	waitFor(t, "engine to wait on the source", engine.awaiting.Load)
+	time.Sleep(2 * engine.watchdogDeadline())
+	if engine.Stalled() {
+		t.Error("expected waiting on the source not to count as a stall")
+	}
+}
+
+func TestEngine_WatchdogDeadline(t *testing.T) {
+	cfg := &config.GameConfig{
+		DrawDuration:      config.Duration(90 * time.Second),
+		WaitDuration:      config.Duration(60 * time.Second),
+		WatchdogTolerance: config.Duration(30 * time.Second),
+	}
+	engine := NewEngine(NewGameService(newMockStore(), cfg), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if got, want := engine.watchdogDeadline(), 3*time.Minute; got != want {
+		t.Errorf("watchdogDeadline() = %v, want %v", got, want)
+	}
+}
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
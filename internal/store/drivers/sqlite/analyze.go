@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// hotQuery is a read query issued on the request path, with representative
+// arguments for planning and timing.
+type hotQuery struct {
+	name  string
+	query string
+	args  []any
+}
+
+// hotQueries mirrors the read queries in queries/game.sql, with sqlc
+// arguments written as ?. TestHotQueries_MatchGameSQL fails when they drift.
+var hotQueries = []hotQuery{
+	{
+		name:  "GetGameByGameID",
+		query: "SELECT game_id, picks, created_at, result_hash FROM games WHERE game_id = ?",
+		args:  []any{1},
+	},
+	{
+		name:  "GetLatestGame",
+		query: "SELECT game_id, picks, created_at, result_hash FROM games ORDER BY game_id DESC LIMIT 1",
+	},
+	{
+		name:  "GetGamesByRange",
+		query: "SELECT game_id, picks, created_at, result_hash FROM games WHERE game_id >= ? ORDER BY game_id LIMIT ?",
+		args:  []any{1, 100},
+	},
+	{
+		name:  "GetLastGameID",
+		query: "SELECT COALESCE(MAX(game_id), 0) AS last_game_id FROM games",
+	},
+}
+
+// QueryPlan is the EXPLAIN QUERY PLAN output and measured run time of a
+// single store query.
+type QueryPlan struct {
+	Name     string
+	Query    string
+	Steps    []string
+	Duration time.Duration
+}
+
+// FullScan reports whether any step scans a table without using an index,
+// which usually means an index is missing.
+func (p QueryPlan) FullScan() bool {
+	for _, step := range p.Steps {
+		if strings.HasPrefix(step, "SCAN ") && !strings.Contains(step, " USING ") {
+			return true
+		}
+	}
+	return false
+}
+
+// TempSort reports whether the query sorts results with a temporary b-tree
+// instead of reading them in index order.
+func (p QueryPlan) TempSort() bool {
+	for _, step := range p.Steps {
+		if strings.HasPrefix(step, "USE TEMP B-TREE") {
+			return true
+		}
+	}
+	return false
+}
+
+// ExplainQueries runs EXPLAIN QUERY PLAN for each hot read query and times a
+// single execution of it against the current data. The query timeout
+// bounds each query's plan and run.
+func (s *Store) ExplainQueries(ctx context.Context) ([]QueryPlan, error) {
+	plans := make([]QueryPlan, 0, len(hotQueries))
+
+	for _, q := range hotQueries {
+		plan, err := s.explainQuery(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// explainQuery plans and times a single hot query.
+func (s *Store) explainQuery(ctx context.Context, q hotQuery) (QueryPlan, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	steps, err := explain(ctx, s.db, q)
+	if err != nil {
+		return QueryPlan{}, fmt.Errorf("explaining %s: %w", q.name, timeoutErr(ctx, err))
+	}
+
+	duration, err := timeQuery(ctx, s.db, q)
+	if err != nil {
+		return QueryPlan{}, fmt.Errorf("running %s: %w", q.name, timeoutErr(ctx, err))
+	}
+
+	return QueryPlan{
+		Name:     q.name,
+		Query:    q.query,
+		Steps:    steps,
+		Duration: duration,
+	}, nil
+}
+
+// explain returns the detail column of EXPLAIN QUERY PLAN for a query.
+func explain(ctx context.Context, db *sql.DB, q hotQuery) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+q.query, q.args...) //nolint:gosec // q.query is a static hot query
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var steps []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		steps = append(steps, detail)
+	}
+
+	return steps, rows.Err()
+}
+
+// timeQuery executes a query, drains its rows, and returns the elapsed time.
+func timeQuery(ctx context.Context, db *sql.DB, q hotQuery) (time.Duration, error) {
+	start := time.Now()
+
+	rows, err := db.QueryContext(ctx, q.query, q.args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		// Drain rows so the full query cost is measured
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
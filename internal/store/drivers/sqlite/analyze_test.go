@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"context"
+	"maps"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestExplainQueries_UseIndexes(t *testing.T) {
+	s := newSeededStore(t, 1000)
+
+	plans, err := s.ExplainQueries(context.Background())
+	if err != nil {
+		t.Fatalf("ExplainQueries() error: %v", err)
+	}
+
+	if len(plans) != len(hotQueries) {
+		t.Fatalf("expected %d plans, got %d", len(hotQueries), len(plans))
+	}
+
+	for _, p := range plans {
+		if len(p.Steps) == 0 {
+			t.Errorf("%s: expected query plan steps", p.Name)
+		}
+		if p.FullScan() {
+			t.Errorf("%s: unexpected full table scan: %v", p.Name, p.Steps)
+		}
+		if p.TempSort() {
+			t.Errorf("%s: unexpected temporary sort: %v", p.Name, p.Steps)
+		}
+	}
+}
+
+func TestQueryPlan_Detection(t *testing.T) {
+	tests := []struct {
+		name         string
+		steps        []string
+		wantFullScan bool
+		wantTempSort bool
+	}{
+		{"index search", []string{"SEARCH games USING INDEX sqlite_autoindex_games_1 (game_id>?)"}, false, false},
+		{"covering index scan", []string{"SCAN games USING INDEX sqlite_autoindex_games_1"}, false, false},
+		{"full scan", []string{"SCAN games"}, true, false},
+		{"full scan with sort", []string{"SCAN games", "USE TEMP B-TREE FOR ORDER BY"}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := QueryPlan{Steps: tt.steps}
+			if got := p.FullScan(); got != tt.wantFullScan {
+				t.Errorf("FullScan() = %v, want %v", got, tt.wantFullScan)
+			}
+			if got := p.TempSort(); got != tt.wantTempSort {
+				t.Errorf("TempSort() = %v, want %v", got, tt.wantTempSort)
+			}
+		})
+	}
+}
+
+// TestHotQueries_MatchGameSQL checks hotQueries lists every read query in
+// queries/game.sql as sqlc generates it.
+func TestHotQueries_MatchGameSQL(t *testing.T) {
+	data, err := os.ReadFile("queries/game.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlcArg := regexp.MustCompile(`sqlc\.n?arg\('[a-z_]+'\)`)
+	want := make(map[string]string)
+	for _, block := range strings.Split(string(data), "-- name: ")[1:] {
+		header, query, _ := strings.Cut(block, "\n")
+		name, kind, _ := strings.Cut(header, " ")
+		if kind == ":exec" {
+			continue
+		}
+		query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+		want[name] = strings.Join(strings.Fields(sqlcArg.ReplaceAllString(query, "?")), " ")
+	}
+
+	got := make(map[string]string)
+	for _, q := range hotQueries {
+		got[q.name] = q.query
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("hotQueries out of sync with queries/game.sql:\ngot  %v\nwant %v", got, want)
+	}
+}
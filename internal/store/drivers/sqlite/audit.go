@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite/gen"
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// auditPageSize is how many game rows an audit reads at a time.
+const auditPageSize = 1000
+
+// Audit is the result of checking every stored game.
+type Audit struct {
+	// Games is the number of game rows checked.
+	Games int
+
+	// Issues holds the problems found, located by game ID.
+	Issues lint.Issues
+
+	// Bad holds the rows with at least one error-level issue, in row order.
+	Bad []BadGame
+}
+
+// BadGame is a game row that failed the audit.
+type BadGame struct {
+	Row    int64
+	GameID int64
+	Reason string
+}
+
+// AuditGames checks every stored game in the order the rows were written:
+// its picks must decode and pass validation against cfg, game IDs must
+// increase, and creation times must not go backwards. Gaps in the game IDs
+// and out-of-order times are warnings; everything else marks the row bad.
+// The query timeout bounds each page of rows read.
+func (s *Store) AuditGames(ctx context.Context, cfg config.GameConfig) (*Audit, error) {
+	audit := &Audit{}
+	c := lint.NewCollector()
+
+	// Rows out of order are compared against the last row in order, so a
+	// single stray row doesn't flag every row after it
+	var prev gen.Game
+	var after int64
+	for {
+		rows, err := s.listGameRows(ctx, after)
+		if err != nil {
+			return nil, fmt.Errorf("reading games: %w", err)
+		}
+		for _, row := range rows {
+			issues := auditGame(row, prev, cfg)
+			c.Merge(issues)
+			if errs := issues.Errors(); len(errs) > 0 {
+				reasons := make([]string, len(errs))
+				for i, issue := range errs {
+					reasons[i] = issue.Rule + ": " + issue.Message
+				}
+				audit.Bad = append(audit.Bad, BadGame{Row: row.ID, GameID: row.GameID, Reason: strings.Join(reasons, "; ")})
+			}
+			audit.Games++
+			after = row.ID
+			if prev.ID == 0 || row.GameID > prev.GameID {
+				prev = row
+			}
+		}
+		if len(rows) < auditPageSize {
+			break
+		}
+	}
+
+	audit.Issues = c.Issues()
+	return audit, nil
+}
+
+// listGameRows reads the page of game rows after the row after.
+func (s *Store) listGameRows(ctx context.Context, after int64) ([]gen.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.queries.ListGameRows(ctx, gen.ListGameRowsParams{After: after, Limit: auditPageSize})
+	if err != nil {
+		return nil, timeoutErr(ctx, err)
+	}
+	return rows, nil
+}
+
+// auditGame checks a single game row against cfg and the row before it,
+// which is zero for the first row.
+func auditGame(row, prev gen.Game, cfg config.GameConfig) lint.Issues {
+	c := lint.NewCollector()
+	location := fmt.Sprintf("games.%d", row.GameID)
+
+	var picks sdk.Picks
+	if err := json.Unmarshal([]byte(row.Picks), &picks); err != nil {
+		c.Errorf("picks-json", location+".picks", "cannot decode picks: %v", err)
+	} else {
+		game := &domain.Game{ID: row.GameID, Picks: picks, ResultHash: row.ResultHash}
+		for _, issue := range game.Validate(cfg) {
+			issue.Location = location + strings.TrimPrefix(issue.Location, "game")
+			c.Add(issue)
+		}
+	}
+
+	switch {
+	case prev.ID == 0:
+	case row.GameID <= prev.GameID:
+		c.Errorf("game-id-order", location+".id", "written after game %d", prev.GameID)
+	case row.GameID > prev.GameID+1:
+		c.Warnf("game-id-gap", location+".id", "games %d-%d are missing", prev.GameID+1, row.GameID-1)
+	}
+
+	switch {
+	case !row.CreatedAt.Valid:
+		c.Warn("created-at-missing", location+".created_at", "no creation time recorded")
+	case prev.CreatedAt.Valid && row.CreatedAt.Time.Before(prev.CreatedAt.Time):
+		c.Warnf("created-at-order", location+".created_at", "%s is before game %d's %s",
+			row.CreatedAt.Time.UTC().Format(sdk.TimeFormat), prev.GameID, prev.CreatedAt.Time.UTC().Format(sdk.TimeFormat))
+	}
+
+	return c.Issues()
+}
+
+// Quarantine moves the bad games into the quarantined_games table with the
+// reason each failed, in a single transaction bounded by the query timeout.
+func (s *Store) Quarantine(ctx context.Context, bad []BadGame) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", timeoutErr(ctx, err))
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	q := gen.New(tx)
+	for _, g := range bad {
+		if err := q.QuarantineGame(ctx, gen.QuarantineGameParams{Reason: g.Reason, ID: g.Row}); err != nil {
+			return fmt.Errorf("quarantining game %d: %w", g.GameID, timeoutErr(ctx, err))
+		}
+		if err := q.DeleteGameRow(ctx, g.Row); err != nil {
+			return fmt.Errorf("removing game %d: %w", g.GameID, timeoutErr(ctx, err))
+		}
+	}
+
+	return timeoutErr(ctx, tx.Commit())
+}
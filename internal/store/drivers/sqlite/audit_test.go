@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+)
+
+func TestAuditGames(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(filepath.Join(t.TempDir(), "audit.db"))
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	defer s.Close()
+
+	picks := `[1,5,12,18,23,29,34,40,41,47,52,55,60,63,66,70,72,75,78,80]`
+	rows := []struct {
+		gameID    int64
+		picks     string
+		createdAt string
+	}{
+		{1, picks, "2026-01-01 00:00:00"},
+		{2, `[1,2`, "2026-01-01 00:03:00"},
+		{4, picks, "2026-01-01 00:06:00"},
+		{3, picks, "2026-01-01 00:09:00"},
+		{5, `[1,1,12,18,23,29,34,40,41,47,52,55,60,63,66,70,72,75,78,80]`, "2026-01-01 00:12:00"},
+		{6, picks, "2026-01-01 00:10:00"},
+	}
+	for _, r := range rows {
+		if _, err := s.db.Exec("INSERT INTO games (game_id, picks, created_at) VALUES (?, ?, ?)", r.gameID, r.picks, r.createdAt); err != nil {
+			t.Fatalf("inserting game %d: %v", r.gameID, err)
+		}
+	}
+
+	cfg := config.Default().Game
+	audit, err := s.AuditGames(ctx, cfg)
+	if err != nil {
+		t.Fatalf("AuditGames() error: %v", err)
+	}
+	if audit.Games != len(rows) {
+		t.Errorf("Games = %d, want %d", audit.Games, len(rows))
+	}
+
+	var rules []string
+	for _, issue := range audit.Issues {
+		rules = append(rules, issue.Location+" "+issue.Rule)
+	}
+	want := []string{
+		"games.2.picks picks-json",
+		"games.4.id game-id-gap",
+		"games.3.id game-id-order",
+		"games.5.picks[1] pick-duplicate",
+		"games.6.created_at created-at-order",
+	}
+	if !slices.Equal(rules, want) {
+		t.Errorf("issues = %v, want %v", rules, want)
+	}
+
+	var bad []int64
+	for _, g := range audit.Bad {
+		bad = append(bad, g.GameID)
+	}
+	if !slices.Equal(bad, []int64{2, 3, 5}) {
+		t.Fatalf("bad games = %v, want [2 3 5]", bad)
+	}
+
+	if err := s.Quarantine(ctx, audit.Bad); err != nil {
+		t.Fatalf("Quarantine() error: %v", err)
+	}
+	var remaining, quarantined int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM games").Scan(&remaining); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM quarantined_games WHERE reason LIKE 'picks-json:%'").Scan(&quarantined); err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 3 || quarantined != 1 {
+		t.Errorf("after quarantine: %d games remain, %d quarantined for bad JSON; want 3 and 1", remaining, quarantined)
+	}
+
+	// What's left only has the gap where the bad games were
+	audit, err = s.AuditGames(ctx, cfg)
+	if err != nil {
+		t.Fatalf("AuditGames() error: %v", err)
+	}
+	if len(audit.Bad) != 0 || audit.Issues.HasErrors() {
+		t.Errorf("audit after quarantine found errors: %v", audit.Issues)
+	}
+}
@@ -11,41 +11,49 @@ import (
 )
 
 const createGame = `-- name: CreateGame :exec
-INSERT INTO games (game_id, picks)
-VALUES (?, ?)
+INSERT INTO games (game_id, picks, result_hash, created_at)
+VALUES (?1, ?2, ?3, COALESCE(?4, CURRENT_TIMESTAMP))
 `
 
 type CreateGameParams struct {
-	GameID int64
-	Picks  string
+	GameID     int64
+	Picks      string
+	ResultHash string
+	CreatedAt  sql.NullTime
 }
 
 func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) error {
-	_, err := q.db.ExecContext(ctx, createGame, arg.GameID, arg.Picks)
+	_, err := q.db.ExecContext(ctx, createGame,
+		arg.GameID,
+		arg.Picks,
+		arg.ResultHash,
+		arg.CreatedAt,
+	)
 	return err
 }
 
 const getGameByGameID = `-- name: GetGameByGameID :one
-SELECT game_id, picks, created_at
+SELECT game_id, picks, created_at, result_hash
 FROM games
 WHERE game_id = ?
 `
 
 type GetGameByGameIDRow struct {
-	GameID    int64
-	Picks     string
-	CreatedAt sql.NullTime
+	GameID     int64
+	Picks      string
+	CreatedAt  sql.NullTime
+	ResultHash string
 }
 
 func (q *Queries) GetGameByGameID(ctx context.Context, gameID int64) (GetGameByGameIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getGameByGameID, gameID)
 	var i GetGameByGameIDRow
-	err := row.Scan(&i.GameID, &i.Picks, &i.CreatedAt)
+	err := row.Scan(&i.GameID, &i.Picks, &i.CreatedAt, &i.ResultHash)
 	return i, err
 }
 
 const getGamesByRange = `-- name: GetGamesByRange :many
-SELECT game_id, picks, created_at
+SELECT game_id, picks, created_at, result_hash
 FROM games
 WHERE game_id >= ?1
 ORDER BY game_id
@@ -58,9 +66,10 @@ type GetGamesByRangeParams struct {
 }
 
 type GetGamesByRangeRow struct {
-	GameID    int64
-	Picks     string
-	CreatedAt sql.NullTime
+	GameID     int64
+	Picks      string
+	CreatedAt  sql.NullTime
+	ResultHash string
 }
 
 func (q *Queries) GetGamesByRange(ctx context.Context, arg GetGamesByRangeParams) ([]GetGamesByRangeRow, error) {
@@ -72,7 +81,7 @@ func (q *Queries) GetGamesByRange(ctx context.Context, arg GetGamesByRangeParams
 	var items []GetGamesByRangeRow
 	for rows.Next() {
 		var i GetGamesByRangeRow
-		if err := rows.Scan(&i.GameID, &i.Picks, &i.CreatedAt); err != nil {
+		if err := rows.Scan(&i.GameID, &i.Picks, &i.CreatedAt, &i.ResultHash); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -99,21 +108,22 @@ func (q *Queries) GetLastGameID(ctx context.Context) (interface{}, error) {
 }
 
 const getLatestGame = `-- name: GetLatestGame :one
-SELECT game_id, picks, created_at
+SELECT game_id, picks, created_at, result_hash
 FROM games
 ORDER BY game_id DESC
 LIMIT 1
 `
 
 type GetLatestGameRow struct {
-	GameID    int64
-	Picks     string
-	CreatedAt sql.NullTime
+	GameID     int64
+	Picks      string
+	CreatedAt  sql.NullTime
+	ResultHash string
 }
 
 func (q *Queries) GetLatestGame(ctx context.Context) (GetLatestGameRow, error) {
 	row := q.db.QueryRowContext(ctx, getLatestGame)
 	var i GetLatestGameRow
-	err := row.Scan(&i.GameID, &i.Picks, &i.CreatedAt)
+	err := row.Scan(&i.GameID, &i.Picks, &i.CreatedAt, &i.ResultHash)
 	return i, err
 }
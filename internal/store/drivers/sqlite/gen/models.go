@@ -6,11 +6,41 @@ package gen
 
 import (
 	"database/sql"
+	"time"
 )
 
 type Game struct {
-	ID        int64
-	GameID    int64
-	CreatedAt sql.NullTime
-	Picks     string
+	ID         int64
+	GameID     int64
+	CreatedAt  sql.NullTime
+	Picks      string
+	ResultHash string
+}
+
+type Maintenance struct {
+	Operation   string
+	CompletedAt time.Time
+}
+
+type QuarantinedGame struct {
+	ID            int64
+	GameID        int64
+	CreatedAt     sql.NullTime
+	Picks         string
+	ResultHash    string
+	Reason        string
+	QuarantinedAt time.Time
+}
+
+type Season struct {
+	SeasonID    int64
+	Name        string
+	FirstGameID int64
+	StartedAt   sql.NullTime
+}
+
+type Setting struct {
+	Key       string
+	Value     string
+	UpdatedAt sql.NullTime
 }
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite/gen"
+)
+
+// Maintenance operations recorded in the maintenance table.
+const (
+	opVacuum = "vacuum"
+	opBackup = "backup"
+)
+
+// Stats reports the database's file sizes, per-table row counts, and when
+// it was last vacuumed or backed up.
+func (s *Store) Stats(ctx context.Context) (*store.Stats, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	stats, err := readStats(ctx, s.db)
+	if err != nil {
+		return nil, timeoutErr(ctx, err)
+	}
+	return stats, nil
+}
+
+func readStats(ctx context.Context, db *sql.DB) (*store.Stats, error) {
+	stats := &store.Stats{Rows: make(map[string]int64)}
+
+	var pageSize, pageCount, freePages int64
+	for pragma, dest := range map[string]*int64{
+		"page_size":      &pageSize,
+		"page_count":     &pageCount,
+		"freelist_count": &freePages,
+	} {
+		if err := db.QueryRowContext(ctx, "PRAGMA "+pragma).Scan(dest); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", pragma, err)
+		}
+	}
+	stats.FileSize = pageSize * pageCount
+	stats.FreeSize = pageSize * freePages
+
+	// In-memory databases have no file; the page count stands in for it
+	path, err := databasePath(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		if info, err := os.Stat(path); err == nil {
+			stats.FileSize = info.Size()
+		}
+		if info, err := os.Stat(path + "-wal"); err == nil {
+			stats.WALSize = info.Size()
+		}
+	}
+
+	tables, err := listTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		var n int64
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %q", table)
+		if err := db.QueryRowContext(ctx, query).Scan(&n); err != nil { //nolint:gosec // table names come from sqlite_master
+			return nil, fmt.Errorf("counting %s rows: %w", table, err)
+		}
+		stats.Rows[table] = n
+	}
+
+	rows, err := gen.New(db).ListMaintenance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading maintenance history: %w", err)
+	}
+	for _, row := range rows {
+		switch row.Operation {
+		case opVacuum:
+			stats.LastVacuum = row.CompletedAt
+		case opBackup:
+			stats.LastBackup = row.CompletedAt
+		}
+	}
+
+	return stats, nil
+}
+
+// databasePath returns the file backing the main database, or "" for an
+// in-memory database.
+func databasePath(ctx context.Context, db *sql.DB) (string, error) {
+	rows, err := db.QueryContext(ctx, "PRAGMA database_list")
+	if err != nil {
+		return "", fmt.Errorf("listing databases: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seq int
+		var name, file string
+		if err := rows.Scan(&seq, &name, &file); err != nil {
+			return "", err
+		}
+		if name == "main" {
+			return file, nil
+		}
+	}
+
+	return "", rows.Err()
+}
+
+// listTables returns the names of the user tables, excluding SQLite's
+// internal ones.
+func listTables(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+
+	return tables, rows.Err()
+}
+
+// Vacuum rebuilds the database to reclaim free pages and records when it
+// ran. It needs exclusive access, so writes block until it finishes.
+func Vacuum(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("vacuuming: %w", err)
+	}
+	return record(ctx, db, opVacuum)
+}
+
+// Backup writes a consistent, compacted copy of the database to path and
+// records when it ran. The file at path must not already exist.
+func Backup(ctx context.Context, db *sql.DB, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup target %s already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking backup target: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("backing up to %s: %w", path, err)
+	}
+	return record(ctx, db, opBackup)
+}
+
+func record(ctx context.Context, db *sql.DB, operation string) error {
+	if err := gen.New(db).RecordMaintenance(ctx, operation); err != nil {
+		return fmt.Errorf("recording %s: %w", operation, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_Stats(t *testing.T) {
+	ctx := context.Background()
+	s := newSeededStore(t, 50)
+
+	stats, err := s.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats() error: %v", err)
+	}
+	if stats.FileSize <= 0 {
+		t.Errorf("FileSize = %d, want positive", stats.FileSize)
+	}
+	if got := stats.Rows["games"]; got != 50 {
+		t.Errorf("Rows[games] = %d, want 50", got)
+	}
+	if _, ok := stats.Rows["settings"]; !ok {
+		t.Errorf("Rows missing settings table: %v", stats.Rows)
+	}
+	if !stats.LastVacuum.IsZero() || !stats.LastBackup.IsZero() {
+		t.Errorf("fresh database reports maintenance: vacuum %v, backup %v", stats.LastVacuum, stats.LastBackup)
+	}
+
+	if err := Vacuum(ctx, s.db); err != nil {
+		t.Fatalf("Vacuum() error: %v", err)
+	}
+	backup := filepath.Join(t.TempDir(), "backup.db")
+	if err := Backup(ctx, s.db, backup); err != nil {
+		t.Fatalf("Backup() error: %v", err)
+	}
+
+	stats, err = s.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats() error: %v", err)
+	}
+	if stats.LastVacuum.IsZero() || stats.LastBackup.IsZero() {
+		t.Errorf("maintenance not recorded: vacuum %v, backup %v", stats.LastVacuum, stats.LastBackup)
+	}
+
+	// The backup is a usable database with the same games
+	copied, err := New(backup)
+	if err != nil {
+		t.Fatalf("opening backup: %v", err)
+	}
+	defer copied.Close()
+	if _, err := copied.GetGame(ctx, 50); err != nil {
+		t.Errorf("backup GetGame(50) error: %v", err)
+	}
+
+	// Backups never overwrite an existing file
+	if err := Backup(ctx, s.db, backup); err == nil {
+		t.Error("Backup() over an existing file succeeded")
+	}
+}
@@ -0,0 +1,11 @@
+-- Table for community seasons. A season covers every game from its
+-- first_game_id up to (but excluding) the next season's first_game_id.
+CREATE TABLE IF NOT EXISTS seasons (
+    season_id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    first_game_id INTEGER NOT NULL,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Existing history belongs to the first season.
+INSERT INTO seasons (season_id, name, first_game_id) VALUES (1, 'Season 1', 1);
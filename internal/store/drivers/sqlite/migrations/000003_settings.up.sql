@@ -0,0 +1,6 @@
+-- Runtime-tunable settings. Values are JSON encoded by the settings service.
+CREATE TABLE IF NOT EXISTS settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
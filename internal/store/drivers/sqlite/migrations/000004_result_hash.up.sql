@@ -0,0 +1,4 @@
+-- Content hash of each game's ID and picks, for mirrors to compare results.
+-- Rows written before this migration keep an empty hash, which the store
+-- fills in on read.
+ALTER TABLE games ADD COLUMN result_hash TEXT NOT NULL DEFAULT '';
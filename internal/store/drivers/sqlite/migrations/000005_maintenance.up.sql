@@ -0,0 +1,5 @@
+-- When each maintenance operation (vacuum, backup) last completed.
+CREATE TABLE IF NOT EXISTS maintenance (
+    operation TEXT PRIMARY KEY,
+    completed_at TIMESTAMP NOT NULL
+);
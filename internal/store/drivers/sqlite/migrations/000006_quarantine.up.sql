@@ -0,0 +1,11 @@
+-- Games moved out of the games table by `taboo db audit --quarantine`,
+-- kept as stored alongside why they failed the audit.
+CREATE TABLE IF NOT EXISTS quarantined_games (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    game_id INTEGER NOT NULL,
+    created_at TIMESTAMP,
+    picks TEXT NOT NULL,
+    result_hash TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL,
+    quarantined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
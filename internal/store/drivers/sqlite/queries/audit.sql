@@ -0,0 +1,16 @@
+-- name: ListGameRows :many
+SELECT id, game_id, created_at, picks, result_hash
+FROM games
+WHERE id > sqlc.arg('after')
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: QuarantineGame :exec
+INSERT INTO quarantined_games (game_id, created_at, picks, result_hash, reason)
+SELECT game_id, created_at, picks, result_hash, CAST(sqlc.arg('reason') AS TEXT)
+FROM games
+WHERE games.id = sqlc.arg('id');
+
+-- name: DeleteGameRow :exec
+DELETE FROM games
+WHERE id = ?;
@@ -1,21 +1,21 @@
 
 -- name: CreateGame :exec
-INSERT INTO games (game_id, picks)
-VALUES (?, ?);
+INSERT INTO games (game_id, picks, result_hash, created_at)
+VALUES (?, ?, ?, COALESCE(sqlc.narg('created_at'), CURRENT_TIMESTAMP));
 
 -- name: GetGameByGameID :one
-SELECT game_id, picks, created_at
+SELECT game_id, picks, created_at, result_hash
 FROM games
 WHERE game_id = ?;
 
 -- name: GetLatestGame :one
-SELECT game_id, picks, created_at
+SELECT game_id, picks, created_at, result_hash
 FROM games
 ORDER BY game_id DESC
 LIMIT 1;
 
 -- name: GetGamesByRange :many
-SELECT game_id, picks, created_at
+SELECT game_id, picks, created_at, result_hash
 FROM games
 WHERE game_id >= sqlc.arg('start')
 ORDER BY game_id
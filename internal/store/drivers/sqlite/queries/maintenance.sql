@@ -0,0 +1,9 @@
+-- name: ListMaintenance :many
+SELECT operation, completed_at
+FROM maintenance;
+
+-- name: RecordMaintenance :exec
+INSERT INTO maintenance (operation, completed_at)
+VALUES (?, CURRENT_TIMESTAMP)
+ON CONFLICT (operation) DO UPDATE SET
+    completed_at = excluded.completed_at;
@@ -0,0 +1,26 @@
+-- name: CreateSeason :exec
+INSERT INTO seasons (season_id, name, first_game_id)
+VALUES (?, ?, ?);
+
+-- name: GetSeason :one
+SELECT season_id, name, first_game_id, started_at
+FROM seasons
+WHERE season_id = ?;
+
+-- name: GetCurrentSeason :one
+SELECT season_id, name, first_game_id, started_at
+FROM seasons
+ORDER BY season_id DESC
+LIMIT 1;
+
+-- name: GetNextSeasonStart :one
+SELECT first_game_id
+FROM seasons
+WHERE season_id > ?
+ORDER BY season_id
+LIMIT 1;
+
+-- name: ListSeasons :many
+SELECT season_id, name, first_game_id, started_at
+FROM seasons
+ORDER BY season_id;
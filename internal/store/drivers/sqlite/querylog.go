@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// queryLog logs every statement run on a database at DEBUG, with how long
+// it took and how many rows it returned or changed, while enabled reports
+// true. Arguments are left out, as they may hold secrets such as settings.
+type queryLog struct {
+	logger  *slog.Logger
+	enabled func() bool
+}
+
+// log records a statement started at start.
+func (l *queryLog) log(ctx context.Context, query string, start time.Time, rows int64, err error) {
+	attrs := []slog.Attr{
+		slog.String("statement", strings.Join(strings.Fields(query), " ")),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int64("rows", rows),
+	}
+	if err != nil {
+		attrs = append(attrs, slogx.Error(err))
+	}
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "Query", attrs...)
+}
+
+// active reports whether statements should be logged, checked as each one
+// starts so the toggle takes effect without reopening the database.
+func (l *queryLog) active(ctx context.Context) bool {
+	return l.enabled() && l.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// queryLogConnector opens connections with a driver that log their
+// statements.
+type queryLogConnector struct {
+	driver driver.Driver
+	dsn    string
+	log    *queryLog
+}
+
+func (c *queryLogConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &queryLogConn{Conn: conn, log: c.log}, nil
+}
+
+func (c *queryLogConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// queryLogConn logs the statements run on a connection, passing through the
+// optional interfaces database/sql relies on.
+type queryLogConn struct {
+	driver.Conn
+	log *queryLog
+}
+
+func (c *queryLogConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if !c.log.active(ctx) {
+		return execer.ExecContext(ctx, query, args)
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.log.log(ctx, query, start, rowsAffected(result), err)
+	return result, err
+}
+
+func (c *queryLogConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if !c.log.active(ctx) {
+		return queryer.QueryContext(ctx, query, args)
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		c.log.log(ctx, query, start, 0, err)
+		return nil, err
+	}
+	return &queryLogRows{Rows: rows, ctx: ctx, query: query, start: start, log: c.log}, nil
+}
+
+func (c *queryLogConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := stmt.(driver.StmtExecContext); !ok {
+		return stmt, nil
+	}
+	if _, ok := stmt.(driver.StmtQueryContext); !ok {
+		return stmt, nil
+	}
+	return &queryLogStmt{Stmt: stmt, query: query, log: c.log}, nil
+}
+
+func (c *queryLogConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Begin() //nolint:staticcheck // only when the driver lacks BeginTx
+}
+
+func (c *queryLogConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *queryLogConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *queryLogConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// queryLogStmt logs each run of a prepared statement.
+type queryLogStmt struct {
+	driver.Stmt
+	query string
+	log   *queryLog
+}
+
+func (s *queryLogStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer := s.Stmt.(driver.StmtExecContext)
+	if !s.log.active(ctx) {
+		return execer.ExecContext(ctx, args)
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, args)
+	s.log.log(ctx, s.query, start, rowsAffected(result), err)
+	return result, err
+}
+
+func (s *queryLogStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer := s.Stmt.(driver.StmtQueryContext)
+	if !s.log.active(ctx) {
+		return queryer.QueryContext(ctx, args)
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		s.log.log(ctx, s.query, start, 0, err)
+		return nil, err
+	}
+	return &queryLogRows{Rows: rows, ctx: ctx, query: s.query, start: start, log: s.log}, nil
+}
+
+// queryLogRows counts the rows a query returns, logging it once they are
+// closed. SQLite steps through results as they are read, so the duration
+// includes reading them.
+type queryLogRows struct {
+	driver.Rows
+	ctx   context.Context
+	query string
+	start time.Time
+	log   *queryLog
+	count int64
+}
+
+func (r *queryLogRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.count++
+	}
+	return err
+}
+
+func (r *queryLogRows) Close() error {
+	err := r.Rows.Close()
+	r.log.log(r.ctx, r.query, r.start, r.count, err)
+	return err
+}
+
+// rowsAffected returns the rows a statement changed, or zero if unknown.
+func rowsAffected(result driver.Result) int64 {
+	if result == nil {
+		return 0
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return n
+}
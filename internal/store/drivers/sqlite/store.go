@@ -7,10 +7,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/aussiebroadwan/taboo/internal/domain"
 	"github.com/aussiebroadwan/taboo/internal/store"
 	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite/gen"
+	"github.com/aussiebroadwan/taboo/sdk"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
@@ -24,6 +27,33 @@ var migrationsFS embed.FS
 type Store struct {
 	db      *sql.DB
 	queries *gen.Queries
+
+	// queryTimeout bounds each store method; zero leaves only the caller's
+	// context deadline.
+	queryTimeout time.Duration
+
+	// queryLog, if set, logs every statement the store runs.
+	queryLog *queryLog
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithQueryTimeout bounds every store call to d, on top of any deadline
+// already on the caller's context. Zero disables the extra bound.
+func WithQueryTimeout(d time.Duration) Option {
+	return func(s *Store) {
+		s.queryTimeout = d
+	}
+}
+
+// WithQueryLog logs every statement the store runs to logger at DEBUG,
+// with its duration and the rows it returned or changed, whenever enabled
+// reports true.
+func WithQueryLog(logger *slog.Logger, enabled func() bool) Option {
+	return func(s *Store) {
+		s.queryLog = &queryLog{logger: logger, enabled: enabled}
+	}
 }
 
 // OpenDB opens a database connection without running migrations.
@@ -63,29 +93,67 @@ func NewMigrate(db *sql.DB) (*migrate.Migrate, error) {
 	return m, nil
 }
 
+// memoryDSN opens an in-memory database.
+const memoryDSN = ":memory:"
+
 // New creates a new SQLite store and runs migrations.
-func New(dsn string) (*Store, error) {
-	db, err := sql.Open("sqlite", dsn)
+func New(dsn string, opts ...Option) (*Store, error) {
+	s, err := Open(dsn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run migrations
+	if err := runMigrations(s.db); err != nil {
+		_ = s.db.Close()
+		return nil, fmt.Errorf("running migrations: %w", err)
+	}
+
+	return s, nil
+}
+
+// Open opens a SQLite store without running migrations, for CLI commands
+// that inspect the database as it is.
+func Open(dsn string, opts ...Option) (*Store, error) {
+	s := &Store{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	db, err := s.open(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
 	}
 
+	// Each connection to :memory: opens its own empty database, so every
+	// query must share the one migrations ran on
+	if dsn == memoryDSN {
+		db.SetMaxOpenConns(1)
+	}
+
 	// Enable WAL mode for better concurrent performance
 	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("enabling WAL mode: %w", err)
 	}
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("running migrations: %w", err)
+	s.db = db
+	s.queries = gen.New(db)
+	return s, nil
+}
+
+// open opens the database, through the query log if the store has one.
+func (s *Store) open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil || s.queryLog == nil {
+		return db, err
 	}
 
-	return &Store{
-		db:      db,
-		queries: gen.New(db),
-	}, nil
+	// Nothing is connected yet, so reopen with the driver registered for
+	// sqlite, wrapping each connection it opens
+	connector := &queryLogConnector{driver: db.Driver(), dsn: dsn, log: s.queryLog}
+	_ = db.Close()
+	return sql.OpenDB(connector), nil
 }
 
 func runMigrations(db *sql.DB) error {
@@ -114,9 +182,34 @@ func runMigrations(db *sql.DB) error {
 // Ensure Store implements store.Store.
 var _ store.Store = (*Store)(nil)
 
+// queryContext derives the context a store call runs under, bounded by the
+// query timeout when one is configured.
+func (s *Store) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.queryTimeout)
+}
+
+// timeoutErr marks err as store.ErrTimeout when ctx's deadline passed while
+// the query ran. The driver reports this inconsistently (an interrupted
+// query or context.DeadlineExceeded), so the context is checked instead.
+func timeoutErr(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", store.ErrTimeout, err)
+	}
+	return err
+}
+
 // Ping checks the database connection.
 func (s *Store) Ping(ctx context.Context) error {
-	return s.db.PingContext(ctx)
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	if err := s.db.PingContext(ctx); err != nil {
+		return timeoutErr(ctx, err)
+	}
+	return nil
 }
 
 // Close closes the database connection.
@@ -124,19 +217,25 @@ func (s *Store) Close() error {
 	return s.db.Close()
 }
 
-// CreateGame persists a new game.
+// CreateGame persists a new game, stamped with its CreatedAt or the
+// current time when that is unset.
 func (s *Store) CreateGame(ctx context.Context, game *domain.Game) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	picks, err := json.Marshal(game.Picks)
 	if err != nil {
 		return fmt.Errorf("marshaling picks: %w", err)
 	}
 
 	err = s.queries.CreateGame(ctx, gen.CreateGameParams{
-		GameID: game.ID,
-		Picks:  string(picks),
+		GameID:     game.ID,
+		Picks:      string(picks),
+		ResultHash: game.ResultHash,
+		CreatedAt:  sql.NullTime{Time: game.CreatedAt.UTC(), Valid: !game.CreatedAt.IsZero()},
 	})
 	if err != nil {
-		return fmt.Errorf("inserting game: %w", err)
+		return fmt.Errorf("inserting game: %w", timeoutErr(ctx, err))
 	}
 
 	return nil
@@ -144,12 +243,15 @@ func (s *Store) CreateGame(ctx context.Context, game *domain.Game) error {
 
 // GetGame retrieves a game by its ID.
 func (s *Store) GetGame(ctx context.Context, id int64) (*domain.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	row, err := s.queries.GetGameByGameID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, store.ErrNotFound
 		}
-		return nil, fmt.Errorf("getting game: %w", err)
+		return nil, fmt.Errorf("getting game: %w", timeoutErr(ctx, err))
 	}
 
 	return rowToGame(row)
@@ -157,12 +259,15 @@ func (s *Store) GetGame(ctx context.Context, id int64) (*domain.Game, error) {
 
 // GetLatestGame retrieves the most recent game.
 func (s *Store) GetLatestGame(ctx context.Context) (*domain.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	row, err := s.queries.GetLatestGame(ctx)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, store.ErrNotFound
 		}
-		return nil, fmt.Errorf("getting latest game: %w", err)
+		return nil, fmt.Errorf("getting latest game: %w", timeoutErr(ctx, err))
 	}
 
 	return rowToGame(gen.GetGameByGameIDRow(row))
@@ -170,12 +275,15 @@ func (s *Store) GetLatestGame(ctx context.Context) (*domain.Game, error) {
 
 // ListGames retrieves games starting from a given ID with a limit.
 func (s *Store) ListGames(ctx context.Context, startID int64, limit int) ([]*domain.Game, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
 	rows, err := s.queries.GetGamesByRange(ctx, gen.GetGamesByRangeParams{
 		Start: startID,
 		Limit: int64(limit),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("querying games: %w", err)
+		return nil, fmt.Errorf("querying games: %w", timeoutErr(ctx, err))
 	}
 
 	games := make([]*domain.Game, 0, len(rows))
@@ -192,14 +300,174 @@ func (s *Store) ListGames(ctx context.Context, startID int64, limit int) ([]*dom
 
 // rowToGame converts a generated query row to a domain.Game.
 func rowToGame(row gen.GetGameByGameIDRow) (*domain.Game, error) {
-	var picks []uint8
+	var picks sdk.Picks
 	if err := json.Unmarshal([]byte(row.Picks), &picks); err != nil {
 		return nil, fmt.Errorf("unmarshaling picks: %w", err)
 	}
 
-	return &domain.Game{
-		ID:        row.GameID,
-		Picks:     picks,
-		CreatedAt: row.CreatedAt.Time,
-	}, nil
+	game := &domain.Game{
+		ID:         row.GameID,
+		Picks:      picks,
+		CreatedAt:  row.CreatedAt.Time,
+		ResultHash: row.ResultHash,
+	}
+	// Games stored before result hashes were recorded
+	if game.ResultHash == "" {
+		game.ResultHash = game.Hash()
+	}
+	return game, nil
+}
+
+// CreateSeason persists a new season.
+func (s *Store) CreateSeason(ctx context.Context, season *domain.Season) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	err := s.queries.CreateSeason(ctx, gen.CreateSeasonParams{
+		SeasonID:    season.ID,
+		Name:        season.Name,
+		FirstGameID: season.FirstGameID,
+	})
+	if err != nil {
+		return fmt.Errorf("inserting season: %w", timeoutErr(ctx, err))
+	}
+
+	return nil
+}
+
+// GetSeason retrieves a season by its ID.
+func (s *Store) GetSeason(ctx context.Context, id int64) (*domain.Season, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	row, err := s.queries.GetSeason(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.ErrNotFound
+		}
+		return nil, fmt.Errorf("getting season: %w", timeoutErr(ctx, err))
+	}
+
+	season := rowToSeason(row)
+
+	end, err := s.queries.GetNextSeasonStart(ctx, id)
+	switch {
+	case err == nil:
+		season.EndGameID = end
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("getting season end: %w", timeoutErr(ctx, err))
+	}
+
+	return season, nil
+}
+
+// GetCurrentSeason retrieves the most recent season.
+func (s *Store) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	row, err := s.queries.GetCurrentSeason(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.ErrNotFound
+		}
+		return nil, fmt.Errorf("getting current season: %w", timeoutErr(ctx, err))
+	}
+
+	return rowToSeason(row), nil
+}
+
+// ListSeasons retrieves all seasons, oldest first.
+func (s *Store) ListSeasons(ctx context.Context) ([]*domain.Season, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.queries.ListSeasons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying seasons: %w", timeoutErr(ctx, err))
+	}
+
+	seasons := make([]*domain.Season, 0, len(rows))
+	for i, row := range rows {
+		season := rowToSeason(row)
+		if i+1 < len(rows) {
+			season.EndGameID = rows[i+1].FirstGameID
+		}
+		seasons = append(seasons, season)
+	}
+
+	return seasons, nil
+}
+
+// GetSetting retrieves the raw value of a setting.
+func (s *Store) GetSetting(ctx context.Context, key string) (string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	row, err := s.queries.GetSetting(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", store.ErrNotFound
+		}
+		return "", fmt.Errorf("getting setting: %w", timeoutErr(ctx, err))
+	}
+
+	return row.Value, nil
+}
+
+// ListSettings retrieves all settings keyed by name.
+func (s *Store) ListSettings(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	rows, err := s.queries.ListSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying settings: %w", timeoutErr(ctx, err))
+	}
+
+	settings := make(map[string]string, len(rows))
+	for _, row := range rows {
+		settings[row.Key] = row.Value
+	}
+
+	return settings, nil
+}
+
+// SetSetting creates or replaces a setting.
+func (s *Store) SetSetting(ctx context.Context, key, value string) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	err := s.queries.UpsertSetting(ctx, gen.UpsertSettingParams{Key: key, Value: value})
+	if err != nil {
+		return fmt.Errorf("upserting setting: %w", timeoutErr(ctx, err))
+	}
+
+	return nil
+}
+
+// DeleteSetting removes a setting.
+func (s *Store) DeleteSetting(ctx context.Context, key string) error {
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	n, err := s.queries.DeleteSetting(ctx, key)
+	if err != nil {
+		return fmt.Errorf("deleting setting: %w", timeoutErr(ctx, err))
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// rowToSeason converts a generated season row to a domain.Season.
+func rowToSeason(row gen.Season) *domain.Season {
+	return &domain.Season{
+		ID:          row.SeasonID,
+		Name:        row.Name,
+		FirstGameID: row.FirstGameID,
+		StartedAt:   row.StartedAt.Time,
+	}
 }
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+var benchRows = flag.Int("bench.rows", 1_000_000, "number of games to seed for store benchmarks")
+
+// seedGames bulk-inserts n games with sequential IDs in a single transaction.
+func seedGames(tb testing.TB, s *Store, n int) {
+	tb.Helper()
+
+	picks, err := json.Marshal(sdk.Picks{1, 5, 12, 18, 23, 29, 34, 40, 41, 47, 52, 55, 60, 63, 66, 70, 72, 75, 78, 80})
+	if err != nil {
+		tb.Fatalf("marshaling picks: %v", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		tb.Fatalf("beginning transaction: %v", err)
+	}
+	stmt, err := tx.Prepare("INSERT INTO games (game_id, picks) VALUES (?, ?)")
+	if err != nil {
+		tb.Fatalf("preparing insert: %v", err)
+	}
+	defer stmt.Close()
+
+	for i := 1; i <= n; i++ {
+		if _, err := stmt.Exec(i, string(picks)); err != nil {
+			tb.Fatalf("inserting game %d: %v", i, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		tb.Fatalf("committing seed: %v", err)
+	}
+}
+
+// newSeededStore creates a file-backed store seeded with n games.
+func newSeededStore(tb testing.TB, n int) *Store {
+	tb.Helper()
+
+	s, err := New(filepath.Join(tb.TempDir(), "bench.db"))
+	if err != nil {
+		tb.Fatalf("creating store: %v", err)
+	}
+	tb.Cleanup(func() { s.Close() })
+
+	seedGames(tb, s, n)
+	return s
+}
+
+// BenchmarkStore measures the hot read paths against a large history.
+// Seeding 1M rows takes a while; use -bench.rows to scale it down locally:
+//
+//	go test ./internal/store/drivers/sqlite -run '^$' -bench Store -bench.rows 100000
+func BenchmarkStore(b *testing.B) {
+	n := *benchRows
+	s := newSeededStore(b, n)
+	ctx := context.Background()
+
+	b.Run("GetLatestGame", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := s.GetLatestGame(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetGame", func(b *testing.B) {
+		id := int64(n / 2)
+		for b.Loop() {
+			if _, err := s.GetGame(ctx, id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("ListGames/FirstPage", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := s.ListGames(ctx, 0, 100); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("ListGames/DeepPage", func(b *testing.B) {
+		cursor := int64(n - 100)
+		for b.Loop() {
+			if _, err := s.ListGames(ctx, cursor, 100); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/config"
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestStore_Seasons(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "seasons.db"))
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	ctx := context.Background()
+
+	// The migration seeds the first season
+	current, err := s.GetCurrentSeason(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentSeason() error: %v", err)
+	}
+	if current.ID != 1 || current.FirstGameID != 1 || !current.Current() {
+		t.Errorf("unexpected seeded season: %+v", current)
+	}
+
+	if err := s.CreateSeason(ctx, &domain.Season{ID: 2, Name: "Season 2", FirstGameID: 11}); err != nil {
+		t.Fatalf("CreateSeason() error: %v", err)
+	}
+
+	first, err := s.GetSeason(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetSeason() error: %v", err)
+	}
+	if first.EndGameID != 11 {
+		t.Errorf("season 1 EndGameID = %d, want 11", first.EndGameID)
+	}
+
+	seasons, err := s.ListSeasons(ctx)
+	if err != nil {
+		t.Fatalf("ListSeasons() error: %v", err)
+	}
+	if len(seasons) != 2 || seasons[0].EndGameID != 11 || !seasons[1].Current() {
+		t.Errorf("unexpected seasons: %+v, %+v", seasons[0], seasons[len(seasons)-1])
+	}
+
+	if _, err := s.GetSeason(ctx, 3); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetSeason(3) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_CreateGame(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "games.db"))
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	ctx := context.Background()
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	game := &domain.Game{ID: 1, Picks: []uint8{1, 2, 3}, ResultHash: "stored", CreatedAt: created}
+	if err := s.CreateGame(ctx, game); err != nil {
+		t.Fatalf("CreateGame() error: %v", err)
+	}
+	got, err := s.GetGame(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetGame() error: %v", err)
+	}
+	if got.ResultHash != "stored" {
+		t.Errorf("ResultHash = %q, want the stored hash", got.ResultHash)
+	}
+	if !got.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, created)
+	}
+
+	// Picks are stored as a JSON array of numbers, never base64
+	var raw string
+	if err := s.db.QueryRowContext(ctx, `SELECT picks FROM games WHERE game_id = 1`).Scan(&raw); err != nil {
+		t.Fatalf("reading stored picks: %v", err)
+	}
+	if raw != "[1,2,3]" {
+		t.Errorf("stored picks = %s, want [1,2,3]", raw)
+	}
+
+	// Rows from before the column existed are hashed on read
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO games (game_id, picks) VALUES (2, '[4,5,6]')`); err != nil {
+		t.Fatalf("inserting legacy game: %v", err)
+	}
+	legacy, err := s.GetGame(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetGame() error: %v", err)
+	}
+	if legacy.ResultHash != legacy.Hash() {
+		t.Errorf("legacy ResultHash = %q, want %q", legacy.ResultHash, legacy.Hash())
+	}
+
+	// Rows written as base64 by older versions still decode
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO games (game_id, picks) VALUES (3, '"BwgJ"')`); err != nil {
+		t.Fatalf("inserting base64 game: %v", err)
+	}
+	b64, err := s.GetGame(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetGame() error: %v", err)
+	}
+	if !slices.Equal(b64.Picks, sdk.Picks{7, 8, 9}) {
+		t.Errorf("base64 picks = %v, want [7 8 9]", b64.Picks)
+	}
+}
+
+func TestStore_Settings(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "settings.db"))
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	ctx := context.Background()
+
+	if _, err := s.GetSetting(ctx, "ui.hints"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetSetting() error = %v, want ErrNotFound", err)
+	}
+
+	for _, value := range []string{`{"banner":"a"}`, `{"banner":"b"}`} {
+		if err := s.SetSetting(ctx, "ui.hints", value); err != nil {
+			t.Fatalf("SetSetting() error: %v", err)
+		}
+	}
+
+	got, err := s.GetSetting(ctx, "ui.hints")
+	if err != nil {
+		t.Fatalf("GetSetting() error: %v", err)
+	}
+	if got != `{"banner":"b"}` {
+		t.Errorf("GetSetting() = %s, want the replaced value", got)
+	}
+
+	all, err := s.ListSettings(ctx)
+	if err != nil {
+		t.Fatalf("ListSettings() error: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("ListSettings() returned %d settings, want 1", len(all))
+	}
+
+	if err := s.DeleteSetting(ctx, "ui.hints"); err != nil {
+		t.Fatalf("DeleteSetting() error: %v", err)
+	}
+	if err := s.DeleteSetting(ctx, "ui.hints"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("DeleteSetting() of missing key error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_QueryTimeout(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "timeout.db"), WithQueryTimeout(time.Nanosecond))
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	if _, err := s.ListSeasons(context.Background()); !errors.Is(err, store.ErrTimeout) {
+		t.Errorf("ListSeasons() error = %v, want ErrTimeout", err)
+	}
+	if _, err := s.AuditGames(context.Background(), config.Default().Game); !errors.Is(err, store.ErrTimeout) {
+		t.Errorf("AuditGames() error = %v, want ErrTimeout", err)
+	}
+	if _, err := s.ExplainQueries(context.Background()); !errors.Is(err, store.ErrTimeout) {
+		t.Errorf("ExplainQueries() error = %v, want ErrTimeout", err)
+	}
+
+	// An expired caller deadline is reported the same way
+	s.queryTimeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	if _, err := s.GetSetting(ctx, "ui.hints"); !errors.Is(err, store.ErrTimeout) {
+		t.Errorf("GetSetting() error = %v, want ErrTimeout", err)
+	}
+
+	// Cancellation is not a timeout
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.GetLatestGame(ctx); err == nil || errors.Is(err, store.ErrTimeout) {
+		t.Errorf("GetLatestGame() error = %v, want a non-timeout error", err)
+	}
+}
+
+func TestStore_Memory(t *testing.T) {
+	s, err := New(memoryDSN)
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	ctx := context.Background()
+
+	if err := s.CreateGame(ctx, domain.NewGame(1, []uint8{1, 2, 3})); err != nil {
+		t.Fatalf("CreateGame() error: %v", err)
+	}
+
+	// Concurrent reads must all see the migrated database the game went to
+	errs := make(chan error, 8)
+	for range cap(errs) {
+		go func() {
+			_, err := s.GetGame(ctx, 1)
+			errs <- err
+		}()
+	}
+	for range cap(errs) {
+		if err := <-errs; err != nil {
+			t.Errorf("GetGame() error: %v", err)
+		}
+	}
+}
+
+func TestStore_QueryLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var enabled atomic.Bool
+
+	s, err := New(filepath.Join(t.TempDir(), "querylog.db"), WithQueryLog(logger, enabled.Load))
+	if err != nil {
+		t.Fatalf("creating store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	ctx := context.Background()
+
+	// Migrations and writes made while disabled aren't logged
+	for _, id := range []int64{1, 2} {
+		if err := s.CreateGame(ctx, domain.NewGame(id, []uint8{1, 2, 3})); err != nil {
+			t.Fatalf("CreateGame() error: %v", err)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no queries logged while disabled, got %s", buf.String())
+	}
+
+	enabled.Store(true)
+	if _, err := s.ListGames(ctx, 1, 10); err != nil {
+		t.Fatalf("ListGames() error: %v", err)
+	}
+	if err := s.SetSetting(ctx, "ui.hints", "{}"); err != nil {
+		t.Fatalf("SetSetting() error: %v", err)
+	}
+
+	type logEntry struct {
+		Msg       string `json:"msg"`
+		Level     string `json:"level"`
+		Statement string `json:"statement"`
+		Rows      int64  `json:"rows"`
+	}
+	var entries []logEntry
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var entry logEntry
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatalf("decoding log: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 queries logged, got %d: %+v", len(entries), entries)
+	}
+	for _, e := range entries {
+		if e.Msg != "Query" || e.Level != "DEBUG" || e.Statement == "" {
+			t.Errorf("unexpected entry: %+v", e)
+		}
+	}
+	if entries[0].Rows != 2 || entries[1].Rows != 1 {
+		t.Errorf("expected 2 rows read then 1 written, got %d and %d", entries[0].Rows, entries[1].Rows)
+	}
+}
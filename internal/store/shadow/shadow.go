@@ -0,0 +1,292 @@
+// Package shadow provides a store.Store decorator that mirrors writes to a
+// secondary store and replays reads against it, to build confidence in a
+// database migration on a live instance before cutting over.
+package shadow
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// maxInFlight bounds the read comparisons running at once; reads beyond it
+// are served but not compared, so a slow shadow can't pile up goroutines.
+const maxInFlight = 64
+
+// compareTimeout bounds each read replayed against the shadow.
+const compareTimeout = 5 * time.Second
+
+// Store serves every call from the primary store. Writes that succeed there
+// are repeated on the shadow, and reads are replayed against it in the
+// background and compared. The shadow never affects what callers see: its
+// failures and divergences are only logged and counted. A write landing
+// between a read and its replay also counts as a divergence, so a low rate
+// is expected on a busy instance.
+type Store struct {
+	primary store.Store
+	shadow  store.Store
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	compared    atomic.Int64
+	diverged    atomic.Int64
+	skipped     atomic.Int64
+	writeErrors atomic.Int64
+	readErrors  atomic.Int64
+}
+
+// New returns a store serving from primary and shadowing to shadow. Both
+// are closed with it. Shadow failures are logged to the caller's context
+// logger.
+func New(primary, shadow store.Store) *Store {
+	return &Store{
+		primary: primary,
+		shadow:  shadow,
+		slots:   make(chan struct{}, maxInFlight),
+	}
+}
+
+// Ping checks the primary store only; the shadow is not needed to serve.
+func (s *Store) Ping(ctx context.Context) error {
+	return s.primary.Ping(ctx)
+}
+
+// Close waits for in-flight comparisons, then closes both stores.
+func (s *Store) Close() error {
+	s.wg.Wait()
+	return errors.Join(s.primary.Close(), s.shadow.Close())
+}
+
+// Stats reports the primary store's stats along with the shadow counters.
+func (s *Store) Stats(ctx context.Context) (*store.Stats, error) {
+	stats, err := s.primary.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats.Shadow = s.ShadowStats()
+	return stats, nil
+}
+
+// ShadowStats returns the shadow counters since the store was created.
+func (s *Store) ShadowStats() *store.ShadowStats {
+	return &store.ShadowStats{
+		Compared:    s.compared.Load(),
+		Diverged:    s.diverged.Load(),
+		Skipped:     s.skipped.Load(),
+		WriteErrors: s.writeErrors.Load(),
+		ReadErrors:  s.readErrors.Load(),
+	}
+}
+
+// CreateGame persists a new game.
+func (s *Store) CreateGame(ctx context.Context, game *domain.Game) error {
+	if err := s.primary.CreateGame(ctx, game); err != nil {
+		return err
+	}
+	s.write(ctx, "create_game", func(ctx context.Context) error {
+		return s.shadow.CreateGame(ctx, game)
+	})
+	return nil
+}
+
+// GetGame retrieves a game by its ID.
+func (s *Store) GetGame(ctx context.Context, id int64) (*domain.Game, error) {
+	game, err := s.primary.GetGame(ctx, id)
+	compare(s, ctx, "get_game", game, err, func(ctx context.Context) (*domain.Game, error) {
+		return s.shadow.GetGame(ctx, id)
+	}, gamesEqual)
+	return game, err
+}
+
+// GetLatestGame retrieves the most recent game.
+func (s *Store) GetLatestGame(ctx context.Context) (*domain.Game, error) {
+	game, err := s.primary.GetLatestGame(ctx)
+	compare(s, ctx, "get_latest_game", game, err, s.shadow.GetLatestGame, gamesEqual)
+	return game, err
+}
+
+// ListGames retrieves games starting from a given ID with a limit.
+func (s *Store) ListGames(ctx context.Context, startID int64, limit int) ([]*domain.Game, error) {
+	games, err := s.primary.ListGames(ctx, startID, limit)
+	compare(s, ctx, "list_games", games, err, func(ctx context.Context) ([]*domain.Game, error) {
+		return s.shadow.ListGames(ctx, startID, limit)
+	}, func(a, b []*domain.Game) bool {
+		return slices.EqualFunc(a, b, gamesEqual)
+	})
+	return games, err
+}
+
+// CreateSeason persists a new season.
+func (s *Store) CreateSeason(ctx context.Context, season *domain.Season) error {
+	if err := s.primary.CreateSeason(ctx, season); err != nil {
+		return err
+	}
+	s.write(ctx, "create_season", func(ctx context.Context) error {
+		return s.shadow.CreateSeason(ctx, season)
+	})
+	return nil
+}
+
+// GetSeason retrieves a season by its ID.
+func (s *Store) GetSeason(ctx context.Context, id int64) (*domain.Season, error) {
+	season, err := s.primary.GetSeason(ctx, id)
+	compare(s, ctx, "get_season", season, err, func(ctx context.Context) (*domain.Season, error) {
+		return s.shadow.GetSeason(ctx, id)
+	}, seasonsEqual)
+	return season, err
+}
+
+// GetCurrentSeason retrieves the most recent season.
+func (s *Store) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	season, err := s.primary.GetCurrentSeason(ctx)
+	compare(s, ctx, "get_current_season", season, err, s.shadow.GetCurrentSeason, seasonsEqual)
+	return season, err
+}
+
+// ListSeasons retrieves all seasons, oldest first.
+func (s *Store) ListSeasons(ctx context.Context) ([]*domain.Season, error) {
+	seasons, err := s.primary.ListSeasons(ctx)
+	compare(s, ctx, "list_seasons", seasons, err, s.shadow.ListSeasons, func(a, b []*domain.Season) bool {
+		return slices.EqualFunc(a, b, seasonsEqual)
+	})
+	return seasons, err
+}
+
+// GetSetting retrieves the raw value of a setting.
+func (s *Store) GetSetting(ctx context.Context, key string) (string, error) {
+	value, err := s.primary.GetSetting(ctx, key)
+	compare(s, ctx, "get_setting", value, err, func(ctx context.Context) (string, error) {
+		return s.shadow.GetSetting(ctx, key)
+	}, func(a, b string) bool { return a == b })
+	return value, err
+}
+
+// ListSettings retrieves all settings keyed by name.
+func (s *Store) ListSettings(ctx context.Context) (map[string]string, error) {
+	settings, err := s.primary.ListSettings(ctx)
+	compare(s, ctx, "list_settings", settings, err, s.shadow.ListSettings, maps.Equal[map[string]string])
+	return settings, err
+}
+
+// SetSetting creates or replaces a setting.
+func (s *Store) SetSetting(ctx context.Context, key, value string) error {
+	if err := s.primary.SetSetting(ctx, key, value); err != nil {
+		return err
+	}
+	s.write(ctx, "set_setting", func(ctx context.Context) error {
+		return s.shadow.SetSetting(ctx, key, value)
+	})
+	return nil
+}
+
+// DeleteSetting removes a setting.
+func (s *Store) DeleteSetting(ctx context.Context, key string) error {
+	if err := s.primary.DeleteSetting(ctx, key); err != nil {
+		return err
+	}
+	s.write(ctx, "delete_setting", func(ctx context.Context) error {
+		err := s.shadow.DeleteSetting(ctx, key)
+		if errors.Is(err, store.ErrNotFound) {
+			// The shadow missed the setting's write; it's gone either way
+			return nil
+		}
+		return err
+	})
+	return nil
+}
+
+// write repeats a write that succeeded on the primary against the shadow.
+// It runs inline so the shadow sees writes in the primary's order.
+func (s *Store) write(ctx context.Context, op string, fn func(context.Context) error) {
+	if err := fn(ctx); err != nil {
+		s.writeErrors.Add(1)
+		slogx.FromContext(ctx).Warn("Shadow write failed",
+			slog.String("op", op),
+			slogx.Error(err),
+		)
+	}
+}
+
+// compare replays a read against the shadow in the background and reports
+// whether it matches what the primary returned. Both failing with
+// store.ErrNotFound counts as a match. The comparison outlives the caller's
+// context, so it is detached from its cancellation.
+func compare[T any](s *Store, ctx context.Context, op string, want T, wantErr error, read func(context.Context) (T, error), equal func(a, b T) bool) {
+	if wantErr != nil && !errors.Is(wantErr, store.ErrNotFound) {
+		// Nothing to compare against
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.skipped.Add(1)
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() { <-s.slots }()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), compareTimeout)
+		defer cancel()
+
+		got, gotErr := read(ctx)
+		if gotErr != nil && !errors.Is(gotErr, store.ErrNotFound) {
+			s.readErrors.Add(1)
+			slogx.FromContext(ctx).Warn("Shadow read failed",
+				slog.String("op", op),
+				slogx.Error(gotErr),
+			)
+			return
+		}
+
+		s.compared.Add(1)
+		wantFound, gotFound := wantErr == nil, gotErr == nil
+		if wantFound == gotFound && (!wantFound || equal(want, got)) {
+			return
+		}
+
+		s.diverged.Add(1)
+		slogx.FromContext(ctx).Warn("Shadow read diverged",
+			slog.String("op", op),
+			slog.Bool("primary_found", wantFound),
+			slog.Bool("shadow_found", gotFound),
+		)
+	}()
+}
+
+// gamesEqual compares games as stored. Creation times are compared to the
+// second, since drivers store timestamps at different precisions.
+func gamesEqual(a, b *domain.Game) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID &&
+		slices.Equal(a.Picks, b.Picks) &&
+		a.ResultHash == b.ResultHash &&
+		a.CreatedAt.Truncate(time.Second).Equal(b.CreatedAt.Truncate(time.Second))
+}
+
+// seasonsEqual compares seasons as stored. StartedAt is assigned by each
+// database as the row is inserted, so it is not compared.
+func seasonsEqual(a, b *domain.Season) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID &&
+		a.Name == b.Name &&
+		a.FirstGameID == b.FirstGameID &&
+		a.EndGameID == b.EndGameID
+}
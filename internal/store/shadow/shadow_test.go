@@ -0,0 +1,138 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+)
+
+// newTestStores returns a shadowed store along with its primary and shadow.
+func newTestStores(t *testing.T) (*Store, *sqlite.Store, *sqlite.Store) {
+	t.Helper()
+	dir := t.TempDir()
+
+	primary, err := sqlite.New(filepath.Join(dir, "primary.db"))
+	if err != nil {
+		t.Fatalf("opening primary: %v", err)
+	}
+	secondary, err := sqlite.New(filepath.Join(dir, "shadow.db"))
+	if err != nil {
+		t.Fatalf("opening shadow: %v", err)
+	}
+
+	s := New(primary, secondary)
+	t.Cleanup(func() { _ = s.Close() })
+	return s, primary, secondary
+}
+
+func testGame(id int64) *domain.Game {
+	return &domain.Game{ID: id, Picks: []uint8{1, 2, 3}, CreatedAt: time.Now()}
+}
+
+func TestStore_MirrorsWrites(t *testing.T) {
+	ctx := context.Background()
+	s, _, secondary := newTestStores(t)
+
+	if err := s.CreateGame(ctx, testGame(1)); err != nil {
+		t.Fatalf("CreateGame() error: %v", err)
+	}
+	if err := s.SetSetting(ctx, "motd", `"hi"`); err != nil {
+		t.Fatalf("SetSetting() error: %v", err)
+	}
+
+	if _, err := secondary.GetGame(ctx, 1); err != nil {
+		t.Errorf("shadow GetGame(1) error: %v", err)
+	}
+	if v, err := secondary.GetSetting(ctx, "motd"); err != nil || v != `"hi"` {
+		t.Errorf("shadow GetSetting(motd) = %q, %v", v, err)
+	}
+
+	// Matching reads, including misses on both sides, are not divergences
+	if _, err := s.GetGame(ctx, 1); err != nil {
+		t.Fatalf("GetGame(1) error: %v", err)
+	}
+	if _, err := s.GetGame(ctx, 2); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("GetGame(2) error = %v, want ErrNotFound", err)
+	}
+	s.wg.Wait()
+
+	got := s.ShadowStats()
+	if got.Compared != 2 || got.Diverged != 0 || got.WriteErrors != 0 {
+		t.Errorf("ShadowStats() = %+v, want 2 compared and nothing else", got)
+	}
+}
+
+func TestStore_ReportsDivergence(t *testing.T) {
+	ctx := context.Background()
+	s, primary, _ := newTestStores(t)
+
+	// Written before shadowing began, so only the primary has it
+	if err := primary.CreateGame(ctx, testGame(1)); err != nil {
+		t.Fatalf("CreateGame() error: %v", err)
+	}
+
+	game, err := s.GetGame(ctx, 1)
+	if err != nil || game.ID != 1 {
+		t.Fatalf("GetGame(1) = %v, %v; want the primary's game", game, err)
+	}
+	if _, err := s.GetLatestGame(ctx); err != nil {
+		t.Fatalf("GetLatestGame() error: %v", err)
+	}
+	s.wg.Wait()
+
+	stats, err := s.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats() error: %v", err)
+	}
+	if stats.Shadow == nil || stats.Shadow.Compared != 2 || stats.Shadow.Diverged != 2 {
+		t.Errorf("Stats().Shadow = %+v, want 2 compared and diverged", stats.Shadow)
+	}
+}
+
+func TestStore_ShadowWriteFailure(t *testing.T) {
+	ctx := context.Background()
+	s, _, secondary := newTestStores(t)
+
+	if err := secondary.Close(); err != nil {
+		t.Fatalf("closing shadow: %v", err)
+	}
+
+	// The primary write still succeeds and is served
+	if err := s.CreateGame(ctx, testGame(1)); err != nil {
+		t.Fatalf("CreateGame() error: %v", err)
+	}
+	if _, err := s.GetGame(ctx, 1); err != nil {
+		t.Fatalf("GetGame(1) error: %v", err)
+	}
+	s.wg.Wait()
+
+	got := s.ShadowStats()
+	if got.WriteErrors != 1 || got.ReadErrors != 1 || got.Diverged != 0 {
+		t.Errorf("ShadowStats() = %+v, want 1 write error and 1 read error", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	now := time.Now()
+	a := &domain.Game{ID: 1, Picks: []uint8{1, 2}, CreatedAt: now, ResultHash: "h"}
+	b := &domain.Game{ID: 1, Picks: []uint8{1, 2}, CreatedAt: now.Truncate(time.Second), ResultHash: "h"}
+	if !gamesEqual(a, b) {
+		t.Error("games differing only in timestamp precision compare unequal")
+	}
+	b.Picks = []uint8{2, 1}
+	if gamesEqual(a, b) {
+		t.Error("games with reordered picks compare equal")
+	}
+
+	s1 := &domain.Season{ID: 1, Name: "one", StartedAt: now}
+	s2 := &domain.Season{ID: 1, Name: "one", StartedAt: now.Add(time.Minute)}
+	if !seasonsEqual(s1, s2) {
+		t.Error("seasons differing only in start time compare unequal")
+	}
+}
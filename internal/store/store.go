@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/aussiebroadwan/taboo/internal/domain"
 )
@@ -10,6 +11,10 @@ import (
 // ErrNotFound is returned when a requested record does not exist.
 var ErrNotFound = errors.New("not found")
 
+// ErrTimeout is returned when a query runs past its deadline, either the
+// store's per-query timeout or one set on the caller's context.
+var ErrTimeout = errors.New("query timeout")
+
 // Store defines the interface for data persistence.
 type Store interface {
 	// Ping checks the database connection.
@@ -18,6 +23,9 @@ type Store interface {
 	// Close closes the database connection.
 	Close() error
 
+	// Stats reports the database's size and maintenance history.
+	Stats(ctx context.Context) (*Stats, error)
+
 	// CreateGame persists a new game.
 	CreateGame(ctx context.Context, game *domain.Game) error
 
@@ -29,4 +37,71 @@ type Store interface {
 
 	// ListGames retrieves games starting from a given ID with a limit.
 	ListGames(ctx context.Context, startID int64, limit int) ([]*domain.Game, error)
+
+	// CreateSeason persists a new season.
+	CreateSeason(ctx context.Context, season *domain.Season) error
+
+	// GetSeason retrieves a season by its ID.
+	GetSeason(ctx context.Context, id int64) (*domain.Season, error)
+
+	// GetCurrentSeason retrieves the most recent season.
+	GetCurrentSeason(ctx context.Context) (*domain.Season, error)
+
+	// ListSeasons retrieves all seasons, oldest first.
+	ListSeasons(ctx context.Context) ([]*domain.Season, error)
+
+	// GetSetting retrieves the raw value of a setting.
+	GetSetting(ctx context.Context, key string) (string, error)
+
+	// ListSettings retrieves all settings keyed by name.
+	ListSettings(ctx context.Context) (map[string]string, error)
+
+	// SetSetting creates or replaces a setting.
+	SetSetting(ctx context.Context, key, value string) error
+
+	// DeleteSetting removes a setting.
+	DeleteSetting(ctx context.Context, key string) error
+}
+
+// Stats describes the size of a database and when it was last maintained,
+// for spotting growth before the disk fills up.
+type Stats struct {
+	// FileSize is the size of the main database file in bytes.
+	FileSize int64
+
+	// WALSize is the size of the write-ahead log in bytes; it shrinks back
+	// once a checkpoint runs.
+	WALSize int64
+
+	// FreeSize is the space held by free pages in bytes, which a vacuum
+	// would return to the filesystem.
+	FreeSize int64
+
+	// Rows is the row count of each table, keyed by table name.
+	Rows map[string]int64
+
+	// LastVacuum and LastBackup are zero if the operation has never run.
+	LastVacuum time.Time
+	LastBackup time.Time
+
+	// Shadow is set when writes are mirrored to a shadow store.
+	Shadow *ShadowStats
+}
+
+// ShadowStats counts how a shadow store has tracked the primary since
+// startup. Divergences from rows written before shadowing began are
+// expected until the shadow is backfilled.
+type ShadowStats struct {
+	// Compared is the number of reads replayed against the shadow, and
+	// Diverged how many of those returned a different result.
+	Compared int64
+	Diverged int64
+
+	// Skipped counts reads not replayed because too many comparisons were
+	// already in flight.
+	Skipped int64
+
+	// WriteErrors and ReadErrors count shadow calls that failed outright.
+	WriteErrors int64
+	ReadErrors  int64
 }
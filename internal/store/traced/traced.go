@@ -0,0 +1,119 @@
+// Package traced provides a store.Store decorator that records every call
+// and its duration against the request it serves, so the request log shows
+// how many queries an endpoint made and N+1 patterns stand out.
+package traced
+
+import (
+	"context"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+// Store passes every call through to the underlying store, recording it
+// with slogx.RecordQuery. Calls made outside a request, such as the game
+// engine's, are passed through unrecorded.
+type Store struct {
+	next store.Store
+}
+
+// New returns a store recording calls to next.
+func New(next store.Store) *Store {
+	return &Store{next: next}
+}
+
+// record counts a call started at start against ctx's request.
+func record(ctx context.Context, start time.Time) {
+	slogx.RecordQuery(ctx, time.Since(start))
+}
+
+// Ping checks the database connection.
+func (s *Store) Ping(ctx context.Context) error {
+	defer record(ctx, time.Now())
+	return s.next.Ping(ctx)
+}
+
+// Close closes the underlying store.
+func (s *Store) Close() error {
+	return s.next.Close()
+}
+
+// Stats reports the database's size and maintenance history.
+func (s *Store) Stats(ctx context.Context) (*store.Stats, error) {
+	defer record(ctx, time.Now())
+	return s.next.Stats(ctx)
+}
+
+// CreateGame persists a new game.
+func (s *Store) CreateGame(ctx context.Context, game *domain.Game) error {
+	defer record(ctx, time.Now())
+	return s.next.CreateGame(ctx, game)
+}
+
+// GetGame retrieves a game by its ID.
+func (s *Store) GetGame(ctx context.Context, id int64) (*domain.Game, error) {
+	defer record(ctx, time.Now())
+	return s.next.GetGame(ctx, id)
+}
+
+// GetLatestGame retrieves the most recent game.
+func (s *Store) GetLatestGame(ctx context.Context) (*domain.Game, error) {
+	defer record(ctx, time.Now())
+	return s.next.GetLatestGame(ctx)
+}
+
+// ListGames retrieves games starting from a given ID with a limit.
+func (s *Store) ListGames(ctx context.Context, startID int64, limit int) ([]*domain.Game, error) {
+	defer record(ctx, time.Now())
+	return s.next.ListGames(ctx, startID, limit)
+}
+
+// CreateSeason persists a new season.
+func (s *Store) CreateSeason(ctx context.Context, season *domain.Season) error {
+	defer record(ctx, time.Now())
+	return s.next.CreateSeason(ctx, season)
+}
+
+// GetSeason retrieves a season by its ID.
+func (s *Store) GetSeason(ctx context.Context, id int64) (*domain.Season, error) {
+	defer record(ctx, time.Now())
+	return s.next.GetSeason(ctx, id)
+}
+
+// GetCurrentSeason retrieves the most recent season.
+func (s *Store) GetCurrentSeason(ctx context.Context) (*domain.Season, error) {
+	defer record(ctx, time.Now())
+	return s.next.GetCurrentSeason(ctx)
+}
+
+// ListSeasons retrieves all seasons, oldest first.
+func (s *Store) ListSeasons(ctx context.Context) ([]*domain.Season, error) {
+	defer record(ctx, time.Now())
+	return s.next.ListSeasons(ctx)
+}
+
+// GetSetting retrieves the raw value of a setting.
+func (s *Store) GetSetting(ctx context.Context, key string) (string, error) {
+	defer record(ctx, time.Now())
+	return s.next.GetSetting(ctx, key)
+}
+
+// ListSettings retrieves all settings keyed by name.
+func (s *Store) ListSettings(ctx context.Context) (map[string]string, error) {
+	defer record(ctx, time.Now())
+	return s.next.ListSettings(ctx)
+}
+
+// SetSetting creates or replaces a setting.
+func (s *Store) SetSetting(ctx context.Context, key, value string) error {
+	defer record(ctx, time.Now())
+	return s.next.SetSetting(ctx, key, value)
+}
+
+// DeleteSetting removes a setting.
+func (s *Store) DeleteSetting(ctx context.Context, key string) error {
+	defer record(ctx, time.Now())
+	return s.next.DeleteSetting(ctx, key)
+}
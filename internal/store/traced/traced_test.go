@@ -0,0 +1,87 @@
+package traced
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/internal/domain"
+	"github.com/aussiebroadwan/taboo/internal/store"
+	"github.com/aussiebroadwan/taboo/internal/store/drivers/sqlite"
+	"github.com/aussiebroadwan/taboo/pkg/slogx"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	st, err := sqlite.New(filepath.Join(t.TempDir(), "taboo.db"))
+	if err != nil {
+		t.Fatalf("opening store: %v", err)
+	}
+
+	s := New(st)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestStore_RecordsCalls(t *testing.T) {
+	s := newTestStore(t)
+	ctx, stats := slogx.WithQueryStats(context.Background())
+
+	if err := s.CreateGame(ctx, &domain.Game{ID: 1, Picks: []uint8{1, 2, 3}, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateGame() error: %v", err)
+	}
+	if _, err := s.GetGame(ctx, 1); err != nil {
+		t.Fatalf("GetGame(1) error: %v", err)
+	}
+	// Failed calls still hit the database
+	if _, err := s.GetGame(ctx, 2); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("GetGame(2) error = %v, want ErrNotFound", err)
+	}
+
+	if got := stats.Calls(); got != 3 {
+		t.Errorf("Calls() = %d, want 3", got)
+	}
+	if stats.Duration() <= 0 {
+		t.Errorf("Duration() = %s, want positive", stats.Duration())
+	}
+
+	// Calls outside a request are passed through unrecorded
+	if _, err := s.GetLatestGame(context.Background()); err != nil {
+		t.Fatalf("GetLatestGame() error: %v", err)
+	}
+	if got := stats.Calls(); got != 3 {
+		t.Errorf("Calls() = %d after an unrecorded call, want 3", got)
+	}
+}
+
+func TestStore_CallsInRequestLog(t *testing.T) {
+	s := newTestStore(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	handler := slogx.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for id := int64(1); id <= 3; id++ {
+			_, _ = s.GetGame(r.Context(), id)
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/games", nil))
+
+	var entry struct {
+		Msg     string `json:"msg"`
+		DBCalls int64  `json:"db_calls"`
+		DBTime  int64  `json:"db_time"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decoding log entry %q: %v", buf.String(), err)
+	}
+	if entry.Msg != "Request completed" || entry.DBCalls != 3 || entry.DBTime <= 0 {
+		t.Errorf("unexpected completion log: %s", buf.String())
+	}
+}
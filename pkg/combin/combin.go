@@ -0,0 +1,40 @@
+package combin
+
+import (
+	"math/big"
+)
+
+// Choose returns the binomial coefficient C(n, k), the number of ways to
+// choose k items from n. It is 0 when k < 0 or k > n.
+func Choose(n, k int) *big.Int {
+	if k < 0 || n < 0 || k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// HitProbability returns the probability that a ticket of spots numbers
+// matches exactly hits of the drawn numbers, when drawn numbers are chosen
+// from a pool of pool. This is the hypergeometric distribution. It is 0 for
+// impossible outcomes and for invalid arguments.
+func HitProbability(pool, drawn, spots, hits int) float64 {
+	if pool < 1 || drawn < 0 || drawn > pool || spots < 0 || spots > pool {
+		return 0
+	}
+	ways := new(big.Int).Mul(Choose(spots, hits), Choose(pool-spots, drawn-hits))
+	p, _ := new(big.Rat).SetFrac(ways, Choose(pool, drawn)).Float64()
+	return p
+}
+
+// HitDistribution returns the probability of matching each number of hits
+// from 0 to spots, indexed by hits. The probabilities sum to 1.
+func HitDistribution(pool, drawn, spots int) []float64 {
+	if spots < 0 {
+		return nil
+	}
+	dist := make([]float64, spots+1)
+	for hits := range dist {
+		dist[hits] = HitProbability(pool, drawn, spots, hits)
+	}
+	return dist
+}
@@ -0,0 +1,64 @@
+package combin
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChoose(t *testing.T) {
+	tests := []struct {
+		n, k int
+		want string
+	}{
+		{5, 2, "10"},
+		{10, 0, "1"},
+		{10, 10, "1"},
+		{80, 20, "3535316142212174320"},
+		{3, 4, "0"},
+		{3, -1, "0"},
+	}
+	for _, tt := range tests {
+		if got := Choose(tt.n, tt.k).String(); got != tt.want {
+			t.Errorf("Choose(%d, %d) = %s, want %s", tt.n, tt.k, got, tt.want)
+		}
+	}
+}
+
+func TestHitProbability(t *testing.T) {
+	tests := []struct {
+		name                     string
+		pool, drawn, spots, hits int
+		want                     float64
+	}{
+		{"1 spot hit", 80, 20, 1, 1, 0.25},
+		{"1 spot miss", 80, 20, 1, 0, 0.75},
+		{"5 spot all", 80, 20, 5, 5, 0.000644924695557607},
+		{"10 spot all", 80, 20, 10, 10, 1.1221189513e-07},
+		{"more hits than spots", 80, 20, 5, 6, 0},
+		{"drawn exceeds pool", 10, 11, 1, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HitProbability(tt.pool, tt.drawn, tt.spots, tt.hits)
+			if math.Abs(got-tt.want) > 1e-6*tt.want {
+				t.Errorf("HitProbability = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHitDistribution_SumsToOne(t *testing.T) {
+	for spots := 1; spots <= 20; spots++ {
+		dist := HitDistribution(80, 20, spots)
+		if len(dist) != spots+1 {
+			t.Fatalf("spots %d: %d probabilities, want %d", spots, len(dist), spots+1)
+		}
+		var sum float64
+		for _, p := range dist {
+			sum += p
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("spots %d: probabilities sum to %v", spots, sum)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package combin provides the exact combinatorics behind Keno odds: how
+// many ways numbers can be chosen, and how likely a ticket is to match a
+// given number of a draw.
+//
+// A 5-spot ticket on a classic board, where 20 numbers are drawn from 80:
+//
+//	combin.Choose(80, 20)               // 3535316142212174320 possible draws
+//	combin.HitProbability(80, 20, 5, 5) // 0.000645, about 1 in 1551
+package combin
@@ -0,0 +1,24 @@
+package httpx
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// RequireBearer is middleware that rejects requests whose Authorization
+// header does not carry token as a bearer token. Tokens are compared in
+// constant time. An empty token rejects every request.
+func RequireBearer(token string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="taboo"`)
+				_ = WriteError(w, ErrUnauthorized("missing or invalid bearer token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireBearer(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{name: "valid token", token: "s3cret", header: "Bearer s3cret", want: http.StatusOK},
+		{name: "wrong token", token: "s3cret", header: "Bearer nope", want: http.StatusUnauthorized},
+		{name: "missing header", token: "s3cret", header: "", want: http.StatusUnauthorized},
+		{name: "wrong scheme", token: "s3cret", header: "Basic s3cret", want: http.StatusUnauthorized},
+		{name: "empty configured token", token: "", header: "Bearer ", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequireBearer(tt.token)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/seasons", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected WWW-Authenticate header")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package httpx
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ChaosHeader is set on responses affected by the Chaos middleware, naming
+// the fault that was injected.
+const ChaosHeader = "X-Taboo-Chaos"
+
+// ChaosConfig holds fault injection configuration.
+type ChaosConfig struct {
+	// LatencyMin and LatencyMax bound the uniformly distributed delay added
+	// before each request is handled.
+	LatencyMin time.Duration
+	LatencyMax time.Duration
+
+	// ErrorRate is the probability (0-1) that a request fails with a 500.
+	ErrorRate float64
+
+	// RouteErrorRates overrides ErrorRate for paths with a matching prefix.
+	// The longest matching prefix wins.
+	RouteErrorRates map[string]float64
+
+	// Stream selects long-lived streaming requests (e.g., SSE), which are
+	// dropped with probability SSEDisconnectRate each second.
+	Stream            RequestMatcher
+	SSEDisconnectRate float64
+
+	// Float64 returns a random number in [0, 1). Defaults to math/rand/v2.
+	Float64 func() float64
+}
+
+// Chaos returns middleware that injects latency, errors and stream
+// disconnects. It is intended for development only, to exercise client
+// retry and reconnect behavior.
+func Chaos(cfg ChaosConfig) Middleware {
+	random := cfg.Float64
+	if random == nil {
+		random = rand.Float64 //nolint:gosec // fault injection does not need secure randomness
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if delay := chaosLatency(cfg.LatencyMin, cfg.LatencyMax, random); delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-r.Context().Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+
+			if random() < chaosErrorRate(cfg, r.URL.Path) {
+				w.Header().Set(ChaosHeader, "error")
+				_ = WriteError(w, ErrInternal("chaos: injected failure"))
+				return
+			}
+
+			if cfg.SSEDisconnectRate > 0 && cfg.Stream != nil && cfg.Stream(r) {
+				ctx, cancel := context.WithCancel(r.Context())
+				defer cancel()
+				go chaosDisconnect(ctx, cancel, cfg.SSEDisconnectRate, random)
+				r = r.WithContext(ctx)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// chaosLatency picks a delay uniformly between lo and hi.
+func chaosLatency(lo, hi time.Duration, random func() float64) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(random()*float64(hi-lo))
+}
+
+// chaosErrorRate returns the error rate for path, preferring the longest
+// matching route override.
+func chaosErrorRate(cfg ChaosConfig, path string) float64 {
+	rate, matched := cfg.ErrorRate, ""
+	for prefix, r := range cfg.RouteErrorRates {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(matched) {
+			rate, matched = r, prefix
+		}
+	}
+	return rate
+}
+
+// chaosDisconnect cancels a stream's context with probability rate on each
+// one-second tick, which makes the handler return and the connection close.
+func chaosDisconnect(ctx context.Context, cancel context.CancelFunc, rate float64, random func() float64) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if random() < rate {
+				cancel()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func fixedRandom(v float64) func() float64 {
+	return func() float64 { return v }
+}
+
+func TestChaos_Latency(t *testing.T) {
+	handler := Chaos(ChaosConfig{
+		LatencyMin: 20 * time.Millisecond,
+		LatencyMax: 40 * time.Millisecond,
+		Float64:    fixedRandom(0.5),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	start := time.Now()
+	handler.ServeHTTP(rec, req)
+
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected at least 30ms of injected latency, got %v", elapsed)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestChaos_ErrorRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		random     float64
+		wantStatus int
+	}{
+		{"below default rate fails", "/api/v1/games", 0.05, http.StatusInternalServerError},
+		{"above default rate passes", "/api/v1/games", 0.5, http.StatusOK},
+		{"route override fails", "/api/v1/games/latest", 0.5, http.StatusInternalServerError},
+		{"route override disabled", "/api/v1/games/current/wait", 0.05, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Chaos(ChaosConfig{
+				ErrorRate: 0.1,
+				RouteErrorRates: map[string]float64{
+					"/api/v1/games/latest":  0.9,
+					"/api/v1/games/current": 0,
+				},
+				Float64: fixedRandom(tt.random),
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK && rec.Header().Get(ChaosHeader) != "error" {
+				t.Errorf("expected %s header to be set", ChaosHeader)
+			}
+		})
+	}
+}
+
+func TestChaos_StreamDisconnect(t *testing.T) {
+	handler := Chaos(ChaosConfig{
+		Stream:            MatchRoutes("GET /events"),
+		SSEDisconnectRate: 1,
+		Float64:           fixedRandom(0.5),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Pattern = "GET /events"
+	rec := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rec, req)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected stream to be disconnected")
+	}
+}
@@ -47,7 +47,7 @@ func CORS(cfg CORSConfig) Middleware {
 			if allowOrigin != "" {
 				w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+EnvelopeHeader)
 				w.Header().Set("Access-Control-Max-Age", "86400")
 
 				// Don't set Vary for wildcard
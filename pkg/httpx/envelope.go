@@ -0,0 +1,40 @@
+package httpx
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// EnvelopeHeader is the request header clients set to opt in to the standard
+// response envelope.
+const EnvelopeHeader = "X-Taboo-Envelope"
+
+// WantsEnvelope reports whether the client opted in to the response envelope.
+func WantsEnvelope(r *http.Request) bool {
+	v := strings.TrimSpace(r.Header.Get(EnvelopeHeader))
+	return v == "1" || strings.EqualFold(v, "true")
+}
+
+// Respond writes v as a JSON response. If the client opted in via
+// EnvelopeHeader, v is wrapped in an sdk.Envelope with the given meta; the
+// request ID is filled from the X-Request-ID response header when unset,
+// and warnings added with Warn are appended to its warnings.
+func Respond(w http.ResponseWriter, r *http.Request, status int, v any, meta sdk.Meta) error {
+	w.Header().Add("Vary", EnvelopeHeader)
+
+	if !WantsEnvelope(r) {
+		return JSON(w, status, v)
+	}
+
+	if meta.RequestID == "" {
+		meta.RequestID = w.Header().Get("X-Request-ID")
+	}
+	meta.Warnings = append(meta.Warnings, sdk.Warnings(w.Header())...)
+
+	return JSON(w, status, sdk.Envelope[any]{
+		Data: v,
+		Meta: meta,
+	})
+}
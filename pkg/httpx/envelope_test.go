@@ -0,0 +1,107 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestRespond_PlainByDefault(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	if err := Respond(rec, req, http.StatusOK, map[string]int{"id": 1}, sdk.Meta{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"id":1}` {
+		t.Errorf("unexpected body: %s", got)
+	}
+	if vary := rec.Header().Get("Vary"); vary != EnvelopeHeader {
+		t.Errorf("expected Vary %q, got %q", EnvelopeHeader, vary)
+	}
+}
+
+func TestWantsEnvelope(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"one", "1", true},
+		{"true", "TRUE", true},
+		{"zero", "0", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(EnvelopeHeader, tt.header)
+			if got := WantsEnvelope(req); got != tt.want {
+				t.Errorf("WantsEnvelope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespond_EnvelopeWithMeta(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(EnvelopeHeader, "1")
+	rec := httptest.NewRecorder()
+	rec.Header().Set("X-Request-ID", "req-123")
+
+	next := int64(42)
+	meta := sdk.Meta{Pagination: &sdk.Pagination{Limit: 10, NextCursor: &next}}
+	if err := Respond(rec, req, http.StatusOK, map[string]int{"id": 1}, meta); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var env sdk.Envelope[map[string]int]
+	if err := json.NewDecoder(rec.Body).Decode(&env); err != nil {
+		t.Fatalf("failed to decode envelope: %v", err)
+	}
+
+	if env.Data["id"] != 1 {
+		t.Errorf("expected data.id 1, got %v", env.Data)
+	}
+	if env.Meta.RequestID != "req-123" {
+		t.Errorf("expected request_id req-123, got %q", env.Meta.RequestID)
+	}
+	if env.Meta.Pagination == nil || env.Meta.Pagination.Limit != 10 {
+		t.Fatalf("expected pagination limit 10, got %+v", env.Meta.Pagination)
+	}
+	if env.Meta.Pagination.NextCursor == nil || *env.Meta.Pagination.NextCursor != 42 {
+		t.Errorf("expected next_cursor 42, got %v", env.Meta.Pagination.NextCursor)
+	}
+}
+
+func TestRespond_Warnings(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(EnvelopeHeader, "1")
+	rec := httptest.NewRecorder()
+
+	Warn(rec, `"offset" is deprecated, use "cursor"`)
+	Warn(rec, "limit will default to 20")
+	if err := Respond(rec, req, http.StatusOK, map[string]int{"id": 1}, sdk.Meta{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := rec.Header().Values(sdk.WarningHeader); len(got) != 2 || got[0] != `299 - "\"offset\" is deprecated, use \"cursor\""` {
+		t.Errorf("unexpected Warning headers: %q", got)
+	}
+
+	var env sdk.Envelope[map[string]int]
+	if err := json.NewDecoder(rec.Body).Decode(&env); err != nil {
+		t.Fatalf("failed to decode envelope: %v", err)
+	}
+	want := []string{`"offset" is deprecated, use "cursor"`, "limit will default to 20"}
+	if !slices.Equal(env.Meta.Warnings, want) {
+		t.Errorf("expected meta warnings %q, got %q", want, env.Meta.Warnings)
+	}
+}
@@ -3,21 +3,27 @@ package httpx
 import (
 	"net/http"
 
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
 	"github.com/aussiebroadwan/taboo/sdk"
 )
 
 // Common error codes.
 const (
-	CodeNotFound   = "NOT_FOUND"
-	CodeBadRequest = "BAD_REQUEST"
-	CodeInternal   = "INTERNAL_ERROR"
+	CodeNotFound     = "NOT_FOUND"
+	CodeBadRequest   = "BAD_REQUEST"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeConflict     = "CONFLICT"
+	CodeInternal     = "INTERNAL_ERROR"
+	CodeUnavailable  = "SERVICE_UNAVAILABLE"
 )
 
-// APIError represents an API error with a code and HTTP status.
+// APIError represents an API error with a code and HTTP status. Param names
+// the request parameter at fault, if any.
 type APIError struct {
 	Code    string
 	Message string
 	Status  int
+	Param   string
 }
 
 // Error implements the error interface.
@@ -43,6 +49,31 @@ func ErrBadRequest(message string) *APIError {
 	}
 }
 
+// ErrInvalidParam creates a bad request error for the named parameter.
+func ErrInvalidParam(param, message string) *APIError {
+	err := ErrBadRequest(message)
+	err.Param = param
+	return err
+}
+
+// ErrUnauthorized creates an unauthorized error.
+func ErrUnauthorized(message string) *APIError {
+	return &APIError{
+		Code:    CodeUnauthorized,
+		Message: message,
+		Status:  http.StatusUnauthorized,
+	}
+}
+
+// ErrConflict creates a conflict error.
+func ErrConflict(message string) *APIError {
+	return &APIError{
+		Code:    CodeConflict,
+		Message: message,
+		Status:  http.StatusConflict,
+	}
+}
+
 // ErrInternal creates an internal server error.
 func ErrInternal(message string) *APIError {
 	return &APIError{
@@ -52,12 +83,23 @@ func ErrInternal(message string) *APIError {
 	}
 }
 
-// WriteError writes an APIError as a JSON response.
+// ErrUnavailable creates a service unavailable error.
+func ErrUnavailable(message string) *APIError {
+	return &APIError{
+		Code:    CodeUnavailable,
+		Message: message,
+		Status:  http.StatusServiceUnavailable,
+	}
+}
+
+// WriteError writes an APIError as a JSON response, translating its message
+// into the response's Content-Language, as set by Language.
 func WriteError(w http.ResponseWriter, err *APIError) error {
 	return JSON(w, err.Status, sdk.ErrorResponse{
 		Error: sdk.ErrorDetail{
 			Code:    err.Code,
-			Message: err.Message,
+			Message: i18n.Translate(w.Header().Get("Content-Language"), err.Message),
+			Param:   err.Param,
 		},
 	})
 }
@@ -3,11 +3,29 @@ package httpx
 import (
 	"compress/gzip"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"sync"
 )
 
+// DefaultGzipMinSize is the smallest response Gzip compresses. Below it the
+// gzip header and CPU cost outweigh the bytes saved, e.g. for health checks.
+const DefaultGzipMinSize = 512
+
+// DefaultGzipContentTypes are the media types Gzip compresses. Entries
+// ending in "/" match every subtype. Images, fonts and archives are already
+// compressed and left alone.
+var DefaultGzipContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/manifest+json",
+	"application/wasm",
+	"image/svg+xml",
+}
+
 // gzipWriterPool pools gzip writers to reduce allocations.
 var gzipWriterPool = sync.Pool{
 	New: func() any {
@@ -15,18 +33,37 @@ var gzipWriterPool = sync.Pool{
 	},
 }
 
-// Gzip returns middleware that compresses responses using gzip.
-// Paths in skipPaths are excluded from compression (e.g., SSE endpoints).
-func Gzip(skipPaths ...string) Middleware {
-	skipSet := make(map[string]struct{}, len(skipPaths))
-	for _, path := range skipPaths {
-		skipSet[path] = struct{}{}
+// GzipConfig holds configuration for the Gzip middleware.
+type GzipConfig struct {
+	// MinSize is the smallest response body, in bytes, that is compressed.
+	// Zero compresses every response.
+	MinSize int
+
+	// ContentTypes lists the media types to compress; entries ending in "/"
+	// match every subtype. Nil uses DefaultGzipContentTypes.
+	ContentTypes []string
+
+	// Skip, if set, selects requests that are never compressed.
+	Skip RequestMatcher
+}
+
+// Gzip returns middleware that compresses responses using gzip with the
+// default size threshold and content types.
+func Gzip() Middleware {
+	return GzipWithConfig(GzipConfig{MinSize: DefaultGzipMinSize})
+}
+
+// GzipWithConfig returns middleware that compresses responses of an allowed
+// content type once they reach cfg.MinSize. Smaller responses are buffered
+// and sent as-is; a flush commits to compressing whatever has been written.
+func GzipWithConfig(cfg GzipConfig) Middleware {
+	if cfg.ContentTypes == nil {
+		cfg.ContentTypes = DefaultGzipContentTypes
 	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip if path is in skip list
-			if _, skip := skipSet[r.URL.Path]; skip {
+			if cfg.Skip != nil && cfg.Skip(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -37,47 +74,170 @@ func Gzip(skipPaths ...string) Middleware {
 				return
 			}
 
-			// Get a gzip writer from the pool
-			gz := gzipWriterPool.Get().(*gzip.Writer)
-			gz.Reset(w)
-			defer func() {
-				_ = gz.Close()
-				gzipWriterPool.Put(gz)
-			}()
+			w.Header().Add("Vary", "Accept-Encoding")
 
-			// Wrap the response writer
 			gzw := &gzipResponseWriter{
 				ResponseWriter: w,
-				Writer:         gz,
+				cfg:            &cfg,
+				status:         http.StatusOK,
 			}
-
-			// Set headers
-			w.Header().Set("Content-Encoding", "gzip")
-			w.Header().Add("Vary", "Accept-Encoding")
-			// Delete Content-Length as it will be wrong after compression
-			w.Header().Del("Content-Length")
+			defer gzw.close()
 
 			next.ServeHTTP(gzw, r)
 		})
 	}
 }
 
-// gzipResponseWriter wraps http.ResponseWriter with gzip compression.
+// gzipResponseWriter buffers the start of a response until it knows whether
+// to compress it: once the body reaches the minimum size, on flush, or when
+// the handler returns.
 type gzipResponseWriter struct {
 	http.ResponseWriter
-	Writer io.Writer
+	cfg *GzipConfig
+
+	status      int
+	wroteHeader bool
+	buf         []byte
+
+	decided bool
+	gz      *gzip.Writer // nil unless compressing
+}
+
+// WriteHeader records the status until the response is committed.
+// Responses that cannot have a body, and partial responses, whose ranges
+// refer to the uncompressed body, are passed through uncompressed.
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader || w.decided {
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+
+	if code < 200 || code == http.StatusNoContent || code == http.StatusNotModified || code == http.StatusPartialContent {
+		w.decide(false)
+	}
 }
 
 func (w *gzipResponseWriter) Write(b []byte) (int, error) {
-	return w.Writer.Write(b)
+	w.wroteHeader = true
+	if w.decided {
+		return w.writer().Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	switch {
+	case !w.compressible():
+		w.decide(false)
+	case len(w.buf) >= w.cfg.MinSize:
+		w.decide(true)
+	}
+	if w.decided {
+		if err := w.flushBuf(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
 }
 
-// Flush implements http.Flusher.
+// Flush implements http.Flusher. Flushing commits to compressing the
+// response, since more data may follow.
 func (w *gzipResponseWriter) Flush() {
-	if gw, ok := w.Writer.(*gzip.Writer); ok {
-		_ = gw.Flush()
+	if !w.decided {
+		w.decide(w.compressible())
+		_ = w.flushBuf()
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
 	}
 	if f, ok := w.ResponseWriter.(http.Flusher); ok {
 		f.Flush()
 	}
 }
+
+// close sends a response still too small to compress, or finishes the gzip
+// stream and returns its writer to the pool.
+func (w *gzipResponseWriter) close() {
+	if !w.decided {
+		if !w.wroteHeader {
+			return
+		}
+		w.decide(false)
+		_ = w.flushBuf()
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		gzipWriterPool.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// decide commits the headers and status, compressing from here on if
+// compress is set.
+func (w *gzipResponseWriter) decide(compress bool) {
+	w.decided = true
+
+	h := w.ResponseWriter.Header()
+	if compress {
+		w.gz = gzipWriterPool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+
+		h.Set("Content-Encoding", "gzip")
+		// Content-Length describes the uncompressed body
+		h.Del("Content-Length")
+		// A strong ETag promises the exact bytes, which compression changes;
+		// weak comparison still matches it for If-None-Match
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// flushBuf writes the buffered start of the body through the chosen writer.
+func (w *gzipResponseWriter) flushBuf() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	buf := w.buf
+	w.buf = nil
+	_, err := w.writer().Write(buf)
+	return err
+}
+
+func (w *gzipResponseWriter) writer() io.Writer {
+	if w.gz != nil {
+		return w.gz
+	}
+	return w.ResponseWriter
+}
+
+// compressible reports whether the response may be compressed: it is not
+// already encoded or a byte range, and its content type, sniffed from the
+// buffered body if the handler set none, is in the allowlist.
+func (w *gzipResponseWriter) compressible() bool {
+	h := w.ResponseWriter.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		if len(w.buf) == 0 {
+			return true
+		}
+		// Set it as net/http would, before compression hides the body
+		ct = http.DetectContentType(w.buf)
+		h.Set("Content-Type", ct)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range w.cfg.ContentTypes {
+		if mediaType == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed)) {
+			return true
+		}
+	}
+	return false
+}
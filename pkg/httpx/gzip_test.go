@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGzip_CompressesResponse(t *testing.T) {
@@ -65,37 +66,38 @@ func TestGzip_SkipsWhenNotAccepted(t *testing.T) {
 	}
 }
 
-func TestGzip_SkipsConfiguredPaths(t *testing.T) {
+func TestGzip_SkipsMatchedRoutes(t *testing.T) {
 	body := strings.Repeat("Hello, World! ", 100)
-	handler := Gzip("/sse", "/events")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := GzipWithConfig(GzipConfig{Skip: MatchRoutes("GET /sse", "GET /events")})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(body))
 	}))
 
 	tests := []struct {
 		name           string
-		path           string
+		pattern        string
 		wantCompressed bool
 	}{
 		{
-			name:           "skipped path /sse",
-			path:           "/sse",
+			name:           "skipped route /sse",
+			pattern:        "GET /sse",
 			wantCompressed: false,
 		},
 		{
-			name:           "skipped path /events",
-			path:           "/events",
+			name:           "skipped route /events",
+			pattern:        "GET /events",
 			wantCompressed: false,
 		},
 		{
-			name:           "regular path",
-			path:           "/api",
+			name:           "regular route",
+			pattern:        "GET /api",
 			wantCompressed: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Pattern = tt.pattern
 			req.Header.Set("Accept-Encoding", "gzip")
 			rec := httptest.NewRecorder()
 
@@ -140,3 +142,141 @@ func TestGzip_Flush(t *testing.T) {
 		t.Errorf("expected body %q, got %q", "part1part2", string(decompressed))
 	}
 }
+
+func TestGzipWithConfig_SkipsSmallAndCompressedResponses(t *testing.T) {
+	large := strings.Repeat("Hello, World! ", 100)
+
+	tests := []struct {
+		name           string
+		contentType    string
+		status         int
+		body           string
+		wantCompressed bool
+	}{
+		{"large json", "application/json", http.StatusOK, large, true},
+		{"large sniffed text", "", http.StatusOK, large, true},
+		{"small json", "application/json; charset=utf-8", http.StatusOK, `{"status":"ok"}`, false},
+		{"small error keeps status", "application/json", http.StatusServiceUnavailable, `{"status":"down"}`, false},
+		{"image", "image/png", http.StatusOK, large, false},
+		{"font", "font/woff2", http.StatusOK, large, false},
+		{"svg", "image/svg+xml", http.StatusOK, large, true},
+	}
+
+	handler := GzipWithConfig(GzipConfig{MinSize: 512})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(tt.status)
+				// Write in pieces so the threshold is crossed mid-response
+				for chunk := range strings.SplitSeq(tt.body, " ") {
+					w.Write([]byte(chunk + " "))
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			compressed := rec.Header().Get("Content-Encoding") == "gzip"
+			if compressed != tt.wantCompressed {
+				t.Fatalf("compressed = %v, want %v", compressed, tt.wantCompressed)
+			}
+
+			var body io.Reader = rec.Body
+			if compressed {
+				reader, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("failed to create gzip reader: %v", err)
+				}
+				defer reader.Close()
+				body = reader
+			}
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			want := tt.body + " "
+			if string(got) != want {
+				t.Errorf("body mismatch: got %d bytes, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestGzipWithConfig_NoContent(t *testing.T) {
+	handler := GzipWithConfig(GzipConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty, unencoded body, got %q (%d bytes)", rec.Header().Get("Content-Encoding"), rec.Body.Len())
+	}
+}
+
+func TestGzip_SkipsPartialContent(t *testing.T) {
+	content := strings.NewReader(strings.Repeat("Hello, World! ", 100))
+	handler := Gzip()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "hello.txt", time.Time{}, content)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("Range", "bytes=0-99")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusPartialContent)
+	}
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.Len() != 100 {
+		t.Errorf("expected 100 unencoded bytes, got %q (%d bytes)", rec.Header().Get("Content-Encoding"), rec.Body.Len())
+	}
+	if got := rec.Header().Get("ETag"); got != `"v1"` {
+		t.Errorf("ETag = %s, want the strong ETag", got)
+	}
+}
+
+func TestGzip_WeakensETag(t *testing.T) {
+	content := strings.NewReader(strings.Repeat("Hello, World! ", 100))
+	handler := Gzip()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "hello.txt", time.Time{}, content)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("expected Content-Encoding: gzip")
+	}
+	etag := rec.Header().Get("ETag")
+	if etag != `W/"v1"` {
+		t.Errorf("ETag = %s, want W/\"v1\"", etag)
+	}
+
+	// The weakened ETag still revalidates
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+}
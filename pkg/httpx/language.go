@@ -0,0 +1,27 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
+)
+
+// Language negotiates the language of each response from the request's
+// Accept-Language header. A request accepting a supported locale carries
+// it in its context (see i18n.FromContext) and its response is marked with
+// Content-Language, which WriteError translates error messages into.
+// Requests that don't get responses as written.
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		locale, ok := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Language", locale)
+		next.ServeHTTP(w, r.WithContext(i18n.NewContext(r.Context(), locale)))
+	})
+}
@@ -0,0 +1,52 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/pkg/i18n"
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestLanguage_TranslatesErrors(t *testing.T) {
+	var locale string
+	handler := Language(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale, _ = i18n.FromContext(r.Context())
+		_ = WriteError(w, ErrBadRequest("invalid game ID"))
+	}))
+
+	tests := []struct {
+		acceptLanguage string
+		wantLocale     string
+		wantMessage    string
+	}{
+		{"", "", "invalid game ID"},
+		{"es-AR,es;q=0.9", "es", "ID de juego no válido"},
+		{"fr", "", "invalid game ID"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		var resp sdk.ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Error.Message != tt.wantMessage {
+			t.Errorf("%q: expected message %q, got %q", tt.acceptLanguage, tt.wantMessage, resp.Error.Message)
+		}
+		if locale != tt.wantLocale || w.Header().Get("Content-Language") != tt.wantLocale {
+			t.Errorf("%q: expected locale %q, got %q with Content-Language %q",
+				tt.acceptLanguage, tt.wantLocale, locale, w.Header().Get("Content-Language"))
+		}
+		if w.Header().Get("Vary") != "Accept-Language" {
+			t.Errorf("expected Vary: Accept-Language, got %q", w.Header().Get("Vary"))
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package httpx
+
+import "net/http"
+
+// RequestMatcher selects requests a middleware treats differently, such as
+// routes it skips.
+type RequestMatcher func(r *http.Request) bool
+
+// MatchRoutes matches requests routed to one of patterns, compared with the
+// ServeMux pattern that matched (r.Pattern), e.g. "GET /api/v1/games/{id}".
+// r.Pattern is only set once the mux has routed the request, so use it in
+// middleware that wraps individual routes rather than the whole mux.
+func MatchRoutes(patterns ...string) RequestMatcher {
+	set := make(map[string]struct{}, len(patterns))
+	for _, p := range patterns {
+		set[p] = struct{}{}
+	}
+	return func(r *http.Request) bool {
+		_, ok := set[r.Pattern]
+		return ok
+	}
+}
+
+// MatchAll matches every request, for middleware wrapping only routes that
+// all need the treatment, e.g. a class of streaming routes.
+func MatchAll(*http.Request) bool {
+	return true
+}
@@ -22,6 +22,17 @@ func Chain(middlewares ...Middleware) Middleware {
 	}
 }
 
+// SetHeader is middleware that sets a response header on every response,
+// e.g. the ID of the instance that served it.
+func SetHeader(key, value string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(key, value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // Recoverer is middleware that recovers from panics and logs the error.
 //
 //nolint:contextcheck // Using r.Context() inside defer is correct for panic recovery
@@ -0,0 +1,106 @@
+package httpx
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryInt parses the query parameter name as an integer between lo and hi
+// inclusive, returning def when it is absent. Pass math.MaxInt64 as hi for
+// a parameter with no upper bound. The error names the parameter.
+func QueryInt[T int | int64](r *http.Request, name string, def, lo, hi T) (T, *APIError) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+
+	parsed, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || parsed < int64(lo) || parsed > int64(hi) {
+		if int64(hi) == math.MaxInt64 {
+			return def, ErrInvalidParam(name, fmt.Sprintf("%s must be an integer of at least %d", name, lo))
+		}
+		return def, ErrInvalidParam(name, fmt.Sprintf("%s must be between %d and %d", name, lo, hi))
+	}
+	return T(parsed), nil
+}
+
+// QueryEnum parses the query parameter name as one of allowed, returning def
+// when it is absent. The error names the parameter and lists the choices.
+func QueryEnum[T ~string](r *http.Request, name string, def T, allowed ...T) (T, *APIError) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+
+	choices := make([]string, len(allowed))
+	for i, a := range allowed {
+		if string(a) == v {
+			return a, nil
+		}
+		choices[i] = string(a)
+	}
+	return def, ErrInvalidParam(name, fmt.Sprintf("%s must be one of %s", name, strings.Join(choices, ", ")))
+}
+
+// QueryList parses the query parameter name as a comma-separated set of
+// values from allowed, returning nil when it is absent. Repeated values are
+// kept once. The error names the parameter and lists the choices.
+func QueryList[T ~string](r *http.Request, name string, allowed ...T) ([]T, *APIError) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	var list []T
+	for item := range strings.SplitSeq(v, ",") {
+		i := slices.Index(allowed, T(strings.TrimSpace(item)))
+		if i < 0 {
+			choices := make([]string, len(allowed))
+			for j, a := range allowed {
+				choices[j] = string(a)
+			}
+			return nil, ErrInvalidParam(name, fmt.Sprintf("%s must be a comma-separated list of %s", name, strings.Join(choices, ", ")))
+		}
+		if !slices.Contains(list, allowed[i]) {
+			list = append(list, allowed[i])
+		}
+	}
+	return list, nil
+}
+
+// QueryTimeRange parses the query parameters fromName and toName as RFC 3339
+// timestamps bounding a time range. Either may be absent, leaving it zero.
+// The error names the parameter at fault, including a range that ends before
+// it starts.
+func QueryTimeRange(r *http.Request, fromName, toName string) (from, to time.Time, apiErr *APIError) {
+	if from, apiErr = queryTime(r, fromName); apiErr != nil {
+		return time.Time{}, time.Time{}, apiErr
+	}
+	if to, apiErr = queryTime(r, toName); apiErr != nil {
+		return time.Time{}, time.Time{}, apiErr
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return time.Time{}, time.Time{}, ErrInvalidParam(toName, fmt.Sprintf("%s must not be before %s", toName, fromName))
+	}
+	return from, to, nil
+}
+
+// queryTime parses the query parameter name as an RFC 3339 timestamp,
+// returning the zero time when it is absent.
+func queryTime(r *http.Request, name string) (time.Time, *APIError) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, ErrInvalidParam(name, fmt.Sprintf("%s must be an RFC 3339 timestamp such as 2024-05-01T09:30:00Z", name))
+	}
+	return t, nil
+}
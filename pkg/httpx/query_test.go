@@ -0,0 +1,164 @@
+package httpx
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestQueryInt(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    int64
+		wantErr string
+	}{
+		{"", 20, ""},
+		{"?n=1", 1, ""},
+		{"?n=100", 100, ""},
+		{"?n=0", 0, "n must be between 1 and 100"},
+		{"?n=101", 0, "n must be between 1 and 100"},
+		{"?n=abc", 0, "n must be between 1 and 100"},
+		{"?n=99999999999999999999", 0, "n must be between 1 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			got, apiErr := QueryInt[int64](r, "n", 20, 1, 100)
+			if tt.wantErr != "" {
+				if apiErr == nil {
+					t.Fatalf("QueryInt() = %d, want error", got)
+				}
+				if apiErr.Message != tt.wantErr || apiErr.Param != "n" || apiErr.Status != http.StatusBadRequest {
+					t.Errorf("QueryInt() error = %+v, want %q for param n", apiErr, tt.wantErr)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatalf("QueryInt() error: %v", apiErr)
+			}
+			if got != tt.want {
+				t.Errorf("QueryInt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryInt_Unbounded(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?cursor=-1", nil)
+	_, apiErr := QueryInt[int64](r, "cursor", 0, 0, math.MaxInt64)
+	if apiErr == nil || apiErr.Message != "cursor must be an integer of at least 0" {
+		t.Errorf("QueryInt() error = %v, want lower bound message", apiErr)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?cursor=9223372036854775807", nil)
+	if got, apiErr := QueryInt[int64](r, "cursor", 0, 0, math.MaxInt64); apiErr != nil || got != math.MaxInt64 {
+		t.Errorf("QueryInt() = %d, %v, want max int64", got, apiErr)
+	}
+}
+
+func TestQueryEnum(t *testing.T) {
+	type order string
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if got, apiErr := QueryEnum(r, "order", order("asc"), "asc", "desc"); apiErr != nil || got != "asc" {
+		t.Errorf("QueryEnum() = %q, %v, want default asc", got, apiErr)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?order=desc", nil)
+	if got, apiErr := QueryEnum(r, "order", order("asc"), "asc", "desc"); apiErr != nil || got != "desc" {
+		t.Errorf("QueryEnum() = %q, %v, want desc", got, apiErr)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?order=up", nil)
+	_, apiErr := QueryEnum(r, "order", order("asc"), "asc", "desc")
+	if apiErr == nil || apiErr.Param != "order" || apiErr.Message != "order must be one of asc, desc" {
+		t.Errorf("QueryEnum() error = %+v, want choices listed for param order", apiErr)
+	}
+}
+
+func TestQueryList(t *testing.T) {
+	allowed := []string{"a", "b", "c"}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if got, apiErr := QueryList(r, "only", allowed...); apiErr != nil || got != nil {
+		t.Errorf("QueryList() = %v, %v, want nil when absent", got, apiErr)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?only=c,%20a,c", nil)
+	if got, apiErr := QueryList(r, "only", allowed...); apiErr != nil || !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("QueryList() = %v, %v, want [c a]", got, apiErr)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?only=a,d", nil)
+	_, apiErr := QueryList(r, "only", allowed...)
+	if apiErr == nil || apiErr.Param != "only" || apiErr.Message != "only must be a comma-separated list of a, b, c" {
+		t.Errorf("QueryList() error = %+v, want choices listed for param only", apiErr)
+	}
+}
+
+func TestQueryTimeRange(t *testing.T) {
+	from := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	tests := []struct {
+		query     string
+		wantFrom  time.Time
+		wantTo    time.Time
+		wantParam string
+	}{
+		{"", time.Time{}, time.Time{}, ""},
+		{"?from=2024-05-01T09:30:00Z", from, time.Time{}, ""},
+		{"?from=2024-05-01T09:30:00Z&to=2024-05-01T20:30:00.000%2B10:00", from, to, ""},
+		{"?from=yesterday", time.Time{}, time.Time{}, "from"},
+		{"?to=1714555800", time.Time{}, time.Time{}, "to"},
+		{"?from=2024-05-01T10:30:00Z&to=2024-05-01T09:30:00Z", time.Time{}, time.Time{}, "to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			gotFrom, gotTo, apiErr := QueryTimeRange(r, "from", "to")
+			if tt.wantParam != "" {
+				if apiErr == nil || apiErr.Param != tt.wantParam {
+					t.Fatalf("QueryTimeRange() error = %+v, want param %s", apiErr, tt.wantParam)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatalf("QueryTimeRange() error: %v", apiErr)
+			}
+			if !gotFrom.Equal(tt.wantFrom) || !gotTo.Equal(tt.wantTo) {
+				t.Errorf("QueryTimeRange() = %v, %v, want %v, %v", gotFrom, gotTo, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestWriteError_Param(t *testing.T) {
+	rec := httptest.NewRecorder()
+	_ = WriteError(rec, ErrInvalidParam("limit", "limit must be between 1 and 100"))
+
+	var body sdk.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if body.Error.Code != CodeBadRequest || body.Error.Param != "limit" {
+		t.Errorf("error = %+v, want BAD_REQUEST for param limit", body.Error)
+	}
+
+	// Errors not tied to a parameter leave it out
+	rec = httptest.NewRecorder()
+	_ = WriteError(rec, ErrNotFound("game not found"))
+	var raw map[string]map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&raw); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if _, ok := raw["error"]["param"]; ok {
+		t.Errorf("error = %v, want param omitted", raw["error"])
+	}
+}
@@ -1,15 +1,56 @@
 package httpx
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
 )
 
+// maxPooledBufferSize caps the capacity of buffers returned to the pool so a
+// single large response doesn't pin memory indefinitely.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool pools JSON encoding buffers to reduce allocations.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// encodeFailureBody is written when a response value cannot be encoded. It is
+// pre-encoded so reporting the failure can't itself fail.
+var encodeFailureBody = []byte(`{"error":{"code":"` + CodeInternal + `","message":"failed to encode response"}}` + "\n")
+
 // JSON writes a JSON response with the given status code.
+//
+// The value is fully encoded into a pooled buffer before anything is written,
+// so an encoding failure produces a clean 500 response instead of a truncated
+// body, and the response carries an accurate Content-Length.
 func JSON(w http.ResponseWriter, status int, v any) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(encodeFailureBody)))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write(encodeFailureBody)
+		return fmt.Errorf("encoding JSON response: %w", err)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
 	w.WriteHeader(status)
-	return json.NewEncoder(w).Encode(v)
+	_, err := w.Write(buf.Bytes())
+	return err
 }
 
 // Error writes a JSON error response.
@@ -0,0 +1,95 @@
+package httpx
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestJSON_WritesBodyWithContentLength(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	if err := JSON(rec, http.StatusCreated, map[string]int{"id": 42}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+	if cl := rec.Header().Get("Content-Length"); cl != strconv.Itoa(rec.Body.Len()) {
+		t.Errorf("expected Content-Length %d, got %q", rec.Body.Len(), cl)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"id":42}` {
+		t.Errorf("unexpected body: %s", got)
+	}
+}
+
+func TestJSON_EncodeFailure(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := JSON(rec, http.StatusOK, map[string]float64{"bad": math.Inf(1)})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	// The body must be a complete, valid error response
+	var resp sdk.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode error body: %v", err)
+	}
+	if resp.Error.Code != CodeInternal {
+		t.Errorf("expected code %s, got %s", CodeInternal, resp.Error.Code)
+	}
+}
+
+func TestJSON_WithGzip(t *testing.T) {
+	payload := map[string]string{"message": strings.Repeat("keno ", 200)}
+	handler := Gzip()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = JSON(w, http.StatusOK, payload)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	// Content-Length describes the uncompressed body and must not leak through
+	if cl := rec.Header().Get("Content-Length"); cl != "" {
+		t.Errorf("expected no Content-Length on gzipped response, got %q", cl)
+	}
+
+	reader, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to create gzip reader: %v", err)
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress: %v", err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if got["message"] != payload["message"] {
+		t.Error("decompressed body mismatch")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/aussiebroadwan/taboo/sdk"
 )
 
 // SSEStream wraps an http.ResponseWriter for SSE communication.
 type SSEStream struct {
 	w       http.ResponseWriter
 	flusher http.Flusher
+	aliases map[string]string
 }
 
 // NewSSEStream creates a new SSE stream and sets appropriate headers.
@@ -34,6 +37,13 @@ func NewSSEStream(w http.ResponseWriter) *SSEStream {
 	}
 }
 
+// SetAliases makes Send repeat each event whose type is a key of aliases
+// under the mapped name, straight after the original, so clients still
+// listening for an event's old name keep receiving it through a rename.
+func (s *SSEStream) SetAliases(aliases map[string]string) {
+	s.aliases = aliases
+}
+
 // Send writes an SSE event with the given type and data.
 func (s *SSEStream) Send(eventType string, data any) error {
 	jsonData, err := json.Marshal(data)
@@ -46,6 +56,11 @@ func (s *SSEStream) Send(eventType string, data any) error {
 	if err != nil {
 		return fmt.Errorf("writing event: %w", err)
 	}
+	if alias, ok := s.aliases[eventType]; ok {
+		if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", alias, jsonData); err != nil {
+			return fmt.Errorf("writing event: %w", err)
+		}
+	}
 
 	s.flusher.Flush()
 	return nil
@@ -53,6 +68,6 @@ func (s *SSEStream) Send(eventType string, data any) error {
 
 // SendHeartbeat sends a heartbeat event.
 func (s *SSEStream) SendHeartbeat() error {
-	return s.Send("game:heartbeat", struct{}{})
+	return s.Send(sdk.EventSysHeartbeat, struct{}{})
 }
 
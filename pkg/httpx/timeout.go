@@ -9,22 +9,16 @@ import (
 
 // Timeout returns middleware that applies a timeout to requests.
 func Timeout(timeout time.Duration) Middleware {
-	return TimeoutWithSkip(timeout)
+	return TimeoutWithSkip(timeout, nil)
 }
 
 // TimeoutWithSkip returns middleware that applies a timeout to requests,
-// skipping requests to paths that match any of the skip patterns.
-// This is useful for SSE endpoints that need long-lived connections.
-func TimeoutWithSkip(timeout time.Duration, skipPaths ...string) Middleware {
-	skipSet := make(map[string]struct{}, len(skipPaths))
-	for _, path := range skipPaths {
-		skipSet[path] = struct{}{}
-	}
-
+// skipping requests selected by skip (which may be nil), e.g. long-lived
+// SSE routes matched with MatchRoutes.
+func TimeoutWithSkip(timeout time.Duration, skip RequestMatcher) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip timeout for specified paths
-			if _, skip := skipSet[r.URL.Path]; skip {
+			if skip != nil && skip(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
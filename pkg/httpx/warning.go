@@ -0,0 +1,15 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// Warn tells the client about a deprecated parameter or behavior about to
+// change, in a Warning header on the response and, for clients that opted
+// in to the envelope, in the warnings of its meta. Call it before the
+// response is written.
+func Warn(w http.ResponseWriter, text string) {
+	w.Header().Add(sdk.WarningHeader, sdk.FormatWarning(text))
+}
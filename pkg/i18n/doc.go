@@ -0,0 +1,14 @@
+// Package i18n translates the API's user-facing messages, such as error
+// messages, into the languages clients ask for with Accept-Language.
+//
+// Messages are written in English in code, and English text is the key
+// they are translated by, so a message without a translation is sent as
+// written:
+//
+//	locale, ok := i18n.Negotiate("es-AR,es;q=0.9,en;q=0.5") // "es", true
+//	i18n.Translate(locale, "invalid game ID")                // "ID de juego no válido"
+//
+// The catalogs are embedded from catalog/: messages.json lists every
+// message to translate, and <locale>.json holds a locale's translations.
+// Lint reports translations missing from a catalog.
+package i18n
@@ -0,0 +1,163 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aussiebroadwan/taboo/pkg/lint"
+)
+
+// Source is the locale messages are written in.
+const Source = "en"
+
+// messagesFile lists the messages to translate, in catalogFS.
+const messagesFile = "messages.json"
+
+//go:embed catalog/*.json
+var catalogFS embed.FS
+
+var (
+	// messages lists every message to translate.
+	messages []string
+
+	// catalogs holds each locale's translations, keyed by message.
+	catalogs map[string]map[string]string
+)
+
+func init() {
+	if err := load(); err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+}
+
+// load reads the embedded catalogs.
+func load() error {
+	data, err := catalogFS.ReadFile(path.Join("catalog", messagesFile))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("%s: %w", messagesFile, err)
+	}
+
+	entries, err := catalogFS.ReadDir("catalog")
+	if err != nil {
+		return err
+	}
+	catalogs = make(map[string]map[string]string)
+	for _, entry := range entries {
+		if entry.Name() == messagesFile {
+			continue
+		}
+		data, err := catalogFS.ReadFile(path.Join("catalog", entry.Name()))
+		if err != nil {
+			return err
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		catalogs[strings.TrimSuffix(entry.Name(), ".json")] = catalog
+	}
+	return nil
+}
+
+// Locales returns the supported locales, Source first.
+func Locales() []string {
+	locales := []string{Source}
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales[1:])
+	return locales
+}
+
+// Supported reports whether messages can be translated into locale.
+func Supported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok || locale == Source
+}
+
+// Messages returns the messages catalogs translate.
+func Messages() []string {
+	return slices.Clone(messages)
+}
+
+// Translate returns msg in locale, or msg as written if locale has no
+// translation for it.
+func Translate(locale, msg string) string {
+	if t, ok := catalogs[locale][msg]; ok && t != "" {
+		return t
+	}
+	return msg
+}
+
+// Negotiate returns the supported locale the client prefers most, given an
+// Accept-Language header. Regional tags match their language, e.g. es-AR
+// matches es. It reports false if the client accepts none of them or
+// didn't say.
+func Negotiate(acceptLanguage string) (string, bool) {
+	best, bestQ := "", 0.0
+	for tag := range strings.SplitSeq(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(tag), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		lang, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if lang == "*" {
+			lang = Source
+		}
+		if q > bestQ && Supported(lang) {
+			best, bestQ = lang, q
+		}
+	}
+	return best, best != ""
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the locale negotiated for a
+// request.
+func NewContext(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale negotiated for a request, reporting false
+// if none was.
+func FromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(contextKey{}).(string)
+	return locale, ok
+}
+
+// Lint checks every catalog translates each message, and nothing else.
+func Lint() lint.Issues {
+	c := lint.NewCollector()
+	for _, locale := range Locales()[1:] {
+		catalog := catalogs[locale]
+		location := "i18n." + locale
+		for _, msg := range messages {
+			if catalog[msg] == "" {
+				c.Warnf("translation-missing", location, "no translation for %q", msg)
+			}
+		}
+		for _, msg := range slices.Sorted(maps.Keys(catalog)) {
+			if !slices.Contains(messages, msg) {
+				c.Warnf("translation-unused", location, "%q is not in %s", msg, messagesFile)
+			}
+		}
+	}
+	return c.Issues()
+}
@@ -0,0 +1,59 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{"", "", false},
+		{"es", "es", true},
+		{"es-AR,es;q=0.9,en;q=0.5", "es", true},
+		{"en-AU,es;q=0.8", "en", true},
+		{"fr,es;q=0.3", "es", true},
+		{"fr, de;q=0.5", "", false},
+		{"es;q=0, en;q=0.1", "en", true},
+		{"*", Source, true},
+		{"es;q=high", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Negotiate(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Negotiate(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if got := Translate("es", "invalid game ID"); got != "ID de juego no válido" {
+		t.Errorf("unexpected translation: %q", got)
+	}
+	for _, locale := range []string{Source, "es", "xx"} {
+		if got := Translate(locale, "not in any catalog"); got != "not in any catalog" {
+			t.Errorf("%s: expected an untranslated message as written, got %q", locale, got)
+		}
+	}
+	if got := Translate(Source, "invalid game ID"); got != "invalid game ID" {
+		t.Errorf("expected the source text, got %q", got)
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("expected no locale on a bare context")
+	}
+	if locale, ok := FromContext(NewContext(context.Background(), "es")); !ok || locale != "es" {
+		t.Errorf("expected es, got %q, %v", locale, ok)
+	}
+}
+
+func TestLint_CatalogsComplete(t *testing.T) {
+	if issues := Lint(); len(issues) > 0 {
+		t.Errorf("catalogs are incomplete:\n%v", issues)
+	}
+}
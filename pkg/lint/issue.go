@@ -7,10 +7,10 @@ import (
 
 // Issue represents a single validation issue.
 type Issue struct {
-	Severity Severity
-	Rule     string // e.g., "env-invalid", "port-privileged"
-	Message  string
-	Location string // e.g., "server.port", "database.dsn"
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"` // e.g., "env-invalid", "port-privileged"
+	Message  string   `json:"message"`
+	Location string   `json:"location"` // e.g., "server.port", "database.dsn"
 }
 
 func (i Issue) String() string {
@@ -21,3 +21,9 @@ func (s Severity) String() string {
 		return "UNKNOWN"
 	}
 }
+
+// MarshalText implements encoding.TextMarshaler so severities encode as
+// their names in JSON.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,9 @@
+// Package numwords spells out numbers as words, for announcing draws to
+// screen readers and text-to-speech.
+//
+// Each supported locale has its own spelling rules:
+//
+//	numwords.Spell("en", 42)  // "forty-two", true
+//	numwords.Spell("es", 42)  // "cuarenta y dos", true
+//	numwords.Spell("xx", 42)  // "", false
+package numwords
@@ -0,0 +1,121 @@
+package numwords
+
+import (
+	"slices"
+	"strings"
+)
+
+// Max is the largest number that can be spelled.
+const Max = 999
+
+// speller spells a number from 0 to Max.
+type speller func(n int) string
+
+// locales maps each supported locale to its speller.
+var locales = map[string]speller{
+	"en": spellEnglish,
+	"es": spellSpanish,
+}
+
+// Locales returns the supported locales, sorted.
+func Locales() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Supported reports whether locale can be spelled.
+func Supported(locale string) bool {
+	_, ok := locales[locale]
+	return ok
+}
+
+// Spell returns n spelled out in locale. It returns false if the locale is
+// not supported or n is outside 0 to Max.
+func Spell(locale string, n int) (string, bool) {
+	spell, ok := locales[locale]
+	if !ok || n < 0 || n > Max {
+		return "", false
+	}
+	return spell(n), true
+}
+
+var (
+	englishOnes = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// spellEnglish spells n in English, joining hundreds with "and" as callers
+// do in Australia, e.g. "one hundred and five".
+func spellEnglish(n int) string {
+	var b strings.Builder
+	if n >= 100 {
+		b.WriteString(englishOnes[n/100])
+		b.WriteString(" hundred")
+		if n%100 == 0 {
+			return b.String()
+		}
+		b.WriteString(" and ")
+		n %= 100
+	}
+
+	switch {
+	case n < 20:
+		b.WriteString(englishOnes[n])
+	case n%10 == 0:
+		b.WriteString(englishTens[n/10])
+	default:
+		b.WriteString(englishTens[n/10])
+		b.WriteString("-")
+		b.WriteString(englishOnes[n%10])
+	}
+	return b.String()
+}
+
+var (
+	spanishOnes = []string{
+		"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+	}
+	spanishTens     = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+	spanishHundreds = []string{
+		"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos",
+		"seiscientos", "setecientos", "ochocientos", "novecientos",
+	}
+)
+
+// spellSpanish spells n in Spanish, e.g. "cuarenta y dos".
+func spellSpanish(n int) string {
+	if n == 100 {
+		return "cien"
+	}
+
+	var b strings.Builder
+	if n >= 100 {
+		b.WriteString(spanishHundreds[n/100])
+		if n%100 == 0 {
+			return b.String()
+		}
+		b.WriteString(" ")
+		n %= 100
+	}
+
+	switch {
+	case n < 30:
+		b.WriteString(spanishOnes[n])
+	case n%10 == 0:
+		b.WriteString(spanishTens[n/10])
+	default:
+		b.WriteString(spanishTens[n/10])
+		b.WriteString(" y ")
+		b.WriteString(spanishOnes[n%10])
+	}
+	return b.String()
+}
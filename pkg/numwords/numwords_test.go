@@ -0,0 +1,68 @@
+package numwords
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpell(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int
+		want   string
+	}{
+		{"en", 0, "zero"},
+		{"en", 7, "seven"},
+		{"en", 13, "thirteen"},
+		{"en", 20, "twenty"},
+		{"en", 42, "forty-two"},
+		{"en", 80, "eighty"},
+		{"en", 100, "one hundred"},
+		{"en", 105, "one hundred and five"},
+		{"en", 255, "two hundred and fifty-five"},
+		{"en", 999, "nine hundred and ninety-nine"},
+		{"es", 1, "uno"},
+		{"es", 16, "dieciséis"},
+		{"es", 22, "veintidós"},
+		{"es", 30, "treinta"},
+		{"es", 42, "cuarenta y dos"},
+		{"es", 100, "cien"},
+		{"es", 101, "ciento uno"},
+		{"es", 500, "quinientos"},
+		{"es", 255, "doscientos cincuenta y cinco"},
+	}
+	for _, tt := range tests {
+		got, ok := Spell(tt.locale, tt.n)
+		if !ok || got != tt.want {
+			t.Errorf("Spell(%q, %d) = %q, %v, want %q", tt.locale, tt.n, got, ok, tt.want)
+		}
+	}
+}
+
+func TestSpell_Unsupported(t *testing.T) {
+	for _, tt := range []struct {
+		locale string
+		n      int
+	}{
+		{"xx", 1},
+		{"", 1},
+		{"en", -1},
+		{"en", Max + 1},
+	} {
+		if got, ok := Spell(tt.locale, tt.n); ok {
+			t.Errorf("Spell(%q, %d) = %q, want unsupported", tt.locale, tt.n, got)
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	got := Locales()
+	if !slices.Equal(got, []string{"en", "es"}) {
+		t.Errorf("Locales() = %v, want [en es]", got)
+	}
+	for _, locale := range got {
+		if !Supported(locale) {
+			t.Errorf("Supported(%q) = false, want true", locale)
+		}
+	}
+}
@@ -2,9 +2,15 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 )
 
+// ErrTooManySubscribers is returned by Join when the broker is at its
+// subscriber limit.
+var ErrTooManySubscribers = errors.New("too many subscribers")
+
 // Option configures a Broker.
 type Option[T any] func(*Broker[T])
 
@@ -15,17 +21,105 @@ func WithBufferSize[T any](size int) Option[T] {
 	}
 }
 
+// WithMaxSubscribers caps the subscribers Join admits. Zero, the default,
+// admits any number.
+func WithMaxSubscribers[T any](n int) Option[T] {
+	return func(b *Broker[T]) {
+		b.maxSubscribers = n
+	}
+}
+
+// WithEvictAfter closes a subscription made by Join once this many events in
+// a row have been dropped for it, so a client that has stopped reading is
+// disconnected rather than silently missing events. Subscribe consumers are
+// never evicted. Zero, the default, never evicts.
+func WithEvictAfter[T any](drops int) Option[T] {
+	return func(b *Broker[T]) {
+		b.evictAfter = drops
+	}
+}
+
+// WithPriority gives events matching isPriority a lane of reserve extra
+// buffer slots on top of the buffer size. Other events are dropped once the
+// buffer size is reached, so a subscriber backed up on them still has room
+// for priority events, which are only dropped once the reserve is full too.
+func WithPriority[T any](isPriority func(T) bool, reserve int) Option[T] {
+	return func(b *Broker[T]) {
+		b.isPriority = isPriority
+		b.reserve = reserve
+	}
+}
+
 // Broker is a generic publish/subscribe message broker.
 type Broker[T any] struct {
 	mu          sync.RWMutex
-	subscribers map[chan T]struct{}
-	bufferSize  int
+	subscribers map[*Subscription[T]]struct{}
+
+	bufferSize     int
+	maxSubscribers int
+	evictAfter     int
+
+	// isPriority, if set, picks the events allowed into the reserve slots
+	// past bufferSize.
+	isPriority func(T) bool
+	reserve    int
+
+	delivered atomic.Int64
+	dropped   atomic.Int64
+	evicted   atomic.Int64
+	rejected  atomic.Int64
+}
+
+// Subscription is a subscriber's event channel along with its delivery
+// counters.
+type Subscription[T any] struct {
+	// C receives published events. It is closed when the subscription's
+	// context is cancelled or the subscriber is evicted.
+	C <-chan T
+
+	ch          chan T
+	evictable   bool
+	delivered   atomic.Int64
+	dropped     atomic.Int64
+	consecutive atomic.Int64
+	evicted     atomic.Bool
+}
+
+// SubscriptionStats counts the events published while a subscription was
+// open.
+type SubscriptionStats struct {
+	Delivered int64
+	Dropped   int64
+
+	// Evicted is set if the subscription was closed for falling behind.
+	Evicted bool
+}
+
+// Stats returns the subscription's delivery counters.
+func (s *Subscription[T]) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		Delivered: s.delivered.Load(),
+		Dropped:   s.dropped.Load(),
+		Evicted:   s.evicted.Load(),
+	}
+}
+
+// Stats counts deliveries across all subscribers since the broker was
+// created.
+type Stats struct {
+	Subscribers int
+	Delivered   int64
+	Dropped     int64
+	Evicted     int64
+
+	// Rejected counts Join calls refused at the subscriber limit.
+	Rejected int64
 }
 
 // New creates a new Broker with the given options.
 func New[T any](opts ...Option[T]) *Broker[T] {
 	b := &Broker[T]{
-		subscribers: make(map[chan T]struct{}),
+		subscribers: make(map[*Subscription[T]]struct{}),
 		bufferSize:  16,
 	}
 	for _, opt := range opts {
@@ -35,39 +129,110 @@ func New[T any](opts ...Option[T]) *Broker[T] {
 }
 
 // Subscribe returns a channel that receives published events.
-// The channel is closed when the context is cancelled.
+// The channel is closed when the context is cancelled. Unlike Join, it is
+// not subject to the subscriber limit or to eviction.
 func (b *Broker[T]) Subscribe(ctx context.Context) <-chan T {
-	ch := make(chan T, b.bufferSize)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.add(ctx, false).C
+}
 
+// Join subscribes like Subscribe, returning the subscription so its
+// delivery can be inspected. It returns ErrTooManySubscribers when the
+// broker is at its subscriber limit.
+func (b *Broker[T]) Join(ctx context.Context) (*Subscription[T], error) {
 	b.mu.Lock()
-	b.subscribers[ch] = struct{}{}
-	b.mu.Unlock()
+	defer b.mu.Unlock()
+
+	if b.maxSubscribers > 0 && len(b.subscribers) >= b.maxSubscribers {
+		b.rejected.Add(1)
+		return nil, ErrTooManySubscribers
+	}
+	return b.add(ctx, true), nil
+}
+
+// add registers a subscription removed when ctx is cancelled, and evicted
+// for falling behind if evictable. The caller must hold b.mu.
+func (b *Broker[T]) add(ctx context.Context, evictable bool) *Subscription[T] {
+	ch := make(chan T, b.bufferSize+b.reserve)
+	sub := &Subscription[T]{C: ch, ch: ch, evictable: evictable}
+	b.subscribers[sub] = struct{}{}
 
 	// Cleanup when context is cancelled
 	go func() {
 		<-ctx.Done()
-		b.mu.Lock()
-		delete(b.subscribers, ch)
-		close(ch)
-		b.mu.Unlock()
+		b.remove(sub)
 	}()
 
-	return ch
+	return sub
+}
+
+// remove unregisters sub and closes its channel, unless an eviction already
+// has.
+func (b *Broker[T]) remove(sub *Subscription[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
 }
 
 // Publish sends an event to all subscribers.
-// Events are dropped for slow subscribers (non-blocking).
-func (b *Broker[T]) Publish(event T) {
+// Events are dropped for slow subscribers (non-blocking). It returns the
+// number of subscribers the event was dropped for.
+func (b *Broker[T]) Publish(event T) (dropped int) {
+	var evict []*Subscription[T]
+
+	// Only priority events may use the reserve. Concurrent publishers may
+	// overshoot bufferSize slightly, which only eats into the reserve.
+	full := b.bufferSize
+	if b.isPriority != nil && b.isPriority(event) {
+		full += b.reserve
+	}
+
 	b.mu.RLock()
-	defer b.mu.RUnlock()
+	for sub := range b.subscribers {
+		if send(sub.ch, event, full) {
+			sub.delivered.Add(1)
+			sub.consecutive.Store(0)
+			continue
+		}
 
-	for ch := range b.subscribers {
-		select {
-		case ch <- event:
-		default:
-			// Drop event if subscriber is slow
+		// Drop event if subscriber is slow
+		dropped++
+		sub.dropped.Add(1)
+		if n := sub.consecutive.Add(1); sub.evictable && b.evictAfter > 0 && n >= int64(b.evictAfter) {
+			evict = append(evict, sub)
 		}
 	}
+	b.delivered.Add(int64(len(b.subscribers) - dropped))
+	b.dropped.Add(int64(dropped))
+	b.mu.RUnlock()
+
+	for _, sub := range evict {
+		if sub.evicted.CompareAndSwap(false, true) {
+			b.evicted.Add(1)
+			b.remove(sub)
+		}
+	}
+
+	return dropped
+}
+
+// send queues event on ch without blocking, unless full events are already
+// queued. It reports whether the event was queued.
+func send[T any](ch chan T, event T, full int) bool {
+	if len(ch) >= full {
+		return false
+	}
+	select {
+	case ch <- event:
+		return true
+	default:
+		return false
+	}
 }
 
 // SubscriberCount returns the current number of subscribers.
@@ -76,3 +241,14 @@ func (b *Broker[T]) SubscriberCount() int {
 	defer b.mu.RUnlock()
 	return len(b.subscribers)
 }
+
+// Stats returns the broker's delivery counters.
+func (b *Broker[T]) Stats() Stats {
+	return Stats{
+		Subscribers: b.SubscriberCount(),
+		Delivered:   b.delivered.Load(),
+		Dropped:     b.dropped.Load(),
+		Evicted:     b.evicted.Load(),
+		Rejected:    b.rejected.Load(),
+	}
+}
@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -106,11 +107,15 @@ func TestBroker_SlowSubscriber(t *testing.T) {
 	ch := b.Subscribe(ctx)
 
 	// Fill buffer
-	b.Publish(1)
+	if dropped := b.Publish(1); dropped != 0 {
+		t.Errorf("expected 0 dropped, got %d", dropped)
+	}
 	b.Publish(2)
 
 	// This should be dropped (buffer full, non-blocking)
-	b.Publish(3)
+	if dropped := b.Publish(3); dropped != 1 {
+		t.Errorf("expected 1 dropped, got %d", dropped)
+	}
 
 	// Should only receive first two
 	received := make([]int, 0, 2)
@@ -316,3 +321,132 @@ func TestBroker_ConcurrentPublishSubscribe(t *testing.T) {
 
 	// If we get here without deadlock or panic, test passed
 }
+
+func TestBroker_Join_MaxSubscribers(t *testing.T) {
+	b := New(WithMaxSubscribers[int](1))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if _, err := b.Join(ctx); err != nil {
+		t.Fatalf("first Join: %v", err)
+	}
+	if _, err := b.Join(context.Background()); !errors.Is(err, ErrTooManySubscribers) {
+		t.Fatalf("expected ErrTooManySubscribers, got %v", err)
+	}
+
+	// Leaving frees the slot
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	sub, err := b.Join(t.Context())
+	if err != nil {
+		t.Fatalf("Join after leave: %v", err)
+	}
+	if sub.C == nil {
+		t.Fatal("expected non-nil channel")
+	}
+	if got := b.Stats().Rejected; got != 1 {
+		t.Errorf("expected 1 rejected, got %d", got)
+	}
+}
+
+func TestBroker_EvictAfter(t *testing.T) {
+	b := New(WithBufferSize[int](1), WithEvictAfter[int](2))
+
+	slow, err := b.Join(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast, err := b.Join(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 3 {
+		b.Publish(i)
+		<-fast.C
+	}
+
+	// The slow subscriber got the first event, then missed two in a row
+	if _, ok := <-slow.C; !ok {
+		t.Fatal("expected the buffered event before the channel closes")
+	}
+	if _, ok := <-slow.C; ok {
+		t.Fatal("expected the evicted subscription's channel to be closed")
+	}
+
+	got := slow.Stats()
+	if got.Delivered != 1 || got.Dropped != 2 || !got.Evicted {
+		t.Errorf("slow stats = %+v, want 1 delivered, 2 dropped, evicted", got)
+	}
+	if got := fast.Stats(); got.Delivered != 3 || got.Evicted {
+		t.Errorf("fast stats = %+v, want 3 delivered", got)
+	}
+
+	stats := b.Stats()
+	if stats.Subscribers != 1 || stats.Delivered != 4 || stats.Dropped != 2 || stats.Evicted != 1 {
+		t.Errorf("broker stats = %+v", stats)
+	}
+}
+
+func TestBroker_EvictAfterSparesSubscribe(t *testing.T) {
+	b := New(WithBufferSize[int](1), WithEvictAfter[int](2))
+
+	ch := b.Subscribe(t.Context())
+	for i := range 5 {
+		b.Publish(i)
+	}
+
+	// Only the first event fit, but the channel stays open for later ones
+	if got := <-ch; got != 0 {
+		t.Fatalf("got %d, want 0", got)
+	}
+	b.Publish(5)
+	select {
+	case got, ok := <-ch:
+		if !ok {
+			t.Fatal("expected the Subscribe channel to survive dropped events")
+		}
+		if got != 5 {
+			t.Errorf("got %d, want 5", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	if stats := b.Stats(); stats.Subscribers != 1 || stats.Evicted != 0 {
+		t.Errorf("broker stats = %+v, want 1 subscriber, none evicted", stats)
+	}
+}
+
+func TestBroker_WithPriority(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	b := New(WithBufferSize[int](2), WithPriority(even, 2))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := b.Subscribe(ctx)
+
+	// Odd events fill the buffer and are then dropped; even ones still fit
+	// in the reserve until it fills too
+	for _, p := range []struct{ event, dropped int }{
+		{1, 0}, {3, 0}, {5, 1}, {2, 0}, {4, 0}, {6, 1},
+	} {
+		if dropped := b.Publish(p.event); dropped != p.dropped {
+			t.Errorf("Publish(%d) dropped = %d, want %d", p.event, dropped, p.dropped)
+		}
+	}
+
+	want := []int{1, 3, 2, 4}
+	for i, w := range want {
+		select {
+		case msg := <-ch:
+			if msg != w {
+				t.Errorf("event %d = %d, want %d", i, msg, w)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("timeout")
+		}
+	}
+	if stats := b.Stats(); stats.Delivered != 4 || stats.Dropped != 2 {
+		t.Errorf("stats = %+v, want 4 delivered and 2 dropped", stats)
+	}
+}
@@ -15,6 +15,7 @@ func New(opts ...Option) *slog.Logger {
 		output:  os.Stdout,
 		service: "",
 		version: "",
+		node:    "",
 	}
 
 	for _, opt := range opts {
@@ -33,6 +34,10 @@ func New(opts ...Option) *slog.Logger {
 		handler = slog.NewTextHandler(cfg.output, handlerOpts)
 	}
 
+	if len(cfg.redactKeys) > 0 {
+		handler = NewRedactor(handler, cfg.redactKeys...)
+	}
+
 	logger := slog.New(handler)
 
 	if cfg.service != "" {
@@ -41,6 +46,9 @@ func New(opts ...Option) *slog.Logger {
 	if cfg.version != "" {
 		logger = logger.With(slog.String("version", cfg.version))
 	}
+	if cfg.node != "" {
+		logger = logger.With(slog.String("node", cfg.node))
+	}
 
 	return logger
 }
@@ -78,11 +86,13 @@ func ParseLevel(s string) slog.Level {
 }
 
 type config struct {
-	level   slog.Level
-	format  Format
-	output  io.Writer
-	service string
-	version string
+	level      slog.Leveler
+	format     Format
+	output     io.Writer
+	service    string
+	version    string
+	node       string
+	redactKeys []string
 }
 
 // Option configures a logger.
@@ -95,6 +105,14 @@ func WithLevel(level slog.Level) Option {
 	}
 }
 
+// WithLevelVar sets the log level from v, so it can be changed while the
+// logger is in use.
+func WithLevelVar(v *slog.LevelVar) Option {
+	return func(c *config) {
+		c.level = v
+	}
+}
+
 // WithFormat sets the log format.
 func WithFormat(format Format) Option {
 	return func(c *config) {
@@ -122,3 +140,19 @@ func WithVersion(version string) Option {
 		c.version = version
 	}
 }
+
+// WithNode adds the ID of the running instance to all log entries, so logs
+// from several replicas can be told apart.
+func WithNode(id string) Option {
+	return func(c *config) {
+		c.node = id
+	}
+}
+
+// WithRedactKeys masks the values of attributes with the given keys.
+// See [Redactor] for how keys are matched.
+func WithRedactKeys(keys ...string) Option {
+	return func(c *config) {
+		c.redactKeys = append(c.redactKeys, keys...)
+	}
+}
@@ -12,7 +12,9 @@ import (
 
 // Middleware returns an HTTP middleware that adds request logging.
 // It attaches a request-scoped logger to the context with request ID,
-// method, and path. It also logs the request completion with status and duration.
+// method, and path. It also logs the request completion with status and duration,
+// and with the number of database calls and time spent in them when the
+// store records them with RecordQuery.
 //
 // Paths in quietPaths are logged at DEBUG instead of INFO, useful for
 // high-frequency endpoints like health probes that would otherwise be noise.
@@ -22,6 +24,21 @@ func Middleware(logger *slog.Logger, quietPaths ...string) func(http.Handler) ht
 		quietSet[p] = struct{}{}
 	}
 
+	return middleware(logger, func(r *http.Request) bool {
+		_, quiet := quietSet[r.URL.Path]
+		return quiet
+	})
+}
+
+// QuietMiddleware is Middleware with every request logged at DEBUG, for
+// wrapping high-frequency routes individually rather than by path.
+func QuietMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return middleware(logger, func(*http.Request) bool { return true })
+}
+
+// middleware implements Middleware, logging completions of requests for
+// which quiet returns true at DEBUG.
+func middleware(logger *slog.Logger, quiet func(*http.Request) bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -40,8 +57,8 @@ func Middleware(logger *slog.Logger, quietPaths ...string) func(http.Handler) ht
 				slog.String("client_ip", clientIP(r)),
 			)
 
-			// Add logger to context
-			ctx := NewContext(r.Context(), reqLogger)
+			// Add logger and query counters to context
+			ctx, queries := WithQueryStats(NewContext(r.Context(), reqLogger))
 			r = r.WithContext(ctx)
 
 			// Set request ID header on response
@@ -60,9 +77,15 @@ func Middleware(logger *slog.Logger, quietPaths ...string) func(http.Handler) ht
 				slog.Duration("duration", duration),
 				slog.Int("bytes", wrapped.bytes),
 			}
+			if calls := queries.Calls(); calls > 0 {
+				completionAttrs = append(completionAttrs,
+					slog.Int64("db_calls", calls),
+					slog.Duration("db_time", queries.Duration()),
+				)
+			}
 
 			level := slog.LevelInfo
-			if _, quiet := quietSet[r.URL.Path]; quiet {
+			if quiet(r) {
 				level = slog.LevelDebug
 			}
 			reqLogger.LogAttrs(ctx, level, "Request completed", completionAttrs...)
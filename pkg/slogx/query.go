@@ -0,0 +1,42 @@
+package slogx
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type queryStatsKey struct{}
+
+// QueryStats counts the database calls made on behalf of one request and
+// the time spent in them. It is safe for concurrent use.
+type QueryStats struct {
+	calls atomic.Int64
+	nanos atomic.Int64
+}
+
+// WithQueryStats returns a context carrying a new QueryStats, which
+// RecordQuery adds to.
+func WithQueryStats(ctx context.Context) (context.Context, *QueryStats) {
+	stats := &QueryStats{}
+	return context.WithValue(ctx, queryStatsKey{}, stats), stats
+}
+
+// RecordQuery counts a database call that took d against the QueryStats
+// in ctx, if any.
+func RecordQuery(ctx context.Context, d time.Duration) {
+	if stats, ok := ctx.Value(queryStatsKey{}).(*QueryStats); ok {
+		stats.calls.Add(1)
+		stats.nanos.Add(int64(d))
+	}
+}
+
+// Calls returns the number of database calls recorded.
+func (s *QueryStats) Calls() int64 {
+	return s.calls.Load()
+}
+
+// Duration returns the total time spent in the recorded calls.
+func (s *QueryStats) Duration() time.Duration {
+	return time.Duration(s.nanos.Load())
+}
@@ -0,0 +1,103 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue replaces the value of redacted attributes.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactKeys are attribute keys that commonly hold secrets or PII.
+var DefaultRedactKeys = []string{
+	"secret",
+	"client_secret",
+	"password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+	"cookie",
+	"email",
+}
+
+// Redactor is a slog.Handler that masks the values of attributes whose key
+// matches a configured key before passing records to the wrapped handler.
+// A key matches if it equals a configured key or ends with "_" followed by
+// one, case-insensitively, so "discord_client_secret" matches
+// "client_secret". Attributes inside groups are matched on their own key.
+type Redactor struct {
+	next slog.Handler
+	keys map[string]struct{}
+}
+
+// NewRedactor wraps next so that attributes matching keys are redacted.
+func NewRedactor(next slog.Handler, keys ...string) *Redactor {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return &Redactor{next: next, keys: set}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *Redactor) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle redacts the record's attributes and passes it on.
+func (h *Redactor) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.redact(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+// WithAttrs redacts attrs before they are bound to the wrapped handler.
+func (h *Redactor) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redact(a)
+	}
+	return &Redactor{next: h.next.WithAttrs(redacted), keys: h.keys}
+}
+
+// WithGroup returns a Redactor wrapping the grouped handler.
+func (h *Redactor) WithGroup(name string) slog.Handler {
+	return &Redactor{next: h.next.WithGroup(name), keys: h.keys}
+}
+
+// redact masks a if its key matches, recursing into groups.
+func (h *Redactor) redact(a slog.Attr) slog.Attr {
+	if h.matches(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+
+	group := a.Value.Group()
+	redacted := make([]slog.Attr, len(group))
+	for i, ga := range group {
+		redacted[i] = h.redact(ga)
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+}
+
+func (h *Redactor) matches(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := h.keys[key]; ok {
+		return true
+	}
+	for k := range h.keys {
+		if strings.HasSuffix(key, "_"+k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package slogx
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     func(l *slog.Logger)
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "exact key",
+			log:     func(l *slog.Logger) { l.Info("msg", slog.String("token", "abc123")) },
+			want:    []string{"token=" + RedactedValue},
+			notWant: []string{"abc123"},
+		},
+		{
+			name:    "suffix key",
+			log:     func(l *slog.Logger) { l.Info("msg", slog.String("discord_client_secret", "shh")) },
+			want:    []string{"discord_client_secret=" + RedactedValue},
+			notWant: []string{"shh"},
+		},
+		{
+			name:    "case insensitive",
+			log:     func(l *slog.Logger) { l.Info("msg", slog.String("Email", "a@b.c")) },
+			notWant: []string{"a@b.c"},
+		},
+		{
+			name: "nested group",
+			log: func(l *slog.Logger) {
+				l.Info("msg", slog.Group("user", slog.String("email", "a@b.c"), slog.Int("id", 7)))
+			},
+			want:    []string{"user.email=" + RedactedValue, "user.id=7"},
+			notWant: []string{"a@b.c"},
+		},
+		{
+			name:    "bound attrs",
+			log:     func(l *slog.Logger) { l.With(slog.String("password", "hunter2")).Info("msg") },
+			want:    []string{"password=" + RedactedValue},
+			notWant: []string{"hunter2"},
+		},
+		{
+			name: "unrelated keys untouched",
+			log:  func(l *slog.Logger) { l.Info("msg", slog.String("tokens_used", "5"), slog.Int64("game_id", 42)) },
+			want: []string{"tokens_used=5", "game_id=42"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(WithOutput(&buf), WithRedactKeys(DefaultRedactKeys...))
+
+			tt.log(logger)
+
+			out := buf.String()
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("expected %q in output: %s", w, out)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(out, nw) {
+					t.Errorf("expected %q to be redacted: %s", nw, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package slogx
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// RotateConfig configures a RotatingFile.
+type RotateConfig struct {
+	// Path is the active log file. Backups are written alongside it as
+	// Path.1, Path.2, ... (newest first), with a .gz suffix when compressed.
+	Path string
+
+	// MaxSize is the size in bytes at which the file is rotated.
+	MaxSize int64
+
+	// MaxBackups is the number of rotated files to keep. Zero keeps none.
+	MaxBackups int
+
+	// Compress gzips rotated files.
+	Compress bool
+}
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it
+// once it reaches MaxSize. It is safe for concurrent use. Rotated files are
+// compressed in the background, so writes don't wait for it.
+type RotatingFile struct {
+	cfg RotateConfig
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+
+	// compressMu is held while backups are compressed or shifted, so a
+	// rotation never renames a backup out from under its compression.
+	compressMu  sync.Mutex
+	compressing sync.WaitGroup
+}
+
+// NewRotatingFile opens (or creates) the log file for appending.
+func NewRotatingFile(cfg RotateConfig) (*RotatingFile, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("log file path is required")
+	}
+	if cfg.MaxSize <= 0 {
+		return nil, fmt.Errorf("max size must be positive, got %d", cfg.MaxSize)
+	}
+
+	rf := &RotatingFile{cfg: cfg}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Write writes p to the current file, rotating first if p would push the
+// file past MaxSize. A single write larger than MaxSize is never split. If
+// rotation fails, p is still written to the active file, which is reopened
+// if need be, and the rotation error is returned.
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+	if rf.file != nil && rf.size > 0 && rf.size+int64(len(p)) > rf.cfg.MaxSize {
+		if err := rf.rotate(); err != nil {
+			rotateErr = fmt.Errorf("rotating log file: %w", err)
+		}
+	}
+
+	// A failed reopen is retried on each write until one succeeds
+	if rf.file == nil {
+		if err := rf.open(); err != nil {
+			return 0, errors.Join(rotateErr, err)
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, errors.Join(rotateErr, err)
+}
+
+// Close closes the current file, waiting for any rotated file still being
+// compressed.
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	var err error
+	if rf.file != nil {
+		err = rf.file.Close()
+		rf.file = nil
+	}
+	rf.closed = true
+	rf.mu.Unlock()
+
+	rf.compressing.Wait()
+	return err
+}
+
+// open opens the active file for appending and records its current size.
+func (rf *RotatingFile) open() error {
+	f, err := os.OpenFile(rf.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	rf.file = f
+	rf.size = info.Size()
+	return nil
+}
+
+// rotate moves the active file aside and opens a fresh one. The active
+// file is reopened whatever happens, appending to the old one if it could
+// not be moved, so a failed rotation never stops logging.
+func (rf *RotatingFile) rotate() error {
+	closeErr := rf.file.Close()
+	rf.file = nil
+
+	var err error
+	if closeErr == nil {
+		err = rf.shift()
+	}
+	return errors.Join(closeErr, err, rf.open())
+}
+
+// shift shifts existing backups up by one, dropping the oldest, and moves
+// the active file to Path.1, compressing it in the background if
+// configured.
+func (rf *RotatingFile) shift() error {
+	if rf.cfg.MaxBackups == 0 {
+		if err := os.Remove(rf.cfg.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	// Waits only if the last rotated file is still being compressed
+	rf.compressMu.Lock()
+	defer rf.compressMu.Unlock()
+
+	for i := rf.cfg.MaxBackups; i >= 1; i-- {
+		for _, ext := range []string{"", ".gz"} {
+			src := rf.backupPath(i) + ext
+			var err error
+			if i == rf.cfg.MaxBackups {
+				err = os.Remove(src)
+			} else {
+				err = os.Rename(src, rf.backupPath(i+1)+ext)
+			}
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	if err := os.Rename(rf.cfg.Path, rf.backupPath(1)); err != nil {
+		return err
+	}
+
+	if rf.cfg.Compress {
+		rf.compressing.Go(rf.compressBackups)
+	}
+	return nil
+}
+
+// compressBackups gzips every backup not yet compressed, including any a
+// failed or overtaken earlier run left behind. Failures are reported on
+// stderr, as the log file is what is being rotated.
+func (rf *RotatingFile) compressBackups() {
+	rf.compressMu.Lock()
+	defer rf.compressMu.Unlock()
+
+	for i := 1; i <= rf.cfg.MaxBackups; i++ {
+		path := rf.backupPath(i)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := compressFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "slogx: compressing %s: %v\n", path, err)
+		}
+	}
+}
+
+func (rf *RotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", rf.cfg.Path, n)
+}
+
+// compressFile gzips path to path.gz and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path) //nolint:gosec // path is derived from the configured log file
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
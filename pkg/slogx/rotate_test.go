@@ -0,0 +1,147 @@
+package slogx
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taboo.log")
+
+	rf, err := NewRotatingFile(RotateConfig{Path: path, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error: %v", err)
+	}
+	defer rf.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+
+	want := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for p, content := range want {
+		got, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("reading %s: %v", p, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", filepath.Base(p), got, content)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected oldest backup to be removed, got %v", err)
+	}
+}
+
+func TestRotatingFile_Compress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taboo.log")
+
+	rf, err := NewRotatingFile(RotateConfig{Path: path, MaxSize: 10, MaxBackups: 1, Compress: true})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error: %v", err)
+	}
+
+	for _, line := range []string{"first\n", "second\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+
+	// Compression runs in the background; Close waits for it
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	f, err := os.Open(path + ".1.gz")
+	if err != nil {
+		t.Fatalf("opening compressed backup: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error: %v", err)
+	}
+	got, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("reading compressed backup: %v", err)
+	}
+	if string(got) != "first\n" {
+		t.Errorf("compressed backup = %q, want %q", got, "first\n")
+	}
+
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("expected uncompressed backup to be removed, got %v", err)
+	}
+}
+
+func TestRotatingFile_AppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taboo.log")
+	if err := os.WriteFile(path, []byte("existing\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rf, err := NewRotatingFile(RotateConfig{Path: path, MaxSize: 1024})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error: %v", err)
+	}
+
+	logger := New(WithOutput(rf))
+	logger.Info("hello")
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(got), "existing\n") || !strings.Contains(string(got), "msg=hello") {
+		t.Errorf("unexpected file contents: %q", got)
+	}
+}
+
+func TestRotatingFile_RotateFailureKeepsLogging(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taboo.log")
+
+	// A non-empty directory in place of the oldest backup can't be removed
+	if err := os.MkdirAll(filepath.Join(path+".2", "blocked"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	rf, err := NewRotatingFile(RotateConfig{Path: path, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() error: %v", err)
+	}
+	defer rf.Close()
+
+	if _, err := rf.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if _, err := rf.Write([]byte("second\n")); err == nil {
+		t.Error("expected the failed rotation to be reported")
+	}
+	if _, err := rf.Write([]byte("third\n")); err != nil && errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Write() after a failed rotation: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "first\nsecond\nthird\n" {
+		t.Errorf("log file = %q, want every line kept", data)
+	}
+}
@@ -0,0 +1,206 @@
+// Package ssetest provides utilities for testing Server-Sent Events handlers.
+//
+// A Recorder is an http.ResponseWriter that streams everything the handler
+// writes through a pipe, so a test can read events as they are sent while
+// the handler keeps running:
+//
+//	rec := ssetest.NewRecorder()
+//	defer rec.Close()
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+//	done := ssetest.Serve(handler, rec, req)
+//
+//	rec.WaitForHeaders()
+//	event, err := rec.Next()
+//
+//	cancel()
+//	<-done
+package ssetest
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned by Recorder.NextTimeout when no event arrives in
+// time.
+var ErrTimeout = errors.New("ssetest: timed out waiting for event")
+
+// Event is a single parsed SSE event.
+type Event struct {
+	Type string
+	Data string
+	ID   string
+}
+
+// ReadEvent reads the next event from r, skipping comments and blank
+// lines between events. Multiple data lines are joined with newlines.
+func ReadEvent(r *bufio.Reader) (Event, error) {
+	var (
+		event   Event
+		data    []string
+		started bool
+	)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return Event{}, err
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		line = strings.TrimSuffix(line, "\r")
+
+		if line == "" {
+			// Empty line marks end of event
+			if started {
+				event.Data = strings.Join(data, "\n")
+				return event, nil
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			event.ID = value
+		default:
+			continue
+		}
+		started = true
+	}
+}
+
+// Recorder implements http.ResponseWriter, http.Flusher and the deadline
+// methods used by http.ResponseController, piping the response body to a
+// reader for the test.
+type Recorder struct {
+	header http.Header
+	pr     *io.PipeReader
+	pw     *io.PipeWriter
+	reader *bufio.Reader
+
+	mu     sync.Mutex
+	status int
+
+	headersDone chan struct{} // closed when WriteHeader is called
+	headersOnce sync.Once
+}
+
+// NewRecorder creates a Recorder. Close it when the test is done to unblock
+// a handler still writing.
+func NewRecorder() *Recorder {
+	pr, pw := io.Pipe()
+	return &Recorder{
+		header:      make(http.Header),
+		pr:          pr,
+		pw:          pw,
+		reader:      bufio.NewReader(pr),
+		headersDone: make(chan struct{}),
+	}
+}
+
+// Header returns the response headers.
+func (r *Recorder) Header() http.Header {
+	return r.header
+}
+
+// Write writes to the pipe, blocking until the test reads it.
+func (r *Recorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.pw.Write(b)
+}
+
+// WriteHeader records the status code of the first call.
+func (r *Recorder) WriteHeader(status int) {
+	r.headersOnce.Do(func() {
+		r.mu.Lock()
+		r.status = status
+		r.mu.Unlock()
+		close(r.headersDone)
+	})
+}
+
+// Flush is a no-op; writes reach the pipe immediately.
+func (r *Recorder) Flush() {}
+
+// SetWriteDeadline implements the interface required by http.ResponseController.
+func (r *Recorder) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// SetReadDeadline implements the interface required by http.ResponseController.
+func (r *Recorder) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+// WaitForHeaders blocks until WriteHeader has been called. The headers are
+// safe to read once it returns.
+func (r *Recorder) WaitForHeaders() {
+	<-r.headersDone
+}
+
+// Status returns the status code written, or 0 if none has been.
+func (r *Recorder) Status() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Next reads the next event the handler sends.
+func (r *Recorder) Next() (Event, error) {
+	return ReadEvent(r.reader)
+}
+
+// NextTimeout is Next bounded by d, returning an error if no event arrives
+// in time. A read still pending when it times out is abandoned, so the
+// Recorder should not be read again afterwards.
+func (r *Recorder) NextTimeout(d time.Duration) (Event, error) {
+	type result struct {
+		event Event
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		event, err := r.Next()
+		ch <- result{event, err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.event, res.err
+	case <-time.After(d):
+		return Event{}, ErrTimeout
+	}
+}
+
+// Close closes the pipe, failing any pending or later writes by the
+// handler and reads by the test.
+func (r *Recorder) Close() error {
+	_ = r.pw.Close()
+	return r.pr.Close()
+}
+
+// Serve runs h on its own goroutine, returning a channel closed when it
+// returns. Cancel the request's context to stop a streaming handler.
+func Serve(h http.Handler, rec *Recorder, req *http.Request) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(rec, req)
+	}()
+	return done
+}
@@ -0,0 +1,79 @@
+package ssetest
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadEvent(t *testing.T) {
+	input := ": comment\n\n" +
+		"event: a\ndata: {\"n\":1}\n\n" +
+		"id: 7\nevent: b\ndata: line one\ndata: line two\r\n\n"
+	r := bufio.NewReader(strings.NewReader(input))
+
+	first, err := ReadEvent(r)
+	if err != nil {
+		t.Fatalf("ReadEvent() error: %v", err)
+	}
+	if first.Type != "a" || first.Data != `{"n":1}` {
+		t.Errorf("first = %+v", first)
+	}
+
+	second, err := ReadEvent(r)
+	if err != nil {
+		t.Fatalf("ReadEvent() error: %v", err)
+	}
+	if second.Type != "b" || second.ID != "7" || second.Data != "line one\nline two" {
+		t.Errorf("second = %+v", second)
+	}
+
+	if _, err := ReadEvent(r); !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "event: ping\ndata: {}\n\n")
+		<-r.Context().Done()
+	})
+
+	rec := NewRecorder()
+	defer rec.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	done := Serve(handler, rec, req)
+
+	rec.WaitForHeaders()
+	if rec.Status() != http.StatusOK {
+		t.Errorf("Status() = %d, want %d", rec.Status(), http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	event, err := rec.NextTimeout(time.Second)
+	if err != nil {
+		t.Fatalf("NextTimeout() error: %v", err)
+	}
+	if event.Type != "ping" {
+		t.Errorf("event.Type = %q, want ping", event.Type)
+	}
+
+	if _, err := rec.NextTimeout(20 * time.Millisecond); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+
+	cancel()
+	<-done
+}
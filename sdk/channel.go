@@ -19,7 +19,8 @@ func NewChannelHandler(buffer int) *ChannelHandler {
 }
 
 // Events returns a channel that receives all game events.
-// Events are one of: GameStateEvent, GamePickEvent, GameCompleteEvent, HeartbeatEvent.
+// Events are one of: GameStateEvent, GamePickEvent, GameCompleteEvent, HeartbeatEvent,
+// ServerNoticeEvent, StreamGapEvent.
 func (h *ChannelHandler) Events() <-chan any {
 	return h.events
 }
@@ -64,6 +65,22 @@ func (h *ChannelHandler) OnGameComplete(e GameCompleteEvent) {
 	}
 }
 
+// OnServerNotice implements NoticeHandler.
+func (h *ChannelHandler) OnServerNotice(e ServerNoticeEvent) {
+	select {
+	case h.events <- e:
+	default:
+	}
+}
+
+// OnStreamGap implements GapHandler.
+func (h *ChannelHandler) OnStreamGap(e StreamGapEvent) {
+	select {
+	case h.events <- e:
+	default:
+	}
+}
+
 func (h *ChannelHandler) OnHeartbeat() {
 	select {
 	case h.events <- HeartbeatEvent{}:
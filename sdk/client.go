@@ -11,23 +11,95 @@ import (
 	"time"
 )
 
+// defaultCallTimeout is the per-call timeout applied when none is configured.
+const defaultCallTimeout = 30 * time.Second
+
+// longPollWait is the longest the server holds a WaitForEvents request open
+// before answering with no events.
+const longPollWait = 25 * time.Second
+
+// envelopeHeader opts in to the standard response envelope.
+const envelopeHeader = "X-Taboo-Envelope"
+
+// NodeHeader carries the node ID of a taboo instance: servers set it on
+// every response, and clients created with [WithNode] send their own.
+const NodeHeader = "X-Taboo-Node"
+
 // Client is a REST client for the Taboo API.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	callTimeout time.Duration
+	envelope    bool
+	onMeta      func(Meta)
+	node        string
+	userAgent   string
+	onWarning   func(string)
 }
 
 // ClientOption configures the Client.
 type ClientOption func(*Client)
 
-// WithTimeout sets the HTTP client timeout.
+// WithCallTimeout sets the timeout applied to each API call (default 30s).
+// The timeout is derived from the context passed to the call, so an earlier
+// deadline on that context still wins. A value <= 0 disables the per-call
+// timeout, leaving cancellation entirely to the caller's context.
+//
+// [Client.WaitForEvents] is allowed the server's long-poll wait (25s) on top
+// of the timeout, so a quiet poll is not cut short by a timeout shorter than
+// the wait.
+func WithCallTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.callTimeout = d
+	}
+}
+
+// WithTimeout sets the per-call timeout.
+//
+// Deprecated: Use [WithCallTimeout]. WithTimeout no longer sets
+// http.Client.Timeout, which would also cap long-lived streaming calls.
 func WithTimeout(d time.Duration) ClientOption {
+	return WithCallTimeout(d)
+}
+
+// WithEnvelope requests the standard response envelope from the server and
+// unwraps it transparently. If onMeta is non-nil it is called with the
+// response metadata (request ID, pagination) of every successful call.
+func WithEnvelope(onMeta func(Meta)) ClientOption {
+	return func(c *Client) {
+		c.envelope = true
+		c.onMeta = onMeta
+	}
+}
+
+// WithNode sends id as the client's node ID on every request, for taboo
+// instances such as mirrors, so the server lists them as cluster peers.
+func WithNode(id string) ClientOption {
 	return func(c *Client) {
-		c.httpClient.Timeout = d
+		c.node = id
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client.
+// WithUserAgent sends ua as the User-Agent instead of [DefaultUserAgent],
+// e.g. to name the bot or app making the calls.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithWarningHandler calls fn with each warning the server sends, about a
+// deprecated parameter or behavior about to change, so integrators hear of
+// API changes before they break. Warnings are sent on successful and
+// failed calls alike.
+func WithWarningHandler(fn func(warning string)) ClientOption {
+	return func(c *Client) {
+		c.onWarning = fn
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client. Prefer [WithCallTimeout] over
+// setting http.Client.Timeout on the provided client.
 func WithHTTPClient(hc *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = hc
@@ -38,10 +110,10 @@ func WithHTTPClient(hc *http.Client) ClientOption {
 func NewClient(baseURL string, opts ...ClientOption) *Client {
 	baseURL = strings.TrimSuffix(baseURL, "/")
 	c := &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:     baseURL,
+		httpClient:  &http.Client{},
+		callTimeout: defaultCallTimeout,
+		userAgent:   DefaultUserAgent(),
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -53,6 +125,9 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 type ListGamesOptions struct {
 	Cursor *int64
 	Limit  *int
+
+	// Season restricts the listing to a single season's games.
+	Season *int64
 }
 
 // ListGames retrieves a paginated list of games.
@@ -70,64 +145,224 @@ func (c *Client) ListGames(ctx context.Context, opts *ListGamesOptions) (*GameLi
 		if opts.Limit != nil {
 			q.Set("limit", strconv.Itoa(*opts.Limit))
 		}
+		if opts.Season != nil {
+			q.Set("season", strconv.FormatInt(*opts.Season, 10))
+		}
 	}
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+	var result GameListResponse
+	if err := c.get(ctx, u.String(), &result); err != nil {
+		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+	return &result, nil
+}
+
+// GetGame retrieves a single game by ID.
+func (c *Client) GetGame(ctx context.Context, id int64) (*Game, error) {
+	u := fmt.Sprintf("%s/api/v1/games/%d", c.baseURL, id)
+
+	var game Game
+	if err := c.get(ctx, u, &game); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseError(resp)
+	return &game, nil
+}
+
+// GetLatestGame retrieves the most recent game.
+func (c *Client) GetLatestGame(ctx context.Context) (*Game, error) {
+	var game Game
+	if err := c.get(ctx, c.baseURL+"/api/v1/games/latest", &game); err != nil {
+		return nil, err
 	}
 
-	var result GameListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
+	return &game, nil
+}
+
+// ListSeasons retrieves all seasons, oldest first.
+func (c *Client) ListSeasons(ctx context.Context) (*SeasonListResponse, error) {
+	var result SeasonListResponse
+	if err := c.get(ctx, c.baseURL+"/api/v1/seasons", &result); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
-// GetGame retrieves a single game by ID.
-func (c *Client) GetGame(ctx context.Context, id int64) (*Game, error) {
-	u := fmt.Sprintf("%s/api/v1/games/%d", c.baseURL, id)
+// GetSeason retrieves a single season by ID.
+func (c *Client) GetSeason(ctx context.Context, id int64) (*Season, error) {
+	u := fmt.Sprintf("%s/api/v1/seasons/%d", c.baseURL, id)
+
+	var season Season
+	if err := c.get(ctx, u, &season); err != nil {
+		return nil, err
+	}
+
+	return &season, nil
+}
+
+// GetCurrentSeason retrieves the season currently in progress.
+func (c *Client) GetCurrentSeason(ctx context.Context) (*Season, error) {
+	var season Season
+	if err := c.get(ctx, c.baseURL+"/api/v1/seasons/current", &season); err != nil {
+		return nil, err
+	}
+
+	return &season, nil
+}
+
+// GetPacing retrieves the timing of each game cycle.
+func (c *Client) GetPacing(ctx context.Context) (*PacingResponse, error) {
+	var pacing PacingResponse
+	if err := c.get(ctx, c.baseURL+"/api/v1/config/pacing", &pacing); err != nil {
+		return nil, err
+	}
+
+	return &pacing, nil
+}
+
+// GetOdds retrieves the odds of a ticket marking spots numbers, with pick
+// frequencies over recent games. A games <= 0 uses the server's default
+// window.
+func (c *Client) GetOdds(ctx context.Context, spots, games int) (*OddsResponse, error) {
+	u := fmt.Sprintf("%s/api/v1/stats/odds?spots=%d", c.baseURL, spots)
+	if games > 0 {
+		u += "&games=" + strconv.Itoa(games)
+	}
+
+	var odds OddsResponse
+	if err := c.get(ctx, u, &odds); err != nil {
+		return nil, err
+	}
+
+	return &odds, nil
+}
+
+// SyncGames retrieves up to limit completed games with IDs greater than
+// afterID, for mirrors replicating history. A limit <= 0 uses the server's
+// default batch size.
+func (c *Client) SyncGames(ctx context.Context, afterID int64, limit int) (*SyncGamesResponse, error) {
+	u := fmt.Sprintf("%s/api/v1/sync/games?after_id=%d", c.baseURL, afterID)
+	if limit > 0 {
+		u += "&limit=" + strconv.Itoa(limit)
+	}
+
+	var result SyncGamesResponse
+	if err := c.get(ctx, u, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// WaitForEvents long-polls for game events with a sequence number greater
+// than sinceSeq. It returns as soon as events are available, or with an empty
+// Events slice when the server-side wait times out. Pass the returned LastSeq
+// as sinceSeq on the next call. Copies of renamed events sent under their
+// old names are removed, so each event appears once.
+//
+// This is a fallback for environments where streaming is blocked; prefer
+// [SSEClient] where possible.
+func (c *Client) WaitForEvents(ctx context.Context, sinceSeq int64) (*WaitEventsResponse, error) {
+	u := fmt.Sprintf("%s/api/v1/games/current/wait?since_seq=%d", c.baseURL, sinceSeq)
+
+	// The server may hold the request for its whole wait before answering
+	timeout := c.callTimeout
+	if timeout > 0 {
+		timeout += longPollWait
+	}
+
+	var result WaitEventsResponse
+	if err := c.getWithin(ctx, timeout, u, &result); err != nil {
+		return nil, err
+	}
+
+	events := make([]EventEnvelope, 0, len(result.Events))
+	for _, e := range result.Events {
+		if n := len(events); n > 0 && e.Seq == events[n-1].Seq && isLegacyCopy(events[n-1].Type, e.Type) {
+			continue
+		}
+		events = append(events, e)
+	}
+	result.Events = events
+
+	return &result, nil
+}
+
+// get performs a GET request bounded by the per-call timeout and decodes a
+// successful JSON response into v.
+func (c *Client) get(ctx context.Context, u string, v any) error {
+	return c.getWithin(ctx, c.callTimeout, u, v)
+}
+
+// getWithin is get with its own timeout in place of the per-call timeout.
+// A timeout <= 0 leaves cancellation to ctx.
+func (c *Client) getWithin(ctx context.Context, timeout time.Duration, u string, v any) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.envelope {
+		req.Header.Set(envelopeHeader, "1")
+	}
+	if c.node != "" {
+		req.Header.Set(NodeHeader, c.node)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if c.onWarning != nil {
+		for _, warning := range Warnings(resp.Header) {
+			c.onWarning(warning)
+		}
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseError(resp)
+		return c.parseError(resp)
 	}
 
-	var game Game
-	if err := json.NewDecoder(resp.Body).Decode(&game); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
+	if !c.envelope {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+		return nil
 	}
 
-	return &game, nil
+	var env Envelope[json.RawMessage]
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if err := json.Unmarshal(env.Data, v); err != nil {
+		return fmt.Errorf("decoding response data: %w", err)
+	}
+	if c.onMeta != nil {
+		c.onMeta(env.Meta)
+	}
+
+	return nil
 }
 
-// APIError represents an error response from the API.
+// APIError represents an error response from the API. Param names the
+// query parameter the server rejected, if any.
 type APIError struct {
 	StatusCode int
 	Code       string
 	Message    string
+	Param      string
+	RequestID  string
 }
 
 func (e *APIError) Error() string {
@@ -141,11 +376,14 @@ func (c *Client) parseError(resp *http.Response) error {
 			StatusCode: resp.StatusCode,
 			Code:       "unknown",
 			Message:    fmt.Sprintf("HTTP %d", resp.StatusCode),
+			RequestID:  resp.Header.Get("X-Request-ID"),
 		}
 	}
 	return &APIError{
 		StatusCode: resp.StatusCode,
 		Code:       errResp.Error.Code,
 		Message:    errResp.Error.Message,
+		Param:      errResp.Error.Param,
+		RequestID:  resp.Header.Get("X-Request-ID"),
 	}
 }
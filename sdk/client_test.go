@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -57,6 +58,9 @@ func TestClient_ListGames_WithOptions(t *testing.T) {
 		if limit != "50" {
 			t.Errorf("expected limit=50, got %s", limit)
 		}
+		if season := r.URL.Query().Get("season"); season != "3" {
+			t.Errorf("expected season=3, got %s", season)
+		}
 
 		resp := sdk.GameListResponse{Games: []sdk.Game{}}
 		w.Header().Set("Content-Type", "application/json")
@@ -68,6 +72,7 @@ func TestClient_ListGames_WithOptions(t *testing.T) {
 	_, err := client.ListGames(context.Background(), &sdk.ListGamesOptions{
 		Cursor: sdk.Ptr(int64(100)),
 		Limit:  sdk.Ptr(50),
+		Season: sdk.Ptr(int64(3)),
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -129,9 +134,329 @@ func TestClient_GetGame_NotFound(t *testing.T) {
 	}
 }
 
+func TestClient_SyncGames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sync/games" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("after_id"); got != "10" {
+			t.Errorf("after_id = %q, want 10", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "50" {
+			t.Errorf("limit = %q, want 50", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.SyncGamesResponse{
+			Games:   []sdk.Game{{ID: 11, Picks: sdk.Picks{1, 2, 3}}},
+			LastID:  11,
+			HasMore: true,
+		})
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(server.URL)
+	result, err := client.SyncGames(context.Background(), 10, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Games) != 1 || result.LastID != 11 || !result.HasMore {
+		t.Errorf("unexpected response: %+v", result)
+	}
+}
+
+func TestGame_VerifyHash(t *testing.T) {
+	game := sdk.Game{
+		ID:         1,
+		Picks:      sdk.Picks{1, 2, 3},
+		ResultHash: "43011b9e0c61d894e3a986006bac5c2ec4fa3bcb932b595f2a94c8ed7b959381",
+	}
+	if !game.VerifyHash() {
+		t.Error("expected hash to verify")
+	}
+
+	game.Picks[2] = 4
+	if game.VerifyHash() {
+		t.Error("expected tampered picks to fail verification")
+	}
+}
+
 func TestClient_WithTimeout(t *testing.T) {
 	client := sdk.NewClient("http://localhost", sdk.WithTimeout(5*time.Second))
 	if client == nil {
 		t.Fatal("expected client, got nil")
 	}
 }
+
+func TestClient_WaitForEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/games/current/wait" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("since_seq"); got != "5" {
+			t.Errorf("expected since_seq=5, got %s", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.WaitEventsResponse{
+			Events: []sdk.EventEnvelope{
+				{Seq: 6, Type: sdk.EventGamePick, Data: json.RawMessage(`{"pick":42}`)},
+			},
+			LastSeq: 6,
+		})
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(server.URL)
+	resp, err := client.WaitForEvents(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.LastSeq != 6 {
+		t.Errorf("expected last_seq 6, got %d", resp.LastSeq)
+	}
+	if len(resp.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(resp.Events))
+	}
+
+	event, err := resp.Events[0].Decode()
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if pick, ok := event.(sdk.GamePickEvent); !ok || pick.Pick != 42 {
+		t.Errorf("expected GamePickEvent{Pick: 42}, got %#v", event)
+	}
+}
+
+func TestClient_WaitForEvents_LegacyNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.WaitEventsResponse{
+			Events: []sdk.EventEnvelope{
+				// A renamed event and its legacy copy, then an older server's
+				// event sent only under the legacy name
+				{Seq: 6, Type: sdk.EventSysHeartbeat, Data: json.RawMessage(`{}`)},
+				{Seq: 6, Type: "game:heartbeat", Data: json.RawMessage(`{}`)},
+				{Seq: 7, Type: "game:heartbeat", Data: json.RawMessage(`{}`)},
+			},
+			LastSeq: 7,
+		})
+	}))
+	defer server.Close()
+
+	resp, err := sdk.NewClient(server.URL).WaitForEvents(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Events) != 2 || resp.Events[0].Seq != 6 || resp.Events[1].Seq != 7 {
+		t.Fatalf("expected one event each for seq 6 and 7, got %+v", resp.Events)
+	}
+	for _, e := range resp.Events {
+		if event, err := e.Decode(); err != nil || event != (sdk.HeartbeatEvent{}) {
+			t.Errorf("Decode(%s) = %#v, %v; want HeartbeatEvent", e.Type, event, err)
+		}
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		json.NewEncoder(w).Encode(sdk.Game{ID: 1})
+	}))
+	defer server.Close()
+
+	if _, err := sdk.NewClient(server.URL).GetGame(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(got, sdk.SDKName+"/") {
+		t.Errorf("expected default User-Agent %q, got %q", sdk.DefaultUserAgent(), got)
+	}
+
+	if _, err := sdk.NewClient(server.URL, sdk.WithUserAgent("results-bot/2.0")).GetGame(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "results-bot/2.0" {
+		t.Errorf("expected User-Agent results-bot/2.0, got %q", got)
+	}
+}
+
+func TestClient_WarningHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(sdk.WarningHeader, sdk.FormatWarning("season is deprecated"))
+		w.Header().Add(sdk.WarningHeader, `110 - "Response is Stale"`)
+		json.NewEncoder(w).Encode(sdk.Game{ID: 1})
+	}))
+	defer server.Close()
+
+	var got []string
+	client := sdk.NewClient(server.URL, sdk.WithWarningHandler(func(warning string) {
+		got = append(got, warning)
+	}))
+	if _, err := client.GetGame(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the server's own warnings are passed on
+	if len(got) != 1 || got[0] != "season is deprecated" {
+		t.Errorf("expected one warning, got %q", got)
+	}
+}
+
+func TestClient_WithCallTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := sdk.NewClient(server.URL, sdk.WithCallTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.GetGame(context.Background(), 1)
+	if err == nil {
+		t.Fatal("expected timeout error, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("call took %v, expected it to be bounded by the call timeout", elapsed)
+	}
+}
+
+func TestClient_WithCallTimeout_ContextDeadlineWins(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := sdk.NewClient(server.URL, sdk.WithCallTimeout(time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetGame(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_WithCallTimeout_WaitForEventsOutlastsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A quiet long-poll held past the client's call timeout
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.WaitEventsResponse{LastSeq: 5})
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(server.URL, sdk.WithCallTimeout(50*time.Millisecond))
+
+	resp, err := client.WaitForEvents(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("WaitForEvents failed: %v", err)
+	}
+	if resp.LastSeq != 5 || len(resp.Events) != 0 {
+		t.Errorf("expected an empty poll at seq 5, got %+v", resp)
+	}
+}
+
+func TestClient_GetLatestGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/games/latest" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.Game{ID: 99, Picks: sdk.Picks{4, 5, 6}, CreatedAt: time.Now()})
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(server.URL)
+	game, err := client.GetLatestGame(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if game.ID != 99 {
+		t.Errorf("expected game ID 99, got %d", game.ID)
+	}
+}
+
+func TestClient_WithEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Taboo-Envelope") != "1" {
+			t.Errorf("expected envelope header, got %q", r.Header.Get("X-Taboo-Envelope"))
+		}
+
+		next := int64(20)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.Envelope[sdk.GameListResponse]{
+			Data: sdk.GameListResponse{Games: []sdk.Game{{ID: 1}}, NextCursor: &next},
+			Meta: sdk.Meta{
+				RequestID:  "req-1",
+				Pagination: &sdk.Pagination{Limit: 20, NextCursor: &next},
+			},
+		})
+	}))
+	defer server.Close()
+
+	var meta sdk.Meta
+	client := sdk.NewClient(server.URL, sdk.WithEnvelope(func(m sdk.Meta) { meta = m }))
+
+	resp, err := client.ListGames(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Games) != 1 || resp.Games[0].ID != 1 {
+		t.Errorf("unexpected games: %+v", resp.Games)
+	}
+	if meta.RequestID != "req-1" {
+		t.Errorf("expected request ID req-1, got %q", meta.RequestID)
+	}
+	if meta.Pagination == nil || meta.Pagination.Limit != 20 {
+		t.Errorf("unexpected pagination: %+v", meta.Pagination)
+	}
+}
+
+func TestClient_ListSeasons(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/seasons" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sdk.SeasonListResponse{
+			Seasons: []sdk.Season{
+				{ID: 1, Name: "Season 1", FirstGameID: 1, EndGameID: 50},
+				{ID: 2, Name: "Season 2", FirstGameID: 50, Current: true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(server.URL)
+	resp, err := client.ListSeasons(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Seasons) != 2 {
+		t.Fatalf("expected 2 seasons, got %d", len(resp.Seasons))
+	}
+	if !resp.Seasons[1].Current || resp.Seasons[0].EndGameID != 50 {
+		t.Errorf("unexpected seasons: %+v", resp.Seasons)
+	}
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ClientHeader identifies the SDK or app opening an event stream and the
+// event names it understands, in the form ClientInfo.String writes.
+const ClientHeader = "X-Taboo-Client"
+
+// EventsVersion is the version of the event names this SDK understands.
+// Version 2 renamed game:heartbeat to sys:heartbeat; a client declaring it
+// is not sent copies of events under their legacy names.
+const EventsVersion = 2
+
+// SDKName is the client name this SDK identifies itself by.
+const SDKName = "taboo-go"
+
+// modulePath is the module the SDK is released in, whose version the SDK
+// reports.
+const modulePath = "github.com/aussiebroadwan/taboo"
+
+// sdkVersion returns the version of the SDK module built into the binary,
+// or "devel" when it isn't known, e.g. in a build from a checkout.
+var sdkVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	version := info.Main.Version
+	if info.Main.Path != modulePath {
+		version = ""
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+				break
+			}
+		}
+	}
+	if version == "" || version == "(devel)" {
+		return "devel"
+	}
+	return version
+})
+
+// DefaultClientInfo returns the ClientInfo the SDK's clients send: the SDK
+// name and version, and the EventsVersion it understands.
+func DefaultClientInfo() ClientInfo {
+	return ClientInfo{Name: SDKName, Version: sdkVersion(), Events: EventsVersion}
+}
+
+// DefaultUserAgent returns the User-Agent the SDK's clients send, e.g.
+// "taboo-go/v1.4.0 (go1.26.0; linux/amd64)", so servers can tell bots
+// built on the SDK apart from browsers.
+func DefaultUserAgent() string {
+	return fmt.Sprintf("%s/%s (%s; %s/%s)", SDKName, sdkVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// maxClientInfoLen bounds the ClientHeader value a server accepts.
+const maxClientInfoLen = 128
+
+// ClientInfo is the capability handshake a client sends in ClientHeader.
+// Events is zero if the client didn't say which event names it
+// understands.
+type ClientInfo struct {
+	Name    string
+	Version string
+	Events  int
+}
+
+// String formats the info as "<name>/<version>; events=<n>", leaving out
+// the version and events when they are unset.
+func (c ClientInfo) String() string {
+	s := c.Name
+	if c.Version != "" {
+		s += "/" + c.Version
+	}
+	if c.Events > 0 {
+		s += "; events=" + strconv.Itoa(c.Events)
+	}
+	return s
+}
+
+// ParseClientInfo parses a ClientHeader value. An empty value returns the
+// zero ClientInfo; parameters other than events are ignored, so newer
+// clients can send more.
+func ParseClientInfo(s string) (ClientInfo, error) {
+	var info ClientInfo
+	if s == "" {
+		return info, nil
+	}
+	if len(s) > maxClientInfoLen {
+		return info, fmt.Errorf("longer than %d bytes", maxClientInfoLen)
+	}
+
+	product, params, _ := strings.Cut(s, ";")
+	info.Name, info.Version, _ = strings.Cut(strings.TrimSpace(product), "/")
+	if info.Name == "" || strings.ContainsAny(info.Name, " \t") || strings.ContainsAny(info.Version, " \t") {
+		return ClientInfo{}, fmt.Errorf("expected <name>/<version>, got %q", product)
+	}
+
+	for param := range strings.SplitSeq(params, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if key != "events" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return ClientInfo{}, fmt.Errorf("events must be a positive integer, got %q", value)
+		}
+		info.Events = n
+	}
+	return info, nil
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import "testing"
+
+func TestParseClientInfo(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    ClientInfo
+		wantErr bool
+	}{
+		{"", ClientInfo{}, false},
+		{"taboo-go/1.4.0; events=2", ClientInfo{Name: "taboo-go", Version: "1.4.0", Events: 2}, false},
+		{"ticker/0.1;events=1;lang=en", ClientInfo{Name: "ticker", Version: "0.1", Events: 1}, false},
+		{"overlay", ClientInfo{Name: "overlay"}, false},
+		{"/1.0", ClientInfo{}, true},
+		{"my bot/1.0", ClientInfo{}, true},
+		{"taboo-go/1.4.0; events=latest", ClientInfo{}, true},
+		{"taboo-go/1.4.0; events=0", ClientInfo{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClientInfo(tt.header)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseClientInfo(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseClientInfo(%q) = %+v, want %+v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestClientInfo_String_RoundTrips(t *testing.T) {
+	info := ClientInfo{Name: "taboo-go", Version: "1.4.0", Events: EventsVersion}
+	got, err := ParseClientInfo(info.String())
+	if err != nil || got != info {
+		t.Errorf("ParseClientInfo(%q) = %+v, %v; want %+v", info.String(), got, err, info)
+	}
+}
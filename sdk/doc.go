@@ -5,7 +5,7 @@
 // Use [Client] to interact with the REST API:
 //
 //	client := sdk.NewClient("http://localhost:8080",
-//	    sdk.WithTimeout(10*time.Second),
+//	    sdk.WithCallTimeout(10*time.Second),
 //	)
 //
 //	// List games with pagination
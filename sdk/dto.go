@@ -1,60 +1,394 @@
 package sdk
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
+	"strconv"
 	"time"
 )
 
-// Picks is a slice of uint8 that marshals to a JSON array of integers
-// instead of base64 (which is the default for []byte/[]uint8).
-type Picks []uint8
-
-// MarshalJSON implements json.Marshaler.
-func (p Picks) MarshalJSON() ([]byte, error) {
-	ints := make([]int, len(p))
-	for i, v := range p {
-		ints[i] = int(v)
-	}
-	return json.Marshal(ints)
-}
-
-// UnmarshalJSON implements json.Unmarshaler.
-func (p *Picks) UnmarshalJSON(data []byte) error {
-	var ints []int
-	if err := json.Unmarshal(data, &ints); err != nil {
-		return err
-	}
-	*p = make(Picks, len(ints))
-	for i, v := range ints {
-		if v < 0 || v > 255 {
-			return fmt.Errorf("pick value %d out of uint8 range at index %d", v, i)
-		}
-		(*p)[i] = uint8(v) //nolint:gosec // bounds checked above
-	}
-	return nil
-}
-
 // Game represents a game in API responses.
 type Game struct {
 	ID        int64     `json:"id"`
 	Picks     Picks     `json:"picks"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// ResultHash is the hex SHA-256 of "<id>:<pick>,<pick>,..." with picks
+	// in draw order. See VerifyHash.
+	ResultHash string `json:"result_hash"`
+}
+
+// MarshalJSON implements json.Marshaler, writing CreatedAt in TimeFormat.
+func (g Game) MarshalJSON() ([]byte, error) {
+	type game Game
+	return json.Marshal(struct {
+		game
+		CreatedAt timestamp `json:"created_at"`
+	}{game(g), timestamp(g.CreatedAt)})
 }
 
-// GameListResponse is the response for listing games.
+// VerifyHash reports whether ResultHash matches the game's ID and picks.
+// Mirrors can compare hashes to detect tampered or diverged results.
+func (g Game) VerifyHash() bool {
+	b := strconv.AppendInt(nil, g.ID, 10)
+	b = append(b, ':')
+	for i, pick := range g.Picks {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendUint(b, uint64(pick), 10)
+	}
+	sum := sha256.Sum256(b)
+	return g.ResultHash == hex.EncodeToString(sum[:])
+}
+
+// GameListResponse is the response for listing games. Season is the season
+// the listing was filtered to, left out when it lists across all seasons.
 type GameListResponse struct {
 	Games      []Game `json:"games"`
+	Season     int64  `json:"season,omitempty"`
 	NextCursor *int64 `json:"next_cursor,omitempty"`
 }
 
+// SyncGamesResponse is a batch of completed games for replicating history,
+// oldest first. LastID should be passed as after_id on the next request;
+// HasMore reports whether more games are already available.
+type SyncGamesResponse struct {
+	Games   []Game `json:"games"`
+	LastID  int64  `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+}
+
+// ServiceDescriptor is returned from / by API-only servers built without
+// the frontend, listing the endpoints they serve.
+type ServiceDescriptor struct {
+	Name      string     `json:"name"`
+	Frontend  bool       `json:"frontend"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint describes an HTTP endpoint in a ServiceDescriptor. Auth is
+// "none", "bearer", or "hmac" for requests signed with a shared secret.
+type Endpoint struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Auth   string `json:"auth"`
+}
+
+// Season represents a community season in API responses. A season covers
+// every game from FirstGameID up to, but excluding, EndGameID; EndGameID is
+// omitted while the season is current.
+type Season struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	FirstGameID int64     `json:"first_game_id"`
+	EndGameID   int64     `json:"end_game_id,omitempty"`
+	Current     bool      `json:"current"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// MarshalJSON implements json.Marshaler, writing StartedAt in TimeFormat.
+func (s Season) MarshalJSON() ([]byte, error) {
+	type season Season
+	return json.Marshal(struct {
+		season
+		StartedAt timestamp `json:"started_at"`
+	}{season(s), timestamp(s.StartedAt)})
+}
+
+// SeasonListResponse is the response for listing seasons.
+type SeasonListResponse struct {
+	Seasons []Season `json:"seasons"`
+}
+
+// HintsRequest is the request body for replacing the UI hints sent with
+// game state events.
+type HintsRequest struct {
+	Hints map[string]string `json:"hints"`
+}
+
+// HintsResponse is the response for reading the current UI hints.
+type HintsResponse struct {
+	Hints map[string]string `json:"hints"`
+}
+
+// LogLevelRequest is the request body for changing the server's log level
+// or query logging while it runs. Omitted fields are left as they are.
+type LogLevelRequest struct {
+	Level      string `json:"level,omitempty"`
+	LogQueries *bool  `json:"log_queries,omitempty"`
+}
+
+// LogLevelResponse reports the server's log level, one of debug, info,
+// warn or error, and whether it logs each database query. Queries are
+// logged at debug, so are only written while Level is debug.
+type LogLevelResponse struct {
+	Level      string `json:"level"`
+	LogQueries bool   `json:"log_queries"`
+}
+
+// SpecialGame is a special event game waiting to run. The engine runs it
+// in place of the regular game due at StartsAt.
+type SpecialGame struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	StartsAt time.Time         `json:"starts_at"`
+	Branding map[string]string `json:"branding,omitempty"`
+	Payouts  map[string]string `json:"payouts,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing StartsAt in TimeFormat.
+func (g SpecialGame) MarshalJSON() ([]byte, error) {
+	type specialGame SpecialGame
+	return json.Marshal(struct {
+		specialGame
+		StartsAt timestamp `json:"starts_at"`
+	}{specialGame(g), timestamp(g.StartsAt)})
+}
+
+// ScheduleSpecialRequest is the request body for scheduling a special game.
+type ScheduleSpecialRequest struct {
+	Name     string            `json:"name"`
+	StartsAt time.Time         `json:"starts_at"`
+	Branding map[string]string `json:"branding,omitempty"`
+	Payouts  map[string]string `json:"payouts,omitempty"`
+}
+
+// PacingResponse describes the timing of every game cycle, so frontends
+// can plan animations and countdowns without duplicating the server's
+// timing. Each pick is revealed at its offset from the start of the game,
+// spaced by RevealCurve; the next game starts a wait after the draw ends.
+// Durations and offsets are in milliseconds.
+type PacingResponse struct {
+	PickCount       int     `json:"pick_count"`
+	DrawDurationMS  int64   `json:"draw_duration_ms"`
+	WaitDurationMS  int64   `json:"wait_duration_ms"`
+	RevealCurve     string  `json:"reveal_curve"`
+	RevealOffsetsMS []int64 `json:"reveal_offsets_ms"`
+}
+
+// OddsResponse is the response for GET /api/v1/stats/odds. HitOdds holds
+// the theoretical probability of an N-spot ticket matching each number of
+// picks, indexed by hits, given PickCount numbers drawn from a board of
+// BoardSize. Numbers holds how often each board number was drawn over the
+// last Games completed games, and Due the numbers gone longest undrawn,
+// longest first.
+type OddsResponse struct {
+	Spots     int               `json:"spots"`
+	BoardSize int               `json:"board_size"`
+	PickCount int               `json:"pick_count"`
+	HitOdds   []float64         `json:"hit_odds"`
+	Games     int               `json:"games"`
+	Numbers   []NumberFrequency `json:"numbers"`
+	Due       []NumberFrequency `json:"due"`
+}
+
+// NumberFrequency is how often a board number has been drawn: Drawn times,
+// the last Since games ago.
+type NumberFrequency struct {
+	Number uint8   `json:"number"`
+	Drawn  int     `json:"drawn"`
+	Rate   float64 `json:"rate"`
+	Since  int     `json:"since"`
+}
+
+// BroadcastNoticeRequest is the body for broadcasting an operator notice
+// to every connected client. Severity defaults to NoticeInfo, and
+// TTLSeconds to five minutes. Translations holds Message in other
+// languages, keyed by locale, e.g. "es".
+type BroadcastNoticeRequest struct {
+	Message      string            `json:"message"`
+	Severity     string            `json:"severity,omitempty"`
+	TTLSeconds   int64             `json:"ttl_seconds,omitempty"`
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+// SpecialGameListResponse is the response for listing scheduled special
+// games, soonest first.
+type SpecialGameListResponse struct {
+	Specials []SpecialGame `json:"specials"`
+}
+
+// ClusterResponse is the response for GET /api/v1/admin/cluster. The
+// answering node is the leader, running the game engine; its peers are the
+// mirrors replicating from it, as of their last sync request.
+type ClusterResponse struct {
+	Node   string        `json:"node"`
+	Leader string        `json:"leader"`
+	Peers  []ClusterPeer `json:"peers"`
+}
+
+// ClusterPeer is a mirror replicating from the leader. SyncedID is the
+// latest game it had been sent when last seen, and LagGames how many
+// completed games it was then behind.
+type ClusterPeer struct {
+	Node     string    `json:"node"`
+	LastSeen time.Time `json:"last_seen"`
+	SyncedID int64     `json:"synced_id"`
+	LagGames int64     `json:"lag_games"`
+}
+
+// MarshalJSON implements json.Marshaler, writing LastSeen in TimeFormat.
+func (p ClusterPeer) MarshalJSON() ([]byte, error) {
+	type clusterPeer ClusterPeer
+	return json.Marshal(struct {
+		clusterPeer
+		LastSeen timestamp `json:"last_seen"`
+	}{clusterPeer(p), timestamp(p.LastSeen)})
+}
+
+// Setting is a single runtime setting and its JSON value.
+type Setting struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// SettingsResponse is the response for listing runtime settings.
+type SettingsResponse struct {
+	Settings map[string]json.RawMessage `json:"settings"`
+}
+
+// DBStatsResponse is the response for GET /api/v1/admin/db/stats. Sizes
+// are in bytes; the maintenance times are omitted if it has never run.
+type DBStatsResponse struct {
+	FileSize   int64            `json:"file_size"`
+	WALSize    int64            `json:"wal_size"`
+	FreeSize   int64            `json:"free_size"`
+	Rows       map[string]int64 `json:"rows"`
+	LastVacuum *time.Time       `json:"last_vacuum,omitempty"`
+	LastBackup *time.Time       `json:"last_backup,omitempty"`
+
+	// Shadow is set when writes are mirrored to a shadow database.
+	Shadow *ShadowStats `json:"shadow,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing the maintenance times in
+// TimeFormat.
+func (r DBStatsResponse) MarshalJSON() ([]byte, error) {
+	type dbStats DBStatsResponse
+	return json.Marshal(struct {
+		dbStats
+		LastVacuum *timestamp `json:"last_vacuum,omitempty"`
+		LastBackup *timestamp `json:"last_backup,omitempty"`
+	}{dbStats(r), optionalTimestamp(r.LastVacuum), optionalTimestamp(r.LastBackup)})
+}
+
+// ShadowStats counts how the shadow database has tracked the primary since
+// the server started.
+type ShadowStats struct {
+	Compared    int64 `json:"compared"`
+	Diverged    int64 `json:"diverged"`
+	Skipped     int64 `json:"skipped"`
+	WriteErrors int64 `json:"write_errors"`
+	ReadErrors  int64 `json:"read_errors"`
+}
+
+// UsageResponse is the response for GET /api/v1/admin/usage: the heaviest
+// clients and routes by request count since Since.
+type UsageResponse struct {
+	Since    time.Time     `json:"since"`
+	Requests int64         `json:"requests"`
+	Clients  []ClientUsage `json:"clients"`
+	Routes   []RouteUsage  `json:"routes"`
+	Streams  StreamUsage   `json:"streams"`
+}
+
+// MarshalJSON implements json.Marshaler, writing Since in TimeFormat.
+func (r UsageResponse) MarshalJSON() ([]byte, error) {
+	type usage UsageResponse
+	return json.Marshal(struct {
+		usage
+		Since timestamp `json:"since"`
+	}{usage(r), timestamp(r.Since)})
+}
+
+// StreamUsage counts the clients receiving game events and deliveries to
+// them. Viewers is keyed by transport, e.g. "sse" or "longpoll";
+// Subscribers also includes the server's own listeners. Dropped events
+// were not sent to a client that had fallen behind; Evicted clients were
+// disconnected for it and Rejected ones turned away at the stream limit.
+// Clients counts the event streams opened by each client name and version
+// sent in ClientHeader, with "unknown" for streams sent without it.
+type StreamUsage struct {
+	Viewers     map[string]int   `json:"viewers"`
+	Subscribers int              `json:"subscribers"`
+	Clients     map[string]int64 `json:"clients"`
+
+	Delivered int64 `json:"delivered"`
+	Dropped   int64 `json:"dropped"`
+	Evicted   int64 `json:"evicted"`
+	Rejected  int64 `json:"rejected"`
+}
+
+// ClientUsage is one client's usage, identified by IP. StreamSeconds is the
+// total time its event streams stayed connected.
+type ClientUsage struct {
+	Client        string  `json:"client"`
+	Requests      int64   `json:"requests"`
+	Streams       int64   `json:"streams"`
+	StreamSeconds float64 `json:"stream_seconds"`
+}
+
+// RouteUsage is the request count of one route pattern.
+type RouteUsage struct {
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+}
+
+// RollSeasonRequest is the request body for starting a new season.
+type RollSeasonRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// IngestGameRequest is the body a results provider pushes to
+// POST /api/v1/ingest/games. It must be signed with [SignIngest].
+type IngestGameRequest struct {
+	// Ref identifies the result at the provider, for logs.
+	Ref   string `json:"ref,omitempty"`
+	Picks Picks  `json:"picks"`
+}
+
+// IngestGameResponse acknowledges a pushed result.
+type IngestGameResponse struct {
+	Ref string `json:"ref,omitempty"`
+	// Queued is how many results are waiting to be drawn, including this one.
+	Queued int `json:"queued"`
+}
+
 // ErrorResponse is the standard error response format.
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
 
-// ErrorDetail contains error information.
+// ErrorDetail contains error information. Param names the query parameter
+// that was rejected, for errors caused by one.
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+}
+
+// Envelope is the standard response wrapper returned by v1 endpoints when the
+// client opts in with the X-Taboo-Envelope header. Data holds the endpoint's
+// regular response body.
+type Envelope[T any] struct {
+	Data T    `json:"data"`
+	Meta Meta `json:"meta"`
+}
+
+// Meta carries response metadata shared by all enveloped responses.
+type Meta struct {
+	RequestID  string      `json:"request_id,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+
+	// Warnings tell the client about deprecated parameters or behavior
+	// about to change, as sent in the response's Warning headers.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Pagination describes the position of a page within a paginated listing.
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor *int64 `json:"next_cursor,omitempty"`
 }
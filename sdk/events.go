@@ -1,31 +1,230 @@
 package sdk
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+)
 
 // SSE event type constants.
 const (
-	EventGameState     = "game:state"
-	EventGamePick      = "game:pick"
-	EventGameComplete  = "game:complete"
-	EventGameHeartbeat = "game:heartbeat"
+	EventGameState    = "game:state"
+	EventGamePick     = "game:pick"
+	EventGameComplete = "game:complete"
+	EventSysHeartbeat = "sys:heartbeat"
+
+	EventEngineDegraded = "engine:degraded"
+	EventServerNotice   = "server:notice"
+	EventStreamGap      = "stream:gap"
+)
+
+// Notice severities, from least to most urgent.
+const (
+	NoticeInfo     = "info"
+	NoticeWarning  = "warning"
+	NoticeCritical = "critical"
 )
 
+// EventGameHeartbeat is the name heartbeats were sent under before being
+// renamed to EventSysHeartbeat.
+//
+// Deprecated: Use EventSysHeartbeat.
+const EventGameHeartbeat = "game:heartbeat"
+
+// legacyEventTypes maps each renamed event type to the name it replaced.
+var legacyEventTypes = map[string]string{
+	EventSysHeartbeat: EventGameHeartbeat,
+}
+
+// LegacyEventTypes returns each renamed event type mapped to the name it
+// replaced. For a deprecation cycle after a rename, servers send each such
+// event twice: under its new name, then straight after under the old one.
+func LegacyEventTypes() map[string]string {
+	return maps.Clone(legacyEventTypes)
+}
+
+// CanonicalEventType returns the current name of an event type, translating
+// a name that has since been replaced.
+func CanonicalEventType(eventType string) string {
+	for current, legacy := range legacyEventTypes {
+		if eventType == legacy {
+			return current
+		}
+	}
+	return eventType
+}
+
+// isLegacyCopy reports whether an event of eventType is the copy a server
+// sends under the old name of a renamed event, immediately after prev.
+func isLegacyCopy(prev, eventType string) bool {
+	legacy, ok := legacyEventTypes[prev]
+	return ok && eventType == legacy
+}
+
 // GameStateEvent is sent when a new game starts or client connects.
 type GameStateEvent struct {
 	GameID   int64     `json:"game_id"`
+	Season   int64     `json:"season,omitempty"`
 	Picks    Picks     `json:"picks"`
 	NextGame time.Time `json:"next_game"`
+
+	// Hints are operator-set UI hints, such as "highlight": "7,21" or
+	// "banner": "summer-finals". Clients should ignore keys they do not
+	// recognise.
+	Hints map[string]string `json:"hints,omitempty"`
+
+	// Special is set when the game is run as a scheduled special event.
+	Special *SpecialGameInfo `json:"special,omitempty"`
+}
+
+// SpecialGameInfo describes the special event a game is run as. Branding
+// holds theming for frontends, such as "accent": "#EF8E3C", and Payouts
+// the custom payout or bonus settings for consumers that settle results,
+// such as "multiplier": "2". Clients should ignore keys they do not
+// recognise.
+type SpecialGameInfo struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Branding map[string]string `json:"branding,omitempty"`
+	Payouts  map[string]string `json:"payouts,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing NextGame in TimeFormat.
+func (e GameStateEvent) MarshalJSON() ([]byte, error) {
+	type stateEvent GameStateEvent
+	return json.Marshal(struct {
+		stateEvent
+		NextGame timestamp `json:"next_game"`
+	}{stateEvent(e), timestamp(e.NextGame)})
 }
 
-// GamePickEvent is sent when a new number is picked.
+// GamePickEvent is sent when a new number is picked. Pick lies within the
+// server's configured min_number..max_number range.
 type GamePickEvent struct {
 	Pick uint8 `json:"pick"`
+
+	// RevealedAt is when the server revealed the pick.
+	RevealedAt time.Time `json:"revealed_at"`
+
+	// NextRevealInMS is how long after RevealedAt the next pick is due, or
+	// 0 after the last pick of a game. Frontends should pace animations by
+	// it rather than assuming a constant interval.
+	NextRevealInMS int64 `json:"next_reveal_in_ms"`
+
+	// CallText is the pick spelled out in the server's call locale, e.g.
+	// "forty-two", for screen readers and text-to-speech, or in the
+	// client's Accept-Language where it is supported. It is omitted when
+	// the server has calls turned off.
+	CallText string `json:"call_text,omitempty"`
+
+	// CallAudio is the path, relative to the server, of a recorded call of
+	// the pick, e.g. "/api/v1/calls/42". It is omitted when the server's
+	// voice pack has no clip for the pick.
+	CallAudio string `json:"call_audio,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, writing RevealedAt in TimeFormat.
+func (e GamePickEvent) MarshalJSON() ([]byte, error) {
+	type pickEvent GamePickEvent
+	return json.Marshal(struct {
+		pickEvent
+		RevealedAt timestamp `json:"revealed_at"`
+	}{pickEvent(e), timestamp(e.RevealedAt)})
 }
 
-// GameCompleteEvent is sent when a game finishes.
+// GameCompleteEvent is sent when a game finishes. Variant is the game
+// played on the draw, e.g. "classic" or "heads_tails"; Outcome holds that
+// variant's result, such as "result": "heads", and is left out when the
+// picks say it all.
 type GameCompleteEvent struct {
-	GameID int64 `json:"game_id"`
+	GameID  int64             `json:"game_id"`
+	Variant string            `json:"variant,omitempty"`
+	Outcome map[string]string `json:"outcome,omitempty"`
+}
+
+// EngineDegradedEvent is sent when a store call made by the game engine
+// exceeds its deadline. Operation names the call, e.g. "create_game". The
+// engine keeps running, but the current game may be skipped or delayed.
+type EngineDegradedEvent struct {
+	Operation string `json:"operation"`
+	Reason    string `json:"reason"`
+}
+
+// ServerNoticeEvent is an operator announcement, e.g. planned maintenance
+// or an event starting. Severity is one of NoticeInfo, NoticeWarning or
+// NoticeCritical. Clients should show it for TTLSeconds after receiving it.
+// Translations holds Message in other languages, keyed by locale; clients
+// whose Accept-Language matches one are sent it as Message.
+type ServerNoticeEvent struct {
+	Message      string            `json:"message"`
+	Severity     string            `json:"severity"`
+	TTLSeconds   int64             `json:"ttl_seconds"`
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+// StreamGapEvent is sent on an SSE stream in place of events the server
+// dropped because the client was reading too slowly. Dropped counts the
+// missing events and LatestSeq is the sequence number of the newest event
+// broadcast. The client's view may be stale, so it should resync, e.g. by
+// rebuilding from the next game:state or fetching the latest game.
+type StreamGapEvent struct {
+	Dropped   int64 `json:"dropped"`
+	LatestSeq int64 `json:"latest_seq"`
 }
 
 // HeartbeatEvent is sent periodically to keep the connection alive.
 type HeartbeatEvent struct{}
+
+// EventEnvelope wraps an event with its type and server-assigned sequence
+// number. It is used by transports that batch events, such as long-polling.
+type EventEnvelope struct {
+	Seq  int64           `json:"seq"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+
+	// Node is the ID of the instance that sent the event. Sequence numbers
+	// are only comparable between events from the same node.
+	Node string `json:"node,omitempty"`
+}
+
+// Decode unmarshals the envelope data into its typed event.
+// The result is one of: GameStateEvent, GamePickEvent, GameCompleteEvent,
+// EngineDegradedEvent, ServerNoticeEvent, HeartbeatEvent. Renamed event
+// types are decoded under either name.
+func (e EventEnvelope) Decode() (any, error) {
+	switch CanonicalEventType(e.Type) {
+	case EventGameState:
+		var v GameStateEvent
+		err := json.Unmarshal(e.Data, &v)
+		return v, err
+	case EventGamePick:
+		var v GamePickEvent
+		err := json.Unmarshal(e.Data, &v)
+		return v, err
+	case EventGameComplete:
+		var v GameCompleteEvent
+		err := json.Unmarshal(e.Data, &v)
+		return v, err
+	case EventEngineDegraded:
+		var v EngineDegradedEvent
+		err := json.Unmarshal(e.Data, &v)
+		return v, err
+	case EventServerNotice:
+		var v ServerNoticeEvent
+		err := json.Unmarshal(e.Data, &v)
+		return v, err
+	case EventSysHeartbeat:
+		return HeartbeatEvent{}, nil
+	default:
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+}
+
+// WaitEventsResponse is the response for long-polling game events.
+// Events is empty if the wait timed out; LastSeq should be passed as
+// since_seq on the next request.
+type WaitEventsResponse struct {
+	Events  []EventEnvelope `json:"events"`
+	LastSeq int64           `json:"last_seq"`
+}
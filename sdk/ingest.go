@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers carrying the signature of a pushed result.
+const (
+	// HeaderIngestTimestamp is the Unix time, in seconds, the request was signed.
+	HeaderIngestTimestamp = "X-Taboo-Timestamp"
+	// HeaderIngestNonce is a value unique to the request.
+	HeaderIngestNonce = "X-Taboo-Nonce"
+	// HeaderIngestSignature is "sha256=" followed by the hex HMAC from [SignIngest].
+	HeaderIngestSignature = "X-Taboo-Signature"
+)
+
+// IngestSignaturePrefix precedes the hex digest in HeaderIngestSignature.
+const IngestSignaturePrefix = "sha256="
+
+// SignIngest returns the HMAC-SHA256, hex encoded, of a pushed result's
+// timestamp, nonce and body, joined by ".". Binding the timestamp and nonce
+// into the signature lets the server reject replayed requests.
+func SignIngest(secret []byte, timestamp int64, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write([]byte(nonce))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
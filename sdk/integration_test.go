@@ -26,10 +26,36 @@ type testServer struct {
 	cancel      context.CancelFunc
 }
 
+// testConditions are the adverse conditions a test server runs under.
+type testConditions struct {
+	latency   time.Duration
+	clockSkew time.Duration
+}
+
+// testServerOption sets up a testServer to run under adverse conditions,
+// rather than the instant, perfectly synchronized timing of loopback.
+type testServerOption func(*testConditions)
+
+// withLatency delays each request by d before the server sees it, and each
+// flush of the response by d before the client does, like a slow link.
+func withLatency(d time.Duration) testServerOption {
+	return func(c *testConditions) { c.latency = d }
+}
+
+// withClockSkew offsets the server's wall clock by d from the client's.
+func withClockSkew(d time.Duration) testServerOption {
+	return func(c *testConditions) { c.clockSkew = d }
+}
+
 // setupTestServer creates a test server with a temporary store and fast game timings.
-func setupTestServer(t *testing.T) *testServer {
+func setupTestServer(t *testing.T, opts ...testServerOption) *testServer {
 	t.Helper()
 
+	var conditions testConditions
+	for _, opt := range opts {
+		opt(&conditions)
+	}
+
 	// Create a temp file for SQLite (in-memory doesn't work well with concurrent access)
 	tmpDir := t.TempDir()
 	dbPath := tmpDir + "/test.db"
@@ -58,8 +84,10 @@ func setupTestServer(t *testing.T) *testServer {
 			DrawDuration: config.Duration(150 * time.Millisecond), // 50ms per pick with 3 picks
 			WaitDuration: config.Duration(50 * time.Millisecond),
 			PickCount:    3,
+			MinNumber:    1,
 			MaxNumber:    10,
 		},
+		Limits: config.Default().Limits,
 	}
 
 	logger := slog.New(slog.NewTextHandler(testWriter{t}, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -67,10 +95,15 @@ func setupTestServer(t *testing.T) *testServer {
 	// Create services
 	gameService := service.NewGameService(store, &cfg.Game)
 	engine := service.NewEngine(gameService, &cfg.Game, logger)
+	engine.SetClockSkew(conditions.clockSkew)
 
 	// Use the real HTTP server handler (routes + middleware)
-	srv := taboohttp.NewServer(cfg, logger, store, gameService, engine)
-	ts := httptest.NewServer(srv.Handler())
+	srv := taboohttp.NewServer(cfg, logger, store, gameService, service.NewSettingsService(store), engine)
+	handler := srv.Handler()
+	if conditions.latency > 0 {
+		handler = withNetworkLatency(handler, conditions.latency)
+	}
+	ts := httptest.NewServer(handler)
 
 	// Start engine in background
 	ctx, cancel := context.WithCancel(context.Background())
@@ -94,6 +127,35 @@ func setupTestServer(t *testing.T) *testServer {
 	}
 }
 
+// withNetworkLatency delays each request by d, and each flush of its
+// response by d, so streamed events reach the client late too.
+func withNetworkLatency(next http.Handler, d time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(d):
+		}
+		next.ServeHTTP(&latencyWriter{ResponseWriter: w, delay: d}, r)
+	})
+}
+
+// latencyWriter delays flushes of the wrapped response.
+type latencyWriter struct {
+	http.ResponseWriter
+	delay time.Duration
+}
+
+func (w *latencyWriter) Flush() {
+	time.Sleep(w.delay)
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *latencyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // testWriter adapts testing.T to io.Writer for slog.
 type testWriter struct {
 	t *testing.T
@@ -405,3 +467,101 @@ func TestIntegration_SSE_GameLifecycle(t *testing.T) {
 	cancel()
 	<-done
 }
+
+// --- Adverse Conditions ---
+
+func TestIntegration_SSE_ReconnectUnderAdverseConditions(t *testing.T) {
+	t.Parallel()
+
+	const skew = -90 * time.Second
+	ts := setupTestServer(t, withLatency(10*time.Millisecond), withClockSkew(skew))
+	handler := newTestEventHandler()
+	sseClient := sdk.NewSSEClient(ts.URL, handler, sdk.WithReconnectDelay(50*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sseClient.Connect(ctx)
+	}()
+
+	waitConnected := func() {
+		t.Helper()
+		select {
+		case <-handler.connected:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for SSE connection")
+		}
+	}
+	waitForStates := func(n int) {
+		t.Helper()
+		for states, _, _, _ := handler.getStats(); states < n; states, _, _, _ = handler.getStats() {
+			if ctx.Err() != nil {
+				t.Fatalf("timeout waiting for %d state events, got %d", n, states)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitConnected()
+	waitForStates(1)
+
+	// Drop the connection mid-game; the client reconnects and picks the
+	// game back up from the next state event
+	ts.Server.CloseClientConnections()
+	waitConnected()
+	before, _, _, _ := handler.getStats()
+	waitForStates(before + 1)
+
+	cancel()
+	<-done
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	// Every server time is on the server's skewed clock, and consistent
+	// with the others, so clients pacing by server times stay in step
+	cycle := 200 * time.Millisecond
+	for _, state := range handler.states {
+		if ahead := time.Until(state.NextGame) - skew; ahead < -time.Second || ahead > cycle+time.Second {
+			t.Errorf("next_game %v is %v from the skewed clock, want within the game cycle", state.NextGame, ahead)
+		}
+	}
+	for _, pick := range handler.picks {
+		if behind := time.Since(pick.RevealedAt) + skew; behind < -time.Second || behind > time.Second {
+			t.Errorf("revealed_at %v is %v from the skewed clock, want about now", pick.RevealedAt, behind)
+		}
+	}
+}
+
+func TestIntegration_LongPoll_UnderLatency(t *testing.T) {
+	t.Parallel()
+
+	ts := setupTestServer(t, withLatency(20*time.Millisecond))
+	client := sdk.NewClient(ts.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Polling from each response's last_seq sees every event exactly once,
+	// however late the responses arrive
+	var sinceSeq, completes int64
+	for completes < 2 {
+		resp, err := client.WaitForEvents(ctx, sinceSeq)
+		if err != nil {
+			t.Fatalf("WaitForEvents(%d) failed: %v", sinceSeq, err)
+		}
+		for _, e := range resp.Events {
+			if sinceSeq > 0 && e.Seq != sinceSeq+1 {
+				t.Fatalf("event seq %d after %d, want no gaps or repeats", e.Seq, sinceSeq)
+			}
+			sinceSeq = e.Seq
+			if e.Type == sdk.EventGameComplete {
+				completes++
+			}
+		}
+		if resp.LastSeq != sinceSeq {
+			t.Errorf("last_seq = %d, want %d", resp.LastSeq, sinceSeq)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Picks is a slice of uint8 that marshals to a JSON array of integers
+// instead of base64 (which is the default for []byte/[]uint8). Each pick
+// lies within the server's configured min_number..max_number range, which
+// is 1-80 for the classic board but need not start at 1.
+type Picks []uint8
+
+// MarshalJSON implements json.Marshaler. A nil Picks marshals as [] so
+// clients never see null.
+func (p Picks) MarshalJSON() ([]byte, error) {
+	ints := make([]int, len(p))
+	for i, v := range p {
+		ints[i] = int(v)
+	}
+	return json.Marshal(ints)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It also accepts the base64
+// string that a plain []uint8 marshals to, so data written before Picks was
+// used everywhere still decodes.
+func (p *Picks) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var raw []byte
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*p = Picks(raw)
+		return nil
+	}
+
+	var ints []int
+	if err := json.Unmarshal(data, &ints); err != nil {
+		return err
+	}
+	*p = make(Picks, len(ints))
+	for i, v := range ints {
+		if v < 0 || v > 255 {
+			return fmt.Errorf("pick value %d out of uint8 range at index %d", v, i)
+		}
+		(*p)[i] = uint8(v) //nolint:gosec // bounds checked above
+	}
+	return nil
+}
+
+// Contains reports whether n was picked.
+func (p Picks) Contains(n uint8) bool {
+	return slices.Contains(p, n)
+}
+
+// Matches returns how many of other's numbers were picked, e.g. the hits on
+// a player's ticket. Repeated numbers in other count once.
+func (p Picks) Matches(other Picks) int {
+	hits := 0
+	for i, n := range other {
+		if p.Contains(n) && !slices.Contains(other[:i], n) {
+			hits++
+		}
+	}
+	return hits
+}
@@ -0,0 +1,80 @@
+package sdk_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+func TestPicks_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		picks sdk.Picks
+		want  string
+	}{
+		{"numbers", sdk.Picks{1, 42, 80}, "[1,42,80]"},
+		{"nil", nil, "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.picks)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestPicks_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    sdk.Picks
+		wantErr bool
+	}{
+		{"array", "[1,42,80]", sdk.Picks{1, 42, 80}, false},
+		{"legacy base64", `"AQID"`, sdk.Picks{1, 2, 3}, false},
+		{"out of range", "[256]", nil, true},
+		{"negative", "[-1]", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sdk.Picks
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(got, tt.want) {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPicks_Matches(t *testing.T) {
+	drawn := sdk.Picks{3, 7, 19, 42}
+
+	if !drawn.Contains(19) || drawn.Contains(20) {
+		t.Errorf("Contains() wrong for %v", drawn)
+	}
+
+	tests := []struct {
+		ticket sdk.Picks
+		want   int
+	}{
+		{sdk.Picks{3, 42}, 2},
+		{sdk.Picks{1, 2}, 0},
+		{sdk.Picks{7, 7, 7}, 1},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := drawn.Matches(tt.ticket); got != tt.want {
+			t.Errorf("Matches(%v) = %d, want %d", tt.ticket, got, tt.want)
+		}
+	}
+}
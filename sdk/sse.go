@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -20,6 +21,24 @@ type EventHandler interface {
 	OnDisconnect(error)
 }
 
+// DegradedHandler is optionally implemented by an EventHandler to receive
+// engine:degraded events.
+type DegradedHandler interface {
+	OnEngineDegraded(EngineDegradedEvent)
+}
+
+// NoticeHandler is optionally implemented by an EventHandler to receive
+// server:notice events.
+type NoticeHandler interface {
+	OnServerNotice(ServerNoticeEvent)
+}
+
+// GapHandler is optionally implemented by an EventHandler to receive
+// stream:gap events, sent when the server dropped events for the stream.
+type GapHandler interface {
+	OnStreamGap(StreamGapEvent)
+}
+
 // BaseEventHandler provides default no-op implementations for EventHandler.
 // Embed this in your handler to only implement the methods you need.
 type BaseEventHandler struct{}
@@ -37,7 +56,10 @@ type SSEClient struct {
 	handler        EventHandler
 	httpClient     *http.Client
 	reconnectDelay time.Duration
-	maxRetries     int // 0 = unlimited
+	maxRetries     int           // 0 = unlimited
+	heartbeat      time.Duration // 0 = server default
+	userAgent      string
+	onWarning      func(string)
 }
 
 // SSEOption configures the SSEClient.
@@ -57,6 +79,32 @@ func WithMaxRetries(n int) SSEOption {
 	}
 }
 
+// WithHeartbeat requests heartbeats every d instead of the server default.
+// The server clamps d to its configured bounds.
+func WithHeartbeat(d time.Duration) SSEOption {
+	return func(c *SSEClient) {
+		c.heartbeat = d
+	}
+}
+
+// WithSSEUserAgent sends ua as the User-Agent instead of
+// [DefaultUserAgent]. The client still identifies itself to the server
+// with [DefaultClientInfo] in [ClientHeader].
+func WithSSEUserAgent(ua string) SSEOption {
+	return func(c *SSEClient) {
+		c.userAgent = ua
+	}
+}
+
+// WithSSEWarningHandler calls fn with each warning the server sends when
+// the stream connects, about a deprecated parameter or behavior about to
+// change.
+func WithSSEWarningHandler(fn func(warning string)) SSEOption {
+	return func(c *SSEClient) {
+		c.onWarning = fn
+	}
+}
+
 // WithSSEHTTPClient sets a custom HTTP client for the SSE connection.
 func WithSSEHTTPClient(hc *http.Client) SSEOption {
 	return func(c *SSEClient) {
@@ -73,6 +121,7 @@ func NewSSEClient(baseURL string, handler EventHandler, opts ...SSEOption) *SSEC
 		httpClient:     &http.Client{},
 		reconnectDelay: 5 * time.Second,
 		maxRetries:     0,
+		userAgent:      DefaultUserAgent(),
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -107,11 +156,18 @@ func (c *SSEClient) Connect(ctx context.Context) error {
 }
 
 func (c *SSEClient) connect(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/events", nil)
+	u := c.baseURL + "/api/v1/events"
+	if c.heartbeat > 0 {
+		u += "?heartbeat=" + url.QueryEscape(c.heartbeat.String())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set(ClientHeader, DefaultClientInfo().String())
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -119,6 +175,12 @@ func (c *SSEClient) connect(ctx context.Context) error {
 	}
 	defer resp.Body.Close()
 
+	if c.onWarning != nil {
+		for _, warning := range Warnings(resp.Header) {
+			c.onWarning(warning)
+		}
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
@@ -126,7 +188,7 @@ func (c *SSEClient) connect(ctx context.Context) error {
 	c.handler.OnConnect()
 
 	scanner := bufio.NewScanner(resp.Body)
-	var eventType string
+	var eventType, prevType string
 	var data strings.Builder
 
 	for scanner.Scan() {
@@ -134,9 +196,12 @@ func (c *SSEClient) connect(ctx context.Context) error {
 
 		if line == "" {
 			// Empty line = end of event
-			if eventType != "" && data.Len() > 0 {
-				c.dispatchEvent(eventType, data.String())
+			// A renamed event is followed by a copy under its old name
+			// during the rename's deprecation cycle; dispatch it once
+			if eventType != "" && data.Len() > 0 && !isLegacyCopy(prevType, eventType) {
+				c.dispatchEvent(CanonicalEventType(eventType), data.String())
 			}
+			prevType = eventType
 			eventType = ""
 			data.Reset()
 			continue
@@ -177,7 +242,25 @@ func (c *SSEClient) dispatchEvent(eventType, data string) {
 		if json.Unmarshal([]byte(data), &e) == nil {
 			c.handler.OnGameComplete(e)
 		}
-	case EventGameHeartbeat:
+	case EventEngineDegraded:
+		var e EngineDegradedEvent
+		h, ok := c.handler.(DegradedHandler)
+		if ok && json.Unmarshal([]byte(data), &e) == nil {
+			h.OnEngineDegraded(e)
+		}
+	case EventServerNotice:
+		var e ServerNoticeEvent
+		h, ok := c.handler.(NoticeHandler)
+		if ok && json.Unmarshal([]byte(data), &e) == nil {
+			h.OnServerNotice(e)
+		}
+	case EventStreamGap:
+		var e StreamGapEvent
+		h, ok := c.handler.(GapHandler)
+		if ok && json.Unmarshal([]byte(data), &e) == nil {
+			h.OnStreamGap(e)
+		}
+	case EventSysHeartbeat:
 		c.handler.OnHeartbeat()
 	}
 }
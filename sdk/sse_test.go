@@ -129,6 +129,104 @@ func TestSSEClient_Connect(t *testing.T) {
 	}
 }
 
+func TestSSEClient_LegacyEventNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		// A renamed event followed by its legacy copy, then a pick, then an
+		// event only under its legacy name as an older server sends it
+		fmt.Fprintf(w, "event: sys:heartbeat\ndata: {}\n\n")
+		fmt.Fprintf(w, "event: game:heartbeat\ndata: {}\n\n")
+		fmt.Fprintf(w, "event: game:pick\ndata: {\"pick\":42}\n\n")
+		fmt.Fprintf(w, "event: game:heartbeat\ndata: {}\n\n")
+	}))
+	defer server.Close()
+
+	handler := &testHandler{}
+	client := sdk.NewSSEClient(server.URL, handler, sdk.WithMaxRetries(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = client.Connect(ctx)
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	if handler.heartbeats != 2 {
+		t.Errorf("expected 2 heartbeats, got %d", handler.heartbeats)
+	}
+	if len(handler.picks) != 1 {
+		t.Errorf("expected 1 pick event, got %d", len(handler.picks))
+	}
+}
+
+func TestSSEClient_WithHeartbeat(t *testing.T) {
+	got := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case got <- r.URL.Query().Get("heartbeat"):
+		default:
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := sdk.NewSSEClient(server.URL, &testHandler{}, sdk.WithMaxRetries(1), sdk.WithHeartbeat(30*time.Second))
+	_ = client.Connect(context.Background())
+
+	if heartbeat := <-got; heartbeat != "30s" {
+		t.Errorf("expected heartbeat=30s, got %q", heartbeat)
+	}
+}
+
+func TestSSEClient_Identifies(t *testing.T) {
+	got := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case got <- r.Header:
+		default:
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := sdk.NewSSEClient(server.URL, &testHandler{}, sdk.WithMaxRetries(1), sdk.WithSSEUserAgent("results-bot/2.0"))
+	_ = client.Connect(context.Background())
+
+	header := <-got
+	if ua := header.Get("User-Agent"); ua != "results-bot/2.0" {
+		t.Errorf("expected User-Agent results-bot/2.0, got %q", ua)
+	}
+	info, err := sdk.ParseClientInfo(header.Get(sdk.ClientHeader))
+	if err != nil || info.Name != sdk.SDKName || info.Events != sdk.EventsVersion {
+		t.Errorf("expected %s client info, got %+v, %v", sdk.SDKName, info, err)
+	}
+}
+
+func TestSSEClient_StreamGap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: stream:gap\ndata: {\"dropped\":3,\"latest_seq\":42}\n\n")
+	}))
+	defer server.Close()
+
+	handler := sdk.NewChannelHandler(10)
+	client := sdk.NewSSEClient(server.URL, handler, sdk.WithMaxRetries(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = client.Connect(ctx)
+
+	select {
+	case e := <-handler.Events():
+		if gap, ok := e.(sdk.StreamGapEvent); !ok || gap.Dropped != 3 || gap.LatestSeq != 42 {
+			t.Errorf("expected a gap of 3 up to seq 42, got %#v", e)
+		}
+	default:
+		t.Fatal("expected a stream:gap event")
+	}
+}
+
 func TestChannelHandler(t *testing.T) {
 	handler := sdk.NewChannelHandler(10)
 
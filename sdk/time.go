@@ -0,0 +1,31 @@
+package sdk
+
+import "time"
+
+// TimeFormat is the layout of every timestamp in API responses and events:
+// RFC 3339 in UTC with exactly millisecond precision, e.g.
+// "2024-05-01T09:30:00.250Z". Decoding accepts any RFC 3339 timestamp, at
+// any precision and offset, so older servers and hand-written requests
+// still parse.
+const TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// timestamp marshals a time.Time in TimeFormat. SDK types keep plain
+// time.Time fields and convert to it in their MarshalJSON methods.
+type timestamp time.Time
+
+// MarshalJSON implements json.Marshaler.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, len(TimeFormat)+2)
+	b = append(b, '"')
+	b = time.Time(t).UTC().AppendFormat(b, TimeFormat)
+	return append(b, '"'), nil
+}
+
+// optionalTimestamp converts an optional time for marshaling.
+func optionalTimestamp(t *time.Time) *timestamp {
+	if t == nil {
+		return nil
+	}
+	ts := timestamp(*t)
+	return &ts
+}
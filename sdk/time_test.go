@@ -0,0 +1,102 @@
+package sdk_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aussiebroadwan/taboo/sdk"
+)
+
+// timestampPattern is the contract for API timestamps: RFC 3339 in UTC with
+// exactly millisecond precision.
+var timestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}Z$`)
+
+func TestTimestamps_Contract(t *testing.T) {
+	// Nanosecond precision in a non-UTC zone, as the server's clock gives
+	at := time.Date(2024, 5, 1, 19, 30, 0, 250_123_456, time.FixedZone("AEST", 10*60*60))
+	const want = "2024-05-01T09:30:00.250Z"
+
+	tests := []struct {
+		name   string
+		value  any
+		fields []string
+	}{
+		{"Game", sdk.Game{ID: 1, Picks: sdk.Picks{1}, CreatedAt: at}, []string{"created_at"}},
+		{"Season", sdk.Season{ID: 1, StartedAt: at}, []string{"started_at"}},
+		{"DBStatsResponse", sdk.DBStatsResponse{LastVacuum: &at, LastBackup: &at}, []string{"last_vacuum", "last_backup"}},
+		{"UsageResponse", sdk.UsageResponse{Since: at}, []string{"since"}},
+		{"GameStateEvent", sdk.GameStateEvent{GameID: 1, NextGame: at}, []string{"next_game"}},
+		{"GamePickEvent", sdk.GamePickEvent{Pick: 7, RevealedAt: at}, []string{"revealed_at"}},
+		{"whole second", sdk.Game{CreatedAt: at.Truncate(time.Second)}, []string{"created_at"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			for _, field := range tt.fields {
+				ts, _ := got[field].(string)
+				if !timestampPattern.MatchString(ts) {
+					t.Errorf("%s = %q, want RFC 3339 UTC with milliseconds", field, got[field])
+				}
+				if tt.name != "whole second" && ts != want {
+					t.Errorf("%s = %q, want %q", field, ts, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTimestamps_KeepOtherFields(t *testing.T) {
+	data, err := json.Marshal(sdk.Game{ID: 42, Picks: sdk.Picks{3, 7}, ResultHash: "abc"})
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	want := `{"id":42,"picks":[3,7],"result_hash":"abc","created_at":"0001-01-01T00:00:00.000Z"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	// Optional times stay omitted
+	data, err = json.Marshal(sdk.DBStatsResponse{})
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if _, ok := got["last_vacuum"]; ok {
+		t.Errorf("Marshal() = %s, want last_vacuum omitted", data)
+	}
+}
+
+func TestTimestamps_Decode(t *testing.T) {
+	want := time.Date(2024, 5, 1, 9, 30, 0, 250_000_000, time.UTC)
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"milliseconds", `"2024-05-01T09:30:00.250Z"`},
+		{"nanoseconds", `"2024-05-01T09:30:00.250000000Z"`},
+		{"offset", `"2024-05-01T19:30:00.25+10:00"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var game sdk.Game
+			if err := json.Unmarshal([]byte(`{"id":1,"created_at":`+tt.data+`}`), &game); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			if !game.CreatedAt.Equal(want) {
+				t.Errorf("CreatedAt = %v, want %v", game.CreatedAt, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WarningHeader carries notices about deprecated parameters and behavior
+// about to change on otherwise successful responses, one per header, in the
+// form FormatWarning writes.
+const WarningHeader = "Warning"
+
+// warnCode is the RFC 7234 warn-code for a persistent warning that fits
+// no other code.
+const warnCode = "299"
+
+// FormatWarning returns text as a Warning header value, e.g.
+// `299 - "legacy event names will be removed"`.
+func FormatWarning(text string) string {
+	return warnCode + " - " + strconv.Quote(text)
+}
+
+// ParseWarning returns the text of a Warning header value written by
+// FormatWarning, reporting false for values in any other form.
+func ParseWarning(v string) (string, bool) {
+	quoted, ok := strings.CutPrefix(v, warnCode+" - ")
+	if !ok {
+		return "", false
+	}
+	text, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", false
+	}
+	return text, true
+}
+
+// Warnings returns the text of each Warning in h, skipping any not in the
+// form FormatWarning writes.
+func Warnings(h http.Header) []string {
+	var warnings []string
+	for _, v := range h.Values(WarningHeader) {
+		if text, ok := ParseWarning(v); ok {
+			warnings = append(warnings, text)
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,18 @@
+package sdk
+
+import "testing"
+
+func TestParseWarning(t *testing.T) {
+	for _, text := range []string{"plain", `with "quotes" and \ backslash`, ""} {
+		got, ok := ParseWarning(FormatWarning(text))
+		if !ok || got != text {
+			t.Errorf("round trip of %q gave %q, %v", text, got, ok)
+		}
+	}
+
+	for _, v := range []string{`110 - "Response is Stale"`, `299 - unquoted`, ""} {
+		if _, ok := ParseWarning(v); ok {
+			t.Errorf("expected %q to be rejected", v)
+		}
+	}
+}